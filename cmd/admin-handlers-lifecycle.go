@@ -0,0 +1,213 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+	"io"
+	"net/http"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// lifecycleDryRunEntry is a single line of the lifecycle dry-run report,
+// either an object version with the action due on it or the final summary.
+// Entries with all fields empty are sent to keep the connection alive.
+type lifecycleDryRunEntry struct {
+	Object    string                  `json:"object,omitempty"`
+	VersionID string                  `json:"versionId,omitempty"`
+	Action    string                  `json:"action,omitempty"`
+	Size      int64                   `json:"size,omitempty"`
+	ModTime   *time.Time              `json:"modTime,omitempty"`
+	Summary   *lifecycleDryRunSummary `json:"summary,omitempty"`
+}
+
+// lifecycleDryRunActionStats counts the object versions and their total
+// size a lifecycle action applies to.
+type lifecycleDryRunActionStats struct {
+	Count uint64 `json:"count"`
+	Bytes uint64 `json:"bytes"`
+}
+
+// lifecycleDryRunSummary summarizes a lifecycle dry-run.
+type lifecycleDryRunSummary struct {
+	AsOf    time.Time                             `json:"asOf"`
+	Scanned uint64                                `json:"scanned"`
+	Actions map[string]lifecycleDryRunActionStats `json:"actions"`
+}
+
+func (s *lifecycleDryRunSummary) add(action lifecycle.Action, size int64) {
+	stats := s.Actions[action.String()]
+	stats.Count++
+	stats.Bytes += uint64(size)
+	s.Actions[action.String()] = stats
+}
+
+// lifecycleDryRun evaluates lc against object versions as of the given
+// time, versions must be ordered newest first per object as returned by
+// ObjectLayer.Walk with WalkVersions.
+type lifecycleDryRun struct {
+	lc   lifecycle.Lifecycle
+	asOf time.Time
+
+	// state of the object whose versions are being evaluated, used
+	// to apply NewerNoncurrentVersions limits.
+	object     string
+	limit      int
+	days       int
+	noncurrent int
+}
+
+func (d *lifecycleDryRun) computeAction(oi ObjectInfo) lifecycle.Action {
+	opts := oi.ToLifecycleOpts()
+	if oi.Name != d.object || oi.IsLatest {
+		d.object = oi.Name
+		d.limit, d.days, d.noncurrent = 0, 0, 0
+		if oi.IsLatest {
+			d.limit, d.days = d.lc.NoncurrentVersionsExpirationLimit(opts)
+		}
+	}
+	if !oi.IsLatest {
+		d.noncurrent++
+		if d.limit > 0 && d.noncurrent > d.limit && !oi.SuccessorModTime.IsZero() &&
+			d.asOf.After(lifecycle.ExpectedExpiryTime(oi.SuccessorModTime, d.days)) {
+			return lifecycle.DeleteVersionAction
+		}
+	}
+	return d.lc.ComputeActionAt(opts, d.asOf)
+}
+
+// LifecycleDryRunHandler - POST /minio/admin/v3/ilm/dry-run?bucket={bucket}&prefix={prefix}&date={date}
+// ----------
+// Evaluates the lifecycle configuration in the request body, or the bucket's
+// lifecycle configuration if the body is empty, against all object versions
+// in the bucket under prefix as of date (RFC3339, defaults to now). Streams
+// back one JSON entry per object version with an action due, followed by a
+// summary of counts and bytes per action. Nothing is modified.
+func (a adminAPIHandlers) LifecycleDryRunHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "LifecycleDryRun")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DataUsageInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	prefix := r.Form.Get("prefix")
+
+	asOf := UTCNow()
+	if date := r.Form.Get("date"); date != "" {
+		t, err := time.Parse(time.RFC3339, date)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminInvalidArgument), r.URL)
+			return
+		}
+		asOf = t.UTC()
+	}
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	var lc *lifecycle.Lifecycle
+	var err error
+	if r.ContentLength > 0 {
+		lc, err = lifecycle.ParseLifecycleConfig(io.LimitReader(r.Body, r.ContentLength))
+		if err == nil {
+			err = lc.Validate()
+		}
+	} else {
+		lc, err = globalLifecycleSys.Get(bucket)
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	objInfoCh := make(chan ObjectInfo)
+	if err = objectAPI.Walk(ctx, bucket, prefix, objInfoCh, ObjectOptions{WalkVersions: true}); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	// Drain the walk results if the client goes away before we are done.
+	defer func() {
+		go func() {
+			for range objInfoCh {
+			}
+		}()
+	}()
+
+	keepAliveTicker := time.NewTicker(500 * time.Millisecond)
+	defer keepAliveTicker.Stop()
+
+	dryRun := lifecycleDryRun{lc: *lc, asOf: asOf}
+	summary := lifecycleDryRunSummary{
+		AsOf:    asOf,
+		Actions: make(map[string]lifecycleDryRunActionStats),
+	}
+	enc := json.NewEncoder(w)
+	written := false
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-keepAliveTicker.C:
+			if !written {
+				// Write a blank entry to prevent client from disconnecting
+				if err := enc.Encode(lifecycleDryRunEntry{}); err != nil {
+					return
+				}
+			}
+			w.(http.Flusher).Flush()
+			written = false
+		case oi, ok := <-objInfoCh:
+			if !ok {
+				if err := enc.Encode(lifecycleDryRunEntry{Summary: &summary}); err != nil {
+					return
+				}
+				w.(http.Flusher).Flush()
+				return
+			}
+			summary.Scanned++
+			action := dryRun.computeAction(oi)
+			if action == lifecycle.NoneAction {
+				continue
+			}
+			summary.add(action, oi.Size)
+			modTime := oi.ModTime
+			if err := enc.Encode(lifecycleDryRunEntry{
+				Object:    oi.Name,
+				VersionID: oi.VersionID,
+				Action:    action.String(),
+				Size:      oi.Size,
+				ModTime:   &modTime,
+			}); err != nil {
+				return
+			}
+			written = true
+		}
+	}
+}
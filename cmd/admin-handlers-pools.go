@@ -0,0 +1,374 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"net/http"
+	"time"
+
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// validatePoolsAdminReq validates the admin request for pool operations,
+// returns the erasure server pools and the index of the pool specified
+// with the `pool` query parameter.
+func validatePoolsAdminReq(ctx context.Context, w http.ResponseWriter, r *http.Request, needPool bool) (*erasureServerPools, int) {
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil, -1
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.DecommissionAdminAction)
+	if objAPI == nil {
+		return nil, -1
+	}
+
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil, -1
+	}
+
+	if !needPool {
+		return z, -1
+	}
+
+	idx, err := z.getPoolIdxByCmdLine(r.URL.Query().Get("pool"))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return nil, -1
+	}
+	return z, idx
+}
+
+// proxyDecommissionRequest forwards the request to the node which owns the
+// first drive of the pool at idx, that node runs the decommission for the pool.
+// Returns true if the request was proxied.
+func proxyDecommissionRequest(ctx context.Context, w http.ResponseWriter, r *http.Request, idx int) bool {
+	host := globalEndpoints[idx].Endpoints[0].Host
+	for nodeIdx, proxyEp := range globalProxyEndpoints {
+		if proxyEp.Host == host {
+			return proxyRequestByNodeIndex(ctx, w, r, nodeIdx)
+		}
+	}
+	return false
+}
+
+// ListPools - GET /minio/admin/v3/pools/list
+// ----------
+// Returns the status of all the pools in the deployment.
+func (a adminAPIHandlers) ListPools(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ListPools")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsAdminReq(ctx, w, r, false)
+	if z == nil {
+		return
+	}
+
+	poolsStatus := make([]PoolStatus, len(globalEndpoints))
+	for idx := range globalEndpoints {
+		status, err := z.Status(r.Context(), idx)
+		if err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+			return
+		}
+		poolsStatus[idx] = status
+	}
+
+	data, err := json.Marshal(poolsStatus)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// StatusPool - GET /minio/admin/v3/pools/status?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Returns the status of the specified pool, including decommission progress.
+func (a adminAPIHandlers) StatusPool(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StatusPool")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, idx := validatePoolsAdminReq(ctx, w, r, true)
+	if z == nil {
+		return
+	}
+
+	// Progress counters are only current on the node running the decommission.
+	if proxyDecommissionRequest(ctx, w, r, idx) {
+		return
+	}
+
+	status, err := z.Status(r.Context(), idx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(status)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// StartDecommission - POST /minio/admin/v3/pools/decommission?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Marks the specified pool read-only and starts moving all of its objects
+// to the remaining pools.
+func (a adminAPIHandlers) StartDecommission(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "StartDecommission")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, idx := validatePoolsAdminReq(ctx, w, r, true)
+	if z == nil {
+		return
+	}
+
+	if proxyDecommissionRequest(ctx, w, r, idx) {
+		return
+	}
+
+	if err := z.Decommission(r.Context(), idx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// CancelDecommission - POST /minio/admin/v3/pools/cancel?pool=http://server{1...4}/disk{1...4}
+// ----------
+// Cancels an ongoing decommission, the pool becomes writable again.
+func (a adminAPIHandlers) CancelDecommission(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "CancelDecommission")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, idx := validatePoolsAdminReq(ctx, w, r, true)
+	if z == nil {
+		return
+	}
+
+	if proxyDecommissionRequest(ctx, w, r, idx) {
+		return
+	}
+
+	if err := z.DecommissionCancel(r.Context(), idx); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// rebalPoolProgress contains the rebalance progress of a pool.
+type rebalPoolProgress struct {
+	NumObjects  uint64        `json:"objects"`
+	NumVersions uint64        `json:"versions"`
+	Bytes       uint64        `json:"bytes"`
+	Bucket      string        `json:"bucket"`
+	Object      string        `json:"object"`
+	Elapsed     time.Duration `json:"elapsed"`
+}
+
+// rebalPoolStatus contains the rebalance status of a pool.
+type rebalPoolStatus struct {
+	ID       int                `json:"id"`     // Pool index (zero-based)
+	Status   string             `json:"status"` // Rebalance status of the pool
+	Used     float64            `json:"used"`   // Percentage used space
+	Progress *rebalPoolProgress `json:"progress,omitempty"`
+}
+
+// rebalanceAdminStatus holds rebalance status related information exported
+// to the admin client.
+type rebalanceAdminStatus struct {
+	ID        string            `json:"id"`
+	Pools     []rebalPoolStatus `json:"pools"`
+	StoppedAt time.Time         `json:"stoppedAt,omitempty"`
+}
+
+// RebalanceStart - POST /minio/admin/v3/rebalance/start
+// ----------
+// Starts moving objects from pools whose free space is below the cluster
+// average by more than the configured threshold to the other pools.
+func (a adminAPIHandlers) RebalanceStart(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStart")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsAdminReq(ctx, w, r, false)
+	if z == nil {
+		return
+	}
+
+	if z.SinglePool() {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errRebalanceNotNeeded), r.URL)
+		return
+	}
+
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	var bucketNames []string
+	for _, b := range buckets {
+		bucketNames = append(bucketNames, b.Name)
+	}
+
+	id, err := z.initRebalanceMeta(ctx, bucketNames)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(struct {
+		ID string `json:"id"`
+	}{
+		ID: id,
+	})
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	globalNotificationSys.LoadRebalanceMeta(ctx, true)
+	z.StartRebalance()
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RebalanceStatus - GET /minio/admin/v3/rebalance/status
+// ----------
+// Returns the rebalance progress of all the pools.
+func (a adminAPIHandlers) RebalanceStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsAdminReq(ctx, w, r, false)
+	if z == nil {
+		return
+	}
+
+	// Progress of pools rebalanced on other nodes is
+	// only as recent as the last saved rebalance state.
+	rs, err := rebalanceStatus(ctx, z)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(rs)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// RebalanceStop - POST /minio/admin/v3/rebalance/stop
+// ----------
+// Stops an ongoing rebalance, objects already moved stay where they are.
+func (a adminAPIHandlers) RebalanceStop(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RebalanceStop")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z, _ := validatePoolsAdminReq(ctx, w, r, false)
+	if z == nil {
+		return
+	}
+
+	if !z.IsRebalanceStarted() {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errRebalanceNotStarted), r.URL)
+		return
+	}
+
+	// Cancel any rebalance running on this node and the peers.
+	logger.LogIf(ctx, z.StopRebalance())
+	globalNotificationSys.StopRebalance(ctx)
+
+	if err := z.saveRebalanceStats(ctx, 0, rebalSaveStoppedAt); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// rebalanceStatus reads the persisted rebalance state and computes the
+// current usage of every pool.
+func rebalanceStatus(ctx context.Context, z *erasureServerPools) (rebalanceAdminStatus, error) {
+	r := &rebalanceMeta{}
+	if err := r.load(ctx, z); err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return rebalanceAdminStatus{}, errRebalanceNotStarted
+		}
+		return rebalanceAdminStatus{}, err
+	}
+
+	rs := rebalanceAdminStatus{
+		ID:        r.ID,
+		StoppedAt: r.StoppedAt,
+		Pools:     make([]rebalPoolStatus, len(r.PoolStats)),
+	}
+	for idx, ps := range r.PoolStats {
+		rs.Pools[idx] = rebalPoolStatus{
+			ID:     idx,
+			Status: ps.Info.Status.String(),
+		}
+		if idx < len(z.serverPools) {
+			if pi, err := z.getPoolSpaceInfo(idx); err == nil && pi.Total > 0 {
+				rs.Pools[idx].Used = float64(pi.Total-pi.Free) * 100 / float64(pi.Total)
+			}
+		}
+		if !ps.Participating {
+			continue
+		}
+
+		elapsed := time.Since(ps.Info.StartTime)
+		if !ps.Info.EndTime.IsZero() {
+			elapsed = ps.Info.EndTime.Sub(ps.Info.StartTime)
+		}
+		rs.Pools[idx].Progress = &rebalPoolProgress{
+			NumObjects:  ps.NumObjects,
+			NumVersions: ps.NumVersions,
+			Bytes:       ps.Bytes,
+			Bucket:      ps.Bucket,
+			Object:      ps.Object,
+			Elapsed:     elapsed,
+		}
+	}
+	return rs, nil
+}
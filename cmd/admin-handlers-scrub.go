@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// validateScrubAdminReq checks the permission to look at scrub results.
+func validateScrubAdminReq(ctx context.Context, w http.ResponseWriter, r *http.Request) *erasureServerPools {
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.HealAdminAction)
+	if objAPI == nil {
+		return nil
+	}
+
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return nil
+	}
+	return z
+}
+
+// ScrubStatus - GET /minio/admin/v3/scrub/status
+// ----------
+// Returns the scrub progress and the counts of the last completed scrub
+// of every erasure set, without the corrupted objects.
+func (a adminAPIHandlers) ScrubStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScrubStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := validateScrubAdminReq(ctx, w, r)
+	if z == nil {
+		return
+	}
+
+	// Progress of sets scrubbed on other nodes is
+	// only as recent as the last saved scrub state.
+	states, err := z.loadScrubStates(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	for i := range states {
+		states[i].Current.Objects = nil
+		states[i].Last.Objects = nil
+	}
+
+	data, err := json.Marshal(states)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// ScrubReport - GET /minio/admin/v3/scrub/report
+// ----------
+// Returns the scrub state of every erasure set as a downloadable report,
+// listing the object versions with corrupted or missing shards and the
+// drives holding them, for the scrub in progress and the last completed one.
+func (a adminAPIHandlers) ScrubReport(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "ScrubReport")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	z := validateScrubAdminReq(ctx, w, r)
+	if z == nil {
+		return
+	}
+
+	states, err := z.loadScrubStates(ctx)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	data, err := json.Marshal(states)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	w.Header().Set(xhttp.ContentDisposition, `attachment; filename="scrub-report.json"`)
+	writeSuccessResponseJSON(w, data)
+}
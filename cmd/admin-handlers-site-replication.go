@@ -0,0 +1,294 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/logger"
+	iampolicy "github.com/minio/pkg/iam/policy"
+)
+
+// SiteReplicationAdd - PUT /minio/admin/v3/site-replication/add
+// ----------
+// Links this site with other sites. The body is the encrypted list of
+// sites, this site included, with the root credentials of each site.
+func (a adminAPIHandlers) SiteReplicationAdd(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationAdd")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var sites []PeerSite
+	if !readSRRequest(ctx, w, r, cred.SecretKey, &sites) {
+		return
+	}
+
+	info, err := globalSiteReplicationSys.AddPeerClusters(ctx, objectAPI, sites)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSRResponseJSON(ctx, w, r, info)
+}
+
+// SiteReplicationInfo - GET /minio/admin/v3/site-replication/info
+// ----------
+// Returns the sites linked with this site.
+func (a adminAPIHandlers) SiteReplicationInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationInfo")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	writeSRResponseJSON(ctx, w, r, globalSiteReplicationSys.GetInfo())
+}
+
+// SiteReplicationStatus - GET /minio/admin/v3/site-replication/status
+// ----------
+// Returns the buckets, bucket configuration and IAM entities which are
+// missing on some sites or differ between sites.
+func (a adminAPIHandlers) SiteReplicationStatus(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SiteReplicationStatus")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	status, err := globalSiteReplicationSys.GetStatus(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSRResponseJSON(ctx, w, r, status)
+}
+
+// SRPeerJoin - PUT /minio/admin/v3/site-replication/peer/join
+// ----------
+// Called by the site on which site replication is enabled, to link this site.
+func (a adminAPIHandlers) SRPeerJoin(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerJoin")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var joinReq srPeerJoinReq
+	if !readSRRequest(ctx, w, r, cred.SecretKey, &joinReq) {
+		return
+	}
+
+	if err := globalSiteReplicationSys.PeerJoinReq(ctx, objectAPI, joinReq); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRPeerLeave - PUT /minio/admin/v3/site-replication/peer/leave
+// ----------
+// Called by the site on which site replication is being enabled, to
+// unlink this site again when another site failed to join.
+func (a adminAPIHandlers) SRPeerLeave(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerLeave")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var leaveReq srPeerLeaveReq
+	if !readSRRequest(ctx, w, r, cred.SecretKey, &leaveReq) {
+		return
+	}
+
+	if err := globalSiteReplicationSys.PeerLeaveReq(ctx, objectAPI, leaveReq); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRPeerBucketOps - PUT /minio/admin/v3/site-replication/peer/bucket-ops?bucket=x&operation=make|delete
+// ----------
+// Creates or deletes a bucket created or deleted on a peer site.
+func (a adminAPIHandlers) SRPeerBucketOps(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerBucketOps")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	query := r.Form
+	bucket := query.Get("bucket")
+
+	// Resyncs send no update time for buckets not changed since
+	// site replication tracks changes.
+	var updatedAt time.Time
+	if v := query.Get("updatedAt"); v != "" {
+		var err error
+		if updatedAt, err = time.Parse(time.RFC3339Nano, v); err != nil {
+			writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errInvalidArgument), r.URL)
+			return
+		}
+	}
+
+	var err error
+	switch query.Get("operation") {
+	case srBucketOpMake:
+		opts := BucketOptions{Location: query.Get("location")}
+		opts.LockEnabled, _ = strconv.ParseBool(query.Get("lockEnabled"))
+		opts.VersioningEnabled, _ = strconv.ParseBool(query.Get("versioningEnabled"))
+		err = globalSiteReplicationSys.PeerBucketMake(ctx, objectAPI, bucket, opts, updatedAt)
+	case srBucketOpDelete:
+		forceDelete, _ := strconv.ParseBool(query.Get("forceDelete"))
+		err = globalSiteReplicationSys.PeerBucketDelete(ctx, objectAPI, bucket, forceDelete, updatedAt)
+	default:
+		err = errInvalidArgument
+	}
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRPeerReplicateBucketMeta - PUT /minio/admin/v3/site-replication/peer/bucket-meta
+// ----------
+// Applies a bucket configuration change made on a peer site.
+func (a adminAPIHandlers) SRPeerReplicateBucketMeta(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerReplicateBucketMeta")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var item srBucketMeta
+	if !readSRRequest(ctx, w, r, cred.SecretKey, &item) {
+		return
+	}
+
+	if err := globalSiteReplicationSys.PeerBucketMetaUpdate(ctx, item); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRPeerReplicateIAMItem - PUT /minio/admin/v3/site-replication/peer/iam-item
+// ----------
+// Applies an IAM change made on a peer site.
+func (a adminAPIHandlers) SRPeerReplicateIAMItem(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerReplicateIAMItem")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, cred := validateAdminReq(ctx, w, r, iampolicy.ConfigUpdateAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	var item srIAMItem
+	if !readSRRequest(ctx, w, r, cred.SecretKey, &item) {
+		return
+	}
+
+	if err := globalSiteReplicationSys.PeerIAMItemUpdate(ctx, item); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+}
+
+// SRPeerGetMetaInfo - GET /minio/admin/v3/site-replication/peer/metainfo
+// ----------
+// Returns the digests of the replicated entities of this site.
+func (a adminAPIHandlers) SRPeerGetMetaInfo(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "SRPeerGetMetaInfo")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ServerInfoAdminAction)
+	if objectAPI == nil {
+		return
+	}
+
+	meta, err := globalSiteReplicationSys.GetSiteMeta(ctx, objectAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSRResponseJSON(ctx, w, r, meta)
+}
+
+// readSRRequest decrypts the request body with the secret key of
+// the requester and decodes the JSON into v.
+func readSRRequest(ctx context.Context, w http.ResponseWriter, r *http.Request, password string, v interface{}) bool {
+	if r.ContentLength > maxEConfigJSONSize || r.ContentLength == -1 {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrAdminConfigTooLarge), r.URL)
+		return false
+	}
+
+	data, err := madmin.DecryptData(password, io.LimitReader(r.Body, r.ContentLength))
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return false
+	}
+
+	if err = json.Unmarshal(data, v); err != nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErrWithErr(ErrAdminConfigBadJSON, err), r.URL)
+		return false
+	}
+	return true
+}
+
+func writeSRResponseJSON(ctx context.Context, w http.ResponseWriter, r *http.Request, v interface{}) {
+	data, err := json.Marshal(v)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
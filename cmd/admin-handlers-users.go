@@ -69,6 +69,11 @@ func (a adminAPIHandlers) RemoveUser(w http.ResponseWriter, r *http.Request) {
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+		Type: srIAMItemUser,
+		Name: accessKey,
+	})
 }
 
 // ListUsers - GET /minio/admin/v3/list-users?bucket={bucket}
@@ -248,6 +253,12 @@ func (a adminAPIHandlers) UpdateGroupMembers(w http.ResponseWriter, r *http.Requ
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+		Type:      srIAMItemGroupMembers,
+		Name:      updReq.Group,
+		GroupInfo: &updReq,
+	})
 }
 
 // GetGroup - /minio/admin/v3/group?group=mygroup1
@@ -340,6 +351,12 @@ func (a adminAPIHandlers) SetGroupStatus(w http.ResponseWriter, r *http.Request)
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+		Type:        srIAMItemGroupStatus,
+		Name:        group,
+		GroupStatus: status,
+	})
 }
 
 // SetUserStatus - PUT /minio/admin/v3/set-user-status?accessKey=<access_key>&status=[enabled|disabled]
@@ -375,6 +392,12 @@ func (a adminAPIHandlers) SetUserStatus(w http.ResponseWriter, r *http.Request)
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+		Type:     srIAMItemUserStatus,
+		Name:     accessKey,
+		UserInfo: &madmin.UserInfo{Status: madmin.AccountStatus(status)},
+	})
 }
 
 // AddUser - PUT /minio/admin/v3/add-user?accessKey=<access_key>
@@ -483,6 +506,12 @@ func (a adminAPIHandlers) AddUser(w http.ResponseWriter, r *http.Request) {
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+		Type:     srIAMItemUser,
+		Name:     accessKey,
+		UserInfo: &uinfo,
+	})
 }
 
 // AddServiceAccount - PUT /minio/admin/v3/add-service-account
@@ -600,6 +629,19 @@ func (a adminAPIHandlers) AddServiceAccount(w http.ResponseWriter, r *http.Reque
 		}
 	}
 
+	globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+		Type: srIAMItemSvcAcct,
+		Name: newCred.AccessKey,
+		SvcAcct: &srSvcAcct{
+			Parent:        newCred.ParentUser,
+			Groups:        targetGroups,
+			AccessKey:     newCred.AccessKey,
+			SecretKey:     newCred.SecretKey,
+			SessionPolicy: createReq.Policy,
+			LDAPUsername:  ldapUsername,
+		},
+	})
+
 	var createResp = madmin.AddServiceAccountResp{
 		Credentials: madmin.Credentials{
 			AccessKey: newCred.AccessKey,
@@ -711,6 +753,18 @@ func (a adminAPIHandlers) UpdateServiceAccount(w http.ResponseWriter, r *http.Re
 		}
 	}
 
+	globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+		Type: srIAMItemSvcAcctUpdate,
+		Name: accessKey,
+		SvcAcct: &srSvcAcct{
+			Parent:        svcAccount.ParentUser,
+			AccessKey:     accessKey,
+			SecretKey:     updateReq.NewSecretKey,
+			SessionPolicy: updateReq.NewPolicy,
+			Status:        updateReq.NewStatus,
+		},
+	})
+
 	writeSuccessNoContent(w)
 }
 
@@ -945,6 +999,15 @@ func (a adminAPIHandlers) DeleteServiceAccount(w http.ResponseWriter, r *http.Re
 		}
 	}
 
+	globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+		Type: srIAMItemSvcAcctDelete,
+		Name: serviceAccount,
+		SvcAcct: &srSvcAcct{
+			Parent:    svcAccount.ParentUser,
+			AccessKey: serviceAccount,
+		},
+	})
+
 	writeSuccessNoContent(w)
 }
 
@@ -1256,6 +1319,11 @@ func (a adminAPIHandlers) RemoveCannedPolicy(w http.ResponseWriter, r *http.Requ
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+		Type: srIAMItemPolicy,
+		Name: policyName,
+	})
 }
 
 // AddCannedPolicy - PUT /minio/admin/v3/add-canned-policy?name=<policy_name>
@@ -1308,6 +1376,16 @@ func (a adminAPIHandlers) AddCannedPolicy(w http.ResponseWriter, r *http.Request
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	if policyData, err := json.Marshal(iamPolicy); err == nil {
+		globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+			Type:   srIAMItemPolicy,
+			Name:   policyName,
+			Policy: policyData,
+		})
+	} else {
+		logger.LogIf(ctx, err)
+	}
 }
 
 // SetPolicyForUserOrGroup - PUT /minio/admin/v3/set-policy?policy=xxx&user-or-group=?[&is-group]
@@ -1350,4 +1428,14 @@ func (a adminAPIHandlers) SetPolicyForUserOrGroup(w http.ResponseWriter, r *http
 			logger.LogIf(ctx, nerr.Err)
 		}
 	}
+
+	globalSiteReplicationSys.IAMChangeHook(ctx, srIAMItem{
+		Type: srIAMItemPolicyMapping,
+		Name: entityName,
+		PolicyMapping: &srPolicyMapping{
+			UserOrGroup: entityName,
+			IsGroup:     isGroup,
+			Policy:      policyName,
+		},
+	})
 }
@@ -18,9 +18,11 @@
 package cmd
 
 import (
+	"archive/tar"
 	"bytes"
 	"context"
 	"encoding/json"
+	"encoding/xml"
 	"io"
 	"io/ioutil"
 	"net/http"
@@ -28,10 +30,14 @@ import (
 	"net/url"
 	"sync"
 	"testing"
+	"time"
 
 	"github.com/gorilla/mux"
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/config/storageclass"
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // adminErasureTestBed - encapsulates subsystems that need to be setup for
@@ -375,3 +381,272 @@ func TestExtractHealInitParams(t *testing.T) {
 	}
 
 }
+
+func TestLifecycleDryRunHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal("Failed to initialize a single node Erasure backend for admin handler tests.", err)
+	}
+	defer adminTestBed.TearDown()
+
+	bucket := "dry-run-bucket"
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	for _, object := range []string{"logs/a", "logs/b", "data/c"} {
+		_, err = adminTestBed.objLayer.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader([]byte("hello")), 5, "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	lcXML := []byte(`<LifecycleConfiguration><Rule><Filter><Prefix>logs/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>1</Days></Expiration></Rule></LifecycleConfiguration>`)
+	testCases := []struct {
+		date     time.Time
+		expected uint64
+	}{
+		{UTCNow(), 0},
+		{UTCNow().Add(3 * 24 * time.Hour), 2},
+	}
+	for i, tc := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", bucket)
+		queryVal.Set("date", tc.date.Format(time.RFC3339))
+		req, err := buildAdminRequest(queryVal, http.MethodPost, "/ilm/dry-run", int64(len(lcXML)), bytes.NewReader(lcXML))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: expected status %d but got %d: %s", i+1, http.StatusOK, rec.Code, rec.Body.String())
+		}
+
+		var (
+			objects []string
+			summary *lifecycleDryRunSummary
+		)
+		dec := json.NewDecoder(rec.Body)
+		for {
+			var entry lifecycleDryRunEntry
+			if err = dec.Decode(&entry); err == io.EOF {
+				break
+			} else if err != nil {
+				t.Fatal(err)
+			}
+			if entry.Summary != nil {
+				summary = entry.Summary
+			} else if entry.Object != "" {
+				objects = append(objects, entry.Object)
+			}
+		}
+		if summary == nil {
+			t.Fatalf("Test %d: expected a summary", i+1)
+		}
+		if summary.Scanned != 3 {
+			t.Fatalf("Test %d: expected 3 scanned versions but got %d", i+1, summary.Scanned)
+		}
+		stats := summary.Actions[lifecycle.DeleteAction.String()]
+		if stats.Count != tc.expected || stats.Bytes != 5*tc.expected || uint64(len(objects)) != tc.expected {
+			t.Fatalf("Test %d: expected %d expirations but got %v, %v", i+1, tc.expected, stats, objects)
+		}
+	}
+
+	// Nothing is removed.
+	loi, err := adminTestBed.objLayer.ListObjects(ctx, bucket, "", "", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(loi.Objects) != 3 {
+		t.Fatalf("Expected 3 objects but got %d", len(loi.Objects))
+	}
+}
+
+func TestLifecycleDryRunNewerNoncurrentVersions(t *testing.T) {
+	lc, err := lifecycle.ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><Filter></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>1</NoncurrentDays><NewerNoncurrentVersions>1</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	now := UTCNow()
+	day := 24 * time.Hour
+	versions := []ObjectInfo{
+		{Name: "obj", VersionID: "v4", IsLatest: true, ModTime: now.Add(-1 * day), NumVersions: 4},
+		{Name: "obj", VersionID: "v3", ModTime: now.Add(-5 * day), SuccessorModTime: now.Add(-1 * day), NumVersions: 4},
+		{Name: "obj", VersionID: "v2", ModTime: now.Add(-9 * day), SuccessorModTime: now.Add(-5 * day), NumVersions: 4},
+		{Name: "obj", VersionID: "v1", ModTime: now.Add(-13 * day), SuccessorModTime: now.Add(-9 * day), NumVersions: 4},
+		{Name: "other", VersionID: "v1", IsLatest: true, ModTime: now.Add(-13 * day), NumVersions: 1},
+	}
+	expected := []lifecycle.Action{
+		lifecycle.NoneAction,
+		lifecycle.NoneAction,
+		lifecycle.DeleteVersionAction,
+		lifecycle.DeleteVersionAction,
+		lifecycle.NoneAction,
+	}
+
+	dryRun := lifecycleDryRun{lc: *lc, asOf: now}
+	for i, oi := range versions {
+		if got := dryRun.computeAction(oi); got != expected[i] {
+			t.Fatalf("Test %d: expected %v but got %v", i+1, expected[i], got)
+		}
+	}
+}
+
+func TestBucketStorageClassHandler(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal("Failed to initialize a single node Erasure backend for admin handler tests.", err)
+	}
+	defer adminTestBed.TearDown()
+
+	restoreGlobalStorageClass := globalStorageClass
+	defer func() {
+		globalStorageClass = restoreGlobalStorageClass
+	}()
+	globalStorageClass = storageclass.Config{
+		Custom: map[string]storageclass.StorageClass{
+			"ARCHIVE": {Parity: 6},
+			"EC:3":    {Parity: 3},
+		},
+	}
+
+	bucket := "storage-class-bucket"
+	if err = adminTestBed.objLayer.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		body         string
+		expectedCode int
+	}{
+		{`{"storageClass":"UNKNOWN"}`, http.StatusBadRequest},
+		{`{"storageClass":`, http.StatusBadRequest},
+		{`{"storageClass":"ARCHIVE"}`, http.StatusOK},
+	}
+	for i, tc := range testCases {
+		queryVal := url.Values{}
+		queryVal.Set("bucket", bucket)
+		req, err := buildAdminRequest(queryVal, http.MethodPut, "/set-bucket-storage-class", int64(len(tc.body)), bytes.NewReader([]byte(tc.body)))
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		adminTestBed.router.ServeHTTP(rec, req)
+		if rec.Code != tc.expectedCode {
+			t.Fatalf("Test %d: expected status %d but got %d: %s", i+1, tc.expectedCode, rec.Code, rec.Body.String())
+		}
+	}
+
+	queryVal := url.Values{}
+	queryVal.Set("bucket", bucket)
+	req, err := buildAdminRequest(queryVal, http.MethodGet, "/get-bucket-storage-class", 0, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	adminTestBed.router.ServeHTTP(rec, req)
+	var scCfg bucketStorageClassConfig
+	if err = json.Unmarshal(rec.Body.Bytes(), &scCfg); err != nil {
+		t.Fatal(err)
+	}
+	if scCfg.StorageClass != "ARCHIVE" {
+		t.Fatalf("Expected default storage class ARCHIVE, got %q", scCfg.StorageClass)
+	}
+
+	// Objects uploaded without a storage class use the bucket default, an
+	// explicit storage class takes precedence. Objects written internally
+	// keep the storage class they are written with.
+	objLayer := adminTestBed.objLayer
+	apiRouter := initTestAPIEndPoints(objLayer, []string{"PutObjectExtract", "PutObject", "NewMultipart"})
+	upload := func(method, urlStr string, data []byte, headers map[string]string) *httptest.ResponseRecorder {
+		t.Helper()
+		req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data),
+			globalActiveCred.AccessKey, globalActiveCred.SecretKey, headers)
+		if err != nil {
+			t.Fatal(err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Expected the response status to be `%d`, but instead found `%d`: %s", http.StatusOK, rec.Code, rec.Body.String())
+		}
+		return rec
+	}
+
+	data := []byte("hello")
+	upload(http.MethodPut, getPutObjectURL("", bucket, "default"), data, nil)
+	upload(http.MethodPut, getPutObjectURL("", bucket, "explicit"), data, map[string]string{xhttp.AmzStorageClass: "EC:3"})
+
+	var archive bytes.Buffer
+	tw := tar.NewWriter(&archive)
+	if err = tw.WriteHeader(&tar.Header{Name: "extracted", Mode: 0600, Size: int64(len(data)), ModTime: UTCNow()}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = tw.Write(data); err != nil {
+		t.Fatal(err)
+	}
+	if err = tw.Close(); err != nil {
+		t.Fatal(err)
+	}
+	upload(http.MethodPut, getPutObjectURL("", bucket, "archive.tar"), archive.Bytes(), map[string]string{xhttp.AmzSnowballExtract: "true"})
+
+	rec = upload(http.MethodPost, getNewMultipartURL("", bucket, "multipart"), nil, nil)
+	multipartResponse := &InitiateMultipartUploadResponse{}
+	if err = xml.Unmarshal(rec.Body.Bytes(), multipartResponse); err != nil {
+		t.Fatal(err)
+	}
+	uploadID := multipartResponse.UploadID
+	pi, err := objLayer.PutObjectPart(ctx, bucket, "multipart", uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = objLayer.CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, []CompletePart{{PartNumber: 1, ETag: pi.ETag}}, ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	if _, err = objLayer.PutObject(ctx, bucket, "internal", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	expected := map[string]struct {
+		storageClass string
+		parity       int
+	}{
+		"default":   {"ARCHIVE", 6},
+		"explicit":  {"EC:3", 3},
+		"extracted": {"ARCHIVE", 6},
+		"multipart": {"ARCHIVE", 6},
+		"internal":  {storageclass.STANDARD, objLayer.(*erasureServerPools).serverPools[0].sets[0].defaultParityCount},
+	}
+	disks := objLayer.(*erasureServerPools).serverPools[0].sets[0].getDisks()
+	for object, exp := range expected {
+		fis, errs := readAllFileInfo(ctx, disks, bucket, object, "", false)
+		fi, err := getLatestFileInfo(ctx, fis, errs)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if fi.Erasure.ParityBlocks != exp.parity {
+			t.Errorf("%s: expected parity %d, got %d", object, exp.parity, fi.Erasure.ParityBlocks)
+		}
+	}
+
+	result, err := objLayer.ListObjects(ctx, bucket, "", "", "", 10)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(result.Objects) != len(expected) {
+		t.Fatalf("Expected %d objects, got %d", len(expected), len(result.Objects))
+	}
+	for _, oi := range result.Objects {
+		if oi.StorageClass != expected[oi.Name].storageClass {
+			t.Errorf("%s: expected storage class %s, got %s", oi.Name, expected[oi.Name].storageClass, oi.StorageClass)
+		}
+	}
+}
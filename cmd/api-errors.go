@@ -33,7 +33,10 @@ import (
 	minio "github.com/minio/minio-go/v7"
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/bucket/cors"
+	"github.com/minio/minio/internal/bucket/lambda"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/logging"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/config/dns"
 	"github.com/minio/minio/internal/crypto"
@@ -41,6 +44,7 @@ import (
 
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/bucket/versioning"
+	"github.com/minio/minio/internal/bucket/website"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/hash"
 	"github.com/minio/pkg/bucket/policy"
@@ -112,6 +116,16 @@ const (
 	ErrNoSuchBucketSSEConfig
 	ErrNoSuchCORSConfiguration
 	ErrNoSuchWebsiteConfiguration
+	ErrInvalidTargetBucketForLogging
+	ErrInvalidChecksum
+	ErrContentChecksumMismatch
+	ErrInvalidAttributeName
+	ErrNoSuchLambdaConfiguration
+	ErrInvalidLambdaARN
+	ErrLambdaResponseNotReceived
+	ErrLambdaInvalidResponse
+	ErrInvalidWriteOffset
+	ErrInvalidRenameSource
 	ErrReplicationConfigurationNotFoundError
 	ErrRemoteDestinationNotFoundError
 	ErrReplicationDestinationMissingLock
@@ -837,6 +851,56 @@ var errorCodes = errorCodeMap{
 		Description:    "The specified bucket does not have a website configuration",
 		HTTPStatusCode: http.StatusNotFound,
 	},
+	ErrInvalidTargetBucketForLogging: {
+		Code:           "InvalidTargetBucketForLogging",
+		Description:    "The target bucket for logging does not exist or is not accessible",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrNoSuchLambdaConfiguration: {
+		Code:           "NoSuchObjectLambdaConfiguration",
+		Description:    "The specified bucket does not have an object lambda configuration",
+		HTTPStatusCode: http.StatusNotFound,
+	},
+	ErrInvalidLambdaARN: {
+		Code:           "InvalidArgument",
+		Description:    "The specified lambda ARN is not configured for the bucket",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrLambdaResponseNotReceived: {
+		Code:           "LambdaResponseNotReceived",
+		Description:    "The transformation endpoint did not respond",
+		HTTPStatusCode: http.StatusBadGateway,
+	},
+	ErrLambdaInvalidResponse: {
+		Code:           "LambdaInvalidResponse",
+		Description:    "The transformation endpoint returned an invalid response",
+		HTTPStatusCode: http.StatusBadGateway,
+	},
+	ErrInvalidChecksum: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid checksum provided.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrContentChecksumMismatch: {
+		Code:           "BadDigest",
+		Description:    "The checksum you specified did not match what we received.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidAttributeName: {
+		Code:           "InvalidArgument",
+		Description:    "Invalid attribute name specified.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidWriteOffset: {
+		Code:           "InvalidWriteOffset",
+		Description:    "The write offset value that you specified does not match the current object size.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
+	ErrInvalidRenameSource: {
+		Code:           "InvalidArgument",
+		Description:    "The rename source must be an object or a prefix which does not overlap with the destination.",
+		HTTPStatusCode: http.StatusBadRequest,
+	},
 	ErrReplicationConfigurationNotFoundError: {
 		Code:           "ReplicationConfigurationNotFoundError",
 		Description:    "The replication configuration was not found",
@@ -1863,6 +1927,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrObjectLockInvalidHeaders
 	case objectlock.ErrMalformedXML:
 		apiErr = ErrMalformedXML
+	case hash.ErrInvalidChecksum:
+		apiErr = ErrInvalidChecksum
 	}
 
 	// Compression errors
@@ -1915,6 +1981,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrInvalidVersionID
 	case VersionNotFound:
 		apiErr = ErrNoSuchVersion
+	case PreConditionFailed:
+		apiErr = ErrPreconditionFailed
 	case ObjectAlreadyExists:
 		apiErr = ErrMethodNotAllowed
 	case ObjectNameInvalid:
@@ -1941,6 +2009,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrSignatureDoesNotMatch
 	case hash.SHA256Mismatch:
 		apiErr = ErrContentSHA256Mismatch
+	case hash.ChecksumMismatch:
+		apiErr = ErrContentChecksumMismatch
 	case ObjectTooLarge:
 		apiErr = ErrEntityTooLarge
 	case ObjectTooSmall:
@@ -1949,6 +2019,8 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNotImplemented
 	case PartTooBig:
 		apiErr = ErrEntityTooLarge
+	case InvalidWriteOffset:
+		apiErr = ErrInvalidWriteOffset
 	case UnsupportedMetadata:
 		apiErr = ErrUnsupportedMetadata
 	case BucketPolicyNotFound:
@@ -1959,6 +2031,12 @@ func toAPIErrorCode(ctx context.Context, err error) (apiErr APIErrorCode) {
 		apiErr = ErrNoSuchBucketSSEConfig
 	case BucketTaggingNotFound:
 		apiErr = ErrBucketTaggingNotFound
+	case BucketCorsConfigNotFound:
+		apiErr = ErrNoSuchCORSConfiguration
+	case BucketWebsiteConfigNotFound:
+		apiErr = ErrNoSuchWebsiteConfiguration
+	case BucketLambdaConfigNotFound:
+		apiErr = ErrNoSuchLambdaConfiguration
 	case BucketObjectLockConfigNotFound:
 		apiErr = ErrObjectLockConfigurationNotFound
 	case BucketQuotaConfigNotFound:
@@ -2122,6 +2200,30 @@ func toAPIError(ctx context.Context, err error) APIError {
 				Description:    e.Error(),
 				HTTPStatusCode: http.StatusBadRequest,
 			}
+		case logging.Error:
+			apiErr = APIError{
+				Code:           "InvalidArgument",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case lambda.Error:
+			apiErr = APIError{
+				Code:           "InvalidArgument",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case website.Error:
+			apiErr = APIError{
+				Code:           "InvalidArgument",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
+		case cors.Error:
+			apiErr = APIError{
+				Code:           "InvalidRequest",
+				Description:    e.Error(),
+				HTTPStatusCode: http.StatusBadRequest,
+			}
 		case replication.Error:
 			apiErr = APIError{
 				Code:           "MalformedXML",
@@ -19,8 +19,12 @@ package cmd
 
 import (
 	"encoding/base64"
+	"net/http"
 	"net/url"
 	"strconv"
+	"strings"
+
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // Parse bucket url queries
@@ -151,3 +155,44 @@ func getObjectResources(values url.Values) (uploadID string, partNumberMarker, m
 	encodingType = values.Get("encoding-type")
 	return
 }
+
+// Parse object attributes args from the request headers.
+func getObjectAttributesArgs(header http.Header) (attributes map[string]bool, partNumberMarker, maxParts int, errCode APIErrorCode) {
+	var err error
+	errCode = ErrNone
+
+	attributes = make(map[string]bool)
+	for _, value := range header.Values(xhttp.AmzObjectAttributes) {
+		for _, attribute := range strings.Split(value, ",") {
+			attribute = strings.TrimSpace(attribute)
+			switch attribute {
+			case "ETag", "Checksum", "ObjectParts", "StorageClass", "ObjectSize":
+				attributes[attribute] = true
+			default:
+				errCode = ErrInvalidAttributeName
+				return
+			}
+		}
+	}
+	if len(attributes) == 0 {
+		errCode = ErrInvalidAttributeName
+		return
+	}
+
+	if header.Get(xhttp.AmzMaxParts) != "" {
+		if maxParts, err = strconv.Atoi(header.Get(xhttp.AmzMaxParts)); err != nil || maxParts < 0 {
+			errCode = ErrInvalidMaxParts
+			return
+		}
+	} else {
+		maxParts = maxPartsList
+	}
+
+	if header.Get(xhttp.AmzPartNumberMarker) != "" {
+		if partNumberMarker, err = strconv.Atoi(header.Get(xhttp.AmzPartNumberMarker)); err != nil || partNumberMarker < 0 {
+			errCode = ErrInvalidPartNumberMarker
+			return
+		}
+	}
+	return
+}
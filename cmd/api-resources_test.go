@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"net/http"
 	"net/url"
 	"testing"
 )
@@ -220,3 +221,80 @@ func TestGetObjectsResources(t *testing.T) {
 		}
 	}
 }
+
+func TestGetObjectAttributesArgs(t *testing.T) {
+	testCases := []struct {
+		header                     http.Header
+		attributes                 []string
+		partNumberMarker, maxParts int
+		errCode                    APIErrorCode
+	}{
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes":  []string{"ETag, ObjectParts", "ObjectSize"},
+				"X-Amz-Part-Number-Marker": []string{"2"},
+				"X-Amz-Max-Parts":          []string{"10"},
+			},
+			attributes:       []string{"ETag", "ObjectParts", "ObjectSize"},
+			partNumberMarker: 2,
+			maxParts:         10,
+			errCode:          ErrNone,
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes": []string{"Checksum,StorageClass"},
+			},
+			attributes: []string{"Checksum", "StorageClass"},
+			maxParts:   maxPartsList,
+			errCode:    ErrNone,
+		},
+		{
+			header:  http.Header{},
+			errCode: ErrInvalidAttributeName,
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes": []string{"ETag,Owner"},
+			},
+			errCode: ErrInvalidAttributeName,
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes": []string{"ObjectParts"},
+				"X-Amz-Max-Parts":         []string{"-1"},
+			},
+			errCode: ErrInvalidMaxParts,
+		},
+		{
+			header: http.Header{
+				"X-Amz-Object-Attributes":  []string{"ObjectParts"},
+				"X-Amz-Part-Number-Marker": []string{"one"},
+			},
+			errCode: ErrInvalidPartNumberMarker,
+		},
+	}
+
+	for i, testCase := range testCases {
+		attributes, partNumberMarker, maxParts, errCode := getObjectAttributesArgs(testCase.header)
+		if errCode != testCase.errCode {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.errCode, errCode)
+		}
+		if errCode != ErrNone {
+			continue
+		}
+		if len(attributes) != len(testCase.attributes) {
+			t.Errorf("Test %d: Expected %v, got %v", i+1, testCase.attributes, attributes)
+		}
+		for _, attribute := range testCase.attributes {
+			if !attributes[attribute] {
+				t.Errorf("Test %d: Expected attribute %s to be set", i+1, attribute)
+			}
+		}
+		if partNumberMarker != testCase.partNumberMarker {
+			t.Errorf("Test %d: Expected %d, got %d", i+1, testCase.partNumberMarker, partNumberMarker)
+		}
+		if maxParts != testCase.maxParts {
+			t.Errorf("Test %d: Expected %d, got %d", i+1, testCase.maxParts, maxParts)
+		}
+	}
+}
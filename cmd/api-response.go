@@ -31,6 +31,7 @@ import (
 
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/handlers"
+	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 )
@@ -156,12 +157,50 @@ type ListObjectsV2Response struct {
 	EncodingType string `xml:"EncodingType,omitempty"`
 }
 
+// ChecksumResponse container for the x-amz-checksum-XXX checksum
+// of an object or part, at most one of the fields is set.
+type ChecksumResponse struct {
+	ChecksumCRC32  string `xml:"ChecksumCRC32,omitempty"`
+	ChecksumCRC32C string `xml:"ChecksumCRC32C,omitempty"`
+	ChecksumSHA1   string `xml:"ChecksumSHA1,omitempty"`
+	ChecksumSHA256 string `xml:"ChecksumSHA256,omitempty"`
+}
+
+// GetObjectAttributesResponse container for the requested object attributes.
+type GetObjectAttributesResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ GetObjectAttributesResponse" json:"-"`
+
+	ETag         string                 `xml:",omitempty"`
+	Checksum     *ChecksumResponse      `xml:",omitempty"`
+	ObjectParts  *ObjectAttributesParts `xml:",omitempty"`
+	StorageClass string                 `xml:",omitempty"`
+	ObjectSize   *int64                 `xml:",omitempty"`
+}
+
+// ObjectAttributesParts container for the parts of a multipart object.
+type ObjectAttributesParts struct {
+	PartsCount           int
+	PartNumberMarker     int
+	NextPartNumberMarker int
+	MaxParts             int
+	IsTruncated          bool
+	Parts                []ObjectAttributesPart `xml:"Part"`
+}
+
+// ObjectAttributesPart container for the attributes of an object part.
+type ObjectAttributesPart struct {
+	PartNumber int
+	Size       int64
+	ChecksumResponse
+}
+
 // Part container for part metadata.
 type Part struct {
 	PartNumber   int
 	LastModified string
 	ETag         string
 	Size         int64
+	ChecksumResponse
 }
 
 // ListPartsResponse - format for list parts response.
@@ -318,11 +357,19 @@ type CopyObjectResponse struct {
 	ETag         string   // md5sum of the copied object.
 }
 
+// RenamePrefixResponse container returns the number of objects renamed
+// by a prefix rename.
+type RenamePrefixResponse struct {
+	XMLName xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ RenamePrefixResult" json:"-"`
+	Renamed int
+}
+
 // CopyObjectPartResponse container returns ETag and LastModified of the successfully copied object
 type CopyObjectPartResponse struct {
 	XMLName      xml.Name `xml:"http://s3.amazonaws.com/doc/2006-03-01/ CopyPartResult" json:"-"`
 	LastModified string   // time string of format "2006-01-02T15:04:05.000Z"
 	ETag         string   // md5sum of the copied object part.
+	ChecksumResponse
 }
 
 // Initiator inherit from Owner struct, fields are same
@@ -351,6 +398,7 @@ type CompleteMultipartUploadResponse struct {
 	Bucket   string
 	Key      string
 	ETag     string
+	ChecksumResponse
 }
 
 // DeleteError structure.
@@ -653,6 +701,50 @@ func generateCompleteMultpartUploadResponse(bucket, key, location, etag string)
 	}
 }
 
+// generates ChecksumResponse for the given checksum.
+func generateChecksumResponse(cs *hash.Checksum) (r ChecksumResponse) {
+	if !cs.Valid() {
+		return r
+	}
+	switch {
+	case cs.Type.Is(hash.ChecksumCRC32):
+		r.ChecksumCRC32 = cs.Encoded
+	case cs.Type.Is(hash.ChecksumCRC32C):
+		r.ChecksumCRC32C = cs.Encoded
+	case cs.Type.Is(hash.ChecksumSHA1):
+		r.ChecksumSHA1 = cs.Encoded
+	case cs.Type.Is(hash.ChecksumSHA256):
+		r.ChecksumSHA256 = cs.Encoded
+	}
+	return r
+}
+
+// generates ObjectAttributesParts for the parts of an object, starting
+// after the part partNumberMarker.
+func generateObjectAttributesParts(parts []ObjectPartInfo, partNumberMarker, maxParts int) *ObjectAttributesParts {
+	objectParts := &ObjectAttributesParts{
+		PartsCount:       len(parts),
+		PartNumberMarker: partNumberMarker,
+		MaxParts:         maxParts,
+	}
+	for _, part := range parts {
+		if part.Number <= partNumberMarker {
+			continue
+		}
+		if len(objectParts.Parts) == maxParts {
+			objectParts.IsTruncated = true
+			break
+		}
+		objectParts.Parts = append(objectParts.Parts, ObjectAttributesPart{
+			PartNumber:       part.Number,
+			Size:             part.ActualSize,
+			ChecksumResponse: generateChecksumResponse(hash.ParseChecksum(part.Checksum)),
+		})
+		objectParts.NextPartNumberMarker = part.Number
+	}
+	return objectParts
+}
+
 // generates ListPartsResponse from ListPartsInfo.
 func generateListPartsResponse(partsInfo ListPartsInfo, encodingType string) ListPartsResponse {
 	listPartsResponse := ListPartsResponse{}
@@ -683,6 +775,7 @@ func generateListPartsResponse(partsInfo ListPartsInfo, encodingType string) Lis
 		newPart.ETag = "\"" + part.ETag + "\""
 		newPart.Size = part.Size
 		newPart.LastModified = part.LastModified.UTC().Format(iso8601TimeFormat)
+		newPart.ChecksumResponse = generateChecksumResponse(hash.ParseChecksum(part.Checksum))
 		listPartsResponse.Parts[index] = newPart
 	}
 	return listPartsResponse
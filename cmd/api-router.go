@@ -114,24 +114,14 @@ var rejectedBucketAPIs = []rejectedAPI{
 		methods: []string{http.MethodGet, http.MethodPut, http.MethodDelete},
 		queries: []string{"inventory", ""},
 	},
-	{
-		api:     "cors",
-		methods: []string{http.MethodPut, http.MethodDelete},
-		queries: []string{"cors", ""},
-	},
 	{
 		api:     "metrics",
 		methods: []string{http.MethodGet, http.MethodPut, http.MethodDelete},
 		queries: []string{"metrics", ""},
 	},
-	{
-		api:     "website",
-		methods: []string{http.MethodPut},
-		queries: []string{"website", ""},
-	},
 	{
 		api:     "logging",
-		methods: []string{http.MethodPut, http.MethodDelete},
+		methods: []string{http.MethodDelete},
 		queries: []string{"logging", ""},
 	},
 	{
@@ -182,6 +172,13 @@ func registerAPIRouter(router *mux.Router) {
 	// API Router
 	apiRouter := router.PathPrefix(SlashSeparator).Subrouter()
 
+	// Website endpoints are matched first, so that website domains
+	// may be sub-domains of the API domains.
+	var websiteRouters []*mux.Router
+	for _, domainName := range globalWebsiteDomainNames {
+		websiteRouters = append(websiteRouters, apiRouter.Host("{bucket:.+}."+domainName).Subrouter())
+	}
+
 	var routers []*mux.Router
 	for _, domainName := range globalDomainNames {
 		if IsKubernetes() {
@@ -215,6 +212,14 @@ func registerAPIRouter(router *mux.Router) {
 		logger.Fatal(err, "Unable to initialize server")
 	}
 
+	for _, router := range websiteRouters {
+		// Website requests are read-only
+		router.Methods(http.MethodGet, http.MethodHead).HandlerFunc(
+			collectAPIStats("website", maxClients(gz(httpTraceAll(api.WebsiteHandler)))))
+		router.NewRoute().HandlerFunc(
+			collectAPIStats("methodnotallowed", httpTraceAll(websiteMethodNotAllowedHandler)))
+	}
+
 	for _, router := range routers {
 		// Register all rejected object APIs
 		for _, r := range rejectedObjAPIs {
@@ -272,9 +277,18 @@ func registerAPIRouter(router *mux.Router) {
 		// GetObjectLegalHold
 		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobjectlegalhold", maxClients(gz(httpTraceAll(api.GetObjectLegalHoldHandler))))).Queries("legal-hold", "")
+		// GetObjectAttributes
+		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
+			collectAPIStats("getobjectattributes", maxClients(gz(httpTraceHdrs(api.GetObjectAttributesHandler))))).Queries("attributes", "")
+		// GetObjectLambda
+		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
+			collectAPIStats("getobjectlambda", maxClients(httpTraceHdrs(api.GetObjectLambdaHandler)))).Queries("lambdaArn", "{lambdaArn:.+}")
 		// GetObject - note gzip compression is *not* added due to Range requests.
 		router.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(
 			collectAPIStats("getobject", maxClients(httpTraceHdrs(api.GetObjectHandler))))
+		// RenameObject
+		router.Methods(http.MethodPut).Path("/{object:.+}").HeadersRegexp(xhttp.MinIORenameSource, ".+").HandlerFunc(
+			collectAPIStats("renameobject", maxClients(gz(httpTraceAll(api.RenameObjectHandler)))))
 		// CopyObject
 		router.Methods(http.MethodPut).Path("/{object:.+}").HeadersRegexp(xhttp.AmzCopySource, ".*?(\\/|%2F).*?").HandlerFunc(
 			collectAPIStats("copyobject", maxClients(gz(httpTraceAll(api.CopyObjectHandler)))))
@@ -314,6 +328,12 @@ func registerAPIRouter(router *mux.Router) {
 		// GetBucketEncryption
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketencryption", maxClients(gz(httpTraceAll(api.GetBucketEncryptionHandler))))).Queries("encryption", "")
+		// GetBucketCors
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketcors", maxClients(gz(httpTraceAll(api.GetBucketCorsHandler))))).Queries("cors", "")
+		// GetBucketWebsite
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketwebsite", maxClients(gz(httpTraceAll(api.GetBucketWebsiteHandler))))).Queries("website", "")
 		// GetBucketObjectLockConfig
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketobjectlockconfiguration", maxClients(gz(httpTraceAll(api.GetBucketObjectLockConfigHandler))))).Queries("object-lock", "")
@@ -337,27 +357,21 @@ func registerAPIRouter(router *mux.Router) {
 		// PutBucketACL -- this is a dummy call.
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketacl", maxClients(gz(httpTraceAll(api.PutBucketACLHandler))))).Queries("acl", "")
-		// GetBucketCors - this is a dummy call.
-		router.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketcors", maxClients(gz(httpTraceAll(api.GetBucketCorsHandler))))).Queries("cors", "")
-		// GetBucketWebsiteHandler - this is a dummy call.
-		router.Methods(http.MethodGet).HandlerFunc(
-			collectAPIStats("getbucketwebsite", maxClients(gz(httpTraceAll(api.GetBucketWebsiteHandler))))).Queries("website", "")
 		// GetBucketAccelerateHandler - this is a dummy call.
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketaccelerate", maxClients(gz(httpTraceAll(api.GetBucketAccelerateHandler))))).Queries("accelerate", "")
 		// GetBucketRequestPaymentHandler - this is a dummy call.
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketrequestpayment", maxClients(gz(httpTraceAll(api.GetBucketRequestPaymentHandler))))).Queries("requestPayment", "")
-		// GetBucketLoggingHandler - this is a dummy call.
+		// GetBucketLogging
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbucketlogging", maxClients(gz(httpTraceAll(api.GetBucketLoggingHandler))))).Queries("logging", "")
+		// GetBucketObjectLambda
+		router.Methods(http.MethodGet).HandlerFunc(
+			collectAPIStats("getbucketobjectlambda", maxClients(gz(httpTraceAll(api.GetBucketObjectLambdaHandler))))).Queries("object-lambda", "")
 		// GetBucketTaggingHandler
 		router.Methods(http.MethodGet).HandlerFunc(
 			collectAPIStats("getbuckettagging", maxClients(gz(httpTraceAll(api.GetBucketTaggingHandler))))).Queries("tagging", "")
-		//DeleteBucketWebsiteHandler
-		router.Methods(http.MethodDelete).HandlerFunc(
-			collectAPIStats("deletebucketwebsite", maxClients(gz(httpTraceAll(api.DeleteBucketWebsiteHandler))))).Queries("website", "")
 		// DeleteBucketTaggingHandler
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebuckettagging", maxClients(gz(httpTraceAll(api.DeleteBucketTaggingHandler))))).Queries("tagging", "")
@@ -386,6 +400,18 @@ func registerAPIRouter(router *mux.Router) {
 		// PutBucketEncryption
 		router.Methods(http.MethodPut).HandlerFunc(
 			collectAPIStats("putbucketencryption", maxClients(gz(httpTraceAll(api.PutBucketEncryptionHandler))))).Queries("encryption", "")
+		// PutBucketCors
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketcors", maxClients(gz(httpTraceAll(api.PutBucketCorsHandler))))).Queries("cors", "")
+		// PutBucketWebsite
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketwebsite", maxClients(gz(httpTraceAll(api.PutBucketWebsiteHandler))))).Queries("website", "")
+		// PutBucketLogging
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketlogging", maxClients(gz(httpTraceAll(api.PutBucketLoggingHandler))))).Queries("logging", "")
+		// PutBucketObjectLambda
+		router.Methods(http.MethodPut).HandlerFunc(
+			collectAPIStats("putbucketobjectlambda", maxClients(gz(httpTraceAll(api.PutBucketObjectLambdaHandler))))).Queries("object-lambda", "")
 
 		// PutBucketPolicy
 		router.Methods(http.MethodPut).HandlerFunc(
@@ -430,6 +456,15 @@ func registerAPIRouter(router *mux.Router) {
 		// DeleteBucketEncryption
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucketencryption", maxClients(gz(httpTraceAll(api.DeleteBucketEncryptionHandler))))).Queries("encryption", "")
+		// DeleteBucketCors
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketcors", maxClients(gz(httpTraceAll(api.DeleteBucketCorsHandler))))).Queries("cors", "")
+		// DeleteBucketWebsite
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketwebsite", maxClients(gz(httpTraceAll(api.DeleteBucketWebsiteHandler))))).Queries("website", "")
+		// DeleteBucketObjectLambda
+		router.Methods(http.MethodDelete).HandlerFunc(
+			collectAPIStats("deletebucketobjectlambda", maxClients(gz(httpTraceAll(api.DeleteBucketObjectLambdaHandler))))).Queries("object-lambda", "")
 		// DeleteBucket
 		router.Methods(http.MethodDelete).HandlerFunc(
 			collectAPIStats("deletebucket", maxClients(gz(httpTraceAll(api.DeleteBucketHandler)))))
@@ -497,7 +532,7 @@ func corsHandler(handler http.Handler) http.Handler {
 		"*",
 	}
 
-	return cors.New(cors.Options{
+	globalCors := cors.New(cors.Options{
 		AllowOriginFunc: func(origin string) bool {
 			for _, allowedOrigin := range globalAPIConfig.getCorsAllowOrigins() {
 				if wildcard.MatchSimple(allowedOrigin, origin) {
@@ -519,4 +554,13 @@ func corsHandler(handler http.Handler) http.Handler {
 		ExposedHeaders:   commonS3Headers,
 		AllowCredentials: true,
 	}).Handler(handler)
+
+	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		// CORS rules of a bucket take precedence over the global settings.
+		if config, ok := getBucketCorsConfig(r); ok {
+			serveBucketCors(w, r, config, handler)
+			return
+		}
+		globalCors.ServeHTTP(w, r)
+	})
 }
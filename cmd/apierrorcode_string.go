@@ -47,248 +47,258 @@ func _() {
 	_ = x[ErrNoSuchBucketSSEConfig-36]
 	_ = x[ErrNoSuchCORSConfiguration-37]
 	_ = x[ErrNoSuchWebsiteConfiguration-38]
-	_ = x[ErrReplicationConfigurationNotFoundError-39]
-	_ = x[ErrRemoteDestinationNotFoundError-40]
-	_ = x[ErrReplicationDestinationMissingLock-41]
-	_ = x[ErrRemoteTargetNotFoundError-42]
-	_ = x[ErrReplicationRemoteConnectionError-43]
-	_ = x[ErrReplicationBandwidthLimitError-44]
-	_ = x[ErrBucketRemoteIdenticalToSource-45]
-	_ = x[ErrBucketRemoteAlreadyExists-46]
-	_ = x[ErrBucketRemoteLabelInUse-47]
-	_ = x[ErrBucketRemoteArnTypeInvalid-48]
-	_ = x[ErrBucketRemoteArnInvalid-49]
-	_ = x[ErrBucketRemoteRemoveDisallowed-50]
-	_ = x[ErrRemoteTargetNotVersionedError-51]
-	_ = x[ErrReplicationSourceNotVersionedError-52]
-	_ = x[ErrReplicationNeedsVersioningError-53]
-	_ = x[ErrReplicationBucketNeedsVersioningError-54]
-	_ = x[ErrReplicationNoMatchingRuleError-55]
-	_ = x[ErrObjectRestoreAlreadyInProgress-56]
-	_ = x[ErrNoSuchKey-57]
-	_ = x[ErrNoSuchUpload-58]
-	_ = x[ErrInvalidVersionID-59]
-	_ = x[ErrNoSuchVersion-60]
-	_ = x[ErrNotImplemented-61]
-	_ = x[ErrPreconditionFailed-62]
-	_ = x[ErrRequestTimeTooSkewed-63]
-	_ = x[ErrSignatureDoesNotMatch-64]
-	_ = x[ErrMethodNotAllowed-65]
-	_ = x[ErrInvalidPart-66]
-	_ = x[ErrInvalidPartOrder-67]
-	_ = x[ErrAuthorizationHeaderMalformed-68]
-	_ = x[ErrMalformedPOSTRequest-69]
-	_ = x[ErrPOSTFileRequired-70]
-	_ = x[ErrSignatureVersionNotSupported-71]
-	_ = x[ErrBucketNotEmpty-72]
-	_ = x[ErrAllAccessDisabled-73]
-	_ = x[ErrMalformedPolicy-74]
-	_ = x[ErrMissingFields-75]
-	_ = x[ErrMissingCredTag-76]
-	_ = x[ErrCredMalformed-77]
-	_ = x[ErrInvalidRegion-78]
-	_ = x[ErrInvalidServiceS3-79]
-	_ = x[ErrInvalidServiceSTS-80]
-	_ = x[ErrInvalidRequestVersion-81]
-	_ = x[ErrMissingSignTag-82]
-	_ = x[ErrMissingSignHeadersTag-83]
-	_ = x[ErrMalformedDate-84]
-	_ = x[ErrMalformedPresignedDate-85]
-	_ = x[ErrMalformedCredentialDate-86]
-	_ = x[ErrMalformedCredentialRegion-87]
-	_ = x[ErrMalformedExpires-88]
-	_ = x[ErrNegativeExpires-89]
-	_ = x[ErrAuthHeaderEmpty-90]
-	_ = x[ErrExpiredPresignRequest-91]
-	_ = x[ErrRequestNotReadyYet-92]
-	_ = x[ErrUnsignedHeaders-93]
-	_ = x[ErrMissingDateHeader-94]
-	_ = x[ErrInvalidQuerySignatureAlgo-95]
-	_ = x[ErrInvalidQueryParams-96]
-	_ = x[ErrBucketAlreadyOwnedByYou-97]
-	_ = x[ErrInvalidDuration-98]
-	_ = x[ErrBucketAlreadyExists-99]
-	_ = x[ErrMetadataTooLarge-100]
-	_ = x[ErrUnsupportedMetadata-101]
-	_ = x[ErrMaximumExpires-102]
-	_ = x[ErrSlowDown-103]
-	_ = x[ErrInvalidPrefixMarker-104]
-	_ = x[ErrBadRequest-105]
-	_ = x[ErrKeyTooLongError-106]
-	_ = x[ErrInvalidBucketObjectLockConfiguration-107]
-	_ = x[ErrObjectLockConfigurationNotFound-108]
-	_ = x[ErrObjectLockConfigurationNotAllowed-109]
-	_ = x[ErrNoSuchObjectLockConfiguration-110]
-	_ = x[ErrObjectLocked-111]
-	_ = x[ErrInvalidRetentionDate-112]
-	_ = x[ErrPastObjectLockRetainDate-113]
-	_ = x[ErrUnknownWORMModeDirective-114]
-	_ = x[ErrBucketTaggingNotFound-115]
-	_ = x[ErrObjectLockInvalidHeaders-116]
-	_ = x[ErrInvalidTagDirective-117]
-	_ = x[ErrInvalidEncryptionMethod-118]
-	_ = x[ErrInsecureSSECustomerRequest-119]
-	_ = x[ErrSSEMultipartEncrypted-120]
-	_ = x[ErrSSEEncryptedObject-121]
-	_ = x[ErrInvalidEncryptionParameters-122]
-	_ = x[ErrInvalidSSECustomerAlgorithm-123]
-	_ = x[ErrInvalidSSECustomerKey-124]
-	_ = x[ErrMissingSSECustomerKey-125]
-	_ = x[ErrMissingSSECustomerKeyMD5-126]
-	_ = x[ErrSSECustomerKeyMD5Mismatch-127]
-	_ = x[ErrInvalidSSECustomerParameters-128]
-	_ = x[ErrIncompatibleEncryptionMethod-129]
-	_ = x[ErrKMSNotConfigured-130]
-	_ = x[ErrNoAccessKey-131]
-	_ = x[ErrInvalidToken-132]
-	_ = x[ErrEventNotification-133]
-	_ = x[ErrARNNotification-134]
-	_ = x[ErrRegionNotification-135]
-	_ = x[ErrOverlappingFilterNotification-136]
-	_ = x[ErrFilterNameInvalid-137]
-	_ = x[ErrFilterNamePrefix-138]
-	_ = x[ErrFilterNameSuffix-139]
-	_ = x[ErrFilterValueInvalid-140]
-	_ = x[ErrOverlappingConfigs-141]
-	_ = x[ErrUnsupportedNotification-142]
-	_ = x[ErrContentSHA256Mismatch-143]
-	_ = x[ErrReadQuorum-144]
-	_ = x[ErrWriteQuorum-145]
-	_ = x[ErrStorageFull-146]
-	_ = x[ErrRequestBodyParse-147]
-	_ = x[ErrObjectExistsAsDirectory-148]
-	_ = x[ErrInvalidObjectName-149]
-	_ = x[ErrInvalidObjectNamePrefixSlash-150]
-	_ = x[ErrInvalidResourceName-151]
-	_ = x[ErrServerNotInitialized-152]
-	_ = x[ErrOperationTimedOut-153]
-	_ = x[ErrClientDisconnected-154]
-	_ = x[ErrOperationMaxedOut-155]
-	_ = x[ErrInvalidRequest-156]
-	_ = x[ErrTransitionStorageClassNotFoundError-157]
-	_ = x[ErrInvalidStorageClass-158]
-	_ = x[ErrBackendDown-159]
-	_ = x[ErrMalformedJSON-160]
-	_ = x[ErrAdminNoSuchUser-161]
-	_ = x[ErrAdminNoSuchGroup-162]
-	_ = x[ErrAdminGroupNotEmpty-163]
-	_ = x[ErrAdminNoSuchPolicy-164]
-	_ = x[ErrAdminInvalidArgument-165]
-	_ = x[ErrAdminInvalidAccessKey-166]
-	_ = x[ErrAdminInvalidSecretKey-167]
-	_ = x[ErrAdminConfigNoQuorum-168]
-	_ = x[ErrAdminConfigTooLarge-169]
-	_ = x[ErrAdminConfigBadJSON-170]
-	_ = x[ErrAdminConfigDuplicateKeys-171]
-	_ = x[ErrAdminCredentialsMismatch-172]
-	_ = x[ErrInsecureClientRequest-173]
-	_ = x[ErrObjectTampered-174]
-	_ = x[ErrAdminBucketQuotaExceeded-175]
-	_ = x[ErrAdminNoSuchQuotaConfiguration-176]
-	_ = x[ErrHealNotImplemented-177]
-	_ = x[ErrHealNoSuchProcess-178]
-	_ = x[ErrHealInvalidClientToken-179]
-	_ = x[ErrHealMissingBucket-180]
-	_ = x[ErrHealAlreadyRunning-181]
-	_ = x[ErrHealOverlappingPaths-182]
-	_ = x[ErrIncorrectContinuationToken-183]
-	_ = x[ErrEmptyRequestBody-184]
-	_ = x[ErrUnsupportedFunction-185]
-	_ = x[ErrInvalidExpressionType-186]
-	_ = x[ErrBusy-187]
-	_ = x[ErrUnauthorizedAccess-188]
-	_ = x[ErrExpressionTooLong-189]
-	_ = x[ErrIllegalSQLFunctionArgument-190]
-	_ = x[ErrInvalidKeyPath-191]
-	_ = x[ErrInvalidCompressionFormat-192]
-	_ = x[ErrInvalidFileHeaderInfo-193]
-	_ = x[ErrInvalidJSONType-194]
-	_ = x[ErrInvalidQuoteFields-195]
-	_ = x[ErrInvalidRequestParameter-196]
-	_ = x[ErrInvalidDataType-197]
-	_ = x[ErrInvalidTextEncoding-198]
-	_ = x[ErrInvalidDataSource-199]
-	_ = x[ErrInvalidTableAlias-200]
-	_ = x[ErrMissingRequiredParameter-201]
-	_ = x[ErrObjectSerializationConflict-202]
-	_ = x[ErrUnsupportedSQLOperation-203]
-	_ = x[ErrUnsupportedSQLStructure-204]
-	_ = x[ErrUnsupportedSyntax-205]
-	_ = x[ErrUnsupportedRangeHeader-206]
-	_ = x[ErrLexerInvalidChar-207]
-	_ = x[ErrLexerInvalidOperator-208]
-	_ = x[ErrLexerInvalidLiteral-209]
-	_ = x[ErrLexerInvalidIONLiteral-210]
-	_ = x[ErrParseExpectedDatePart-211]
-	_ = x[ErrParseExpectedKeyword-212]
-	_ = x[ErrParseExpectedTokenType-213]
-	_ = x[ErrParseExpected2TokenTypes-214]
-	_ = x[ErrParseExpectedNumber-215]
-	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-216]
-	_ = x[ErrParseExpectedTypeName-217]
-	_ = x[ErrParseExpectedWhenClause-218]
-	_ = x[ErrParseUnsupportedToken-219]
-	_ = x[ErrParseUnsupportedLiteralsGroupBy-220]
-	_ = x[ErrParseExpectedMember-221]
-	_ = x[ErrParseUnsupportedSelect-222]
-	_ = x[ErrParseUnsupportedCase-223]
-	_ = x[ErrParseUnsupportedCaseClause-224]
-	_ = x[ErrParseUnsupportedAlias-225]
-	_ = x[ErrParseUnsupportedSyntax-226]
-	_ = x[ErrParseUnknownOperator-227]
-	_ = x[ErrParseMissingIdentAfterAt-228]
-	_ = x[ErrParseUnexpectedOperator-229]
-	_ = x[ErrParseUnexpectedTerm-230]
-	_ = x[ErrParseUnexpectedToken-231]
-	_ = x[ErrParseUnexpectedKeyword-232]
-	_ = x[ErrParseExpectedExpression-233]
-	_ = x[ErrParseExpectedLeftParenAfterCast-234]
-	_ = x[ErrParseExpectedLeftParenValueConstructor-235]
-	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-236]
-	_ = x[ErrParseExpectedArgumentDelimiter-237]
-	_ = x[ErrParseCastArity-238]
-	_ = x[ErrParseInvalidTypeParam-239]
-	_ = x[ErrParseEmptySelect-240]
-	_ = x[ErrParseSelectMissingFrom-241]
-	_ = x[ErrParseExpectedIdentForGroupName-242]
-	_ = x[ErrParseExpectedIdentForAlias-243]
-	_ = x[ErrParseUnsupportedCallWithStar-244]
-	_ = x[ErrParseNonUnaryAgregateFunctionCall-245]
-	_ = x[ErrParseMalformedJoin-246]
-	_ = x[ErrParseExpectedIdentForAt-247]
-	_ = x[ErrParseAsteriskIsNotAloneInSelectList-248]
-	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-249]
-	_ = x[ErrParseInvalidContextForWildcardInSelectList-250]
-	_ = x[ErrIncorrectSQLFunctionArgumentType-251]
-	_ = x[ErrValueParseFailure-252]
-	_ = x[ErrEvaluatorInvalidArguments-253]
-	_ = x[ErrIntegerOverflow-254]
-	_ = x[ErrLikeInvalidInputs-255]
-	_ = x[ErrCastFailed-256]
-	_ = x[ErrInvalidCast-257]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-258]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-259]
-	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-260]
-	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-261]
-	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-262]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-263]
-	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-264]
-	_ = x[ErrEvaluatorBindingDoesNotExist-265]
-	_ = x[ErrMissingHeaders-266]
-	_ = x[ErrInvalidColumnIndex-267]
-	_ = x[ErrAdminConfigNotificationTargetsFailed-268]
-	_ = x[ErrAdminProfilerNotEnabled-269]
-	_ = x[ErrInvalidDecompressedSize-270]
-	_ = x[ErrAddUserInvalidArgument-271]
-	_ = x[ErrAdminAccountNotEligible-272]
-	_ = x[ErrAccountNotEligible-273]
-	_ = x[ErrAdminServiceAccountNotFound-274]
-	_ = x[ErrPostPolicyConditionInvalidFormat-275]
+	_ = x[ErrInvalidTargetBucketForLogging-39]
+	_ = x[ErrInvalidChecksum-40]
+	_ = x[ErrContentChecksumMismatch-41]
+	_ = x[ErrInvalidAttributeName-42]
+	_ = x[ErrNoSuchLambdaConfiguration-43]
+	_ = x[ErrInvalidLambdaARN-44]
+	_ = x[ErrLambdaResponseNotReceived-45]
+	_ = x[ErrLambdaInvalidResponse-46]
+	_ = x[ErrInvalidWriteOffset-47]
+	_ = x[ErrInvalidRenameSource-48]
+	_ = x[ErrReplicationConfigurationNotFoundError-49]
+	_ = x[ErrRemoteDestinationNotFoundError-50]
+	_ = x[ErrReplicationDestinationMissingLock-51]
+	_ = x[ErrRemoteTargetNotFoundError-52]
+	_ = x[ErrReplicationRemoteConnectionError-53]
+	_ = x[ErrReplicationBandwidthLimitError-54]
+	_ = x[ErrBucketRemoteIdenticalToSource-55]
+	_ = x[ErrBucketRemoteAlreadyExists-56]
+	_ = x[ErrBucketRemoteLabelInUse-57]
+	_ = x[ErrBucketRemoteArnTypeInvalid-58]
+	_ = x[ErrBucketRemoteArnInvalid-59]
+	_ = x[ErrBucketRemoteRemoveDisallowed-60]
+	_ = x[ErrRemoteTargetNotVersionedError-61]
+	_ = x[ErrReplicationSourceNotVersionedError-62]
+	_ = x[ErrReplicationNeedsVersioningError-63]
+	_ = x[ErrReplicationBucketNeedsVersioningError-64]
+	_ = x[ErrReplicationNoMatchingRuleError-65]
+	_ = x[ErrObjectRestoreAlreadyInProgress-66]
+	_ = x[ErrNoSuchKey-67]
+	_ = x[ErrNoSuchUpload-68]
+	_ = x[ErrInvalidVersionID-69]
+	_ = x[ErrNoSuchVersion-70]
+	_ = x[ErrNotImplemented-71]
+	_ = x[ErrPreconditionFailed-72]
+	_ = x[ErrRequestTimeTooSkewed-73]
+	_ = x[ErrSignatureDoesNotMatch-74]
+	_ = x[ErrMethodNotAllowed-75]
+	_ = x[ErrInvalidPart-76]
+	_ = x[ErrInvalidPartOrder-77]
+	_ = x[ErrAuthorizationHeaderMalformed-78]
+	_ = x[ErrMalformedPOSTRequest-79]
+	_ = x[ErrPOSTFileRequired-80]
+	_ = x[ErrSignatureVersionNotSupported-81]
+	_ = x[ErrBucketNotEmpty-82]
+	_ = x[ErrAllAccessDisabled-83]
+	_ = x[ErrMalformedPolicy-84]
+	_ = x[ErrMissingFields-85]
+	_ = x[ErrMissingCredTag-86]
+	_ = x[ErrCredMalformed-87]
+	_ = x[ErrInvalidRegion-88]
+	_ = x[ErrInvalidServiceS3-89]
+	_ = x[ErrInvalidServiceSTS-90]
+	_ = x[ErrInvalidRequestVersion-91]
+	_ = x[ErrMissingSignTag-92]
+	_ = x[ErrMissingSignHeadersTag-93]
+	_ = x[ErrMalformedDate-94]
+	_ = x[ErrMalformedPresignedDate-95]
+	_ = x[ErrMalformedCredentialDate-96]
+	_ = x[ErrMalformedCredentialRegion-97]
+	_ = x[ErrMalformedExpires-98]
+	_ = x[ErrNegativeExpires-99]
+	_ = x[ErrAuthHeaderEmpty-100]
+	_ = x[ErrExpiredPresignRequest-101]
+	_ = x[ErrRequestNotReadyYet-102]
+	_ = x[ErrUnsignedHeaders-103]
+	_ = x[ErrMissingDateHeader-104]
+	_ = x[ErrInvalidQuerySignatureAlgo-105]
+	_ = x[ErrInvalidQueryParams-106]
+	_ = x[ErrBucketAlreadyOwnedByYou-107]
+	_ = x[ErrInvalidDuration-108]
+	_ = x[ErrBucketAlreadyExists-109]
+	_ = x[ErrMetadataTooLarge-110]
+	_ = x[ErrUnsupportedMetadata-111]
+	_ = x[ErrMaximumExpires-112]
+	_ = x[ErrSlowDown-113]
+	_ = x[ErrInvalidPrefixMarker-114]
+	_ = x[ErrBadRequest-115]
+	_ = x[ErrKeyTooLongError-116]
+	_ = x[ErrInvalidBucketObjectLockConfiguration-117]
+	_ = x[ErrObjectLockConfigurationNotFound-118]
+	_ = x[ErrObjectLockConfigurationNotAllowed-119]
+	_ = x[ErrNoSuchObjectLockConfiguration-120]
+	_ = x[ErrObjectLocked-121]
+	_ = x[ErrInvalidRetentionDate-122]
+	_ = x[ErrPastObjectLockRetainDate-123]
+	_ = x[ErrUnknownWORMModeDirective-124]
+	_ = x[ErrBucketTaggingNotFound-125]
+	_ = x[ErrObjectLockInvalidHeaders-126]
+	_ = x[ErrInvalidTagDirective-127]
+	_ = x[ErrInvalidEncryptionMethod-128]
+	_ = x[ErrInsecureSSECustomerRequest-129]
+	_ = x[ErrSSEMultipartEncrypted-130]
+	_ = x[ErrSSEEncryptedObject-131]
+	_ = x[ErrInvalidEncryptionParameters-132]
+	_ = x[ErrInvalidSSECustomerAlgorithm-133]
+	_ = x[ErrInvalidSSECustomerKey-134]
+	_ = x[ErrMissingSSECustomerKey-135]
+	_ = x[ErrMissingSSECustomerKeyMD5-136]
+	_ = x[ErrSSECustomerKeyMD5Mismatch-137]
+	_ = x[ErrInvalidSSECustomerParameters-138]
+	_ = x[ErrIncompatibleEncryptionMethod-139]
+	_ = x[ErrKMSNotConfigured-140]
+	_ = x[ErrNoAccessKey-141]
+	_ = x[ErrInvalidToken-142]
+	_ = x[ErrEventNotification-143]
+	_ = x[ErrARNNotification-144]
+	_ = x[ErrRegionNotification-145]
+	_ = x[ErrOverlappingFilterNotification-146]
+	_ = x[ErrFilterNameInvalid-147]
+	_ = x[ErrFilterNamePrefix-148]
+	_ = x[ErrFilterNameSuffix-149]
+	_ = x[ErrFilterValueInvalid-150]
+	_ = x[ErrOverlappingConfigs-151]
+	_ = x[ErrUnsupportedNotification-152]
+	_ = x[ErrContentSHA256Mismatch-153]
+	_ = x[ErrReadQuorum-154]
+	_ = x[ErrWriteQuorum-155]
+	_ = x[ErrStorageFull-156]
+	_ = x[ErrRequestBodyParse-157]
+	_ = x[ErrObjectExistsAsDirectory-158]
+	_ = x[ErrInvalidObjectName-159]
+	_ = x[ErrInvalidObjectNamePrefixSlash-160]
+	_ = x[ErrInvalidResourceName-161]
+	_ = x[ErrServerNotInitialized-162]
+	_ = x[ErrOperationTimedOut-163]
+	_ = x[ErrClientDisconnected-164]
+	_ = x[ErrOperationMaxedOut-165]
+	_ = x[ErrInvalidRequest-166]
+	_ = x[ErrTransitionStorageClassNotFoundError-167]
+	_ = x[ErrInvalidStorageClass-168]
+	_ = x[ErrBackendDown-169]
+	_ = x[ErrMalformedJSON-170]
+	_ = x[ErrAdminNoSuchUser-171]
+	_ = x[ErrAdminNoSuchGroup-172]
+	_ = x[ErrAdminGroupNotEmpty-173]
+	_ = x[ErrAdminNoSuchPolicy-174]
+	_ = x[ErrAdminInvalidArgument-175]
+	_ = x[ErrAdminInvalidAccessKey-176]
+	_ = x[ErrAdminInvalidSecretKey-177]
+	_ = x[ErrAdminConfigNoQuorum-178]
+	_ = x[ErrAdminConfigTooLarge-179]
+	_ = x[ErrAdminConfigBadJSON-180]
+	_ = x[ErrAdminConfigDuplicateKeys-181]
+	_ = x[ErrAdminCredentialsMismatch-182]
+	_ = x[ErrInsecureClientRequest-183]
+	_ = x[ErrObjectTampered-184]
+	_ = x[ErrAdminBucketQuotaExceeded-185]
+	_ = x[ErrAdminNoSuchQuotaConfiguration-186]
+	_ = x[ErrHealNotImplemented-187]
+	_ = x[ErrHealNoSuchProcess-188]
+	_ = x[ErrHealInvalidClientToken-189]
+	_ = x[ErrHealMissingBucket-190]
+	_ = x[ErrHealAlreadyRunning-191]
+	_ = x[ErrHealOverlappingPaths-192]
+	_ = x[ErrIncorrectContinuationToken-193]
+	_ = x[ErrEmptyRequestBody-194]
+	_ = x[ErrUnsupportedFunction-195]
+	_ = x[ErrInvalidExpressionType-196]
+	_ = x[ErrBusy-197]
+	_ = x[ErrUnauthorizedAccess-198]
+	_ = x[ErrExpressionTooLong-199]
+	_ = x[ErrIllegalSQLFunctionArgument-200]
+	_ = x[ErrInvalidKeyPath-201]
+	_ = x[ErrInvalidCompressionFormat-202]
+	_ = x[ErrInvalidFileHeaderInfo-203]
+	_ = x[ErrInvalidJSONType-204]
+	_ = x[ErrInvalidQuoteFields-205]
+	_ = x[ErrInvalidRequestParameter-206]
+	_ = x[ErrInvalidDataType-207]
+	_ = x[ErrInvalidTextEncoding-208]
+	_ = x[ErrInvalidDataSource-209]
+	_ = x[ErrInvalidTableAlias-210]
+	_ = x[ErrMissingRequiredParameter-211]
+	_ = x[ErrObjectSerializationConflict-212]
+	_ = x[ErrUnsupportedSQLOperation-213]
+	_ = x[ErrUnsupportedSQLStructure-214]
+	_ = x[ErrUnsupportedSyntax-215]
+	_ = x[ErrUnsupportedRangeHeader-216]
+	_ = x[ErrLexerInvalidChar-217]
+	_ = x[ErrLexerInvalidOperator-218]
+	_ = x[ErrLexerInvalidLiteral-219]
+	_ = x[ErrLexerInvalidIONLiteral-220]
+	_ = x[ErrParseExpectedDatePart-221]
+	_ = x[ErrParseExpectedKeyword-222]
+	_ = x[ErrParseExpectedTokenType-223]
+	_ = x[ErrParseExpected2TokenTypes-224]
+	_ = x[ErrParseExpectedNumber-225]
+	_ = x[ErrParseExpectedRightParenBuiltinFunctionCall-226]
+	_ = x[ErrParseExpectedTypeName-227]
+	_ = x[ErrParseExpectedWhenClause-228]
+	_ = x[ErrParseUnsupportedToken-229]
+	_ = x[ErrParseUnsupportedLiteralsGroupBy-230]
+	_ = x[ErrParseExpectedMember-231]
+	_ = x[ErrParseUnsupportedSelect-232]
+	_ = x[ErrParseUnsupportedCase-233]
+	_ = x[ErrParseUnsupportedCaseClause-234]
+	_ = x[ErrParseUnsupportedAlias-235]
+	_ = x[ErrParseUnsupportedSyntax-236]
+	_ = x[ErrParseUnknownOperator-237]
+	_ = x[ErrParseMissingIdentAfterAt-238]
+	_ = x[ErrParseUnexpectedOperator-239]
+	_ = x[ErrParseUnexpectedTerm-240]
+	_ = x[ErrParseUnexpectedToken-241]
+	_ = x[ErrParseUnexpectedKeyword-242]
+	_ = x[ErrParseExpectedExpression-243]
+	_ = x[ErrParseExpectedLeftParenAfterCast-244]
+	_ = x[ErrParseExpectedLeftParenValueConstructor-245]
+	_ = x[ErrParseExpectedLeftParenBuiltinFunctionCall-246]
+	_ = x[ErrParseExpectedArgumentDelimiter-247]
+	_ = x[ErrParseCastArity-248]
+	_ = x[ErrParseInvalidTypeParam-249]
+	_ = x[ErrParseEmptySelect-250]
+	_ = x[ErrParseSelectMissingFrom-251]
+	_ = x[ErrParseExpectedIdentForGroupName-252]
+	_ = x[ErrParseExpectedIdentForAlias-253]
+	_ = x[ErrParseUnsupportedCallWithStar-254]
+	_ = x[ErrParseNonUnaryAgregateFunctionCall-255]
+	_ = x[ErrParseMalformedJoin-256]
+	_ = x[ErrParseExpectedIdentForAt-257]
+	_ = x[ErrParseAsteriskIsNotAloneInSelectList-258]
+	_ = x[ErrParseCannotMixSqbAndWildcardInSelectList-259]
+	_ = x[ErrParseInvalidContextForWildcardInSelectList-260]
+	_ = x[ErrIncorrectSQLFunctionArgumentType-261]
+	_ = x[ErrValueParseFailure-262]
+	_ = x[ErrEvaluatorInvalidArguments-263]
+	_ = x[ErrIntegerOverflow-264]
+	_ = x[ErrLikeInvalidInputs-265]
+	_ = x[ErrCastFailed-266]
+	_ = x[ErrInvalidCast-267]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPattern-268]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbolForParsing-269]
+	_ = x[ErrEvaluatorTimestampFormatPatternDuplicateFields-270]
+	_ = x[ErrEvaluatorTimestampFormatPatternHourClockAmPmMismatch-271]
+	_ = x[ErrEvaluatorUnterminatedTimestampFormatPatternToken-272]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternToken-273]
+	_ = x[ErrEvaluatorInvalidTimestampFormatPatternSymbol-274]
+	_ = x[ErrEvaluatorBindingDoesNotExist-275]
+	_ = x[ErrMissingHeaders-276]
+	_ = x[ErrInvalidColumnIndex-277]
+	_ = x[ErrAdminConfigNotificationTargetsFailed-278]
+	_ = x[ErrAdminProfilerNotEnabled-279]
+	_ = x[ErrInvalidDecompressedSize-280]
+	_ = x[ErrAddUserInvalidArgument-281]
+	_ = x[ErrAdminAccountNotEligible-282]
+	_ = x[ErrAccountNotEligible-283]
+	_ = x[ErrAdminServiceAccountNotFound-284]
+	_ = x[ErrPostPolicyConditionInvalidFormat-285]
 }
 
-const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationNoMatchingRuleErrorObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormat"
+const _APIErrorCode_name = "NoneAccessDeniedBadDigestEntityTooSmallEntityTooLargePolicyTooLargeIncompleteBodyInternalErrorInvalidAccessKeyIDInvalidBucketNameInvalidDigestInvalidRangeInvalidRangePartNumberInvalidCopyPartRangeInvalidCopyPartRangeSourceInvalidMaxKeysInvalidEncodingMethodInvalidMaxUploadsInvalidMaxPartsInvalidPartNumberMarkerInvalidPartNumberInvalidRequestBodyInvalidCopySourceInvalidMetadataDirectiveInvalidCopyDestInvalidPolicyDocumentInvalidObjectStateMalformedXMLMissingContentLengthMissingContentMD5MissingRequestBodyErrorMissingSecurityHeaderNoSuchBucketNoSuchBucketPolicyNoSuchBucketLifecycleNoSuchLifecycleConfigurationNoSuchBucketSSEConfigNoSuchCORSConfigurationNoSuchWebsiteConfigurationInvalidTargetBucketForLoggingInvalidChecksumContentChecksumMismatchInvalidAttributeNameNoSuchLambdaConfigurationInvalidLambdaARNLambdaResponseNotReceivedLambdaInvalidResponseInvalidWriteOffsetInvalidRenameSourceReplicationConfigurationNotFoundErrorRemoteDestinationNotFoundErrorReplicationDestinationMissingLockRemoteTargetNotFoundErrorReplicationRemoteConnectionErrorReplicationBandwidthLimitErrorBucketRemoteIdenticalToSourceBucketRemoteAlreadyExistsBucketRemoteLabelInUseBucketRemoteArnTypeInvalidBucketRemoteArnInvalidBucketRemoteRemoveDisallowedRemoteTargetNotVersionedErrorReplicationSourceNotVersionedErrorReplicationNeedsVersioningErrorReplicationBucketNeedsVersioningErrorReplicationNoMatchingRuleErrorObjectRestoreAlreadyInProgressNoSuchKeyNoSuchUploadInvalidVersionIDNoSuchVersionNotImplementedPreconditionFailedRequestTimeTooSkewedSignatureDoesNotMatchMethodNotAllowedInvalidPartInvalidPartOrderAuthorizationHeaderMalformedMalformedPOSTRequestPOSTFileRequiredSignatureVersionNotSupportedBucketNotEmptyAllAccessDisabledMalformedPolicyMissingFieldsMissingCredTagCredMalformedInvalidRegionInvalidServiceS3InvalidServiceSTSInvalidRequestVersionMissingSignTagMissingSignHeadersTagMalformedDateMalformedPresignedDateMalformedCredentialDateMalformedCredentialRegionMalformedExpiresNegativeExpiresAuthHeaderEmptyExpiredPresignRequestRequestNotReadyYetUnsignedHeadersMissingDateHeaderInvalidQuerySignatureAlgoInvalidQueryParamsBucketAlreadyOwnedByYouInvalidDurationBucketAlreadyExistsMetadataTooLargeUnsupportedMetadataMaximumExpiresSlowDownInvalidPrefixMarkerBadRequestKeyTooLongErrorInvalidBucketObjectLockConfigurationObjectLockConfigurationNotFoundObjectLockConfigurationNotAllowedNoSuchObjectLockConfigurationObjectLockedInvalidRetentionDatePastObjectLockRetainDateUnknownWORMModeDirectiveBucketTaggingNotFoundObjectLockInvalidHeadersInvalidTagDirectiveInvalidEncryptionMethodInsecureSSECustomerRequestSSEMultipartEncryptedSSEEncryptedObjectInvalidEncryptionParametersInvalidSSECustomerAlgorithmInvalidSSECustomerKeyMissingSSECustomerKeyMissingSSECustomerKeyMD5SSECustomerKeyMD5MismatchInvalidSSECustomerParametersIncompatibleEncryptionMethodKMSNotConfiguredNoAccessKeyInvalidTokenEventNotificationARNNotificationRegionNotificationOverlappingFilterNotificationFilterNameInvalidFilterNamePrefixFilterNameSuffixFilterValueInvalidOverlappingConfigsUnsupportedNotificationContentSHA256MismatchReadQuorumWriteQuorumStorageFullRequestBodyParseObjectExistsAsDirectoryInvalidObjectNameInvalidObjectNamePrefixSlashInvalidResourceNameServerNotInitializedOperationTimedOutClientDisconnectedOperationMaxedOutInvalidRequestTransitionStorageClassNotFoundErrorInvalidStorageClassBackendDownMalformedJSONAdminNoSuchUserAdminNoSuchGroupAdminGroupNotEmptyAdminNoSuchPolicyAdminInvalidArgumentAdminInvalidAccessKeyAdminInvalidSecretKeyAdminConfigNoQuorumAdminConfigTooLargeAdminConfigBadJSONAdminConfigDuplicateKeysAdminCredentialsMismatchInsecureClientRequestObjectTamperedAdminBucketQuotaExceededAdminNoSuchQuotaConfigurationHealNotImplementedHealNoSuchProcessHealInvalidClientTokenHealMissingBucketHealAlreadyRunningHealOverlappingPathsIncorrectContinuationTokenEmptyRequestBodyUnsupportedFunctionInvalidExpressionTypeBusyUnauthorizedAccessExpressionTooLongIllegalSQLFunctionArgumentInvalidKeyPathInvalidCompressionFormatInvalidFileHeaderInfoInvalidJSONTypeInvalidQuoteFieldsInvalidRequestParameterInvalidDataTypeInvalidTextEncodingInvalidDataSourceInvalidTableAliasMissingRequiredParameterObjectSerializationConflictUnsupportedSQLOperationUnsupportedSQLStructureUnsupportedSyntaxUnsupportedRangeHeaderLexerInvalidCharLexerInvalidOperatorLexerInvalidLiteralLexerInvalidIONLiteralParseExpectedDatePartParseExpectedKeywordParseExpectedTokenTypeParseExpected2TokenTypesParseExpectedNumberParseExpectedRightParenBuiltinFunctionCallParseExpectedTypeNameParseExpectedWhenClauseParseUnsupportedTokenParseUnsupportedLiteralsGroupByParseExpectedMemberParseUnsupportedSelectParseUnsupportedCaseParseUnsupportedCaseClauseParseUnsupportedAliasParseUnsupportedSyntaxParseUnknownOperatorParseMissingIdentAfterAtParseUnexpectedOperatorParseUnexpectedTermParseUnexpectedTokenParseUnexpectedKeywordParseExpectedExpressionParseExpectedLeftParenAfterCastParseExpectedLeftParenValueConstructorParseExpectedLeftParenBuiltinFunctionCallParseExpectedArgumentDelimiterParseCastArityParseInvalidTypeParamParseEmptySelectParseSelectMissingFromParseExpectedIdentForGroupNameParseExpectedIdentForAliasParseUnsupportedCallWithStarParseNonUnaryAgregateFunctionCallParseMalformedJoinParseExpectedIdentForAtParseAsteriskIsNotAloneInSelectListParseCannotMixSqbAndWildcardInSelectListParseInvalidContextForWildcardInSelectListIncorrectSQLFunctionArgumentTypeValueParseFailureEvaluatorInvalidArgumentsIntegerOverflowLikeInvalidInputsCastFailedInvalidCastEvaluatorInvalidTimestampFormatPatternEvaluatorInvalidTimestampFormatPatternSymbolForParsingEvaluatorTimestampFormatPatternDuplicateFieldsEvaluatorTimestampFormatPatternHourClockAmPmMismatchEvaluatorUnterminatedTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternTokenEvaluatorInvalidTimestampFormatPatternSymbolEvaluatorBindingDoesNotExistMissingHeadersInvalidColumnIndexAdminConfigNotificationTargetsFailedAdminProfilerNotEnabledInvalidDecompressedSizeAddUserInvalidArgumentAdminAccountNotEligibleAccountNotEligibleAdminServiceAccountNotFoundPostPolicyConditionInvalidFormat"
 
-var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 142, 154, 176, 196, 222, 236, 257, 274, 289, 312, 329, 347, 364, 388, 403, 424, 442, 454, 474, 491, 514, 535, 547, 565, 586, 614, 635, 658, 684, 721, 751, 784, 809, 841, 871, 900, 925, 947, 973, 995, 1023, 1052, 1086, 1117, 1154, 1184, 1214, 1223, 1235, 1251, 1264, 1278, 1296, 1316, 1337, 1353, 1364, 1380, 1408, 1428, 1444, 1472, 1486, 1503, 1518, 1531, 1545, 1558, 1571, 1587, 1604, 1625, 1639, 1660, 1673, 1695, 1718, 1743, 1759, 1774, 1789, 1810, 1828, 1843, 1860, 1885, 1903, 1926, 1941, 1960, 1976, 1995, 2009, 2017, 2036, 2046, 2061, 2097, 2128, 2161, 2190, 2202, 2222, 2246, 2270, 2291, 2315, 2334, 2357, 2383, 2404, 2422, 2449, 2476, 2497, 2518, 2542, 2567, 2595, 2623, 2639, 2650, 2662, 2679, 2694, 2712, 2741, 2758, 2774, 2790, 2808, 2826, 2849, 2870, 2880, 2891, 2902, 2918, 2941, 2958, 2986, 3005, 3025, 3042, 3060, 3077, 3091, 3126, 3145, 3156, 3169, 3184, 3200, 3218, 3235, 3255, 3276, 3297, 3316, 3335, 3353, 3377, 3401, 3422, 3436, 3460, 3489, 3507, 3524, 3546, 3563, 3581, 3601, 3627, 3643, 3662, 3683, 3687, 3705, 3722, 3748, 3762, 3786, 3807, 3822, 3840, 3863, 3878, 3897, 3914, 3931, 3955, 3982, 4005, 4028, 4045, 4067, 4083, 4103, 4122, 4144, 4165, 4185, 4207, 4231, 4250, 4292, 4313, 4336, 4357, 4388, 4407, 4429, 4449, 4475, 4496, 4518, 4538, 4562, 4585, 4604, 4624, 4646, 4669, 4700, 4738, 4779, 4809, 4823, 4844, 4860, 4882, 4912, 4938, 4966, 4999, 5017, 5040, 5075, 5115, 5157, 5189, 5206, 5231, 5246, 5263, 5273, 5284, 5322, 5376, 5422, 5474, 5522, 5565, 5609, 5637, 5651, 5669, 5705, 5728, 5751, 5773, 5796, 5814, 5841, 5873}
+var _APIErrorCode_index = [...]uint16{0, 4, 16, 25, 39, 53, 67, 81, 94, 112, 129, 142, 154, 176, 196, 222, 236, 257, 274, 289, 312, 329, 347, 364, 388, 403, 424, 442, 454, 474, 491, 514, 535, 547, 565, 586, 614, 635, 658, 684, 713, 728, 751, 771, 796, 812, 837, 858, 876, 895, 932, 962, 995, 1020, 1052, 1082, 1111, 1136, 1158, 1184, 1206, 1234, 1263, 1297, 1328, 1365, 1395, 1425, 1434, 1446, 1462, 1475, 1489, 1507, 1527, 1548, 1564, 1575, 1591, 1619, 1639, 1655, 1683, 1697, 1714, 1729, 1742, 1756, 1769, 1782, 1798, 1815, 1836, 1850, 1871, 1884, 1906, 1929, 1954, 1970, 1985, 2000, 2021, 2039, 2054, 2071, 2096, 2114, 2137, 2152, 2171, 2187, 2206, 2220, 2228, 2247, 2257, 2272, 2308, 2339, 2372, 2401, 2413, 2433, 2457, 2481, 2502, 2526, 2545, 2568, 2594, 2615, 2633, 2660, 2687, 2708, 2729, 2753, 2778, 2806, 2834, 2850, 2861, 2873, 2890, 2905, 2923, 2952, 2969, 2985, 3001, 3019, 3037, 3060, 3081, 3091, 3102, 3113, 3129, 3152, 3169, 3197, 3216, 3236, 3253, 3271, 3288, 3302, 3337, 3356, 3367, 3380, 3395, 3411, 3429, 3446, 3466, 3487, 3508, 3527, 3546, 3564, 3588, 3612, 3633, 3647, 3671, 3700, 3718, 3735, 3757, 3774, 3792, 3812, 3838, 3854, 3873, 3894, 3898, 3916, 3933, 3959, 3973, 3997, 4018, 4033, 4051, 4074, 4089, 4108, 4125, 4142, 4166, 4193, 4216, 4239, 4256, 4278, 4294, 4314, 4333, 4355, 4376, 4396, 4418, 4442, 4461, 4503, 4524, 4547, 4568, 4599, 4618, 4640, 4660, 4686, 4707, 4729, 4749, 4773, 4796, 4815, 4835, 4857, 4880, 4911, 4949, 4990, 5020, 5034, 5055, 5071, 5093, 5123, 5149, 5177, 5210, 5228, 5251, 5286, 5326, 5368, 5400, 5417, 5442, 5457, 5474, 5484, 5495, 5533, 5587, 5633, 5685, 5733, 5776, 5820, 5848, 5862, 5880, 5916, 5939, 5962, 5984, 6007, 6025, 6052, 6084}
 
 func (i APIErrorCode) String() string {
 	if i < 0 || i >= APIErrorCode(len(_APIErrorCode_index)-1) {
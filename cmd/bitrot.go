@@ -29,6 +29,7 @@ import (
 	"github.com/minio/highwayhash"
 	"github.com/minio/minio/internal/logger"
 	"golang.org/x/crypto/blake2b"
+	"lukechampine.com/blake3"
 )
 
 // magic HH-256 key as HH-256 hash of the first 100 decimals of π as utf-8 string with a zero key.
@@ -39,6 +40,7 @@ var bitrotAlgorithms = map[BitrotAlgorithm]string{
 	BLAKE2b512:      "blake2b",
 	HighwayHash256:  "highwayhash256",
 	HighwayHash256S: "highwayhash256S",
+	BLAKE3256S:      "blake3S",
 }
 
 // New returns a new hash.Hash calculating the given bitrot algorithm.
@@ -55,6 +57,8 @@ func (a BitrotAlgorithm) New() hash.Hash {
 	case HighwayHash256S:
 		hh, _ := highwayhash.New(magicHighwayHash256Key) // New will never return error since key is 256 bit
 		return hh
+	case BLAKE3256S:
+		return blake3.New(32, nil)
 	default:
 		logger.CriticalIf(GlobalContext, errors.New("Unsupported bitrot algorithm"))
 		return nil
@@ -77,6 +81,21 @@ func (a BitrotAlgorithm) String() string {
 	return name
 }
 
+// Streaming reports whether the given algorithm protects each shard
+// of a part with its own checksum stored next to the shard.
+func (a BitrotAlgorithm) Streaming() bool {
+	return a == HighwayHash256S || a == BLAKE3256S
+}
+
+// configuredBitrotAlgorithm returns the bitrot algorithm protecting new
+// objects, as set by the storage class config.
+func configuredBitrotAlgorithm() BitrotAlgorithm {
+	if algo := BitrotAlgorithmFromString(globalStorageClass.GetBitrotAlgorithm()); algo.Streaming() {
+		return algo
+	}
+	return DefaultBitrotAlgorithm
+}
+
 // NewBitrotVerifier returns a new BitrotVerifier implementing the given algorithm.
 func NewBitrotVerifier(algorithm BitrotAlgorithm, checksum []byte) *BitrotVerifier {
 	return &BitrotVerifier{algorithm, checksum}
@@ -101,14 +120,14 @@ func BitrotAlgorithmFromString(s string) (a BitrotAlgorithm) {
 }
 
 func newBitrotWriter(disk StorageAPI, volume, filePath string, length int64, algo BitrotAlgorithm, shardSize int64) io.Writer {
-	if algo == HighwayHash256S {
+	if algo.Streaming() {
 		return newStreamingBitrotWriter(disk, volume, filePath, length, algo, shardSize)
 	}
 	return newWholeBitrotWriter(disk, volume, filePath, algo, shardSize)
 }
 
 func newBitrotReader(disk StorageAPI, data []byte, bucket string, filePath string, tillOffset int64, algo BitrotAlgorithm, sum []byte, shardSize int64) io.ReaderAt {
-	if algo == HighwayHash256S {
+	if algo.Streaming() {
 		return newStreamingBitrotReader(disk, data, bucket, filePath, tillOffset, algo, shardSize)
 	}
 	return newWholeBitrotReader(disk, bucket, filePath, algo, tillOffset, sum)
@@ -142,7 +161,7 @@ func bitrotWriterSum(w io.Writer) []byte {
 
 // Returns the size of the file with bitrot protection
 func bitrotShardFileSize(size int64, shardSize int64, algo BitrotAlgorithm) int64 {
-	if algo != HighwayHash256S {
+	if !algo.Streaming() {
 		return size
 	}
 	return ceilFrac(size, shardSize)*int64(algo.New().Size()) + size
@@ -150,7 +169,7 @@ func bitrotShardFileSize(size int64, shardSize int64, algo BitrotAlgorithm) int6
 
 // bitrotVerify a single stream of data.
 func bitrotVerify(r io.Reader, wantSize, partSize int64, algo BitrotAlgorithm, want []byte, shardSize int64) error {
-	if algo != HighwayHash256S {
+	if !algo.Streaming() {
 		h := algo.New()
 		if n, err := io.Copy(h, r); err != nil || n != wantSize {
 			// Premature failure in reading the object, file is corrupt.
@@ -215,6 +234,7 @@ func bitrotSelfTest() {
 		BLAKE2b512:      "e519b7d84b1c3c917985f544773a35cf265dcab10948be3550320d156bab612124a5ae2ae5a8c73c0eea360f68b0e28136f26e858756dbfe7375a7389f26c669",
 		HighwayHash256:  "39c0407ed3f01b18d22c85db4aeff11e060ca5f43131b0126731ca197cd42313",
 		HighwayHash256S: "39c0407ed3f01b18d22c85db4aeff11e060ca5f43131b0126731ca197cd42313",
+		BLAKE3256S:      "59d11fa729e3b687a5352c7e65dc8e60a7e28100c95da6b0a3b9100e1ac6bd52",
 	}
 	for algorithm := range bitrotAlgorithms {
 		if !algorithm.Available() {
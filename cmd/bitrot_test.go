@@ -18,6 +18,7 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
 	"io"
 	"io/ioutil"
@@ -83,3 +84,54 @@ func TestAllBitrotAlgorithms(t *testing.T) {
 		testBitrotReaderWriterAlgo(t, bitrotAlgo)
 	}
 }
+
+// Streaming bitrot algorithms compared by the benchmarks.
+var benchmarkStreamingBitrotAlgorithms = []BitrotAlgorithm{HighwayHash256S, BLAKE3256S}
+
+// Shard size of an erasure set with 4 data drives.
+const benchmarkBitrotShardSize = blockSizeV2 / 4
+
+func BenchmarkBitrotStreamingWrite(b *testing.B) {
+	shard := make([]byte, benchmarkBitrotShardSize)
+	for _, algo := range benchmarkStreamingBitrotAlgorithms {
+		b.Run(algo.String(), func(b *testing.B) {
+			w := newStreamingBitrotWriterBuffer(ioutil.Discard, algo, benchmarkBitrotShardSize)
+			b.SetBytes(benchmarkBitrotShardSize)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if _, err := w.Write(shard); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func BenchmarkBitrotStreamingRead(b *testing.B) {
+	const shards = 16
+	shard := make([]byte, benchmarkBitrotShardSize)
+	for _, algo := range benchmarkStreamingBitrotAlgorithms {
+		b.Run(algo.String(), func(b *testing.B) {
+			var buf bytes.Buffer
+			w := newStreamingBitrotWriterBuffer(&buf, algo, benchmarkBitrotShardSize)
+			for i := 0; i < shards; i++ {
+				if _, err := w.Write(shard); err != nil {
+					b.Fatal(err)
+				}
+			}
+			data := buf.Bytes()
+			b.SetBytes(shards * benchmarkBitrotShardSize)
+			b.ReportAllocs()
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				r := newStreamingBitrotReader(nil, data, "", "", shards*benchmarkBitrotShardSize, algo, benchmarkBitrotShardSize)
+				for offset := int64(0); offset < shards*benchmarkBitrotShardSize; offset += benchmarkBitrotShardSize {
+					if _, err := r.ReadAt(shard, offset); err != nil {
+						b.Fatal(err)
+					}
+				}
+			}
+		})
+	}
+}
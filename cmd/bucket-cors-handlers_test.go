@@ -0,0 +1,299 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+const testBucketCorsConfig = `<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><CORSRule><AllowedHeader>x-amz-*</AllowedHeader><AllowedMethod>PUT</AllowedMethod><AllowedOrigin>https://*.example.com</AllowedOrigin><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>600</MaxAgeSeconds></CORSRule><CORSRule><AllowedMethod>GET</AllowedMethod><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`
+
+// Test S3 Bucket CORS APIs
+func TestBucketCors(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketCorsHandlers, []string{"GetBucketCors", "PutBucketCors", "DeleteBucketCors"})
+}
+
+// Simple tests of bucket CORS: PUT, GET, DELETE.
+// Tests are related and the order is important.
+func testBucketCorsHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+
+	testCases := []struct {
+		method             string
+		body               []byte
+		expectedRespStatus int
+		corsResponse       []byte
+		errorResponse      APIErrorResponse
+		shouldPass         bool
+	}{
+		// Test case - 1.
+		// Unsupported method.
+		{
+			method:             http.MethodPut,
+			body:               []byte(`<CORSConfiguration><CORSRule><AllowedMethod>PATCH</AllowedMethod><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "InvalidRequest",
+				Message:  "Found unsupported HTTP method in CORS config. Unsupported method is PATCH",
+			},
+		},
+		// Test case - 2.
+		// Malformed XML.
+		{
+			method:             http.MethodPut,
+			body:               []byte(`<CORSConfiguration><CORSRule>`),
+			expectedRespStatus: http.StatusBadRequest,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "MalformedXML",
+				Message:  "The XML you provided was not well-formed or did not validate against our published schema. (XML syntax error on line 1: unexpected EOF)",
+			},
+		},
+		// Test case - 3.
+		// No configuration yet.
+		{
+			method:             http.MethodGet,
+			expectedRespStatus: http.StatusNotFound,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "NoSuchCORSConfiguration",
+				Message:  "The CORS configuration does not exist",
+			},
+		},
+		// Test case - 4.
+		{
+			method:             http.MethodPut,
+			body:               []byte(testBucketCorsConfig),
+			expectedRespStatus: http.StatusOK,
+			corsResponse:       []byte(``),
+			shouldPass:         true,
+		},
+		// Test case - 5.
+		{
+			method:             http.MethodGet,
+			expectedRespStatus: http.StatusOK,
+			corsResponse:       []byte(testBucketCorsConfig),
+			shouldPass:         true,
+		},
+		// Test case - 6.
+		{
+			method:             http.MethodDelete,
+			expectedRespStatus: http.StatusNoContent,
+			corsResponse:       []byte(``),
+			shouldPass:         true,
+		},
+		// Test case - 7.
+		{
+			method:             http.MethodGet,
+			expectedRespStatus: http.StatusNotFound,
+			errorResponse: APIErrorResponse{
+				Resource: SlashSeparator + bucketName + SlashSeparator,
+				Code:     "NoSuchCORSConfiguration",
+				Message:  "The CORS configuration does not exist",
+			},
+		},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(testCase.method, getBucketCorsURL("", bucketName),
+			int64(len(testCase.body)), bytes.NewReader(testCase.body), creds.AccessKey, creds.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if testCase.shouldPass {
+			if !bytes.Equal(testCase.corsResponse, rec.Body.Bytes()) {
+				t.Errorf("Test %d: %s: Expected the response to be `%s`, but instead found `%s`", i+1, instanceType, string(testCase.corsResponse), rec.Body.String())
+			}
+			continue
+		}
+		errorResponse := APIErrorResponse{}
+		if err = xml.Unmarshal(rec.Body.Bytes(), &errorResponse); err != nil {
+			t.Fatalf("Test %d: %s: Unable to unmarshal response body %s", i+1, instanceType, rec.Body.String())
+		}
+		if errorResponse.Resource != testCase.errorResponse.Resource {
+			t.Errorf("Test %d: %s: Expected the error resource to be `%s`, but instead found `%s`", i+1, instanceType, testCase.errorResponse.Resource, errorResponse.Resource)
+		}
+		if errorResponse.Message != testCase.errorResponse.Message {
+			t.Errorf("Test %d: %s: Expected the error message to be `%s`, but instead found `%s`", i+1, instanceType, testCase.errorResponse.Message, errorResponse.Message)
+		}
+		if errorResponse.Code != testCase.errorResponse.Code {
+			t.Errorf("Test %d: %s: Expected the error code to be `%s`, but instead found `%s`", i+1, instanceType, testCase.errorResponse.Code, errorResponse.Code)
+		}
+	}
+}
+
+// Test evaluation of bucket CORS rules for cross-origin requests.
+func TestBucketCorsRequests(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketCorsRequests, []string{"GetBucketCors", "PutBucketCors"})
+}
+
+func testBucketCorsRequests(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+	handler := corsHandler(apiRouter)
+
+	body := []byte(testBucketCorsConfig)
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodPut, getBucketCorsURL("", bucketName),
+		int64(len(body)), bytes.NewReader(body), creds.AccessKey, creds.SecretKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	handler.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: unable to set bucket CORS config: %d %s", instanceType, rec.Code, rec.Body.String())
+	}
+
+	testCases := []struct {
+		method         string
+		origin         string
+		requestMethod  string
+		requestHeaders string
+
+		expectedStatus  int
+		expectedOrigin  string
+		expectedHeaders string
+		expectedMaxAge  string
+	}{
+		// Test 1: preflight allowed by the first rule.
+		{
+			method: http.MethodOptions, origin: "https://app.example.com", requestMethod: http.MethodPut, requestHeaders: "X-Amz-Date, x-amz-content-sha256",
+			expectedStatus: http.StatusOK, expectedOrigin: "https://app.example.com", expectedHeaders: "X-Amz-Date, x-amz-content-sha256", expectedMaxAge: "600",
+		},
+		// Test 2: preflight with a header not allowed.
+		{
+			method: http.MethodOptions, origin: "https://app.example.com", requestMethod: http.MethodPut, requestHeaders: "Authorization",
+			expectedStatus: http.StatusForbidden,
+		},
+		// Test 3: preflight from an origin not allowed.
+		{
+			method: http.MethodOptions, origin: "https://app.example.org", requestMethod: http.MethodPut,
+			expectedStatus: http.StatusForbidden,
+		},
+		// Test 4: preflight allowed for any origin.
+		{
+			method: http.MethodOptions, origin: "https://app.example.org", requestMethod: http.MethodGet,
+			expectedStatus: http.StatusOK, expectedOrigin: "*",
+		},
+		// Test 5: actual request allowed by the second rule.
+		{
+			method: http.MethodGet, origin: "https://app.example.org",
+			expectedStatus: http.StatusOK, expectedOrigin: "*",
+		},
+	}
+
+	for i, tc := range testCases {
+		var req *http.Request
+		if tc.method == http.MethodOptions {
+			req, err = http.NewRequest(tc.method, getBucketCorsURL("", bucketName), nil)
+		} else {
+			req, err = newTestSignedRequestV4(tc.method, getBucketCorsURL("", bucketName), 0, nil, creds.AccessKey, creds.SecretKey, nil)
+		}
+		if err != nil {
+			t.Fatal(err)
+		}
+		req.Header.Set(xhttp.Origin, tc.origin)
+		if tc.requestMethod != "" {
+			req.Header.Set(xhttp.AccessControlRequestMethod, tc.requestMethod)
+		}
+		if tc.requestHeaders != "" {
+			req.Header.Set(xhttp.AccessControlRequestHeaders, tc.requestHeaders)
+		}
+
+		rec := httptest.NewRecorder()
+		handler.ServeHTTP(rec, req)
+		if rec.Code != tc.expectedStatus {
+			t.Errorf("Test %d: %s: expected status %d, got %d", i+1, instanceType, tc.expectedStatus, rec.Code)
+		}
+		if got := rec.Header().Get(xhttp.AccessControlAllowOrigin); got != tc.expectedOrigin {
+			t.Errorf("Test %d: %s: expected allowed origin %q, got %q", i+1, instanceType, tc.expectedOrigin, got)
+		}
+		if got := rec.Header().Get(xhttp.AccessControlAllowHeaders); got != tc.expectedHeaders {
+			t.Errorf("Test %d: %s: expected allowed headers %q, got %q", i+1, instanceType, tc.expectedHeaders, got)
+		}
+		if got := rec.Header().Get(xhttp.AccessControlMaxAge); got != tc.expectedMaxAge {
+			t.Errorf("Test %d: %s: expected max age %q, got %q", i+1, instanceType, tc.expectedMaxAge, got)
+		}
+	}
+	// Cross-origin requests to a bucket which does not exist must
+	// not look up its metadata on disk every time.
+	missingBucket := "cors-missing-bucket"
+	req, err = http.NewRequest(http.MethodOptions, getBucketCorsURL("", missingBucket), nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	req.Header.Set(xhttp.Origin, "https://app.example.com")
+	req.Header.Set(xhttp.AccessControlRequestMethod, http.MethodGet)
+	handler.ServeHTTP(httptest.NewRecorder(), req)
+
+	globalBucketMetadataSys.RLock()
+	_, missing := globalBucketMetadataSys.corsMissingMap[missingBucket]
+	globalBucketMetadataSys.RUnlock()
+	if !missing {
+		t.Fatalf("%s: expected bucket %s to be cached as missing", instanceType, missingBucket)
+	}
+
+	// Other lookups of bucket metadata are not cached.
+	if _, err = globalBucketMetadataSys.GetConfig("other-missing-bucket"); err == nil {
+		t.Fatalf("%s: expected an error for a missing bucket", instanceType)
+	}
+	globalBucketMetadataSys.RLock()
+	_, missing = globalBucketMetadataSys.corsMissingMap["other-missing-bucket"]
+	globalBucketMetadataSys.RUnlock()
+	if missing {
+		t.Fatalf("%s: expected only cross-origin lookups to be cached", instanceType)
+	}
+
+	// The cache is bounded, expired buckets are pruned once it is full.
+	globalBucketMetadataSys.Lock()
+	for i := 0; i < bucketCorsMissingMax; i++ {
+		globalBucketMetadataSys.corsMissingMap[fmt.Sprintf("expired-%d", i)] = time.Now().Add(-bucketCorsMissingExpiry)
+	}
+	globalBucketMetadataSys.Unlock()
+	globalBucketMetadataSys.setCorsMissing("new-missing-bucket")
+	globalBucketMetadataSys.Lock()
+	_, expired := globalBucketMetadataSys.corsMissingMap["expired-0"]
+	_, missing = globalBucketMetadataSys.corsMissingMap["new-missing-bucket"]
+	delete(globalBucketMetadataSys.corsMissingMap, "new-missing-bucket")
+	globalBucketMetadataSys.Unlock()
+	if expired || !missing {
+		t.Fatalf("%s: expected the expired buckets to be pruned", instanceType)
+	}
+
+	if err = obj.MakeBucketWithLocation(context.Background(), missingBucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = globalBucketMetadataSys.GetConfig(missingBucket); err != nil {
+		t.Fatalf("%s: expected metadata of the new bucket, got %v", instanceType, err)
+	}
+}
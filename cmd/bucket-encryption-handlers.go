@@ -95,6 +95,8 @@ func (api objectAPIHandlers) PutBucketEncryptionHandler(w http.ResponseWriter, r
 		return
 	}
 
+	globalSiteReplicationSys.BucketMetaHook(ctx, bucket, bucketSSEConfig, configData)
+
 	writeSuccessResponseHeadersOnly(w)
 }
 
@@ -175,5 +177,7 @@ func (api objectAPIHandlers) DeleteBucketEncryptionHandler(w http.ResponseWriter
 		return
 	}
 
+	globalSiteReplicationSys.BucketMetaHook(ctx, bucket, bucketSSEConfig, nil)
+
 	writeSuccessNoContent(w)
 }
@@ -774,6 +774,9 @@ func (api objectAPIHandlers) PutBucketHandler(w http.ResponseWriter, r *http.Req
 	// Load updated bucket metadata into memory.
 	globalNotificationSys.LoadBucketMetadata(GlobalContext, bucket)
 
+	// Create the bucket on the peer sites.
+	globalSiteReplicationSys.MakeBucketHook(ctx, bucket, opts)
+
 	// Make sure to add Location information here only for bucket
 	if cp := pathClean(r.URL.Path); cp != "" {
 		w.Header().Set(xhttp.Location, cp) // Clean any trailing slashes.
@@ -1251,6 +1254,9 @@ func (api objectAPIHandlers) DeleteBucketHandler(w http.ResponseWriter, r *http.
 
 	globalNotificationSys.DeleteBucketMetadata(ctx, bucket)
 
+	// Remove the bucket from the peer sites.
+	globalSiteReplicationSys.DeleteBucketHook(ctx, bucket, forceDelete)
+
 	// Write success response.
 	writeSuccessNoContent(w)
 
@@ -1316,6 +1322,8 @@ func (api objectAPIHandlers) PutBucketObjectLockConfigHandler(w http.ResponseWri
 		return
 	}
 
+	globalSiteReplicationSys.BucketMetaHook(ctx, bucket, objectLockConfig, configData)
+
 	// Write success response.
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -1401,6 +1409,8 @@ func (api objectAPIHandlers) PutBucketTaggingHandler(w http.ResponseWriter, r *h
 		return
 	}
 
+	globalSiteReplicationSys.BucketMetaHook(ctx, bucket, bucketTaggingConfig, configData)
+
 	// Write success response.
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -1469,6 +1479,8 @@ func (api objectAPIHandlers) DeleteBucketTaggingHandler(w http.ResponseWriter, r
 		return
 	}
 
+	globalSiteReplicationSys.BucketMetaHook(ctx, bucket, bucketTaggingConfig, nil)
+
 	// Write success response.
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -0,0 +1,398 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net/http"
+	"net/url"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio-go/v7/pkg/signer"
+	"github.com/minio/minio/internal/bucket/lambda"
+	"github.com/minio/minio/internal/crypto"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+const (
+	// Bucket object lambda configuration file name.
+	bucketLambdaConfig = "lambda.xml"
+
+	// Maximum size of a bucket object lambda configuration.
+	maxBucketLambdaConfigSize = 16 * 1024
+
+	// Validity of the presigned URL handed to transformation endpoints.
+	lambdaPresignExpiry = 5 * time.Minute
+)
+
+// lambdaTransformer is implemented by notification targets which
+// can act as object lambda transformation endpoints.
+type lambdaTransformer interface {
+	Transform(ctx context.Context, lambdaEvent interface{}) (*http.Response, error)
+}
+
+// PutBucketObjectLambdaHandler - Sets the transformation endpoints of a bucket
+// ----------
+// Every transformation refers to a webhook notification target
+// configured on the server by its object lambda ARN.
+func (api objectAPIHandlers) PutBucketObjectLambdaHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketObjectLambda")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if globalIsGateway {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	lambdaConfig, err := lambda.ParseConfig(io.LimitReader(r.Body, maxBucketLambdaConfigSize))
+	if err != nil {
+		apiErr := APIError{
+			Code:           "MalformedXML",
+			Description:    fmt.Sprintf("%s (%s)", errorCodes[ErrMalformedXML].Description, err),
+			HTTPStatusCode: errorCodes[ErrMalformedXML].HTTPStatusCode,
+		}
+		writeErrorResponse(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	if err = lambdaConfig.Validate(globalServerRegion, globalNotificationSys.targetList); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(lambdaConfig)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalBucketMetadataSys.Update(bucket, bucketLambdaConfig, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketObjectLambdaHandler - Returns the transformation endpoints of a bucket
+func (api objectAPIHandlers) GetBucketObjectLambdaHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketObjectLambda")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, err := globalBucketMetadataSys.GetLambdaConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeleteBucketObjectLambdaHandler - Removes the transformation endpoints of a bucket
+func (api objectAPIHandlers) DeleteBucketObjectLambdaHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketObjectLambda")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalBucketMetadataSys.Update(bucket, bucketLambdaConfig, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// GetObjectLambdaHandler - GET Object through a transformation endpoint
+// ----------
+// The transformation endpoint receives a presigned URL for the original
+// object, signed with the credentials of the requester, and responds with
+// the transformed object. Its response is the equivalent of the
+// WriteGetObjectResponse call of S3 Object Lambda: it must echo the
+// x-amz-request-route and x-amz-request-token it was sent, may set
+// x-amz-fwd-status, x-amz-fwd-error-code and x-amz-fwd-error-message, and
+// the response headers as x-amz-fwd-header-<Name>. The body is streamed
+// to the client as is.
+func (api objectAPIHandlers) GetObjectLambdaHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectLambda")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := unescapePath(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	lambdaARN := r.Form.Get("lambdaArn")
+	config, err := globalBucketMetadataSys.GetLambdaConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+	targetID, ok := config.Lookup(lambdaARN)
+	if !ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidLambdaARN), r.URL)
+		return
+	}
+	target, ok := globalNotificationSys.Target(targetID)
+	if !ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidLambdaARN), r.URL)
+		return
+	}
+	transformer, ok := target.(lambdaTransformer)
+	if !ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidLambdaARN), r.URL)
+		return
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Fail early if the object does not exist, instead of
+	// relying on the transformation endpoint to report it.
+	if _, err = objectAPI.GetObjectInfo(ctx, bucket, object, opts); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	inputURL, err := presignLambdaInputURL(r)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	cred := getReqAccessCred(r, globalServerRegion)
+	identity := lambda.UserIdentity{
+		Type:        "IAMUser",
+		PrincipalID: cred.AccessKey,
+		AccessKeyID: cred.AccessKey,
+	}
+	if cred.ParentUser != "" {
+		identity.PrincipalID = cred.ParentUser
+	}
+	if cred.AccessKey == "" {
+		identity.Type = "AnonymousUser"
+	}
+
+	route, token := mustGetUUID(), mustGetUUID()
+	lambdaEvent := lambda.NewEvent(w.Header().Get(xhttp.AmzRequestID), lambdaARN,
+		lambda.GetObjectContext{
+			InputS3URL:  inputURL,
+			OutputRoute: route,
+			OutputToken: token,
+		},
+		lambdaUserRequest(r), identity)
+
+	resp, err := transformer.Transform(ctx, lambdaEvent)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrLambdaResponseNotReceived), r.URL)
+		return
+	}
+	defer resp.Body.Close()
+
+	if resp.Header.Get(xhttp.AmzRequestRoute) != route || resp.Header.Get(xhttp.AmzRequestToken) != token {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrLambdaInvalidResponse), r.URL)
+		return
+	}
+
+	statusCode := resp.StatusCode
+	if status := resp.Header.Get(xhttp.AmzFwdStatus); status != "" {
+		statusCode, err = strconv.Atoi(status)
+		if err != nil || statusCode < 200 || statusCode > 599 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrLambdaInvalidResponse), r.URL)
+			return
+		}
+	}
+
+	if statusCode >= http.StatusBadRequest {
+		apiErr := APIError{
+			Code:           resp.Header.Get(xhttp.AmzFwdErrorCode),
+			Description:    resp.Header.Get(xhttp.AmzFwdErrorMessage),
+			HTTPStatusCode: statusCode,
+		}
+		if apiErr.Code == "" {
+			apiErr.Code = http.StatusText(statusCode)
+		}
+		writeErrorResponse(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	for k, v := range resp.Header {
+		if name := strings.TrimPrefix(strings.ToLower(k), xhttp.AmzFwdHeaderPrefix); name != strings.ToLower(k) {
+			w.Header()[http.CanonicalHeaderKey(name)] = v
+		}
+	}
+	if resp.ContentLength >= 0 {
+		w.Header().Set(xhttp.ContentLength, strconv.FormatInt(resp.ContentLength, 10))
+	}
+
+	w.WriteHeader(statusCode)
+	if _, err = io.Copy(w, resp.Body); err != nil {
+		logger.LogIf(ctx, err)
+	}
+}
+
+// lambdaUserRequest returns the original request as passed to the
+// transformation endpoint. Credentials and SSE-C keys are removed,
+// the endpoint reads the object through the presigned input URL.
+func lambdaUserRequest(r *http.Request) lambda.UserRequest {
+	header := r.Header.Clone()
+	header.Del(xhttp.Authorization)
+	header.Del(xhttp.AmzSecurityToken)
+	header.Del("Cookie")
+	crypto.RemoveSensitiveHeaders(header)
+	header.Del(xhttp.AmzServerSideEncryptionCustomerKeyMD5)
+	header.Del(xhttp.AmzServerSideEncryptionCopyCustomerKeyMD5)
+
+	u := *r.URL
+	query := u.Query()
+	for _, key := range []string{
+		xhttp.AmzCredential, xhttp.AmzSignature, xhttp.AmzSecurityToken,
+		xhttp.AmzAccessKeyID, xhttp.AmzSignatureV2,
+	} {
+		query.Del(key)
+	}
+	u.RawQuery = query.Encode()
+
+	return lambda.UserRequest{
+		URL:     getURLScheme(globalIsTLS) + "://" + r.Host + u.RequestURI(),
+		Headers: header,
+	}
+}
+
+// presignLambdaInputURL returns the URL of the original object of an
+// object lambda request, presigned with the credentials of the requester.
+// The URL of an anonymous request is not signed.
+func presignLambdaInputURL(r *http.Request) (string, error) {
+	query := url.Values{}
+	if versionID := r.Form.Get(xhttp.VersionID); versionID != "" {
+		query.Set(xhttp.VersionID, versionID)
+	}
+	u := url.URL{
+		Scheme:   getURLScheme(globalIsTLS),
+		Host:     r.Host,
+		Path:     r.URL.Path,
+		RawPath:  r.URL.RawPath,
+		RawQuery: query.Encode(),
+	}
+	req, err := http.NewRequest(http.MethodGet, u.String(), nil)
+	if err != nil {
+		return "", err
+	}
+
+	cred := getReqAccessCred(r, globalServerRegion)
+	if cred.AccessKey == "" {
+		return req.URL.String(), nil
+	}
+	req = signer.PreSignV4(*req, cred.AccessKey, cred.SecretKey, cred.SessionToken,
+		globalServerRegion, int64(lambdaPresignExpiry/time.Second))
+	return req.URL.String(), nil
+}
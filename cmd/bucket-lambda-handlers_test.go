@@ -0,0 +1,374 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/json"
+	"encoding/xml"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/bucket/lambda"
+	"github.com/minio/minio/internal/event/target"
+	xhttp "github.com/minio/minio/internal/http"
+	xnet "github.com/minio/pkg/net"
+)
+
+// Test S3 Object Lambda APIs
+func TestBucketObjectLambda(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketObjectLambdaHandlers, []string{
+		"GetBucketObjectLambda", "PutBucketObjectLambda", "DeleteBucketObjectLambda", "GetObjectLambda", "GetObject",
+	})
+}
+
+// Tests the object lambda configuration and GET through a
+// transformation endpoint which upper-cases the object.
+// Tests are related and the order is important.
+func testBucketObjectLambdaHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+	ctx, cancel := context.WithCancel(GlobalContext)
+	defer cancel()
+
+	const (
+		endPoint   = "http://127.0.0.1:9000"
+		objectName = "object"
+		lambdaARN  = "arn:minio:s3-object-lambda:us-east-1:1:webhook"
+		config     = `<ObjectLambdaConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><Transformation><Arn>` + lambdaARN + `</Arn></Transformation></ObjectLambdaConfiguration>`
+	)
+
+	data := []byte("hello, world")
+	if _, err := obj.PutObject(ctx, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+	}
+
+	// The transformation endpoint reads the original object through
+	// the presigned URL and responds with the upper-cased content.
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			return
+		}
+		var lambdaEvent lambda.Event
+		if err := json.NewDecoder(r.Body).Decode(&lambdaEvent); err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		w.Header().Set(xhttp.AmzRequestRoute, lambdaEvent.GetObjectContext.OutputRoute)
+		w.Header().Set(xhttp.AmzRequestToken, lambdaEvent.GetObjectContext.OutputToken)
+
+		// Credentials of the caller are never forwarded.
+		if http.Header(lambdaEvent.UserRequest.Headers).Get(xhttp.Authorization) != "" ||
+			strings.Contains(lambdaEvent.UserRequest.URL, xhttp.AmzSignature) {
+			w.Header().Set(xhttp.AmzFwdStatus, "400")
+			w.Header().Set(xhttp.AmzFwdErrorCode, "InvalidRequest")
+			w.Header().Set(xhttp.AmzFwdErrorMessage, "credentials forwarded")
+			return
+		}
+
+		req, err := http.NewRequest(http.MethodGet, lambdaEvent.GetObjectContext.InputS3URL, nil)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			w.Header().Set(xhttp.AmzFwdStatus, "403")
+			w.Header().Set(xhttp.AmzFwdErrorCode, "AccessDenied")
+			w.Header().Set(xhttp.AmzFwdErrorMessage, rec.Body.String())
+			return
+		}
+		w.Header().Set(xhttp.AmzFwdHeaderPrefix+"Content-Type", "text/plain")
+		w.Write(bytes.ToUpper(rec.Body.Bytes()))
+	}))
+	defer webhook.Close()
+
+	endpoint, err := xnet.ParseHTTPURL(webhook.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhookTarget, err := target.NewWebhookTarget(ctx, "1", target.WebhookArgs{Enable: true, Endpoint: *endpoint},
+		func(ctx context.Context, err error, id interface{}, kind ...interface{}) {},
+		http.DefaultTransport.(*http.Transport).Clone(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalNotificationSys = NewNotificationSys(EndpointServerPools{})
+	defer func() { globalNotificationSys = nil }()
+	if err = globalNotificationSys.targetList.Add(webhookTarget); err != nil {
+		t.Fatal(err)
+	}
+
+	lambdaQuery := func(arn string) url.Values {
+		return url.Values{"lambdaArn": []string{arn}}
+	}
+
+	testCases := []struct {
+		method             string
+		url                string
+		body               []byte
+		presign            bool
+		expectedRespStatus int
+		expectedResponse   []byte
+	}{
+		// Test case - 1.
+		// No configuration.
+		{
+			method:             http.MethodGet,
+			url:                getBucketObjectLambdaURL(endPoint, bucketName),
+			expectedRespStatus: http.StatusNotFound,
+		},
+		// Test case - 2.
+		// Unknown target.
+		{
+			method:             http.MethodPut,
+			url:                getBucketObjectLambdaURL(endPoint, bucketName),
+			body:               []byte(strings.Replace(config, ":1:", ":2:", 1)),
+			expectedRespStatus: http.StatusBadRequest,
+		},
+		// Test case - 3.
+		// Notification ARNs are not lambda ARNs.
+		{
+			method:             http.MethodPut,
+			url:                getBucketObjectLambdaURL(endPoint, bucketName),
+			body:               []byte(strings.Replace(config, "s3-object-lambda", "sqs", 1)),
+			expectedRespStatus: http.StatusBadRequest,
+		},
+		// Test case - 4.
+		{
+			method:             http.MethodPut,
+			url:                getBucketObjectLambdaURL(endPoint, bucketName),
+			body:               []byte(config),
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   []byte(``),
+		},
+		// Test case - 5.
+		{
+			method:             http.MethodGet,
+			url:                getBucketObjectLambdaURL(endPoint, bucketName),
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   []byte(config),
+		},
+		// Test case - 6.
+		// GET through the transformation endpoint.
+		{
+			method:             http.MethodGet,
+			url:                makeTestTargetURL(endPoint, bucketName, objectName, lambdaQuery(lambdaARN)),
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   bytes.ToUpper(data),
+		},
+		// Test case - 7.
+		// Presigned GET through the transformation endpoint.
+		{
+			method:             http.MethodGet,
+			url:                makeTestTargetURL(endPoint, bucketName, objectName, lambdaQuery(lambdaARN)),
+			presign:            true,
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   bytes.ToUpper(data),
+		},
+		// Test case - 8.
+		// The original object is not modified.
+		{
+			method:             http.MethodGet,
+			url:                makeTestTargetURL(endPoint, bucketName, objectName, nil),
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   data,
+		},
+		// Test case - 9.
+		// ARN not configured for the bucket.
+		{
+			method:             http.MethodGet,
+			url:                makeTestTargetURL(endPoint, bucketName, objectName, lambdaQuery(strings.Replace(lambdaARN, ":1:", ":2:", 1))),
+			expectedRespStatus: http.StatusBadRequest,
+		},
+		// Test case - 10.
+		// Non-existent object.
+		{
+			method:             http.MethodGet,
+			url:                makeTestTargetURL(endPoint, bucketName, "non-existent", lambdaQuery(lambdaARN)),
+			expectedRespStatus: http.StatusNotFound,
+		},
+		// Test case - 11.
+		{
+			method:             http.MethodDelete,
+			url:                getBucketObjectLambdaURL(endPoint, bucketName),
+			expectedRespStatus: http.StatusNoContent,
+		},
+		// Test case - 12.
+		{
+			method:             http.MethodGet,
+			url:                makeTestTargetURL(endPoint, bucketName, objectName, lambdaQuery(lambdaARN)),
+			expectedRespStatus: http.StatusNotFound,
+		},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		var req *http.Request
+		if testCase.presign {
+			req, err = newTestRequest(testCase.method, testCase.url, 0, nil)
+			if err == nil {
+				err = preSignV4(req, creds.AccessKey, creds.SecretKey, 60)
+			}
+		} else {
+			req, err = newTestSignedRequestV4(testCase.method, testCase.url,
+				int64(len(testCase.body)), bytes.NewReader(testCase.body), creds.AccessKey, creds.SecretKey, nil)
+		}
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`: %s", i+1, instanceType, testCase.expectedRespStatus, rec.Code, rec.Body.String())
+		}
+		if testCase.expectedResponse != nil && !bytes.Equal(testCase.expectedResponse, rec.Body.Bytes()) {
+			t.Errorf("Test %d: %s: Expected the response to be `%s`, but instead found `%s`", i+1, instanceType, string(testCase.expectedResponse), rec.Body.String())
+		}
+	}
+}
+
+// Tests that errors reported by the transformation endpoint and
+// invalid responses are forwarded to the client.
+func TestGetObjectLambdaErrors(t *testing.T) {
+	ExecObjectLayerAPITest(t, testGetObjectLambdaErrors, []string{"PutBucketObjectLambda", "GetObjectLambda"})
+}
+
+func testGetObjectLambdaErrors(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+	ctx, cancel := context.WithCancel(GlobalContext)
+	defer cancel()
+
+	const (
+		objectName = "object"
+		lambdaARN  = "arn:minio:s3-object-lambda:us-east-1:1:webhook"
+	)
+
+	if _, err := obj.PutObject(ctx, bucketName, objectName, mustGetPutObjReader(t, bytes.NewReader([]byte("data")), 4, "", ""), ObjectOptions{}); err != nil {
+		t.Fatalf("%s: Failed to put object: <ERROR> %v", instanceType, err)
+	}
+
+	var respond func(w http.ResponseWriter, lambdaEvent lambda.Event)
+	webhook := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		if r.Method != http.MethodPost {
+			return
+		}
+		var lambdaEvent lambda.Event
+		json.NewDecoder(r.Body).Decode(&lambdaEvent)
+		respond(w, lambdaEvent)
+	}))
+	defer webhook.Close()
+
+	endpoint, err := xnet.ParseHTTPURL(webhook.URL)
+	if err != nil {
+		t.Fatal(err)
+	}
+	webhookTarget, err := target.NewWebhookTarget(ctx, "1", target.WebhookArgs{Enable: true, Endpoint: *endpoint},
+		func(ctx context.Context, err error, id interface{}, kind ...interface{}) {},
+		http.DefaultTransport.(*http.Transport).Clone(), true)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	globalNotificationSys = NewNotificationSys(EndpointServerPools{})
+	defer func() { globalNotificationSys = nil }()
+	if err = globalNotificationSys.targetList.Add(webhookTarget); err != nil {
+		t.Fatal(err)
+	}
+
+	config := `<ObjectLambdaConfiguration><Transformation><Arn>` + lambdaARN + `</Arn></Transformation></ObjectLambdaConfiguration>`
+	req, err := newTestSignedRequestV4(http.MethodPut, getBucketObjectLambdaURL("", bucketName),
+		int64(len(config)), strings.NewReader(config), creds.AccessKey, creds.SecretKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	rec := httptest.NewRecorder()
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Failed to put object lambda configuration: %d %s", instanceType, rec.Code, rec.Body.String())
+	}
+
+	testCases := []struct {
+		respond            func(w http.ResponseWriter, lambdaEvent lambda.Event)
+		expectedRespStatus int
+		expectedCode       string
+	}{
+		// Test case - 1.
+		// Error forwarded by the transformation endpoint.
+		{
+			respond: func(w http.ResponseWriter, lambdaEvent lambda.Event) {
+				w.Header().Set(xhttp.AmzRequestRoute, lambdaEvent.GetObjectContext.OutputRoute)
+				w.Header().Set(xhttp.AmzRequestToken, lambdaEvent.GetObjectContext.OutputToken)
+				w.Header().Set(xhttp.AmzFwdStatus, "403")
+				w.Header().Set(xhttp.AmzFwdErrorCode, "AccessDenied")
+				w.Header().Set(xhttp.AmzFwdErrorMessage, "redacted")
+			},
+			expectedRespStatus: http.StatusForbidden,
+			expectedCode:       "AccessDenied",
+		},
+		// Test case - 2.
+		// Wrong request token.
+		{
+			respond: func(w http.ResponseWriter, lambdaEvent lambda.Event) {
+				w.Header().Set(xhttp.AmzRequestRoute, lambdaEvent.GetObjectContext.OutputRoute)
+				w.Header().Set(xhttp.AmzRequestToken, "token")
+			},
+			expectedRespStatus: http.StatusBadGateway,
+			expectedCode:       "LambdaInvalidResponse",
+		},
+		// Test case - 3.
+		// Invalid forwarded status.
+		{
+			respond: func(w http.ResponseWriter, lambdaEvent lambda.Event) {
+				w.Header().Set(xhttp.AmzRequestRoute, lambdaEvent.GetObjectContext.OutputRoute)
+				w.Header().Set(xhttp.AmzRequestToken, lambdaEvent.GetObjectContext.OutputToken)
+				w.Header().Set(xhttp.AmzFwdStatus, "OK")
+			},
+			expectedRespStatus: http.StatusBadGateway,
+			expectedCode:       "LambdaInvalidResponse",
+		},
+	}
+
+	for i, testCase := range testCases {
+		respond = testCase.respond
+		req, err := newTestSignedRequestV4(http.MethodGet, makeTestTargetURL("", bucketName, objectName, url.Values{"lambdaArn": []string{lambdaARN}}),
+			0, nil, creds.AccessKey, creds.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		errBytes, _ := ioutil.ReadAll(rec.Body)
+		var errResp APIErrorResponse
+		if err = xml.Unmarshal(errBytes, &errResp); err != nil {
+			t.Fatalf("Test %d: %s: Failed to parse error response: %v", i+1, instanceType, err)
+		}
+		if errResp.Code != testCase.expectedCode {
+			t.Errorf("Test %d: %s: Expected the error code to be `%s`, but instead found `%s`", i+1, instanceType, testCase.expectedCode, errResp.Code)
+		}
+	}
+}
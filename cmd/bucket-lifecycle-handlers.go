@@ -96,6 +96,8 @@ func (api objectAPIHandlers) PutBucketLifecycleHandler(w http.ResponseWriter, r
 		return
 	}
 
+	globalSiteReplicationSys.BucketMetaHook(ctx, bucket, bucketLifecycleConfig, configData)
+
 	// Success.
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -173,6 +175,8 @@ func (api objectAPIHandlers) DeleteBucketLifecycleHandler(w http.ResponseWriter,
 		return
 	}
 
+	globalSiteReplicationSys.BucketMetaHook(ctx, bucket, bucketLifecycleConfig, nil)
+
 	// Success.
 	writeSuccessNoContent(w)
 }
@@ -80,34 +80,57 @@ type expiryTask struct {
 	restoredObject bool
 }
 
+type newerNoncurrentTask struct {
+	bucket   string
+	versions []ObjectToDelete
+}
+
 type expiryState struct {
-	once     sync.Once
-	expiryCh chan expiryTask
+	once              sync.Once
+	expiryCh          chan expiryTask
+	newerNoncurrentCh chan newerNoncurrentTask
 }
 
 // PendingTasks returns the number of pending ILM expiry tasks.
 func (es *expiryState) PendingTasks() int {
-	return len(es.expiryCh)
+	return len(es.expiryCh) + len(es.newerNoncurrentCh)
+}
+
+func (es *expiryState) close() {
+	es.once.Do(func() {
+		close(es.expiryCh)
+		close(es.newerNoncurrentCh)
+	})
 }
 
 func (es *expiryState) queueExpiryTask(oi ObjectInfo, restoredObject bool, rmVersion bool) {
 	select {
 	case <-GlobalContext.Done():
-		es.once.Do(func() {
-			close(es.expiryCh)
-		})
+		es.close()
 	case es.expiryCh <- expiryTask{objInfo: oi, versionExpiry: rmVersion, restoredObject: restoredObject}:
 	default:
 	}
 }
 
+// queueNewerNoncurrentTask queues noncurrent versions in excess of
+// NewerNoncurrentVersions for removal.
+func (es *expiryState) queueNewerNoncurrentTask(bucket string, versions []ObjectToDelete) {
+	select {
+	case <-GlobalContext.Done():
+		es.close()
+	case es.newerNoncurrentCh <- newerNoncurrentTask{bucket: bucket, versions: versions}:
+	default:
+	}
+}
+
 var (
 	globalExpiryState *expiryState
 )
 
 func newExpiryState() *expiryState {
 	return &expiryState{
-		expiryCh: make(chan expiryTask, 10000),
+		expiryCh:          make(chan expiryTask, 10000),
+		newerNoncurrentCh: make(chan newerNoncurrentTask, 10000),
 	}
 }
 
@@ -122,6 +145,44 @@ func initBackgroundExpiry(ctx context.Context, objectAPI ObjectLayer) {
 			}
 		}
 	}()
+	go func() {
+		for t := range globalExpiryState.newerNoncurrentCh {
+			deleteObjectVersions(ctx, objectAPI, t.bucket, t.versions)
+		}
+	}()
+}
+
+// deleteObjectVersions removes the given object versions expired by
+// lifecycle, versions of transitioned objects are removed from the
+// remote tier by the scanner afterwards.
+func deleteObjectVersions(ctx context.Context, objectAPI ObjectLayer, bucket string, versions []ObjectToDelete) {
+	deletedObjs, errs := objectAPI.DeleteObjects(ctx, bucket, versions, ObjectOptions{
+		Versioned:        globalBucketVersioningSys.Enabled(bucket),
+		VersionSuspended: globalBucketVersioningSys.Suspended(bucket),
+	})
+	for i, err := range errs {
+		if err != nil {
+			if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+				logger.LogIf(ctx, err)
+			}
+			continue
+		}
+		objInfo := ObjectInfo{
+			Bucket:    bucket,
+			Name:      deletedObjs[i].ObjectName,
+			VersionID: deletedObjs[i].VersionID,
+		}
+		// Send audit for the lifecycle delete operation
+		auditLogLifecycle(ctx, objInfo, ILMExpiry)
+
+		// Notify object deleted event.
+		sendEvent(eventArgs{
+			EventName:  event.ObjectRemovedDelete,
+			BucketName: bucket,
+			Object:     objInfo,
+			Host:       "Internal: [ILM-EXPIRY]",
+		})
+	}
 }
 
 type transitionState struct {
@@ -704,6 +765,7 @@ func (oi ObjectInfo) ToLifecycleOpts() lifecycle.ObjectOpts {
 	return lifecycle.ObjectOpts{
 		Name:                   oi.Name,
 		UserTags:               oi.UserTags,
+		Size:                   oi.Size,
 		VersionID:              oi.VersionID,
 		ModTime:                oi.ModTime,
 		IsLatest:               oi.IsLatest,
@@ -19,7 +19,9 @@ package cmd
 
 import (
 	"bytes"
+	"context"
 	"net/http"
+	"reflect"
 	"testing"
 	"time"
 
@@ -245,3 +247,173 @@ func TestValidateTransitionTier(t *testing.T) {
 		}
 	}
 }
+
+// TestAbortIncompleteMultipartUpload tests stale multipart uploads are
+// aborted per AbortIncompleteMultipartUpload lifecycle rules, and kept
+// by the stale uploads clean-up until the rules abort them.
+func TestAbortIncompleteMultipartUpload(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+	newAllSubsystems()
+	setObjectLayer(obj)
+	defer resetGlobalObjectAPI()
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	lc, err := lifecycle.ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><ID>rule</ID><Filter><Prefix>uploads/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>1</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule><Rule><ID>keep</ID><Filter><Prefix>keep/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = lc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	meta := newBucketMetadata(bucket)
+	meta.lifecycleConfig = lc
+	globalBucketMetadataSys.Set(bucket, meta)
+	defer globalBucketMetadataSys.Remove(bucket)
+
+	testCases := []struct {
+		object    string
+		initiated time.Time
+		aborted   bool
+	}{
+		{"uploads/stale", UTCNow().Add(-3 * 24 * time.Hour), true},
+		{"uploads/recent", UTCNow(), false},
+		{"keep/stale", UTCNow().Add(-3 * 24 * time.Hour), false},
+		// Not governed by a rule, removed as a stale upload.
+		{"other/stale", UTCNow().Add(-3 * 24 * time.Hour), true},
+	}
+	z := obj.(*erasureServerPools)
+	for i, tc := range testCases {
+		uploadID, err := obj.NewMultipartUpload(ctx, bucket, tc.object, ObjectOptions{
+			MTime:       tc.initiated,
+			UserDefined: map[string]string{},
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		set := z.serverPools[0].getHashedSet(tc.object)
+		set.cleanupStaleUploads(ctx, GlobalStaleUploadsExpiry)
+
+		_, err = obj.GetMultipartInfo(ctx, bucket, tc.object, uploadID, ObjectOptions{})
+		_, aborted := err.(InvalidUploadID)
+		if aborted != tc.aborted {
+			t.Fatalf("Test %d: expected aborted %v, got %v (%v)", i+1, tc.aborted, aborted, err)
+		}
+	}
+}
+
+// TestApplyNewerNoncurrentVersionLimit tests noncurrent versions in excess
+// of NewerNoncurrentVersions are queued for removal by the scanner.
+func TestApplyNewerNoncurrentVersionLimit(t *testing.T) {
+	lc, err := lifecycle.ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><ID>rule</ID><Filter><Prefix>dir/</Prefix></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>5</NoncurrentDays><NewerNoncurrentVersions>2</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	savedExpiryState := globalExpiryState
+	defer func() { globalExpiryState = savedExpiryState }()
+	globalExpiryState = newExpiryState()
+
+	// Versions newest first, each written 4 days after the next one.
+	now := UTCNow()
+	fivs := make([]FileInfo, 6)
+	for i := range fivs {
+		fivs[i] = FileInfo{
+			Volume:    "bucket",
+			Name:      "dir/object",
+			VersionID: mustGetUUID(),
+			ModTime:   now.Add(-time.Duration(4*i) * 24 * time.Hour),
+			IsLatest:  i == 0,
+		}
+		if i > 0 {
+			fivs[i].SuccessorModTime = fivs[i-1].ModTime
+		}
+	}
+	// A free version of a transitioned object is not a noncurrent version.
+	freeVersion := FileInfo{
+		Volume:    "bucket",
+		Name:      "dir/object",
+		VersionID: mustGetUUID(),
+		ModTime:   now.Add(-30 * 24 * time.Hour),
+	}
+	freeVersion.SetTierFreeVersion()
+	fivs = append(fivs, freeVersion)
+
+	item := scannerItem{
+		bucket:     "bucket",
+		prefix:     "dir",
+		objectName: "object",
+		lifeCycle:  lc,
+	}
+	retained := item.applyNewerNoncurrentVersionLimit(context.Background(), fivs)
+
+	// The latest version and 2 newer noncurrent versions are retained,
+	// the remaining noncurrent versions have been noncurrent for more
+	// than 5 days and are removed.
+	var expected []ObjectToDelete
+	for _, fi := range fivs[3:6] {
+		expected = append(expected, ObjectToDelete{ObjectName: fi.Name, VersionID: fi.VersionID})
+	}
+	if len(retained) != len(fivs)-len(expected) {
+		t.Fatalf("expected %d retained versions, got %d", len(fivs)-len(expected), len(retained))
+	}
+	select {
+	case task := <-globalExpiryState.newerNoncurrentCh:
+		if task.bucket != "bucket" || !reflect.DeepEqual(task.versions, expected) {
+			t.Fatalf("expected %v to be removed, got %v", expected, task.versions)
+		}
+	default:
+		t.Fatal("expected noncurrent versions to be queued for removal")
+	}
+
+	// Nothing is removed for objects not matching the rule.
+	item.prefix = "other"
+	if retained = item.applyNewerNoncurrentVersionLimit(context.Background(), fivs); len(retained) != len(fivs) {
+		t.Fatalf("expected all %d versions retained, got %d", len(fivs), len(retained))
+	}
+
+	// Rules are matched per version, a size filter not matching
+	// the latest version still applies to the noncurrent versions.
+	lc, err = lifecycle.ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><ID>rule</ID><Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>5</NoncurrentDays><NewerNoncurrentVersions>1</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	item.prefix = "dir"
+	item.lifeCycle = lc
+	fivs = fivs[:6]
+	for i := range fivs {
+		fivs[i].Size = 2048
+	}
+	fivs[0].Size = 10
+	fivs[4].Size = 10
+	retained = item.applyNewerNoncurrentVersionLimit(context.Background(), fivs)
+
+	// The noncurrent versions in excess of 1 which have been noncurrent
+	// for more than 5 days are removed, except for the small noncurrent
+	// version no rule matches.
+	expected = nil
+	for _, fi := range []FileInfo{fivs[3], fivs[5]} {
+		expected = append(expected, ObjectToDelete{ObjectName: fi.Name, VersionID: fi.VersionID})
+	}
+	if len(retained) != len(fivs)-len(expected) {
+		t.Fatalf("expected %d retained versions, got %d", len(fivs)-len(expected), len(retained))
+	}
+	select {
+	case task := <-globalExpiryState.newerNoncurrentCh:
+		if !reflect.DeepEqual(task.versions, expected) {
+			t.Fatalf("expected %v to be removed, got %v", expected, task.versions)
+		}
+	default:
+		t.Fatal("expected noncurrent versions to be queued for removal")
+	}
+}
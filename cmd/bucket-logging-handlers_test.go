@@ -0,0 +1,201 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+// Test S3 Bucket logging APIs
+func TestBucketLogging(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketLoggingHandlers, []string{"GetBucketLogging", "PutBucketLogging"})
+}
+
+// Simple tests of bucket logging: PUT, GET.
+// Tests are related and the order is important.
+func testBucketLoggingHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+
+	const (
+		disabled = `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></BucketLoggingStatus>`
+		enabled  = `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LoggingEnabled><TargetBucket>` + "%s" + `</TargetBucket><TargetPrefix>logs/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`
+	)
+	enabledConfig := strings.Replace(enabled, "%s", bucketName, 1)
+
+	testCases := []struct {
+		method             string
+		body               []byte
+		expectedRespStatus int
+		expectedResponse   []byte
+	}{
+		// Test case - 1.
+		// Logging is disabled by default.
+		{
+			method:             http.MethodGet,
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   []byte(disabled),
+		},
+		// Test case - 2.
+		// Target bucket does not exist.
+		{
+			method:             http.MethodPut,
+			body:               []byte(strings.Replace(enabled, "%s", "non-existent-bucket", 1)),
+			expectedRespStatus: http.StatusBadRequest,
+		},
+		// Test case - 3.
+		// Missing target bucket.
+		{
+			method:             http.MethodPut,
+			body:               []byte(`<BucketLoggingStatus><LoggingEnabled><TargetPrefix>logs/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`),
+			expectedRespStatus: http.StatusBadRequest,
+		},
+		// Test case - 4.
+		{
+			method:             http.MethodPut,
+			body:               []byte(enabledConfig),
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   []byte(``),
+		},
+		// Test case - 5.
+		{
+			method:             http.MethodGet,
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   []byte(enabledConfig),
+		},
+		// Test case - 6.
+		// Empty logging status disables logging.
+		{
+			method:             http.MethodPut,
+			body:               []byte(disabled),
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   []byte(``),
+		},
+		// Test case - 7.
+		{
+			method:             http.MethodGet,
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   []byte(disabled),
+		},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(testCase.method, getBucketLoggingURL("", bucketName),
+			int64(len(testCase.body)), bytes.NewReader(testCase.body), creds.AccessKey, creds.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if testCase.expectedResponse != nil && !bytes.Equal(testCase.expectedResponse, rec.Body.Bytes()) {
+			t.Errorf("Test %d: %s: Expected the response to be `%s`, but instead found `%s`", i+1, instanceType, string(testCase.expectedResponse), rec.Body.String())
+		}
+	}
+}
+
+// Test delivery of server access logs into the target bucket.
+func TestBucketAccessLogger(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketAccessLogger, []string{"PutBucketLogging"})
+}
+
+func testBucketAccessLogger(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+	ctx := context.Background()
+
+	targetBucket := getRandomBucketName()
+	if err := obj.MakeBucketWithLocation(ctx, targetBucket, BucketOptions{}); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	config := `<BucketLoggingStatus><LoggingEnabled><TargetBucket>` + targetBucket + `</TargetBucket><TargetPrefix>access-</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`
+	if err := globalBucketMetadataSys.Update(bucketName, bucketLoggingConfig, []byte(config)); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	l := newBucketAccessLogger()
+	if !l.Enabled(bucketName) {
+		t.Fatalf("%s: expected logging to be enabled on %s", instanceType, bucketName)
+	}
+	if l.Enabled(targetBucket) {
+		t.Fatalf("%s: expected logging to be disabled on %s", instanceType, targetBucket)
+	}
+
+	for _, object := range []string{"a", "b"} {
+		entry := audit.NewEntry("")
+		entry.ReqMethod = http.MethodGet
+		entry.ReqPath = "/" + bucketName + "/" + object
+		entry.API.Bucket = bucketName
+		entry.API.Object = object
+		entry.API.StatusCode = http.StatusOK
+		l.Send(entry)
+	}
+	l.flush(ctx, obj)
+
+	result, err := obj.ListObjects(ctx, targetBucket, "access-", "", "", 10)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(result.Objects) != 1 {
+		t.Fatalf("%s: expected 1 log object, found %d", instanceType, len(result.Objects))
+	}
+	logObject := result.Objects[0].Name
+	if _, err = time.Parse("2006-01-02-15-04-05", strings.TrimPrefix(logObject, "access-")[:19]); err != nil {
+		t.Fatalf("%s: unexpected log object name %s: %v", instanceType, logObject, err)
+	}
+
+	gr, err := obj.GetObjectNInfo(ctx, targetBucket, logObject, nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	data, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	records := strings.Split(strings.TrimSuffix(string(data), "\n"), "\n")
+	if len(records) != 2 {
+		t.Fatalf("%s: expected 2 records, found %d: %s", instanceType, len(records), data)
+	}
+	for i, object := range []string{"a", "b"} {
+		if !strings.Contains(records[i], " REST.GET.OBJECT "+object+" ") {
+			t.Errorf("%s: unexpected record %s", instanceType, records[i])
+		}
+	}
+
+	// Nothing buffered, nothing written.
+	l.flush(ctx, obj)
+	result, err = obj.ListObjects(ctx, targetBucket, "access-", "", "", 10)
+	if err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+	if len(result.Objects) != 1 {
+		t.Fatalf("%s: expected 1 log object, found %d", instanceType, len(result.Objects))
+	}
+}
@@ -0,0 +1,168 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/logging"
+	"github.com/minio/minio/internal/event"
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+const (
+	// Interval at which the server access logs of a node are delivered.
+	bucketAccessLogInterval = 5 * time.Minute
+
+	// Maximum size of the server access logs buffered for all target
+	// buckets before they are delivered ahead of the interval.
+	maxBucketAccessLogBuffer = 4 << 20
+)
+
+// accessLogTarget - the destination of server access logs.
+type accessLogTarget struct {
+	bucket string
+	prefix string
+}
+
+// bucketAccessLogger batches the server access log records of the
+// requests served by this node and delivers them periodically as log
+// objects into the target buckets. Delivery is best effort, buffered
+// records are lost if the server is stopped.
+type bucketAccessLogger struct {
+	sync.Mutex
+	records map[accessLogTarget][]string
+	size    int
+
+	flushCh chan struct{}
+}
+
+func newBucketAccessLogger() *bucketAccessLogger {
+	return &bucketAccessLogger{
+		records: make(map[accessLogTarget][]string),
+		flushCh: make(chan struct{}, 1),
+	}
+}
+
+// initBucketAccessLogging starts delivering the server access logs of
+// buckets with logging enabled.
+func initBucketAccessLogging(ctx context.Context, objAPI ObjectLayer) {
+	l := newBucketAccessLogger()
+	go l.run(ctx, objAPI)
+	logger.SetBucketLogTarget(l)
+}
+
+// Enabled - returns true if server access logging is enabled on the bucket.
+func (l *bucketAccessLogger) Enabled(bucket string) bool {
+	config, err := globalBucketMetadataSys.GetLoggingConfig(bucket)
+	return err == nil && config.Enabled()
+}
+
+// Send - buffers the server access log record of an audit entry.
+func (l *bucketAccessLogger) Send(entry audit.Entry) {
+	config, err := globalBucketMetadataSys.GetLoggingConfig(entry.API.Bucket)
+	if err != nil || !config.Enabled() {
+		return
+	}
+	bucket, prefix := config.Target()
+	target := accessLogTarget{bucket: bucket, prefix: prefix}
+	record := logging.FormatEntry(entry)
+
+	l.Lock()
+	l.records[target] = append(l.records[target], record)
+	l.size += len(record) + 1
+	full := l.size >= maxBucketAccessLogBuffer
+	l.Unlock()
+
+	if full {
+		select {
+		case l.flushCh <- struct{}{}:
+		default:
+		}
+	}
+}
+
+func (l *bucketAccessLogger) run(ctx context.Context, objAPI ObjectLayer) {
+	ticker := time.NewTicker(bucketAccessLogInterval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		case <-l.flushCh:
+		}
+		l.flush(ctx, objAPI)
+	}
+}
+
+// flush - delivers all the buffered records, one log object per target.
+func (l *bucketAccessLogger) flush(ctx context.Context, objAPI ObjectLayer) {
+	l.Lock()
+	records := l.records
+	l.records = make(map[accessLogTarget][]string)
+	l.size = 0
+	l.Unlock()
+
+	now := UTCNow()
+	for target, lines := range records {
+		logger.LogIf(ctx, writeBucketAccessLog(ctx, objAPI, target, lines, now))
+	}
+}
+
+// accessLogObjectName - returns the name of a log object, in the
+// TargetPrefixYYYY-mm-DD-HH-MM-SS-UniqueString format used by S3.
+func accessLogObjectName(prefix string, t time.Time) string {
+	unique := strings.ToUpper(strings.ReplaceAll(mustGetUUID(), "-", "")[:16])
+	return prefix + t.Format("2006-01-02-15-04-05") + "-" + unique
+}
+
+func writeBucketAccessLog(ctx context.Context, objAPI ObjectLayer, target accessLogTarget, records []string, t time.Time) error {
+	data := []byte(strings.Join(records, "\n") + "\n")
+	hashReader, err := hash.NewReader(bytes.NewReader(data), int64(len(data)), "", getSHA256Hash(data), int64(len(data)))
+	if err != nil {
+		return err
+	}
+
+	object := accessLogObjectName(target.prefix, t)
+	objInfo, err := objAPI.PutObject(ctx, target.bucket, object, NewPutObjReader(hashReader), ObjectOptions{
+		Versioned:        globalBucketVersioningSys.Enabled(target.bucket),
+		VersionSuspended: globalBucketVersioningSys.Suspended(target.bucket),
+		UserDefined: map[string]string{
+			"content-type": "text/plain",
+		},
+	})
+	if err != nil {
+		return err
+	}
+
+	sendEvent(eventArgs{
+		EventName:  event.ObjectCreatedPut,
+		BucketName: target.bucket,
+		Object:     objInfo,
+		Host:       "Internal: [Bucket-Logging]",
+	})
+	return nil
+}
@@ -23,14 +23,19 @@ import (
 	"errors"
 	"fmt"
 	"sync"
+	"time"
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/cors"
 	bucketsse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/bucket/lambda"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/logging"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
+	"github.com/minio/minio/internal/bucket/website"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/kms"
 	"github.com/minio/minio/internal/logger"
@@ -38,10 +43,24 @@ import (
 	"github.com/minio/pkg/bucket/policy"
 )
 
+const (
+	// bucketCorsMissingExpiry is how long a bucket found missing by a
+	// cross-origin request is remembered before it is looked up again.
+	bucketCorsMissingExpiry = 30 * time.Second
+
+	// bucketCorsMissingMax is the maximum number of missing buckets
+	// remembered, further buckets are looked up on every request.
+	bucketCorsMissingMax = 10000
+)
+
 // BucketMetadataSys captures all bucket metadata for a given cluster.
 type BucketMetadataSys struct {
 	sync.RWMutex
 	metadataMap map[string]BucketMetadata
+
+	// buckets found missing by cross-origin requests and when
+	// they were looked up, see GetCorsConfigForRequest.
+	corsMissingMap map[string]time.Time
 }
 
 // Remove bucket metadata from memory.
@@ -51,6 +70,7 @@ func (sys *BucketMetadataSys) Remove(bucket string) {
 	}
 	sys.Lock()
 	delete(sys.metadataMap, bucket)
+	delete(sys.corsMissingMap, bucket)
 	globalBucketMonitor.DeleteBucket(bucket)
 	sys.Unlock()
 }
@@ -68,6 +88,7 @@ func (sys *BucketMetadataSys) Set(bucket string, meta BucketMetadata) {
 	if bucket != minioMetaBucket {
 		sys.Lock()
 		sys.metadataMap[bucket] = meta
+		delete(sys.corsMissingMap, bucket)
 		sys.Unlock()
 	}
 }
@@ -110,6 +131,24 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 				meta.TaggingConfigXML = configData
 				return meta.Save(GlobalContext, objAPI)
 			}
+		case bucketCorsConfig:
+			if globalGatewayName == NASBackendGateway {
+				meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+				if err != nil {
+					return err
+				}
+				meta.CorsConfigXML = configData
+				return meta.Save(GlobalContext, objAPI)
+			}
+		case bucketWebsiteConfig:
+			if globalGatewayName == NASBackendGateway {
+				meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+				if err != nil {
+					return err
+				}
+				meta.WebsiteConfigXML = configData
+				return meta.Save(GlobalContext, objAPI)
+			}
 		case bucketNotificationConfig:
 			if globalGatewayName == NASBackendGateway {
 				meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
@@ -152,8 +191,18 @@ func (sys *BucketMetadataSys) Update(bucket string, configFile string, configDat
 		meta.EncryptionConfigXML = configData
 	case bucketTaggingConfig:
 		meta.TaggingConfigXML = configData
+	case bucketCorsConfig:
+		meta.CorsConfigXML = configData
+	case bucketWebsiteConfig:
+		meta.WebsiteConfigXML = configData
+	case bucketLoggingConfig:
+		meta.LoggingConfigXML = configData
+	case bucketLambdaConfig:
+		meta.LambdaConfigXML = configData
 	case bucketQuotaConfigFile:
 		meta.QuotaConfigJSON = configData
+	case bucketStorageClassConfigFile:
+		meta.StorageClassConfigJSON = configData
 	case objectLockConfig:
 		if !globalIsErasure && !globalIsDistErasure {
 			return NotImplemented{}
@@ -330,6 +379,140 @@ func (sys *BucketMetadataSys) GetSSEConfig(bucket string) (*bucketsse.BucketSSEC
 	return meta.sseConfig, nil
 }
 
+// GetCorsConfig returns configured bucket CORS config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCorsConfig(bucket string) (*cors.Config, error) {
+	if globalIsGateway && globalGatewayName == NASBackendGateway {
+		// Only needed in case of NAS gateway.
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return nil, errServerNotInitialized
+		}
+		meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+		if err != nil {
+			return nil, err
+		}
+		if meta.corsConfig == nil {
+			return nil, BucketCorsConfigNotFound{Bucket: bucket}
+		}
+		return meta.corsConfig, nil
+	}
+
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketCorsConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.corsConfig == nil {
+		return nil, BucketCorsConfigNotFound{Bucket: bucket}
+	}
+	return meta.corsConfig, nil
+}
+
+// GetCorsConfigForRequest returns the CORS config of a bucket addressed
+// by a cross-origin request. Such requests are not authenticated, buckets
+// which do not exist are remembered for a while so that requests for them
+// do not go to disk every time.
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetCorsConfigForRequest(bucket string) (*cors.Config, error) {
+	sys.RLock()
+	missingSince, missing := sys.corsMissingMap[bucket]
+	sys.RUnlock()
+	if missing && time.Since(missingSince) < bucketCorsMissingExpiry {
+		return nil, BucketNotFound{Bucket: bucket}
+	}
+
+	config, err := sys.GetCorsConfig(bucket)
+	if errors.Is(err, errVolumeNotFound) {
+		sys.setCorsMissing(bucket)
+	}
+	return config, err
+}
+
+// setCorsMissing remembers a bucket found missing by a cross-origin
+// request, expired buckets are pruned when the map is full.
+func (sys *BucketMetadataSys) setCorsMissing(bucket string) {
+	sys.Lock()
+	defer sys.Unlock()
+	if len(sys.corsMissingMap) >= bucketCorsMissingMax {
+		for k, since := range sys.corsMissingMap {
+			if time.Since(since) >= bucketCorsMissingExpiry {
+				delete(sys.corsMissingMap, k)
+			}
+		}
+		if len(sys.corsMissingMap) >= bucketCorsMissingMax {
+			return
+		}
+	}
+	sys.corsMissingMap[bucket] = time.Now()
+}
+
+// GetWebsiteConfig returns configured bucket website config
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetWebsiteConfig(bucket string) (*website.Config, error) {
+	if globalIsGateway && globalGatewayName == NASBackendGateway {
+		// Only needed in case of NAS gateway.
+		objAPI := newObjectLayerFn()
+		if objAPI == nil {
+			return nil, errServerNotInitialized
+		}
+		meta, err := loadBucketMetadata(GlobalContext, objAPI, bucket)
+		if err != nil {
+			return nil, err
+		}
+		if meta.websiteConfig == nil {
+			return nil, BucketWebsiteConfigNotFound{Bucket: bucket}
+		}
+		return meta.websiteConfig, nil
+	}
+
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketWebsiteConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.websiteConfig == nil {
+		return nil, BucketWebsiteConfigNotFound{Bucket: bucket}
+	}
+	return meta.websiteConfig, nil
+}
+
+// GetLoggingConfig returns configured bucket logging status
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetLoggingConfig(bucket string) (*logging.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketLoggingConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.loggingConfig == nil {
+		return nil, BucketLoggingConfigNotFound{Bucket: bucket}
+	}
+	return meta.loggingConfig, nil
+}
+
+// GetLambdaConfig returns configured bucket object lambda transformations
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetLambdaConfig(bucket string) (*lambda.Config, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil, BucketLambdaConfigNotFound{Bucket: bucket}
+		}
+		return nil, err
+	}
+	if meta.lambdaConfig == nil {
+		return nil, BucketLambdaConfigNotFound{Bucket: bucket}
+	}
+	return meta.lambdaConfig, nil
+}
+
 // GetPolicyConfig returns configured bucket policy
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetPolicyConfig(bucket string) (*policy.Policy, error) {
@@ -364,6 +547,16 @@ func (sys *BucketMetadataSys) GetQuotaConfig(bucket string) (*madmin.BucketQuota
 	return meta.quotaConfig, nil
 }
 
+// GetStorageClassConfig returns configured bucket default storage class
+// The returned object may not be modified.
+func (sys *BucketMetadataSys) GetStorageClassConfig(bucket string) (*bucketStorageClassConfig, error) {
+	meta, err := sys.GetConfig(bucket)
+	if err != nil {
+		return nil, err
+	}
+	return meta.storageClassConfig, nil
+}
+
 // GetReplicationConfig returns configured bucket replication config
 // The returned object may not be modified.
 func (sys *BucketMetadataSys) GetReplicationConfig(ctx context.Context, bucket string) (*replication.Config, error) {
@@ -436,6 +629,7 @@ func (sys *BucketMetadataSys) GetConfig(bucket string) (BucketMetadata, error) {
 	}
 	sys.Lock()
 	sys.metadataMap[bucket] = meta
+	delete(sys.corsMissingMap, bucket)
 	sys.Unlock()
 
 	return meta, nil
@@ -474,6 +668,7 @@ func (sys *BucketMetadataSys) concurrentLoad(ctx context.Context, buckets []Buck
 			}
 			sys.Lock()
 			sys.metadataMap[buckets[index].Name] = meta
+			delete(sys.corsMissingMap, buckets[index].Name)
 			sys.Unlock()
 			return nil
 		}, index)
@@ -504,12 +699,16 @@ func (sys *BucketMetadataSys) Reset() {
 	for k := range sys.metadataMap {
 		delete(sys.metadataMap, k)
 	}
+	for k := range sys.corsMissingMap {
+		delete(sys.corsMissingMap, k)
+	}
 	sys.Unlock()
 }
 
 // NewBucketMetadataSys - creates new policy system.
 func NewBucketMetadataSys() *BucketMetadataSys {
 	return &BucketMetadataSys{
-		metadataMap: make(map[string]BucketMetadata),
+		metadataMap:    make(map[string]BucketMetadata),
+		corsMissingMap: make(map[string]time.Time),
 	}
 }
@@ -31,11 +31,15 @@ import (
 
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/bucket/cors"
 	bucketsse "github.com/minio/minio/internal/bucket/encryption"
+	"github.com/minio/minio/internal/bucket/lambda"
 	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/bucket/logging"
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/bucket/versioning"
+	"github.com/minio/minio/internal/bucket/website"
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/fips"
@@ -81,6 +85,11 @@ type BucketMetadata struct {
 	ReplicationConfigXML        []byte
 	BucketTargetsConfigJSON     []byte
 	BucketTargetsConfigMetaJSON []byte
+	CorsConfigXML               []byte
+	WebsiteConfigXML            []byte
+	LoggingConfigXML            []byte
+	LambdaConfigXML             []byte
+	StorageClassConfigJSON      []byte
 
 	// Unexported fields. Must be updated atomically.
 	policyConfig           *policy.Policy
@@ -94,6 +103,11 @@ type BucketMetadata struct {
 	replicationConfig      *replication.Config
 	bucketTargetConfig     *madmin.BucketTargets
 	bucketTargetConfigMeta map[string]string
+	corsConfig             *cors.Config
+	websiteConfig          *website.Config
+	loggingConfig          *logging.Config
+	lambdaConfig           *lambda.Config
+	storageClassConfig     *bucketStorageClassConfig
 }
 
 // newBucketMetadata creates BucketMetadata with the supplied name and Created to Now.
@@ -104,7 +118,8 @@ func newBucketMetadata(name string) BucketMetadata {
 		notificationConfig: &event.Config{
 			XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
 		},
-		quotaConfig: &madmin.BucketQuota{},
+		quotaConfig:        &madmin.BucketQuota{},
+		storageClassConfig: &bucketStorageClassConfig{},
 		versioningConfig: &versioning.Versioning{
 			XMLNS: "http://s3.amazonaws.com/doc/2006-03-01/",
 		},
@@ -236,6 +251,15 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 		}
 	}
 
+	if len(b.StorageClassConfigJSON) != 0 {
+		b.storageClassConfig, err = parseBucketStorageClass(b.StorageClassConfigJSON)
+		if err != nil {
+			return err
+		}
+	} else {
+		b.storageClassConfig = &bucketStorageClassConfig{}
+	}
+
 	if len(b.ReplicationConfigXML) != 0 {
 		b.replicationConfig, err = replication.ParseConfig(bytes.NewReader(b.ReplicationConfigXML))
 		if err != nil {
@@ -245,6 +269,42 @@ func (b *BucketMetadata) parseAllConfigs(ctx context.Context, objectAPI ObjectLa
 		b.replicationConfig = nil
 	}
 
+	if len(b.CorsConfigXML) != 0 {
+		b.corsConfig, err = cors.ParseBucketCorsConfig(bytes.NewReader(b.CorsConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.corsConfig = nil
+	}
+
+	if len(b.WebsiteConfigXML) != 0 {
+		b.websiteConfig, err = website.ParseConfig(bytes.NewReader(b.WebsiteConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.websiteConfig = nil
+	}
+
+	if len(b.LoggingConfigXML) != 0 {
+		b.loggingConfig, err = logging.ParseConfig(bytes.NewReader(b.LoggingConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.loggingConfig = nil
+	}
+
+	if len(b.LambdaConfigXML) != 0 {
+		b.lambdaConfig, err = lambda.ParseConfig(bytes.NewReader(b.LambdaConfigXML))
+		if err != nil {
+			return err
+		}
+	} else {
+		b.lambdaConfig = nil
+	}
+
 	if len(b.BucketTargetsConfigJSON) != 0 {
 		b.bucketTargetConfig, err = parseBucketTargetConfig(b.Name, b.BucketTargetsConfigJSON, b.BucketTargetsConfigMetaJSON)
 		if err != nil {
@@ -99,6 +99,8 @@ func (api objectAPIHandlers) PutBucketPolicyHandler(w http.ResponseWriter, r *ht
 		return
 	}
 
+	globalSiteReplicationSys.BucketMetaHook(ctx, bucket, bucketPolicyConfig, configData)
+
 	// Success.
 	writeSuccessNoContent(w)
 }
@@ -134,6 +136,8 @@ func (api objectAPIHandlers) DeleteBucketPolicyHandler(w http.ResponseWriter, r
 		return
 	}
 
+	globalSiteReplicationSys.BucketMetaHook(ctx, bucket, bucketPolicyConfig, nil)
+
 	// Success.
 	writeSuccessNoContent(w)
 }
@@ -0,0 +1,530 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/logger"
+	"github.com/tinylib/msgp/msgp"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore replicationJournal replicationJournalSegment
+
+// The replication journal persists the replication tasks queued by this
+// node on a local disk, so that they survive restarts and are never
+// dropped when the workers fall behind. New tasks are appended to the
+// active journal, which is rotated into numbered read-only segments that
+// are drained in order. Each kind of task is queued to its own workers,
+// a kind whose queue is full is retried later without holding back the
+// other kinds. A segment is removed once all of its tasks are replicated,
+// segments left over by a previous run are replayed at startup.
+
+const (
+	replicationJournalVersion = 1
+	replicationJournalHdrLen  = 2 // 2 bytes
+
+	replicationJournalActive = "active.bin"
+	replicationJournalSegExt = ".bin"
+
+	// Interval at which tasks whose queue was full are retried.
+	replicationJournalRetryInterval = time.Second
+)
+
+// Kinds of replication journal entries.
+const (
+	rjEntryObject uint8 = iota + 1
+	rjEntryDelete
+	rjEntryFailed
+
+	rjEntryKinds // number of kinds, including the zero kind
+)
+
+// rjEntry - a replication task in the replication journal.
+type rjEntry struct {
+	Kind       uint8     `msg:"k"`
+	Queued     time.Time `msg:"q"`
+	Bucket     string    `msg:"b"`
+	Object     string    `msg:"o"`
+	VersionID  string    `msg:"v"`
+	OpType     int       `msg:"t"`
+	RetryCount uint32    `msg:"r"`
+	ResetID    string    `msg:"rid"`
+
+	// Delete replication only.
+	DeleteMarker                  bool      `msg:"dm"`
+	DeleteMarkerVersionID         string    `msg:"dmv"`
+	DeleteMarkerMTime             time.Time `msg:"dmt"`
+	DeleteMarkerReplicationStatus string    `msg:"dms"`
+	VersionPurgeStatus            string    `msg:"vps"`
+}
+
+func newRJObjectEntry(kind uint8, ri ReplicateObjectInfo) rjEntry {
+	return rjEntry{
+		Kind:       kind,
+		Queued:     UTCNow(),
+		Bucket:     ri.Bucket,
+		Object:     ri.Name,
+		VersionID:  ri.VersionID,
+		OpType:     int(ri.OpType),
+		RetryCount: ri.RetryCount,
+		ResetID:    ri.ResetID,
+	}
+}
+
+func newRJDeleteEntry(doi DeletedObjectReplicationInfo) rjEntry {
+	return rjEntry{
+		Kind:                          rjEntryDelete,
+		Queued:                        UTCNow(),
+		Bucket:                        doi.Bucket,
+		Object:                        doi.ObjectName,
+		VersionID:                     doi.VersionID,
+		OpType:                        int(doi.OpType),
+		ResetID:                       doi.ResetID,
+		DeleteMarker:                  doi.DeleteMarker,
+		DeleteMarkerVersionID:         doi.DeleteMarkerVersionID,
+		DeleteMarkerMTime:             doi.DeleteMarkerMTime.Time,
+		DeleteMarkerReplicationStatus: doi.DeleteMarkerReplicationStatus,
+		VersionPurgeStatus:            string(doi.VersionPurgeStatus),
+	}
+}
+
+// queue returns the kind of the entry, unknown kinds are queued along
+// with the objects.
+func (e rjEntry) queue() uint8 {
+	if e.Kind == rjEntryDelete || e.Kind == rjEntryFailed {
+		return e.Kind
+	}
+	return rjEntryObject
+}
+
+func (e rjEntry) objectInfo() ReplicateObjectInfo {
+	return ReplicateObjectInfo{
+		ObjectInfo: ObjectInfo{
+			Bucket:    e.Bucket,
+			Name:      e.Object,
+			VersionID: e.VersionID,
+		},
+		OpType:     replication.Type(e.OpType),
+		RetryCount: e.RetryCount,
+		ResetID:    e.ResetID,
+	}
+}
+
+func (e rjEntry) deletedObjectInfo() DeletedObjectReplicationInfo {
+	return DeletedObjectReplicationInfo{
+		DeletedObject: DeletedObject{
+			ObjectName:                    e.Object,
+			VersionID:                     e.VersionID,
+			DeleteMarker:                  e.DeleteMarker,
+			DeleteMarkerVersionID:         e.DeleteMarkerVersionID,
+			DeleteMarkerMTime:             DeleteMarkerMTime{e.DeleteMarkerMTime},
+			DeleteMarkerReplicationStatus: e.DeleteMarkerReplicationStatus,
+			VersionPurgeStatus:            VersionPurgeStatusType(e.VersionPurgeStatus),
+		},
+		Bucket:  e.Bucket,
+		OpType:  replication.Type(e.OpType),
+		ResetID: e.ResetID,
+	}
+}
+
+// replicationJournalSegment - a read-only part of the replication journal.
+type replicationJournalSegment struct {
+	j     *replicationJournal
+	seq   uint64
+	count int       // number of entries
+	first time.Time // queue time of the first entry
+
+	// Protected by the journal lock.
+	pending int               // entries handed to the workers and not yet done
+	read    bool              // all entries handed to the workers
+	sent    [rjEntryKinds]int // entries of each kind handed to the workers
+}
+
+// done marks one of the tasks read from the segment as done, it is a
+// no-op for tasks which were not read from a journal.
+func (s *replicationJournalSegment) done() {
+	if s == nil {
+		return
+	}
+	j := s.j
+	j.Lock()
+	defer j.Unlock()
+	s.pending--
+	j.backlog--
+	j.removeIfDone(s)
+}
+
+type replicationJournal struct {
+	sync.Mutex
+	dir string
+
+	file        *os.File // active journal
+	activeCount int
+	activeFirst time.Time
+
+	nextSeq  uint64
+	segments []*replicationJournalSegment // outstanding segments, oldest first
+	backlog  int64                        // tasks journaled and not yet done
+
+	notifyCh chan struct{}
+}
+
+// newReplicationJournal opens the replication journal stored on the disk,
+// tasks left over by a previous run are queued ahead of new tasks.
+func newReplicationJournal(ctx context.Context, diskPath string) (*replicationJournal, error) {
+	j := &replicationJournal{
+		dir:      filepath.Join(diskPath, minioMetaBucket, "replication", "journal"),
+		notifyCh: make(chan struct{}, 1),
+	}
+	if err := os.MkdirAll(j.dir, os.FileMode(0700)); err != nil {
+		return nil, err
+	}
+
+	entries, err := ioutil.ReadDir(j.dir)
+	if err != nil {
+		return nil, err
+	}
+	var seqs []uint64
+	for _, entry := range entries {
+		name := entry.Name()
+		if name == replicationJournalActive || !strings.HasSuffix(name, replicationJournalSegExt) {
+			continue
+		}
+		seq, err := strconv.ParseUint(strings.TrimSuffix(name, replicationJournalSegExt), 16, 64)
+		if err != nil {
+			continue
+		}
+		seqs = append(seqs, seq)
+	}
+	sort.Slice(seqs, func(i, k int) bool { return seqs[i] < seqs[k] })
+	if len(seqs) > 0 {
+		j.nextSeq = seqs[len(seqs)-1] + 1
+	}
+	for _, seq := range seqs {
+		j.replaySegment(ctx, seq)
+	}
+
+	// The active journal of the previous run becomes the newest segment.
+	if _, err = os.Stat(j.activePath()); err == nil {
+		seq := j.nextSeq
+		j.nextSeq++
+		if err = os.Rename(j.activePath(), j.segmentPath(seq)); err != nil {
+			return nil, err
+		}
+		j.replaySegment(ctx, seq)
+	}
+	return j, nil
+}
+
+// replaySegment queues a segment of a previous run for draining.
+func (j *replicationJournal) replaySegment(ctx context.Context, seq uint64) {
+	var (
+		count int
+		first time.Time
+	)
+	err := j.walkSegment(seq, func(e rjEntry) bool {
+		if count == 0 {
+			first = e.Queued
+		}
+		count++
+		return true
+	})
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("replication-journal: failed to read journal segment %s: %w", j.segmentPath(seq), err))
+	}
+	if count == 0 {
+		os.Remove(j.segmentPath(seq))
+		return
+	}
+	j.segments = append(j.segments, &replicationJournalSegment{j: j, seq: seq, count: count, first: first})
+	j.backlog += int64(count)
+}
+
+func (j *replicationJournal) activePath() string {
+	return filepath.Join(j.dir, replicationJournalActive)
+}
+
+func (j *replicationJournal) segmentPath(seq uint64) string {
+	return filepath.Join(j.dir, fmt.Sprintf("%016x%s", seq, replicationJournalSegExt))
+}
+
+// add appends a task to the active journal.
+func (j *replicationJournal) add(e rjEntry) error {
+	b, err := e.MarshalMsg(nil)
+	if err != nil {
+		return err
+	}
+
+	j.Lock()
+	defer j.Unlock()
+	if j.file == nil {
+		if j.file, err = j.openActive(); err != nil {
+			return err
+		}
+	}
+	if _, err = j.file.Write(b); err != nil {
+		j.file.Close()
+		j.file = nil // reset to allow subsequent reopen when file/disk is available.
+		return err
+	}
+	if j.activeCount == 0 {
+		j.activeFirst = e.Queued
+	}
+	j.activeCount++
+	j.backlog++
+
+	select {
+	case j.notifyCh <- struct{}{}:
+	default:
+	}
+	return nil
+}
+
+func (j *replicationJournal) openActive() (*os.File, error) {
+	f, err := os.OpenFile(j.activePath(), os.O_APPEND|os.O_CREATE|os.O_WRONLY|writeMode, 0666)
+	if err != nil {
+		return nil, err
+	}
+	fi, err := f.Stat()
+	if err != nil {
+		f.Close()
+		return nil, err
+	}
+	if fi.Size() == 0 {
+		var data [replicationJournalHdrLen]byte
+		binary.LittleEndian.PutUint16(data[:], replicationJournalVersion)
+		if _, err = f.Write(data[:]); err != nil {
+			f.Close()
+			return nil, err
+		}
+	}
+	return f, nil
+}
+
+// unreadSegments returns the segments not yet read, oldest first. The
+// active journal is rotated into a new segment if it holds any tasks.
+func (j *replicationJournal) unreadSegments() (segments []*replicationJournalSegment, err error) {
+	j.Lock()
+	defer j.Unlock()
+	if j.activeCount > 0 {
+		if j.file != nil {
+			j.file.Close()
+			j.file = nil
+		}
+		s := &replicationJournalSegment{j: j, seq: j.nextSeq, count: j.activeCount, first: j.activeFirst}
+		if err = os.Rename(j.activePath(), j.segmentPath(s.seq)); err == nil {
+			j.nextSeq++
+			j.activeCount = 0
+			j.activeFirst = time.Time{}
+			j.segments = append(j.segments, s)
+		}
+	}
+	for _, s := range j.segments {
+		if !s.read {
+			segments = append(segments, s)
+		}
+	}
+	return segments, err
+}
+
+// removeIfDone removes a segment once all of its tasks are done, it must
+// be called with the journal lock held.
+func (j *replicationJournal) removeIfDone(s *replicationJournalSegment) {
+	if !s.read || s.pending > 0 {
+		return
+	}
+	for i := range j.segments {
+		if j.segments[i] == s {
+			j.segments = append(j.segments[:i], j.segments[i+1:]...)
+			os.Remove(j.segmentPath(s.seq))
+			return
+		}
+	}
+}
+
+// walkSegment calls fn for every entry of a segment until fn returns false.
+func (j *replicationJournal) walkSegment(seq uint64, fn func(rjEntry) bool) error {
+	f, err := os.Open(j.segmentPath(seq))
+	if err != nil {
+		return err
+	}
+	defer f.Close()
+
+	var data [replicationJournalHdrLen]byte
+	if _, err = io.ReadFull(f, data[:]); err != nil {
+		if err == io.EOF {
+			return nil
+		}
+		return err
+	}
+	if binary.LittleEndian.Uint16(data[:]) != replicationJournalVersion {
+		return errUnsupportedJournalVersion
+	}
+
+	mr := msgp.NewReader(f)
+	for {
+		var e rjEntry
+		if err = e.DecodeMsg(mr); err != nil {
+			if errors.Is(err, io.EOF) {
+				return nil
+			}
+			return err
+		}
+		if !fn(e) {
+			return nil
+		}
+	}
+}
+
+// drain hands the journaled tasks to dispatch, the tasks of each kind in
+// the order they were queued. dispatch returns false if the queue of the
+// task is full, tasks of that kind are retried later.
+func (j *replicationJournal) drain(ctx context.Context, dispatch func(rjEntry, *replicationJournalSegment) bool) {
+	ticker := time.NewTicker(time.Minute)
+	defer ticker.Stop()
+	for {
+		segments, err := j.unreadSegments()
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("replication-journal: failed to rotate journal: %w", err))
+		}
+
+		var full [rjEntryKinds]bool
+		for _, s := range segments {
+			j.drainSegment(ctx, s, &full, dispatch)
+			if ctx.Err() != nil {
+				// Shutting down, the segments are replayed at startup.
+				return
+			}
+		}
+
+		if full != [rjEntryKinds]bool{} {
+			select {
+			case <-ctx.Done():
+				return
+			case <-time.After(replicationJournalRetryInterval):
+			}
+			continue
+		}
+		select {
+		case <-ctx.Done():
+			return
+		case <-j.notifyCh:
+		case <-ticker.C:
+		}
+	}
+}
+
+// drainSegment hands the tasks of a segment not yet handed over to
+// dispatch. Kinds of tasks whose queue is full are skipped, and marked
+// in full to keep the tasks of later segments behind them.
+func (j *replicationJournal) drainSegment(ctx context.Context, s *replicationJournalSegment, full *[rjEntryKinds]bool, dispatch func(rjEntry, *replicationJournalSegment) bool) {
+	j.Lock()
+	sent := s.sent
+	j.Unlock()
+
+	var seen [rjEntryKinds]int
+	err := j.walkSegment(s.seq, func(e rjEntry) bool {
+		q := e.queue()
+		seen[q]++
+		if seen[q] <= sent[q] || full[q] {
+			return true
+		}
+		j.Lock()
+		s.pending++
+		j.Unlock()
+		if !dispatch(e, s) {
+			j.Lock()
+			s.pending--
+			j.Unlock()
+			full[q] = true
+			return ctx.Err() == nil
+		}
+		sent[q]++
+		return true
+	})
+	if ctx.Err() != nil {
+		return
+	}
+
+	j.Lock()
+	defer j.Unlock()
+	s.sent = sent
+	n := 0
+	for _, c := range sent {
+		n += c
+	}
+	switch {
+	case err != nil:
+		if *full != [rjEntryKinds]bool{} {
+			// Retried along with the full queues.
+			return
+		}
+		// Entries after a corrupted entry are lost, the
+		// scanner eventually replicates these objects.
+		logger.LogIf(ctx, fmt.Errorf("replication-journal: failed to read journal segment %s: %w", j.segmentPath(s.seq), err))
+		if n < s.count {
+			// Tasks of the corrupted part will never be done.
+			j.backlog -= int64(s.count - n)
+		}
+	case n < s.count:
+		return
+	}
+	s.read = true
+	j.removeIfDone(s)
+}
+
+// Backlog returns the number of journaled tasks not yet done, and the
+// queue time of the oldest one.
+func (j *replicationJournal) Backlog() (count int64, oldest time.Time) {
+	if j == nil {
+		return 0, oldest
+	}
+	j.Lock()
+	defer j.Unlock()
+	if len(j.segments) > 0 {
+		oldest = j.segments[0].first
+	} else if j.activeCount > 0 {
+		oldest = j.activeFirst
+	}
+	return j.backlog, oldest
+}
+
+// Close closes the active journal.
+func (j *replicationJournal) Close() {
+	j.Lock()
+	defer j.Unlock()
+	if j.file != nil {
+		j.file.Close()
+		j.file = nil
+	}
+}
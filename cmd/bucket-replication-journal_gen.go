@@ -0,0 +1,410 @@
+package cmd
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// DecodeMsg implements msgp.Decodable
+func (z *rjEntry) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "k":
+			z.Kind, err = dc.ReadUint8()
+			if err != nil {
+				err = msgp.WrapError(err, "Kind")
+				return
+			}
+		case "q":
+			z.Queued, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "Queued")
+				return
+			}
+		case "b":
+			z.Bucket, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Bucket")
+				return
+			}
+		case "o":
+			z.Object, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Object")
+				return
+			}
+		case "v":
+			z.VersionID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "VersionID")
+				return
+			}
+		case "t":
+			z.OpType, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "OpType")
+				return
+			}
+		case "r":
+			z.RetryCount, err = dc.ReadUint32()
+			if err != nil {
+				err = msgp.WrapError(err, "RetryCount")
+				return
+			}
+		case "rid":
+			z.ResetID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "ResetID")
+				return
+			}
+		case "dm":
+			z.DeleteMarker, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "DeleteMarker")
+				return
+			}
+		case "dmv":
+			z.DeleteMarkerVersionID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "DeleteMarkerVersionID")
+				return
+			}
+		case "dmt":
+			z.DeleteMarkerMTime, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "DeleteMarkerMTime")
+				return
+			}
+		case "dms":
+			z.DeleteMarkerReplicationStatus, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "DeleteMarkerReplicationStatus")
+				return
+			}
+		case "vps":
+			z.VersionPurgeStatus, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "VersionPurgeStatus")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *rjEntry) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 13
+	// write "k"
+	err = en.Append(0x8d, 0xa1, 0x6b)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint8(z.Kind)
+	if err != nil {
+		err = msgp.WrapError(err, "Kind")
+		return
+	}
+	// write "q"
+	err = en.Append(0xa1, 0x71)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.Queued)
+	if err != nil {
+		err = msgp.WrapError(err, "Queued")
+		return
+	}
+	// write "b"
+	err = en.Append(0xa1, 0x62)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Bucket)
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	// write "o"
+	err = en.Append(0xa1, 0x6f)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Object)
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	// write "v"
+	err = en.Append(0xa1, 0x76)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.VersionID)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionID")
+		return
+	}
+	// write "t"
+	err = en.Append(0xa1, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.OpType)
+	if err != nil {
+		err = msgp.WrapError(err, "OpType")
+		return
+	}
+	// write "r"
+	err = en.Append(0xa1, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint32(z.RetryCount)
+	if err != nil {
+		err = msgp.WrapError(err, "RetryCount")
+		return
+	}
+	// write "rid"
+	err = en.Append(0xa3, 0x72, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.ResetID)
+	if err != nil {
+		err = msgp.WrapError(err, "ResetID")
+		return
+	}
+	// write "dm"
+	err = en.Append(0xa2, 0x64, 0x6d)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.DeleteMarker)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarker")
+		return
+	}
+	// write "dmv"
+	err = en.Append(0xa3, 0x64, 0x6d, 0x76)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.DeleteMarkerVersionID)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerVersionID")
+		return
+	}
+	// write "dmt"
+	err = en.Append(0xa3, 0x64, 0x6d, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.DeleteMarkerMTime)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerMTime")
+		return
+	}
+	// write "dms"
+	err = en.Append(0xa3, 0x64, 0x6d, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.DeleteMarkerReplicationStatus)
+	if err != nil {
+		err = msgp.WrapError(err, "DeleteMarkerReplicationStatus")
+		return
+	}
+	// write "vps"
+	err = en.Append(0xa3, 0x76, 0x70, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.VersionPurgeStatus)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionPurgeStatus")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *rjEntry) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 13
+	// string "k"
+	o = append(o, 0x8d, 0xa1, 0x6b)
+	o = msgp.AppendUint8(o, z.Kind)
+	// string "q"
+	o = append(o, 0xa1, 0x71)
+	o = msgp.AppendTime(o, z.Queued)
+	// string "b"
+	o = append(o, 0xa1, 0x62)
+	o = msgp.AppendString(o, z.Bucket)
+	// string "o"
+	o = append(o, 0xa1, 0x6f)
+	o = msgp.AppendString(o, z.Object)
+	// string "v"
+	o = append(o, 0xa1, 0x76)
+	o = msgp.AppendString(o, z.VersionID)
+	// string "t"
+	o = append(o, 0xa1, 0x74)
+	o = msgp.AppendInt(o, z.OpType)
+	// string "r"
+	o = append(o, 0xa1, 0x72)
+	o = msgp.AppendUint32(o, z.RetryCount)
+	// string "rid"
+	o = append(o, 0xa3, 0x72, 0x69, 0x64)
+	o = msgp.AppendString(o, z.ResetID)
+	// string "dm"
+	o = append(o, 0xa2, 0x64, 0x6d)
+	o = msgp.AppendBool(o, z.DeleteMarker)
+	// string "dmv"
+	o = append(o, 0xa3, 0x64, 0x6d, 0x76)
+	o = msgp.AppendString(o, z.DeleteMarkerVersionID)
+	// string "dmt"
+	o = append(o, 0xa3, 0x64, 0x6d, 0x74)
+	o = msgp.AppendTime(o, z.DeleteMarkerMTime)
+	// string "dms"
+	o = append(o, 0xa3, 0x64, 0x6d, 0x73)
+	o = msgp.AppendString(o, z.DeleteMarkerReplicationStatus)
+	// string "vps"
+	o = append(o, 0xa3, 0x76, 0x70, 0x73)
+	o = msgp.AppendString(o, z.VersionPurgeStatus)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *rjEntry) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "k":
+			z.Kind, bts, err = msgp.ReadUint8Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Kind")
+				return
+			}
+		case "q":
+			z.Queued, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Queued")
+				return
+			}
+		case "b":
+			z.Bucket, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Bucket")
+				return
+			}
+		case "o":
+			z.Object, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Object")
+				return
+			}
+		case "v":
+			z.VersionID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "VersionID")
+				return
+			}
+		case "t":
+			z.OpType, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "OpType")
+				return
+			}
+		case "r":
+			z.RetryCount, bts, err = msgp.ReadUint32Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "RetryCount")
+				return
+			}
+		case "rid":
+			z.ResetID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "ResetID")
+				return
+			}
+		case "dm":
+			z.DeleteMarker, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DeleteMarker")
+				return
+			}
+		case "dmv":
+			z.DeleteMarkerVersionID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DeleteMarkerVersionID")
+				return
+			}
+		case "dmt":
+			z.DeleteMarkerMTime, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DeleteMarkerMTime")
+				return
+			}
+		case "dms":
+			z.DeleteMarkerReplicationStatus, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "DeleteMarkerReplicationStatus")
+				return
+			}
+		case "vps":
+			z.VersionPurgeStatus, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "VersionPurgeStatus")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *rjEntry) Msgsize() (s int) {
+	s = 1 + 2 + msgp.Uint8Size + 2 + msgp.TimeSize + 2 + msgp.StringPrefixSize + len(z.Bucket) + 2 + msgp.StringPrefixSize + len(z.Object) + 2 + msgp.StringPrefixSize + len(z.VersionID) + 2 + msgp.IntSize + 2 + msgp.Uint32Size + 4 + msgp.StringPrefixSize + len(z.ResetID) + 3 + msgp.BoolSize + 4 + msgp.StringPrefixSize + len(z.DeleteMarkerVersionID) + 4 + msgp.TimeSize + 4 + msgp.StringPrefixSize + len(z.DeleteMarkerReplicationStatus) + 4 + msgp.StringPrefixSize + len(z.VersionPurgeStatus)
+	return
+}
@@ -0,0 +1,240 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/replication"
+)
+
+func collectJournal(ctx context.Context, t *testing.T, j *replicationJournal, want int, done bool) []rjEntry {
+	t.Helper()
+	ch := make(chan rjEntry, want)
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+	go j.drain(ctx, func(e rjEntry, s *replicationJournalSegment) bool {
+		if done {
+			defer s.done()
+		}
+		select {
+		case ch <- e:
+			return true
+		case <-ctx.Done():
+			return false
+		}
+	})
+	var entries []rjEntry
+	timeout := time.After(10 * time.Second)
+	for len(entries) < want {
+		select {
+		case e := <-ch:
+			entries = append(entries, e)
+		case <-timeout:
+			t.Fatalf("expected %d journal entries, got %d", want, len(entries))
+		}
+	}
+	return entries
+}
+
+func TestReplicationJournal(t *testing.T) {
+	diskPath, err := ioutil.TempDir(globalTestTmpDir, "minio-journal-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(diskPath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	j, err := newReplicationJournal(ctx, diskPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	const n = 10
+	for i := 0; i < n; i++ {
+		var e rjEntry
+		if i%2 == 0 {
+			e = newRJObjectEntry(rjEntryObject, ReplicateObjectInfo{
+				ObjectInfo: ObjectInfo{
+					Bucket:    "bucket",
+					Name:      fmt.Sprintf("object-%d", i),
+					VersionID: mustGetUUID(),
+				},
+				OpType: replication.ObjectReplicationType,
+			})
+		} else {
+			e = newRJDeleteEntry(DeletedObjectReplicationInfo{
+				DeletedObject: DeletedObject{
+					ObjectName:   fmt.Sprintf("object-%d", i),
+					DeleteMarker: true,
+				},
+				Bucket: "bucket",
+				OpType: replication.DeleteReplicationType,
+			})
+		}
+		if err = j.add(e); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if count, oldest := j.Backlog(); count != n || oldest.IsZero() {
+		t.Fatalf("expected backlog of %d with oldest time set, got %d, %v", n, count, oldest)
+	}
+
+	// Entries are dispatched in order but not marked done, they must
+	// survive a restart.
+	entries := collectJournal(ctx, t, j, n, false)
+	for i, e := range entries {
+		name := fmt.Sprintf("object-%d", i)
+		if i%2 == 0 {
+			if e.Kind != rjEntryObject || e.objectInfo().Name != name {
+				t.Fatalf("entry %d: expected object %s, got %#v", i, name, e)
+			}
+		} else {
+			doi := e.deletedObjectInfo()
+			if e.Kind != rjEntryDelete || doi.ObjectName != name || !doi.DeleteMarker {
+				t.Fatalf("entry %d: expected delete of %s, got %#v", i, name, e)
+			}
+		}
+	}
+	j.Close()
+
+	j, err = newReplicationJournal(ctx, diskPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if count, _ := j.Backlog(); count != n {
+		t.Fatalf("expected backlog of %d after restart, got %d", n, count)
+	}
+
+	// Marking the replayed entries done removes the segments.
+	collectJournal(ctx, t, j, n, true)
+	deadline := time.Now().Add(10 * time.Second)
+	for {
+		count, _ := j.Backlog()
+		if count == 0 {
+			break
+		}
+		if time.Now().After(deadline) {
+			t.Fatalf("expected empty backlog, got %d", count)
+		}
+		time.Sleep(10 * time.Millisecond)
+	}
+	segments, err := filepath.Glob(filepath.Join(j.dir, "*"+replicationJournalSegExt))
+	if err != nil {
+		t.Fatal(err)
+	}
+	for _, segment := range segments {
+		if filepath.Base(segment) != replicationJournalActive {
+			t.Fatalf("expected journal segment %s to be removed", segment)
+		}
+	}
+	j.Close()
+}
+
+// Tests tasks whose queue is full do not hold back tasks of other kinds,
+// and are handed over once their queue has room.
+func TestReplicationJournalFullQueue(t *testing.T) {
+	diskPath, err := ioutil.TempDir(globalTestTmpDir, "minio-journal-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(diskPath)
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	j, err := newReplicationJournal(ctx, diskPath)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer j.Close()
+
+	add := func(kind uint8, name string) {
+		t.Helper()
+		if err := j.add(newRJObjectEntry(kind, ReplicateObjectInfo{
+			ObjectInfo: ObjectInfo{Bucket: "bucket", Name: name},
+			OpType:     replication.ObjectReplicationType,
+		})); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// The queue of failed tasks is full until mrfCh is set.
+	var mrfCh atomic.Value
+	objCh := make(chan rjEntry, 100)
+	go j.drain(ctx, func(e rjEntry, s *replicationJournalSegment) bool {
+		ch := objCh
+		if e.Kind == rjEntryFailed {
+			v, _ := mrfCh.Load().(chan rjEntry)
+			if v == nil {
+				return false
+			}
+			ch = v
+		}
+		select {
+		case ch <- e:
+			s.done()
+			return true
+		default:
+			return false
+		}
+	})
+	expect := func(ch chan rjEntry, names ...string) {
+		t.Helper()
+		for _, name := range names {
+			select {
+			case e := <-ch:
+				if e.Object != name {
+					t.Fatalf("expected %s, got %s", name, e.Object)
+				}
+			case <-time.After(10 * time.Second):
+				t.Fatalf("expected %s to be dispatched", name)
+			}
+		}
+	}
+
+	add(rjEntryFailed, "failed-1")
+	add(rjEntryObject, "object-1")
+	add(rjEntryFailed, "failed-2")
+	add(rjEntryObject, "object-2")
+	expect(objCh, "object-1", "object-2")
+
+	// Tasks queued in later segments are not held back either.
+	add(rjEntryObject, "object-3")
+	expect(objCh, "object-3")
+
+	ch := make(chan rjEntry, 100)
+	mrfCh.Store(ch)
+	expect(ch, "failed-1", "failed-2")
+	select {
+	case e := <-objCh:
+		t.Fatalf("expected %s to be dispatched once", e.Object)
+	case <-time.After(2 * replicationJournalRetryInterval):
+	}
+	if count, _ := j.Backlog(); count != 0 {
+		t.Fatalf("expected empty backlog, got %d", count)
+	}
+}
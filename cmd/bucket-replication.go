@@ -164,6 +164,64 @@ func mustReplicate(ctx context.Context, bucket, object string, mopts mustReplica
 	return cfg.Replicate(opts), false
 }
 
+// replicationOriginKey holds the deployment ID of the site a replica
+// object version was first written to.
+const replicationOriginKey = ReservedMetadataPrefixLower + "replication-origin"
+
+// replicationOrigin returns the deployment ID of the site the object
+// version was first written to.
+func replicationOrigin(oi ObjectInfo) string {
+	if origin, ok := oi.UserDefined[replicationOriginKey]; ok && origin != "" {
+		return origin
+	}
+	return globalDeploymentID
+}
+
+type replicationOriginCtxKey struct{}
+
+// withReplicationOrigin returns a context whose requests to remote
+// targets carry the origin of the replicated object version.
+func withReplicationOrigin(ctx context.Context, origin string) context.Context {
+	return context.WithValue(ctx, replicationOriginCtxKey{}, origin)
+}
+
+// replicationOriginTransport adds the origin of the replicated object
+// version to requests made to remote targets.
+type replicationOriginTransport struct {
+	http.RoundTripper
+}
+
+func (t replicationOriginTransport) RoundTrip(req *http.Request) (*http.Response, error) {
+	if origin, ok := req.Context().Value(replicationOriginCtxKey{}).(string); ok && origin != "" {
+		req = req.Clone(req.Context())
+		req.Header.Set(xhttp.MinIOSourceOrigin, origin)
+	}
+	return t.RoundTripper.RoundTrip(req)
+}
+
+// isReplicationRequest returns true if the request replicates a change
+// made on a peer, such changes are never replicated again to prevent
+// sites replicating into each other from looping.
+func isReplicationRequest(r *http.Request) bool {
+	_, ok := r.Header[xhttp.MinIOSourceReplicationRequest]
+	return ok
+}
+
+// replicaIsStale returns true if the incoming replica of an object version
+// loses against the same version already present on this site. Conflicting
+// writes are resolved by last writer wins, see isNewerVersion.
+func replicaIsStale(ctx context.Context, objAPI ObjectLayer, bucket, object string, opts ObjectOptions) bool {
+	versionID := opts.VersionID
+	if versionID == "" {
+		versionID = nullVersionID
+	}
+	oi, err := objAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+	if err != nil && !(oi.DeleteMarker && isErrMethodNotAllowed(err)) {
+		return false
+	}
+	return !isNewerVersion(opts.MTime, opts.VersionID, oi.ModTime, oi.VersionID)
+}
+
 // Standard headers that needs to be extracted from User metadata.
 var standardHeaders = []string{
 	xhttp.ContentType,
@@ -756,6 +814,7 @@ func replicateObject(ctx context.Context, ri ReplicateObjectInfo, objectAPI Obje
 	closeOnDefer = true
 
 	objInfo = gr.ObjInfo
+	ctx = withReplicationOrigin(ctx, replicationOrigin(objInfo))
 	size, err := objInfo.GetActualSize()
 	if err != nil {
 		logger.LogIf(ctx, err)
@@ -1018,6 +1077,8 @@ type DeletedObjectReplicationInfo struct {
 	Bucket  string
 	OpType  replication.Type
 	ResetID string
+
+	journalSeg *replicationJournalSegment // set if read from the replication journal
 }
 
 // Replication specific APIName
@@ -1067,6 +1128,9 @@ type ReplicationPool struct {
 	mrfWorkerWg             sync.WaitGroup
 	once                    sync.Once
 	mu                      sync.Mutex
+
+	// journal persists queued tasks, nil if no local disk is usable.
+	journal *replicationJournal
 }
 
 // NewReplicationPool creates a pool of replication workers of specified size
@@ -1101,6 +1165,7 @@ func (p *ReplicationPool) AddMRFWorker() {
 				return
 			}
 			replicateObject(p.ctx, oi, p.objLayer, ReplicateMRF)
+			oi.journalSeg.done()
 		case <-p.mrfWorkerKillCh:
 			return
 		}
@@ -1119,11 +1184,13 @@ func (p *ReplicationPool) AddWorker() {
 				return
 			}
 			replicateObject(p.ctx, oi, p.objLayer, ReplicateIncoming)
+			oi.journalSeg.done()
 		case doi, ok := <-p.replicaDeleteCh:
 			if !ok {
 				return
 			}
 			replicateDelete(p.ctx, doi, p.objLayer, ReplicateDelete)
+			doi.journalSeg.done()
 		case <-p.workerKillCh:
 			return
 		}
@@ -1187,6 +1254,10 @@ func (p *ReplicationPool) queueReplicaFailedTask(ri ReplicateObjectInfo) {
 	if p == nil {
 		return
 	}
+	ri.journalSeg = nil
+	if p.journalTask(newRJObjectEntry(rjEntryFailed, ri)) {
+		return
+	}
 	select {
 	case <-GlobalContext.Done():
 		p.once.Do(func() {
@@ -1203,6 +1274,10 @@ func (p *ReplicationPool) queueReplicaTask(ri ReplicateObjectInfo) {
 	if p == nil {
 		return
 	}
+	ri.journalSeg = nil
+	if isJournaledReplicationType(ri.OpType) && p.journalTask(newRJObjectEntry(rjEntryObject, ri)) {
+		return
+	}
 	var ch chan ReplicateObjectInfo
 	switch ri.OpType {
 	case replication.ExistingObjectReplicationType:
@@ -1228,6 +1303,10 @@ func (p *ReplicationPool) queueReplicaDeleteTask(doi DeletedObjectReplicationInf
 	if p == nil {
 		return
 	}
+	doi.journalSeg = nil
+	if isJournaledReplicationType(doi.OpType) && p.journalTask(newRJDeleteEntry(doi)) {
+		return
+	}
 
 	var ch chan DeletedObjectReplicationInfo
 	switch doi.OpType {
@@ -1250,16 +1329,79 @@ func (p *ReplicationPool) queueReplicaDeleteTask(doi DeletedObjectReplicationInf
 	}
 }
 
+// isJournaledReplicationType returns true if tasks of the type are
+// journaled. Tasks queued by the scanner are not, the scanner queues
+// them again on its next cycle.
+func isJournaledReplicationType(opType replication.Type) bool {
+	return opType != replication.ExistingObjectReplicationType && opType != replication.HealReplicationType
+}
+
+// journalTask appends a task to the replication journal, it returns
+// false if the task must be queued in memory instead.
+func (p *ReplicationPool) journalTask(e rjEntry) bool {
+	if p.journal == nil {
+		return false
+	}
+	if err := p.journal.add(e); err != nil {
+		logger.LogOnceIf(p.ctx, fmt.Errorf("replication-journal: failed to journal replication task: %w", err), "replication-journal-add")
+		return false
+	}
+	return true
+}
+
+// dispatchJournalTask hands a task read from the replication journal to
+// the workers, it returns false if the queue of the task is full.
+func (p *ReplicationPool) dispatchJournalTask(e rjEntry, seg *replicationJournalSegment) bool {
+	switch e.queue() {
+	case rjEntryDelete:
+		doi := e.deletedObjectInfo()
+		doi.journalSeg = seg
+		select {
+		case p.replicaDeleteCh <- doi:
+		default:
+			return false
+		}
+	case rjEntryFailed:
+		ri := e.objectInfo()
+		ri.journalSeg = seg
+		select {
+		case p.mrfReplicaCh <- ri:
+		default:
+			return false
+		}
+	default:
+		ri := e.objectInfo()
+		ri.journalSeg = seg
+		select {
+		case p.replicaCh <- ri:
+		default:
+			return false
+		}
+	}
+	return true
+}
+
 type replicationPoolOpts struct {
 	Workers       int
 	FailedWorkers int
 }
 
 func initBackgroundReplication(ctx context.Context, objectAPI ObjectLayer) {
-	globalReplicationPool = NewReplicationPool(ctx, objectAPI, replicationPoolOpts{
+	pool := NewReplicationPool(ctx, objectAPI, replicationPoolOpts{
 		Workers:       globalAPIConfig.getReplicationWorkers(),
 		FailedWorkers: globalAPIConfig.getReplicationFailedWorkers(),
 	})
+	for _, diskPath := range globalEndpoints.LocalDisksPaths() {
+		journal, err := newReplicationJournal(ctx, diskPath)
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("replication-journal: unable to open journal on %s: %w", diskPath, err))
+			continue
+		}
+		pool.journal = journal
+		go journal.drain(ctx, pool.dispatchJournalTask)
+		break
+	}
+	globalReplicationPool = pool
 	globalReplicationStats = NewReplicationStats(ctx, objectAPI)
 }
 
@@ -18,9 +18,14 @@
 package cmd
 
 import (
+	"bytes"
 	"context"
+	"encoding/xml"
 	"fmt"
+	"io/ioutil"
 	"net/http"
+	"net/http/httptest"
+	"net/url"
 	"testing"
 	"time"
 
@@ -207,3 +212,338 @@ func TestReplicationResyncwrapper(t *testing.T) {
 		}
 	}
 }
+
+// testSite is one of two in-process erasure deployments replicating
+// into each other.
+type testSite struct {
+	deploymentID string
+	obj          ObjectLayer
+	apiRouter    http.Handler
+}
+
+// do sends a signed request to the object handlers of the site.
+func (s testSite) do(t *testing.T, method, urlStr string, data []byte, headers map[string]string) *httptest.ResponseRecorder {
+	t.Helper()
+	req, err := newTestSignedRequestV4(method, urlStr, int64(len(data)), bytes.NewReader(data),
+		globalActiveCred.AccessKey, globalActiveCred.SecretKey, headers)
+	if err != nil {
+		t.Fatal(err)
+	}
+	// The handlers of both sites use the global object layer.
+	setObjectLayer(s.obj)
+	rec := httptest.NewRecorder()
+	s.apiRouter.ServeHTTP(rec, req)
+	return rec
+}
+
+// replicaHeaders returns the headers sent by replication along with a
+// change of the object version oi, first written on the site origin.
+func replicaHeaders(oi ObjectInfo, origin string) map[string]string {
+	return map[string]string{
+		xhttp.AmzBucketReplicationStatus:    replication.Replica.String(),
+		xhttp.MinIOSourceReplicationRequest: "",
+		xhttp.MinIOSourceMTime:              oi.ModTime.Format(time.RFC3339Nano),
+		xhttp.MinIOSourceETag:               oi.ETag,
+		xhttp.MinIOSourceOrigin:             origin,
+	}
+}
+
+// replicateTestVersion sends an object version or delete marker of site
+// src to site dst, the way replication sends it to a remote target.
+func replicateTestVersion(ctx context.Context, t *testing.T, src, dst testSite, bucket, object, versionID string) *httptest.ResponseRecorder {
+	t.Helper()
+	oi, err := src.obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+	if err != nil && !oi.DeleteMarker {
+		t.Fatal(err)
+	}
+	origin := oi.UserDefined[replicationOriginKey]
+	if origin == "" {
+		origin = src.deploymentID
+	}
+	headers := replicaHeaders(oi, origin)
+	urlStr := makeTestTargetURL("", bucket, object, url.Values{xhttp.VersionID: []string{versionID}})
+	if oi.DeleteMarker {
+		headers[xhttp.MinIOSourceDeleteMarker] = "true"
+		return dst.do(t, http.MethodDelete, urlStr, nil, headers)
+	}
+
+	gr, err := src.obj.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{VersionID: versionID})
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer gr.Close()
+	data, err := ioutil.ReadAll(gr)
+	if err != nil {
+		t.Fatal(err)
+	}
+	return dst.do(t, http.MethodPut, urlStr, data, headers)
+}
+
+// Tests two sites replicating into each other through the object
+// handlers converge on the same latest version, and changes applied
+// by replication are not replicated again.
+func TestActiveActiveReplication(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resetTestGlobals()
+	// Replication can only be configured on erasure coded backends.
+	globalIsErasure = true
+	defer resetGlobalIsErasure()
+
+	var sites [2]testSite
+	for i, id := range []string{"site-a", "site-b"} {
+		obj, fsDirs, err := prepareErasure16(ctx)
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer obj.Shutdown(context.Background())
+		defer removeRoots(fsDirs)
+		_, apiRouter, err := initAPIHandlerTest(obj, []string{"CopyObject", "PutObject", "DeleteObject", "NewMultipart"})
+		if err != nil {
+			t.Fatal(err)
+		}
+		sites[i] = testSite{deploymentID: id, obj: obj, apiRouter: apiRouter}
+	}
+	siteA, siteB := sites[0], sites[1]
+	if err := newTestConfig(globalMinioDefaultRegion, siteA.obj); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both sites replicate the bucket to the other site. No replication
+	// workers are running, tasks are only queued.
+	bucket := "bucket"
+	arn := "arn:minio:replication::peer:bucket"
+	globalBucketTargetSys.arnRemotesMap[arn] = &TargetClient{}
+	globalReplicationPool = &ReplicationPool{
+		replicaCh:       make(chan ReplicateObjectInfo, 100),
+		replicaDeleteCh: make(chan DeletedObjectReplicationInfo, 100),
+	}
+	defer func() { globalReplicationPool = nil }()
+	replicationConfig := []byte(`<ReplicationConfiguration><Role>` + arn + `</Role><Rule><ID>active-active</ID><Status>Enabled</Status><Priority>1</Priority>` +
+		`<DeleteMarkerReplication><Status>Enabled</Status></DeleteMarkerReplication><DeleteReplication><Status>Enabled</Status></DeleteReplication>` +
+		`<Filter><Prefix></Prefix></Filter><Destination><Bucket>arn:aws:s3:::bucket</Bucket></Destination></Rule></ReplicationConfiguration>`)
+	for _, site := range sites {
+		setObjectLayer(site.obj)
+		if err := site.obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+			t.Fatal(err)
+		}
+		if err := globalBucketMetadataSys.Update(bucket, bucketVersioningConfig, enabledBucketVersioningConfig); err != nil {
+			t.Fatal(err)
+		}
+		if err := globalBucketMetadataSys.Update(bucket, bucketReplicationConfig, replicationConfig); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	put := func(site testSite, object, data string, mtime time.Time) ObjectInfo {
+		t.Helper()
+		rec := site.do(t, http.MethodPut, getPutObjectURL("", bucket, object), []byte(data), map[string]string{
+			xhttp.MinIOSourceMTime: mtime.Format(time.RFC3339Nano),
+		})
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: expected the response status to be `%d`, but instead found `%d`", site.deploymentID, http.StatusOK, rec.Code)
+		}
+		oi, err := site.obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: rec.Header().Get(xhttp.AmzVersionID)})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return oi
+	}
+	replicate := func(src, dst testSite, object, versionID string, expectedStatus int) {
+		t.Helper()
+		if rec := replicateTestVersion(ctx, t, src, dst, bucket, object, versionID); rec.Code != expectedStatus {
+			t.Fatalf("%s: expected the response status to be `%d`, but instead found `%d`", dst.deploymentID, expectedStatus, rec.Code)
+		}
+	}
+	// expectQueued checks the number of changes queued for replication
+	// since the last check.
+	expectQueued := func(expected int) {
+		t.Helper()
+		p := globalReplicationPool
+		n := len(p.replicaCh) + len(p.replicaDeleteCh)
+		for len(p.replicaCh) > 0 {
+			<-p.replicaCh
+		}
+		for len(p.replicaDeleteCh) > 0 {
+			<-p.replicaDeleteCh
+		}
+		if n != expected {
+			t.Errorf("expected %d changes queued for replication, got %d", expected, n)
+		}
+	}
+	getInfo := func(site testSite, object, versionID string) ObjectInfo {
+		t.Helper()
+		oi, err := site.obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: versionID})
+		if err != nil && !oi.DeleteMarker {
+			t.Fatal(err)
+		}
+		return oi
+	}
+
+	now := UTCNow()
+
+	// Conflicting writes, the later write wins on both sites.
+	older := put(siteA, "conflict", "written on site-a", now)
+	newer := put(siteB, "conflict", "written on site-b", now.Add(time.Second))
+	for _, oi := range []ObjectInfo{older, newer} {
+		if oi.ReplicationStatus != replication.Pending {
+			t.Errorf("expected local write to be replicated, got status %s", oi.ReplicationStatus)
+		}
+	}
+	expectQueued(2)
+	replicate(siteA, siteB, "conflict", older.VersionID, http.StatusOK)
+	replicate(siteB, siteA, "conflict", newer.VersionID, http.StatusOK)
+	expectQueued(0)
+	for _, site := range sites {
+		if oi := getInfo(site, "conflict", ""); oi.VersionID != newer.VersionID {
+			t.Errorf("%s: expected latest version %s, got %s", site.deploymentID, newer.VersionID, oi.VersionID)
+		}
+	}
+
+	// Replicas are not replicated again, and record the site they
+	// originated on.
+	replica := getInfo(siteA, "conflict", newer.VersionID)
+	if replica.ReplicationStatus != replication.Replica {
+		t.Errorf("expected replica status %s, got %s", replication.Replica, replica.ReplicationStatus)
+	}
+	if origin := replica.UserDefined[replicationOriginKey]; origin != siteB.deploymentID {
+		t.Errorf("expected origin %s, got %s", siteB.deploymentID, origin)
+	}
+	if replicationOrigin(replica) != siteB.deploymentID {
+		t.Errorf("expected replication origin %s, got %s", siteB.deploymentID, replicationOrigin(replica))
+	}
+	if replicationOrigin(older) != globalDeploymentID {
+		t.Errorf("expected local version to originate on %s, got %s", globalDeploymentID, replicationOrigin(older))
+	}
+
+	// Metadata replicated by a peer is not replicated back.
+	copyHeaders := replicaHeaders(replica, siteB.deploymentID)
+	copyHeaders["X-Amz-Copy-Source"] = url.PathEscape(bucket+SlashSeparator+"conflict") + "?versionId=" + newer.VersionID
+	copyHeaders[xhttp.AmzMetadataDirective] = "REPLACE"
+	copyHeaders["X-Amz-Meta-Color"] = "blue"
+	rec := siteA.do(t, http.MethodPut, makeTestTargetURL("", bucket, "conflict", url.Values{xhttp.VersionID: []string{newer.VersionID}}), nil, copyHeaders)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected the response status to be `%d`, but instead found `%d`: %s", http.StatusOK, rec.Code, rec.Body.String())
+	}
+	replica = getInfo(siteA, "conflict", newer.VersionID)
+	if replica.UserDefined["X-Amz-Meta-Color"] != "blue" {
+		t.Errorf("expected replicated metadata, got %v", replica.UserDefined)
+	}
+	if replica.ReplicationStatus != replication.Replica {
+		t.Errorf("expected replica status %s after metadata replication, got %s", replication.Replica, replica.ReplicationStatus)
+	}
+	expectQueued(0)
+
+	// Replaying a replica already applied is rejected, it does not undo
+	// later changes of the version.
+	replicate(siteB, siteA, "conflict", newer.VersionID, http.StatusOK)
+	oi := getInfo(siteA, "conflict", newer.VersionID)
+	if oi.UserDefined["X-Amz-Meta-Color"] != "blue" {
+		t.Errorf("expected replicated metadata to be kept, got %v", oi.UserDefined)
+	}
+	if oi.NumVersions != 2 {
+		t.Errorf("expected 2 versions, got %d", oi.NumVersions)
+	}
+	expectQueued(0)
+
+	// Writes with the same modification time, the higher version ID wins.
+	tie1 := put(siteA, "tie", "written on site-a", now)
+	tie2 := put(siteB, "tie", "written on site-b", now)
+	expectQueued(2)
+	replicate(siteA, siteB, "tie", tie1.VersionID, http.StatusOK)
+	replicate(siteB, siteA, "tie", tie2.VersionID, http.StatusOK)
+	expectQueued(0)
+	winner := tie1.VersionID
+	if tie2.VersionID > winner {
+		winner = tie2.VersionID
+	}
+	for _, site := range sites {
+		if oi := getInfo(site, "tie", ""); oi.VersionID != winner {
+			t.Errorf("%s: expected latest version %s, got %s", site.deploymentID, winner, oi.VersionID)
+		}
+	}
+
+	// Delete markers propagate and win over older writes.
+	rec = siteA.do(t, http.MethodDelete, getDeleteObjectURL("", bucket, "conflict"), nil, map[string]string{
+		xhttp.MinIOSourceMTime: now.Add(2 * time.Second).Format(time.RFC3339Nano),
+	})
+	if rec.Code != http.StatusNoContent {
+		t.Fatalf("expected the response status to be `%d`, but instead found `%d`", http.StatusNoContent, rec.Code)
+	}
+	dm := getInfo(siteA, "conflict", "")
+	if !dm.DeleteMarker || dm.ReplicationStatus != replication.Pending {
+		t.Fatalf("expected a delete marker to be replicated, got delete marker %v with status %s", dm.DeleteMarker, dm.ReplicationStatus)
+	}
+	expectQueued(1)
+	for i := 0; i < 2; i++ {
+		// The second attempt is a replay.
+		replicate(siteA, siteB, "conflict", dm.VersionID, http.StatusNoContent)
+	}
+	expectQueued(0)
+	for _, site := range sites {
+		oi := getInfo(site, "conflict", "")
+		if !oi.DeleteMarker || oi.VersionID != dm.VersionID {
+			t.Errorf("%s: expected delete marker %s to be latest, got %s (delete marker %v)", site.deploymentID, dm.VersionID, oi.VersionID, oi.DeleteMarker)
+		}
+	}
+	if oi := getInfo(siteB, "conflict", ""); oi.NumVersions != 3 || oi.ReplicationStatus != replication.Replica {
+		t.Errorf("expected 3 versions with a replica delete marker, got %d versions with status %s", oi.NumVersions, oi.ReplicationStatus)
+	}
+
+	// Multipart uploads replicated by a peer record the site they
+	// originated on.
+	headers := replicaHeaders(older, siteA.deploymentID)
+	rec = siteB.do(t, http.MethodPost, getNewMultipartURL("", bucket, "multipart"), nil, headers)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+	}
+	multipartResponse := &InitiateMultipartUploadResponse{}
+	if err := xml.Unmarshal(rec.Body.Bytes(), multipartResponse); err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("written on site-a")
+	pi, err := siteB.obj.PutObjectPart(ctx, bucket, "multipart", multipartResponse.UploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	oi, err = siteB.obj.CompleteMultipartUpload(ctx, bucket, "multipart", multipartResponse.UploadID, []CompletePart{{PartNumber: 1, ETag: pi.ETag}}, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.ReplicationStatus != replication.Replica || oi.UserDefined[replicationOriginKey] != siteA.deploymentID {
+		t.Errorf("expected a replica originating on %s, got status %s and origin %s", siteA.deploymentID, oi.ReplicationStatus, oi.UserDefined[replicationOriginKey])
+	}
+}
+
+// Tests the origin of replicated versions is sent to remote targets.
+func TestReplicationOriginTransport(t *testing.T) {
+	var origin string
+	ts := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		origin = r.Header.Get(xhttp.MinIOSourceOrigin)
+	}))
+	defer ts.Close()
+
+	client := &http.Client{Transport: replicationOriginTransport{http.DefaultTransport}}
+	for _, expected := range []string{"", "site-a"} {
+		ctx := context.Background()
+		if expected != "" {
+			ctx = withReplicationOrigin(ctx, expected)
+		}
+		req, err := http.NewRequestWithContext(ctx, http.MethodPut, ts.URL, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp, err := client.Do(req)
+		if err != nil {
+			t.Fatal(err)
+		}
+		resp.Body.Close()
+		if origin != expected {
+			t.Errorf("expected origin %q, got %q", expected, origin)
+		}
+		if req.Header.Get(xhttp.MinIOSourceOrigin) != "" {
+			t.Error("request of the caller must not be modified")
+		}
+	}
+}
@@ -0,0 +1,58 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"encoding/json"
+
+	"github.com/minio/minio/internal/config/storageclass"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+const bucketStorageClassConfigFile = "storageclass.json"
+
+// bucketStorageClassConfig holds the default storage class of the
+// objects created in a bucket without an explicit storage class.
+type bucketStorageClassConfig struct {
+	StorageClass string `json:"storageClass"`
+}
+
+// parseBucketStorageClass parses bucketStorageClassConfig from json
+func parseBucketStorageClass(data []byte) (*bucketStorageClassConfig, error) {
+	scCfg := &bucketStorageClassConfig{}
+	if err := json.Unmarshal(data, scCfg); err != nil {
+		return scCfg, err
+	}
+	return scCfg, nil
+}
+
+// setBucketDefaultStorageClass sets the storage class of an object uploaded
+// to bucket to the bucket's default storage class, unless one was requested.
+// It is applied by the upload handlers only, objects written internally,
+// e.g. moved by decommissioning, keep their storage class.
+func setBucketDefaultStorageClass(bucket string, metadata map[string]string) {
+	if metadata[xhttp.AmzStorageClass] != "" || isMinioMetaBucketName(bucket) ||
+		globalBucketMetadataSys == nil {
+		return
+	}
+	scCfg, err := globalBucketMetadataSys.GetStorageClassConfig(bucket)
+	if err != nil || scCfg.StorageClass == "" || scCfg.StorageClass == storageclass.STANDARD {
+		return
+	}
+	metadata[xhttp.AmzStorageClass] = scCfg.StorageClass
+}
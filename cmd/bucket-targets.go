@@ -364,7 +364,7 @@ func (sys *BucketTargetSys) getRemoteTargetClient(tcfg *madmin.BucketTarget) (*T
 		Creds:     creds,
 		Secure:    tcfg.Secure,
 		Region:    tcfg.Region,
-		Transport: getRemoteTargetInstanceTransport,
+		Transport: replicationOriginTransport{getRemoteTargetInstanceTransport},
 	})
 	if err != nil {
 		return nil, err
@@ -91,6 +91,8 @@ func (api objectAPIHandlers) PutBucketVersioningHandler(w http.ResponseWriter, r
 		return
 	}
 
+	globalSiteReplicationSys.BucketMetaHook(ctx, bucket, bucketVersioningConfig, configData)
+
 	writeSuccessResponseHeadersOnly(w)
 }
 
@@ -0,0 +1,313 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/xml"
+	"fmt"
+	"io"
+	"net"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/bucket/website"
+	"github.com/minio/minio/internal/handlers"
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/bucket/policy"
+)
+
+const (
+	// Bucket website configuration file name.
+	bucketWebsiteConfig = "website.xml"
+
+	// Maximum size of a bucket website configuration.
+	maxBucketWebsiteConfigSize = 128 * 1024
+)
+
+// PutBucketWebsiteHandler - Stores given bucket website configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketWebsite.html
+func (api objectAPIHandlers) PutBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "PutBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	// There are no dedicated website policy actions, re-purpose
+	// the bucket policy actions like the other bucket calls.
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	websiteConfig, err := website.ParseConfig(io.LimitReader(r.Body, maxBucketWebsiteConfigSize))
+	if err != nil {
+		var apiErr APIError
+		if _, ok := err.(website.Error); ok {
+			apiErr = toAPIError(ctx, err)
+		} else {
+			apiErr = APIError{
+				Code:           "MalformedXML",
+				Description:    fmt.Sprintf("%s (%s)", errorCodes[ErrMalformedXML].Description, err),
+				HTTPStatusCode: errorCodes[ErrMalformedXML].HTTPStatusCode,
+			}
+		}
+		writeErrorResponse(ctx, w, apiErr, r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(websiteConfig)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if err = globalBucketMetadataSys.Update(bucket, bucketWebsiteConfig, configData); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseHeadersOnly(w)
+}
+
+// GetBucketWebsiteHandler - Returns bucket website configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_GetBucketWebsite.html
+func (api objectAPIHandlers) GetBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, err := globalBucketMetadataSys.GetWebsiteConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	configData, err := xml.Marshal(config)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, configData)
+}
+
+// DeleteBucketWebsiteHandler - Removes bucket website configuration
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_DeleteBucketWebsite.html
+func (api objectAPIHandlers) DeleteBucketWebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "DeleteBucketWebsite")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objAPI := api.ObjectAPI()
+	if objAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutBucketPolicyAction, bucket, ""); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check if bucket exists.
+	if _, err := objAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if err := globalBucketMetadataSys.Update(bucket, bucketWebsiteConfig, nil); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessNoContent(w)
+}
+
+// WebsiteHandler - GET/HEAD on the website endpoint of a bucket
+// ----------
+// Serves the objects of a bucket with a website configuration as a static
+// website. Only objects readable according to the bucket policy are served
+// to anonymous requests.
+func (api objectAPIHandlers) WebsiteHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "Website")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	key := strings.TrimPrefix(r.URL.Path, SlashSeparator)
+
+	if _, err := objectAPI.GetBucketInfo(ctx, bucket); err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	config, err := globalBucketMetadataSys.GetWebsiteConfig(bucket)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	scheme := handlers.GetSourceScheme(r)
+	if scheme == "" {
+		scheme = getURLScheme(globalIsTLS)
+	}
+
+	if location, ok := config.RedirectAll(scheme, key); ok {
+		http.Redirect(w, r, location, http.StatusMovedPermanently)
+		return
+	}
+	if location, code, ok := config.Route(scheme, r.Host, key, 0); ok {
+		http.Redirect(w, r, location, code)
+		return
+	}
+
+	object := config.IndexKey(key)
+	s3Error := websiteObjectStatus(ctx, objectAPI, bucket, object, r)
+	if s3Error == ErrNoSuchKey && object == key {
+		// Requests for a directory without the trailing slash
+		// are redirected to the directory, same as AWS S3.
+		if websiteObjectStatus(ctx, objectAPI, bucket, config.IndexKey(key+SlashSeparator), r) == ErrNone {
+			u := url.URL{Path: SlashSeparator + key + SlashSeparator}
+			http.Redirect(w, r, u.String(), http.StatusFound)
+			return
+		}
+	}
+	if s3Error != ErrNone {
+		writeWebsiteErrorResponse(ctx, objectAPI, config, bucket, key, scheme, errorCodes.ToAPIErr(s3Error), w, r)
+		return
+	}
+
+	if r.Method == http.MethodHead {
+		api.headObjectHandler(ctx, objectAPI, bucket, object, w, r)
+	} else {
+		api.getObjectHandler(ctx, objectAPI, bucket, object, w, r)
+	}
+}
+
+// websiteMethodNotAllowedHandler - rejects all requests except GET and HEAD
+// on the website endpoint of a bucket.
+func websiteMethodNotAllowedHandler(w http.ResponseWriter, r *http.Request) {
+	writeErrorResponse(r.Context(), w, errorCodes.ToAPIErr(ErrMethodNotAllowed), r.URL)
+}
+
+// websiteObjectStatus returns ErrNone if object exists and the request is
+// allowed to read it.
+func websiteObjectStatus(ctx context.Context, objectAPI ObjectLayer, bucket, object string, r *http.Request) APIErrorCode {
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		return s3Error
+	}
+	if _, err := objectAPI.GetObjectInfo(ctx, bucket, object, ObjectOptions{}); err != nil {
+		return toAPIErrorCode(ctx, err)
+	}
+	return ErrNone
+}
+
+// writeWebsiteErrorResponse redirects failed website requests according to
+// the routing rules, or serves the configured error document with the
+// status code of the error.
+func writeWebsiteErrorResponse(ctx context.Context, objectAPI ObjectLayer, config *website.Config, bucket, key, scheme string, apiErr APIError, w http.ResponseWriter, r *http.Request) {
+	if location, code, ok := config.Route(scheme, r.Host, key, apiErr.HTTPStatusCode); ok {
+		http.Redirect(w, r, location, code)
+		return
+	}
+
+	if errKey, ok := config.ErrorKey(); ok && websiteObjectStatus(ctx, objectAPI, bucket, errKey, r) == ErrNone {
+		gr, err := objectAPI.GetObjectNInfo(ctx, bucket, errKey, nil, http.Header{}, readLock, ObjectOptions{})
+		if err == nil {
+			defer gr.Close()
+			w.Header().Set(xhttp.ContentType, gr.ObjInfo.ContentType)
+			w.WriteHeader(apiErr.HTTPStatusCode)
+			if r.Method != http.MethodHead {
+				if _, err = io.Copy(w, gr); err != nil {
+					logger.LogIf(ctx, err)
+				}
+			}
+			return
+		}
+		logger.LogIf(ctx, err)
+	}
+
+	if r.Method == http.MethodHead {
+		writeErrorResponseHeadersOnly(w, apiErr)
+		return
+	}
+	writeErrorResponse(ctx, w, apiErr, r.URL)
+}
+
+// getWebsiteBucket returns the bucket if the request is addressed to the
+// website endpoint of a bucket.
+func getWebsiteBucket(r *http.Request) (string, bool) {
+	host, _, err := net.SplitHostPort(r.Host)
+	if err != nil {
+		host = r.Host
+	}
+	for _, domain := range globalWebsiteDomainNames {
+		if strings.HasSuffix(host, "."+domain) {
+			return strings.TrimSuffix(host, "."+domain), true
+		}
+	}
+	return "", false
+}
@@ -0,0 +1,188 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/minio/minio/internal/auth"
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+const testBucketWebsiteConfig = `<WebsiteConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><ErrorDocument><Key>error.html</Key></ErrorDocument><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>old/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>new/</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`
+
+// Test S3 Bucket website APIs
+func TestBucketWebsite(t *testing.T) {
+	ExecObjectLayerAPITest(t, testBucketWebsiteHandlers, []string{"GetBucketWebsite", "PutBucketWebsite", "DeleteBucketWebsite"})
+}
+
+// Simple tests of bucket website: PUT, GET, DELETE.
+// Tests are related and the order is important.
+func testBucketWebsiteHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+
+	testCases := []struct {
+		method             string
+		body               []byte
+		expectedRespStatus int
+		expectedResponse   []byte
+	}{
+		// Test case - 1.
+		// No configuration yet.
+		{method: http.MethodGet, expectedRespStatus: http.StatusNotFound},
+		// Test case - 2.
+		// Missing index document.
+		{
+			method:             http.MethodPut,
+			body:               []byte(`<WebsiteConfiguration><ErrorDocument><Key>error.html</Key></ErrorDocument></WebsiteConfiguration>`),
+			expectedRespStatus: http.StatusBadRequest,
+		},
+		// Test case - 3.
+		{
+			method:             http.MethodPut,
+			body:               []byte(testBucketWebsiteConfig),
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   []byte(``),
+		},
+		// Test case - 4.
+		{
+			method:             http.MethodGet,
+			expectedRespStatus: http.StatusOK,
+			expectedResponse:   []byte(testBucketWebsiteConfig),
+		},
+		// Test case - 5.
+		{
+			method:             http.MethodDelete,
+			expectedRespStatus: http.StatusNoContent,
+			expectedResponse:   []byte(``),
+		},
+		// Test case - 6.
+		{method: http.MethodGet, expectedRespStatus: http.StatusNotFound},
+	}
+
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(testCase.method, getBucketWebsiteURL("", bucketName),
+			int64(len(testCase.body)), bytes.NewReader(testCase.body), creds.AccessKey, creds.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectedRespStatus {
+			t.Errorf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.expectedRespStatus, rec.Code)
+		}
+		if testCase.expectedResponse != nil && !bytes.Equal(testCase.expectedResponse, rec.Body.Bytes()) {
+			t.Errorf("Test %d: %s: Expected the response to be `%s`, but instead found `%s`", i+1, instanceType, string(testCase.expectedResponse), rec.Body.String())
+		}
+	}
+}
+
+// Test serving objects on the website endpoint of a bucket.
+func TestWebsiteHandler(t *testing.T) {
+	ExecObjectLayerAPITest(t, testWebsiteHandler, []string{"PutBucketWebsite"})
+}
+
+func testWebsiteHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	creds auth.Credentials, t *testing.T) {
+	ctx := context.Background()
+
+	objects := map[string]string{
+		"index.html":      "root index",
+		"docs/index.html": "docs index",
+		"error.html":      "error page",
+		"new/a.html":      "new page",
+	}
+	for object, content := range objects {
+		_, err := obj.PutObject(ctx, bucketName, object, mustGetPutObjReader(t, bytes.NewReader([]byte(content)), int64(len(content)), "", ""), ObjectOptions{
+			UserDefined: map[string]string{"content-type": "text/html"},
+		})
+		if err != nil {
+			t.Fatalf("%s: %v", instanceType, err)
+		}
+	}
+
+	// Allow anonymous reads of all objects.
+	policy := fmt.Sprintf(`{"Version":"2012-10-17","Statement":[{"Effect":"Allow","Principal":{"AWS":["*"]},"Action":["s3:GetObject"],"Resource":["arn:aws:s3:::%s/*"]}]}`, bucketName)
+	if err := globalBucketMetadataSys.Update(bucketName, bucketPolicyConfig, []byte(policy)); err != nil {
+		t.Fatalf("%s: %v", instanceType, err)
+	}
+
+	body := []byte(testBucketWebsiteConfig)
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodPut, getBucketWebsiteURL("", bucketName),
+		int64(len(body)), bytes.NewReader(body), creds.AccessKey, creds.SecretKey, nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: unable to set bucket website config: %d %s", instanceType, rec.Code, rec.Body.String())
+	}
+
+	api := objectAPIHandlers{
+		ObjectAPI: func() ObjectLayer { return obj },
+		CacheAPI:  func() CacheObjectLayer { return nil },
+	}
+
+	testCases := []struct {
+		method           string
+		path             string
+		expectedStatus   int
+		expectedBody     string
+		expectedLocation string
+	}{
+		// Test 1: root is served by the index document.
+		{method: http.MethodGet, path: "/", expectedStatus: http.StatusOK, expectedBody: "root index"},
+		// Test 2: directory is served by its index document.
+		{method: http.MethodGet, path: "/docs/", expectedStatus: http.StatusOK, expectedBody: "docs index"},
+		// Test 3: directory without trailing slash is redirected.
+		{method: http.MethodGet, path: "/docs", expectedStatus: http.StatusFound, expectedLocation: "/docs/"},
+		// Test 4: missing object is served by the error document.
+		{method: http.MethodGet, path: "/missing.html", expectedStatus: http.StatusNotFound, expectedBody: "error page"},
+		// Test 5: routing rule redirects old prefix to new prefix.
+		{method: http.MethodGet, path: "/old/a.html", expectedStatus: http.StatusMovedPermanently, expectedLocation: "http://" + bucketName + ".website.local/new/a.html"},
+		// Test 6: HEAD on the root.
+		{method: http.MethodHead, path: "/", expectedStatus: http.StatusOK},
+	}
+
+	for i, tc := range testCases {
+		req, err := http.NewRequest(tc.method, "http://"+bucketName+".website.local"+tc.path, nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		req = mux.SetURLVars(req, map[string]string{"bucket": bucketName})
+
+		rec := httptest.NewRecorder()
+		api.WebsiteHandler(rec, req)
+		if rec.Code != tc.expectedStatus {
+			t.Errorf("Test %d: %s: expected status %d, got %d (%s)", i+1, instanceType, tc.expectedStatus, rec.Code, rec.Body.String())
+		}
+		if tc.expectedBody != "" && rec.Body.String() != tc.expectedBody {
+			t.Errorf("Test %d: %s: expected body %q, got %q", i+1, instanceType, tc.expectedBody, rec.Body.String())
+		}
+		if got := rec.Header().Get(xhttp.Location); got != tc.expectedLocation {
+			t.Errorf("Test %d: %s: expected location %q, got %q", i+1, instanceType, tc.expectedLocation, got)
+		}
+	}
+}
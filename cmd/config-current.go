@@ -37,7 +37,9 @@ import (
 	xtls "github.com/minio/minio/internal/config/identity/tls"
 	"github.com/minio/minio/internal/config/notify"
 	"github.com/minio/minio/internal/config/policy/opa"
+	"github.com/minio/minio/internal/config/rebalance"
 	"github.com/minio/minio/internal/config/scanner"
+	"github.com/minio/minio/internal/config/scrub"
 	"github.com/minio/minio/internal/config/storageclass"
 	"github.com/minio/minio/internal/config/subnet"
 	"github.com/minio/minio/internal/crypto"
@@ -66,6 +68,8 @@ func initHelp() {
 		config.AuditKafkaSubSys:     logger.DefaultAuditKafkaKVS,
 		config.HealSubSys:           heal.DefaultKVS,
 		config.ScannerSubSys:        scanner.DefaultKVS,
+		config.RebalanceSubSys:      rebalance.DefaultKVS,
+		config.ScrubSubSys:          scrub.DefaultKVS,
 		config.SubnetSubSys:         subnet.DefaultKVS,
 	}
 	for k, v := range notify.DefaultNotificationKVS {
@@ -122,6 +126,14 @@ func initHelp() {
 			Key:         config.ScannerSubSys,
 			Description: "manage namespace scanning for usage calculation, lifecycle, healing and more",
 		},
+		config.HelpKV{
+			Key:         config.RebalanceSubSys,
+			Description: "manage rebalancing of objects between server pools",
+		},
+		config.HelpKV{
+			Key:         config.ScrubSubSys,
+			Description: "manage scheduled bitrot verification of all objects",
+		},
 		config.HelpKV{
 			Key:             config.LoggerWebhookSubSys,
 			Description:     "send server logs to webhook endpoints",
@@ -214,6 +226,8 @@ func initHelp() {
 		config.CompressionSubSys:    compress.Help,
 		config.HealSubSys:           heal.Help,
 		config.ScannerSubSys:        scanner.Help,
+		config.RebalanceSubSys:      rebalance.Help,
+		config.ScrubSubSys:          scrub.Help,
 		config.IdentityOpenIDSubSys: openid.Help,
 		config.IdentityLDAPSubSys:   xldap.Help,
 		config.IdentityTLSSubSys:    xtls.Help,
@@ -301,6 +315,14 @@ func validateConfig(s config.Config) error {
 		return err
 	}
 
+	if _, err = rebalance.LookupConfig(s[config.RebalanceSubSys][config.Default]); err != nil {
+		return err
+	}
+
+	if _, err = scrub.LookupConfig(s[config.ScrubSubSys][config.Default]); err != nil {
+		return err
+	}
+
 	{
 		etcdCfg, err := etcd.LookupConfig(s[config.EtcdSubSys][config.Default], globalRootCAs)
 		if err != nil {
@@ -619,6 +641,18 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 		return fmt.Errorf("Unable to apply scanner config: %w", err)
 	}
 
+	// Rebalance
+	rebalanceCfg, err := rebalance.LookupConfig(s[config.RebalanceSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply rebalance config: %w", err)
+	}
+
+	// Scrub
+	scrubCfg, err := scrub.LookupConfig(s[config.ScrubSubSys][config.Default])
+	if err != nil {
+		return fmt.Errorf("Unable to apply scrub config: %w", err)
+	}
+
 	// Apply configurations.
 	// We should not fail after this.
 	var setDriveCounts []int
@@ -637,6 +671,12 @@ func applyDynamicConfig(ctx context.Context, objAPI ObjectLayer, s config.Config
 	scannerCycle.Update(scannerCfg.Cycle)
 	logger.LogIf(ctx, scannerSleeper.Update(scannerCfg.Delay, scannerCfg.MaxWait))
 
+	// update dynamic rebalance values.
+	logger.LogIf(ctx, globalRebalanceConfig.Update(rebalanceCfg))
+
+	// update dynamic scrub values.
+	globalScrubConfig.Update(scrubCfg)
+
 	// Update all dynamic config values in memory.
 	globalServerConfigMu.Lock()
 	defer globalServerConfigMu.Unlock()
@@ -842,6 +842,25 @@ type sizeSummary struct {
 	replicaSize    int64
 	pendingCount   uint64
 	failedCount    uint64
+	tiers          allTierUsage
+}
+
+// addTransitioned accounts oi to the usage of the tier it was
+// transitioned to, if any.
+func (s *sizeSummary) addTransitioned(oi ObjectInfo) {
+	if oi.TransitionedObject.Status != lifecycle.TransitionComplete || oi.TransitionedObject.Tier == "" {
+		return
+	}
+	if s.tiers == nil {
+		s.tiers = make(allTierUsage, 1)
+	}
+	u := s.tiers[oi.TransitionedObject.Tier]
+	u.Size += uint64(oi.Size)
+	u.Versions++
+	if oi.IsLatest {
+		u.Objects++
+	}
+	s.tiers[oi.TransitionedObject.Tier] = u
 }
 
 type getSizeFn func(item scannerItem) (sizeSummary, error)
@@ -898,6 +917,7 @@ func (i *scannerItem) applyLifecycle(ctx context.Context, o ObjectLayer, oi Obje
 		lifecycle.ObjectOpts{
 			Name:                   i.objectPath(),
 			UserTags:               oi.UserTags,
+			Size:                   oi.Size,
 			ModTime:                oi.ModTime,
 			VersionID:              oi.VersionID,
 			DeleteMarker:           oi.DeleteMarker,
@@ -962,6 +982,60 @@ func (i *scannerItem) applyTierObjSweep(ctx context.Context, o ObjectLayer, oi O
 
 }
 
+// applyNewerNoncurrentVersionLimit removes the noncurrent versions of a
+// scanned item in excess of NewerNoncurrentVersions once they have been
+// noncurrent for NoncurrentDays, the rule is matched per version. fivs
+// must be sorted newest first, the versions remaining after removal are
+// returned.
+func (i *scannerItem) applyNewerNoncurrentVersionLimit(ctx context.Context, fivs []FileInfo) []FileInfo {
+	if i.lifeCycle == nil || len(fivs) == 0 {
+		return fivs
+	}
+
+	rcfg, _ := globalBucketObjectLockSys.Get(i.bucket)
+	var (
+		retained   = make([]FileInfo, 0, len(fivs))
+		toDel      []ObjectToDelete
+		noncurrent int
+	)
+	for idx, fi := range fivs {
+		// Free versions of transitioned objects are not noncurrent versions.
+		if idx == 0 || fi.TierFreeVersion() {
+			retained = append(retained, fi)
+			continue
+		}
+		noncurrent++
+		oi := fi.ToObjectInfo(i.bucket, i.objectPath())
+		lim, days := i.lifeCycle.NoncurrentVersionsExpirationLimit(oi.ToLifecycleOpts())
+		if lim == 0 || noncurrent <= lim {
+			retained = append(retained, fi)
+			continue
+		}
+		if time.Now().UTC().Before(lifecycle.ExpectedExpiryTime(oi.SuccessorModTime, days)) {
+			retained = append(retained, fi)
+			continue
+		}
+		if rcfg.LockEnabled && enforceRetentionForDeletion(ctx, oi) {
+			if i.debug {
+				console.Debugf(applyActionsLogPrefix+" lifecycle: %s v(%s) is locked, not deleting\n", oi.Name, oi.VersionID)
+			}
+			retained = append(retained, fi)
+			continue
+		}
+		toDel = append(toDel, ObjectToDelete{
+			ObjectName: oi.Name,
+			VersionID:  oi.VersionID,
+		})
+	}
+	if len(toDel) > 0 {
+		if i.debug {
+			console.Debugf(applyActionsLogPrefix+" lifecycle: %q removing %d noncurrent versions in excess of NewerNoncurrentVersions\n", i.objectPath(), len(toDel))
+		}
+		globalExpiryState.queueNewerNoncurrentTask(i.bucket, toDel)
+	}
+	return retained
+}
+
 // applyActions will apply lifecycle checks on to a scanned item.
 // The resulting size on disk will always be returned.
 // The metadata will be compared to consensus on the object layer before any changes are applied.
@@ -1173,6 +1247,7 @@ func (i *scannerItem) healReplicationDeletes(ctx context.Context, o ObjectLayer,
 				VersionPurgeStatus:            oi.VersionPurgeStatus,
 			},
 			Bucket: oi.Bucket,
+			OpType: replication.HealReplicationType,
 		}
 		if existingObject {
 			doi.OpType = replication.ExistingObjectReplicationType
@@ -1312,6 +1387,8 @@ const (
 	ILMFreeVersionDelete = "ilm:free-version-delete"
 	// ILMTransition - audit trail for ILM transitioning.
 	ILMTransition = " ilm:transition"
+	// ILMAbortMultipartUpload - audit trail for ILM abort of incomplete multipart uploads.
+	ILMAbortMultipartUpload = "ilm:abort-incomplete-multipart-upload"
 )
 
 func auditLogLifecycle(ctx context.Context, oi ObjectInfo, trigger string) {
@@ -56,6 +56,26 @@ type dataUsageEntry struct {
 	ObjSizes         sizeHistogram
 	ReplicationStats *replicationStats
 	Compacted        bool
+	TierUsage        *allTierUsage
+}
+
+// allTierUsage is the usage of the remote tiers by tier name.
+type allTierUsage map[string]tierUsage
+
+//msgp:tuple tierUsage
+
+// tierUsage is the usage of a remote tier, the object versions
+// transitioned to it.
+type tierUsage struct {
+	Size     uint64 `json:"size"`
+	Objects  uint64 `json:"objects"` // Latest versions only.
+	Versions uint64 `json:"versions"`
+}
+
+func (u *tierUsage) add(o tierUsage) {
+	u.Size += o.Size
+	u.Objects += o.Objects
+	u.Versions += o.Versions
 }
 
 //msgp:tuple replicationStats
@@ -72,8 +92,8 @@ type replicationStats struct {
 	AfterThresholdCount  uint64
 }
 
-//msgp:encode ignore dataUsageEntryV2 dataUsageEntryV3 dataUsageEntryV4
-//msgp:marshal ignore dataUsageEntryV2 dataUsageEntryV3 dataUsageEntryV4
+//msgp:encode ignore dataUsageEntryV2 dataUsageEntryV3 dataUsageEntryV4 dataUsageEntryV5
+//msgp:marshal ignore dataUsageEntryV2 dataUsageEntryV3 dataUsageEntryV4 dataUsageEntryV5
 
 //msgp:tuple dataUsageEntryV2
 type dataUsageEntryV2 struct {
@@ -107,6 +127,18 @@ type dataUsageEntryV4 struct {
 	ReplicationStats replicationStats
 }
 
+//msgp:tuple dataUsageEntryV5
+type dataUsageEntryV5 struct {
+	Children dataUsageHashMap
+	// These fields do no include any children.
+	Size             int64
+	Objects          uint64
+	Versions         uint64 // Versions that are not delete markers.
+	ObjSizes         sizeHistogram
+	ReplicationStats *replicationStats
+	Compacted        bool
+}
+
 // dataUsageCache contains a cache of data usage entries latest version.
 type dataUsageCache struct {
 	Info  dataUsageCacheInfo
@@ -114,8 +146,8 @@ type dataUsageCache struct {
 	Disks []string
 }
 
-//msgp:encode ignore dataUsageCacheV2 dataUsageCacheV3 dataUsageCacheV4
-//msgp:marshal ignore dataUsageCacheV2 dataUsageCacheV3 dataUsageCacheV4
+//msgp:encode ignore dataUsageCacheV2 dataUsageCacheV3 dataUsageCacheV4 dataUsageCacheV5
+//msgp:marshal ignore dataUsageCacheV2 dataUsageCacheV3 dataUsageCacheV4 dataUsageCacheV5
 
 // dataUsageCacheV2 contains a cache of data usage entries version 2.
 type dataUsageCacheV2 struct {
@@ -138,6 +170,13 @@ type dataUsageCacheV4 struct {
 	Cache map[string]dataUsageEntryV4
 }
 
+// dataUsageCache contains a cache of data usage entries version 5.
+type dataUsageCacheV5 struct {
+	Info  dataUsageCacheInfo
+	Cache map[string]dataUsageEntryV5
+	Disks []string
+}
+
 //msgp:ignore dataUsageEntryInfo
 type dataUsageEntryInfo struct {
 	Name   string
@@ -182,6 +221,27 @@ func (e *dataUsageEntry) addSizes(summary sizeSummary) {
 		e.ReplicationStats.PendingCount += summary.pendingCount
 		e.ReplicationStats.FailedCount += summary.failedCount
 	}
+	e.addTierUsage(summary.tiers)
+}
+
+// addTierUsage adds the usage of the given tiers. The usage of e is
+// replaced, not modified, as it may be shared by flattened copies of e.
+func (e *dataUsageEntry) addTierUsage(tiers allTierUsage) {
+	if len(tiers) == 0 {
+		return
+	}
+	usage := make(allTierUsage, len(tiers))
+	if e.TierUsage != nil {
+		for tier, u := range *e.TierUsage {
+			usage[tier] = u
+		}
+	}
+	for tier, u := range tiers {
+		tu := usage[tier]
+		tu.add(u)
+		usage[tier] = tu
+	}
+	e.TierUsage = &usage
 }
 
 // merge other data usage entry into this, excluding children.
@@ -207,6 +267,9 @@ func (e *dataUsageEntry) merge(other dataUsageEntry) {
 	for i, v := range other.ObjSizes[:] {
 		e.ObjSizes[i] += v
 	}
+	if other.TierUsage != nil {
+		e.addTierUsage(*other.TierUsage)
+	}
 }
 
 // mod returns true if the hash mod cycles == cycle.
@@ -253,6 +316,13 @@ func (e dataUsageEntry) clone() dataUsageEntry {
 		r := *e.ReplicationStats
 		e.ReplicationStats = &r
 	}
+	if e.TierUsage != nil {
+		tiers := make(allTierUsage, len(*e.TierUsage))
+		for k, v := range *e.TierUsage {
+			tiers[k] = v
+		}
+		e.TierUsage = &tiers
+	}
 	return e
 }
 
@@ -790,7 +860,8 @@ func (d *dataUsageCache) save(ctx context.Context, store objectIO, name string)
 // Bumping the cache version will drop data from previous versions
 // and write new data with the new version.
 const (
-	dataUsageCacheVerCurrent = 5
+	dataUsageCacheVerCurrent = 6
+	dataUsageCacheVerV5      = 5
 	dataUsageCacheVerV4      = 4
 	dataUsageCacheVerV3      = 3
 	dataUsageCacheVerV2      = 2
@@ -937,6 +1008,32 @@ func (d *dataUsageCache) deserialize(r io.Reader) error {
 			d.Cache[k] = e
 		}
 		return nil
+	case dataUsageCacheVerV5:
+		// Zstd compressed.
+		dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(2))
+		if err != nil {
+			return err
+		}
+		defer dec.Close()
+		dold := &dataUsageCacheV5{}
+		if err = dold.DecodeMsg(msgp.NewReader(dec)); err != nil {
+			return err
+		}
+		d.Info = dold.Info
+		d.Disks = dold.Disks
+		d.Cache = make(map[string]dataUsageEntry, len(dold.Cache))
+		for k, v := range dold.Cache {
+			d.Cache[k] = dataUsageEntry{
+				Children:         v.Children,
+				Size:             v.Size,
+				Objects:          v.Objects,
+				Versions:         v.Versions,
+				ObjSizes:         v.ObjSizes,
+				ReplicationStats: v.ReplicationStats,
+				Compacted:        v.Compacted,
+			}
+		}
+		return nil
 	case dataUsageCacheVerCurrent:
 		// Zstd compressed.
 		dec, err := zstd.NewReader(r, zstd.WithDecoderConcurrency(2))
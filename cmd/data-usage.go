@@ -95,6 +95,33 @@ func loadPrefixUsageFromBackend(ctx context.Context, objAPI ObjectLayer, bucket
 	return m, nil
 }
 
+// loadTierUsageFromBackend returns the usage of the remote tiers,
+// summed over the data usage caches of all erasure sets.
+func loadTierUsageFromBackend(ctx context.Context, objAPI ObjectLayer) (allTierUsage, error) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		// Tiering is only supported in erasure mode
+		return allTierUsage{}, nil
+	}
+
+	var total dataUsageEntry
+	for _, pool := range z.serverPools {
+		for _, er := range pool.sets {
+			cache := dataUsageCache{}
+			if err := cache.load(ctx, er, dataUsageCacheName); err != nil {
+				return nil, err
+			}
+			if root := cache.root(); root != nil {
+				total.merge(cache.flatten(*root))
+			}
+		}
+	}
+	if total.TierUsage == nil {
+		return allTierUsage{}, nil
+	}
+	return *total.TierUsage, nil
+}
+
 func loadDataUsageFromBackend(ctx context.Context, objAPI ObjectLayer) (madmin.DataUsageInfo, error) {
 	r, err := objAPI.GetObjectNInfo(ctx, dataUsageBucket, dataUsageObjName, nil, http.Header{}, readLock, ObjectOptions{})
 	if err != nil {
@@ -26,7 +26,10 @@ import (
 	"os"
 	"path"
 	"path/filepath"
+	"reflect"
 	"testing"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
 )
 
 type usageTestFile struct {
@@ -592,6 +595,7 @@ func TestDataUsageCacheSerialize(t *testing.T) {
 		MissedThresholdCount: 9,
 		AfterThresholdCount:  10,
 	}
+	e.TierUsage = &allTierUsage{"WARM": {Size: 300, Objects: 1, Versions: 2}}
 	want.replace("abucket/dir2", "", *e)
 	var buf bytes.Buffer
 	err = want.serializeTo(&buf)
@@ -634,3 +638,65 @@ func equalAsJSON(a, b interface{}) bool {
 	}
 	return bytes.Equal(aj, bj)
 }
+
+func TestDataUsageTierUsage(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	transitioned := func(name, tier string, size int64, latest bool) ObjectInfo {
+		return ObjectInfo{
+			Name:     name,
+			Size:     size,
+			IsLatest: latest,
+			TransitionedObject: TransitionedObject{
+				Tier:   tier,
+				Status: lifecycle.TransitionComplete,
+			},
+		}
+	}
+	var s1, s2 sizeSummary
+	s1.addTransitioned(transitioned("dir/a", "WARM", 100, true))
+	s1.addTransitioned(transitioned("dir/a", "WARM", 50, false))
+	s1.addTransitioned(ObjectInfo{Name: "dir/a", Size: 10, IsLatest: true})
+	s2.addTransitioned(transitioned("dir/b", "WARM", 200, true))
+	s2.addTransitioned(transitioned("dir/b", "COLD", 400, false))
+
+	cache := dataUsageCache{Info: dataUsageCacheInfo{Name: "bucket"}}
+	var a, b dataUsageEntry
+	a.addSizes(s1)
+	b.addSizes(s2)
+	cache.replace("bucket/dir/a", "bucket/dir", a)
+	cache.replace("bucket/dir/b", "bucket/dir", b)
+	cache.replace("bucket/dir", "bucket", *cache.find("bucket/dir"))
+
+	want := allTierUsage{
+		"WARM": {Size: 350, Objects: 2, Versions: 3},
+		"COLD": {Size: 400, Versions: 1},
+	}
+	// Flattening must not modify the cached entries.
+	for i := 0; i < 2; i++ {
+		got := cache.flatten(*cache.root()).TierUsage
+		if got == nil || !reflect.DeepEqual(*got, want) {
+			t.Fatalf("Expected tier usage %v, got %v", want, got)
+		}
+	}
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	if err = cache.save(ctx, z.serverPools[0].sets[0], dataUsageCacheName); err != nil {
+		t.Fatal(err)
+	}
+	got, err := loadTierUsageFromBackend(ctx, obj)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("Expected tier usage %v, got %v", want, got)
+	}
+}
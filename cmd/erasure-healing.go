@@ -460,6 +460,7 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 			partSize := latestMeta.Parts[partIndex].Size
 			partActualSize := latestMeta.Parts[partIndex].ActualSize
 			partNumber := latestMeta.Parts[partIndex].Number
+			partChecksum := latestMeta.Parts[partIndex].Checksum
 			tillOffset := erasure.ShardFileOffset(0, partSize, partSize)
 			readers := make([]io.ReaderAt, len(latestDisks))
 			checksumAlgo := erasureInfo.GetChecksumInfo(partNumber).Algorithm
@@ -472,6 +473,12 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 				readers[i] = newBitrotReader(disk, partsMetadata[i].Data, bucket, partPath, tillOffset, checksumAlgo,
 					checksumInfo.Hash, erasure.ShardSize())
 			}
+			// Healed parts keep their streaming bitrot algorithm, parts
+			// of legacy objects are migrated to the default algorithm.
+			healAlgo := checksumAlgo
+			if !healAlgo.Streaming() {
+				healAlgo = DefaultBitrotAlgorithm
+			}
 			writers := make([]io.Writer, len(outDatedDisks))
 			for i, disk := range outDatedDisks {
 				if disk == OfflineDisk {
@@ -480,10 +487,10 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 				partPath := pathJoin(tmpID, dstDataDir, fmt.Sprintf("part.%d", partNumber))
 				if len(inlineBuffers) > 0 {
 					inlineBuffers[i] = bytes.NewBuffer(make([]byte, 0, erasure.ShardFileSize(latestMeta.Size)+32))
-					writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], DefaultBitrotAlgorithm, erasure.ShardSize())
+					writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], healAlgo, erasure.ShardSize())
 				} else {
 					writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, partPath,
-						tillOffset, DefaultBitrotAlgorithm, erasure.ShardSize())
+						tillOffset, healAlgo, erasure.ShardSize())
 				}
 			}
 			err = erasure.Heal(ctx, readers, writers, partSize, bp)
@@ -509,10 +516,10 @@ func (er erasureObjects) healObject(ctx context.Context, bucket string, object s
 				}
 
 				partsMetadata[i].DataDir = dstDataDir
-				partsMetadata[i].AddObjectPart(partNumber, "", partSize, partActualSize)
+				partsMetadata[i].AddObjectPart(partNumber, "", partSize, partActualSize, partChecksum)
 				partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 					PartNumber: partNumber,
-					Algorithm:  checksumAlgo,
+					Algorithm:  healAlgo,
 					Hash:       bitrotWriterSum(writers[i]),
 				})
 				if len(inlineBuffers) > 0 && inlineBuffers[i] != nil {
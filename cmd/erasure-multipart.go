@@ -30,6 +30,7 @@ import (
 	"time"
 
 	"github.com/minio/minio-go/v7/pkg/set"
+	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
 	"github.com/minio/minio/internal/logger"
 	"github.com/minio/minio/internal/sync/errgroup"
@@ -99,6 +100,10 @@ func (er erasureObjects) removeObjectPart(bucket, object, uploadID, dataDir stri
 
 // Clean-up the old multipart uploads. Should be run in a Go routine.
 func (er erasureObjects) cleanupStaleUploads(ctx context.Context, expiry time.Duration) {
+	// Uploads governed by lifecycle rules are not stale
+	// until the rules abort them.
+	governed := er.applyAbortIncompleteMultipartUploads(ctx)
+
 	// run multiple cleanup's local to this server.
 	var wg sync.WaitGroup
 	for _, disk := range er.getLoadBalancedLocalDisks() {
@@ -106,7 +111,7 @@ func (er erasureObjects) cleanupStaleUploads(ctx context.Context, expiry time.Du
 			wg.Add(1)
 			go func(disk StorageAPI) {
 				defer wg.Done()
-				er.cleanupStaleUploadsOnDisk(ctx, disk, expiry)
+				er.cleanupStaleUploadsOnDisk(ctx, disk, expiry, governed)
 			}(disk)
 		}
 	}
@@ -143,14 +148,18 @@ func (er erasureObjects) deleteAll(ctx context.Context, bucket, prefix string) {
 	wg.Wait()
 }
 
-// Remove the old multipart uploads on the given disk.
-func (er erasureObjects) cleanupStaleUploadsOnDisk(ctx context.Context, disk StorageAPI, expiry time.Duration) {
+// Remove the old multipart uploads on the given disk, except for
+// the governed uploads.
+func (er erasureObjects) cleanupStaleUploadsOnDisk(ctx context.Context, disk StorageAPI, expiry time.Duration, governed map[string]bool) {
 	now := time.Now()
 	diskPath := disk.Endpoint().Path
 
 	readDirFn(pathJoin(diskPath, minioMetaMultipartBucket), func(shaDir string, typ os.FileMode) error {
 		return readDirFn(pathJoin(diskPath, minioMetaMultipartBucket, shaDir), func(uploadIDDir string, typ os.FileMode) error {
 			uploadIDPath := pathJoin(shaDir, uploadIDDir)
+			if governed[uploadIDPath] {
+				return nil
+			}
 			fi, err := disk.ReadVersion(ctx, minioMetaMultipartBucket, uploadIDPath, "", false)
 			if err != nil {
 				return nil
@@ -181,6 +190,84 @@ func (er erasureObjects) cleanupStaleUploadsOnDisk(ctx context.Context, disk Sto
 	})
 }
 
+// Evaluates the AbortIncompleteMultipartUpload lifecycle rules once for
+// the multipart uploads of this set, the uploads are listed from one of
+// its local disks. Uploads past DaysAfterInitiation of a matching rule
+// are aborted, the uploads a rule matches but which are not due yet
+// are returned.
+func (er erasureObjects) applyAbortIncompleteMultipartUploads(ctx context.Context) map[string]bool {
+	var disk StorageAPI
+	for _, d := range er.getLoadBalancedLocalDisks() {
+		if d != nil {
+			disk = d
+			break
+		}
+	}
+	if disk == nil {
+		return nil
+	}
+
+	now := time.Now()
+	diskPath := disk.Endpoint().Path
+	governed := make(map[string]bool)
+	readDirFn(pathJoin(diskPath, minioMetaMultipartBucket), func(shaDir string, typ os.FileMode) error {
+		return readDirFn(pathJoin(diskPath, minioMetaMultipartBucket, shaDir), func(uploadIDDir string, typ os.FileMode) error {
+			uploadIDPath := pathJoin(shaDir, uploadIDDir)
+			fi, err := disk.ReadVersion(ctx, minioMetaMultipartBucket, uploadIDPath, "", false)
+			if err != nil {
+				return nil
+			}
+			due, ok := abortIncompleteMultipartUploadTime(fi)
+			if !ok {
+				return nil
+			}
+			if now.Before(due) {
+				governed[uploadIDPath] = true
+				return nil
+			}
+			wait := er.deletedCleanupSleeper.Timer(ctx)
+			er.abortIncompleteMultipartUpload(ctx, fi, strings.TrimSuffix(uploadIDDir, SlashSeparator))
+			wait()
+			return nil
+		})
+	})
+	return governed
+}
+
+// abortIncompleteMultipartUploadTime returns the time the multipart upload
+// is to be aborted per the lifecycle rules of its bucket, if a rule matches.
+func abortIncompleteMultipartUploadTime(fi FileInfo) (time.Time, bool) {
+	uploadObject := fi.Metadata[uploadObjectKey]
+	if uploadObject == "" {
+		// Uploads initiated by older releases do not record their object.
+		return time.Time{}, false
+	}
+	bucket, object := path2BucketObject(uploadObject)
+	lc, err := globalLifecycleSys.Get(bucket)
+	if err != nil {
+		return time.Time{}, false
+	}
+	return lc.AbortMultipartUploadTime(object, fi.ModTime)
+}
+
+// Aborts a multipart upload per an AbortIncompleteMultipartUpload
+// lifecycle rule of its bucket.
+func (er erasureObjects) abortIncompleteMultipartUpload(ctx context.Context, fi FileInfo, uploadID string) {
+	bucket, object := path2BucketObject(fi.Metadata[uploadObjectKey])
+	if err := er.AbortMultipartUpload(ctx, bucket, object, uploadID, ObjectOptions{}); err != nil {
+		// Another node may have aborted this upload already.
+		if _, ok := err.(InvalidUploadID); !ok {
+			logger.LogIf(ctx, err)
+		}
+		return
+	}
+	auditLogInternal(ctx, bucket, object, AuditLogOptions{
+		Trigger:  ILMAbortMultipartUpload,
+		APIName:  "ILMAbortMultipartUpload",
+		UploadID: uploadID,
+	})
+}
+
 // ListMultipartUploads - lists all the pending multipart
 // uploads for a particular object in a bucket.
 //
@@ -344,6 +431,14 @@ func (er erasureObjects) newMultipartUpload(ctx context.Context, bucket string,
 
 	onlineDisks, partsMetadata = shuffleDisksAndPartsMetadata(onlineDisks, partsMetadata, fi)
 
+	// Record the object of this upload, the upload ID
+	// directory only holds a hash of the object name.
+	opts.UserDefined[uploadObjectKey] = pathJoin(bucket, object)
+
+	// All parts of the upload are protected with the same bitrot
+	// algorithm, even if the configured algorithm changes meanwhile.
+	opts.UserDefined[uploadBitrotAlgorithmKey] = configuredBitrotAlgorithm().String()
+
 	// Fill all the necessary metadata.
 	// Update `xl.meta` content on each disks.
 	for index := range partsMetadata {
@@ -513,12 +608,17 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 	if len(buffer) > int(fi.Erasure.BlockSize) {
 		buffer = buffer[:fi.Erasure.BlockSize]
 	}
+	// Uploads started before the bitrot algorithm was recorded use the default algorithm.
+	bitrotAlgo := BitrotAlgorithmFromString(fi.Metadata[uploadBitrotAlgorithmKey])
+	if !bitrotAlgo.Streaming() {
+		bitrotAlgo = DefaultBitrotAlgorithm
+	}
 	writers := make([]io.Writer, len(onlineDisks))
 	for i, disk := range onlineDisks {
 		if disk == nil {
 			continue
 		}
-		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), DefaultBitrotAlgorithm, erasure.ShardSize())
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), bitrotAlgo, erasure.ShardSize())
 	}
 
 	n, err := erasure.Encode(pctx, data, writers, buffer, writeQuorum)
@@ -582,7 +682,7 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 	md5hex := r.MD5CurrentHexString()
 
 	// Add the current part.
-	fi.AddObjectPart(partID, md5hex, n, data.ActualSize())
+	fi.AddObjectPart(partID, md5hex, n, data.ActualSize(), opts.WantChecksum.String())
 
 	for i, disk := range onlineDisks {
 		if disk == OfflineDisk {
@@ -593,7 +693,7 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 		partsMetadata[i].Parts = fi.Parts
 		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 			PartNumber: partID,
-			Algorithm:  DefaultBitrotAlgorithm,
+			Algorithm:  bitrotAlgo,
 			Hash:       bitrotWriterSum(writers[i]),
 		})
 	}
@@ -612,6 +712,7 @@ func (er erasureObjects) PutObjectPart(ctx context.Context, bucket, object, uplo
 		LastModified: fi.ModTime,
 		Size:         n,
 		ActualSize:   data.ActualSize(),
+		Checksum:     opts.WantChecksum.String(),
 	}, nil
 }
 
@@ -745,6 +846,7 @@ func (er erasureObjects) ListObjectParts(ctx context.Context, bucket, object, up
 			ETag:         part.ETag,
 			LastModified: fi.ModTime,
 			Size:         part.Size,
+			Checksum:     part.Checksum,
 		})
 		count--
 		if count == 0 {
@@ -825,6 +927,10 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	// Allocate parts similar to incoming slice.
 	fi.Parts = make([]ObjectPartInfo, len(parts))
 
+	// Checksum algorithm the upload was initiated with, if any.
+	checksumType := hash.NewChecksumType(fi.Metadata[uploadChecksumTypeKey])
+	var partChecksums []*hash.Checksum
+
 	// Validate each part and then commit to disk.
 	for i, part := range parts {
 		partIdx := objectPartIndex(currentFI.Parts, part.PartNumber)
@@ -848,6 +954,19 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 			return oi, invp
 		}
 
+		// Part checksums must match the ones returned by PutObjectPart.
+		if checksumType.IsSet() {
+			got := hash.ParseChecksum(currentFI.Parts[partIdx].Checksum)
+			if !got.Equal(part.checksum(checksumType)) {
+				return oi, InvalidPart{
+					PartNumber: part.PartNumber,
+					ExpETag:    currentFI.Parts[partIdx].ETag,
+					GotETag:    part.ETag,
+				}
+			}
+			partChecksums = append(partChecksums, got)
+		}
+
 		// All parts except the last part has to be atleast 5MB.
 		if (i < len(parts)-1) && !isMinAllowedPartSize(currentFI.Parts[partIdx].ActualSize) {
 			return oi, PartTooSmall{
@@ -868,9 +987,18 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 			Number:     part.PartNumber,
 			Size:       currentFI.Parts[partIdx].Size,
 			ActualSize: currentFI.Parts[partIdx].ActualSize,
+			Checksum:   currentFI.Parts[partIdx].Checksum,
 		}
 	}
 
+	// Save the checksum of the object, computed from the part checksums.
+	if checksumType.IsSet() {
+		fi.Metadata[objectChecksumKey] = hash.NewCompositeChecksum(checksumType, partChecksums).String()
+		delete(fi.Metadata, uploadChecksumTypeKey)
+	}
+	delete(fi.Metadata, uploadObjectKey)
+	delete(fi.Metadata, uploadBitrotAlgorithmKey)
+
 	// Save the final object size and modtime.
 	fi.Size = objectSize
 	fi.ModTime = opts.MTime
@@ -899,13 +1027,19 @@ func (er erasureObjects) CompleteMultipartUpload(ctx context.Context, bucket str
 	}
 
 	// Hold namespace to complete the transaction
-	lk := er.NewNSLock(bucket, object)
-	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
-	if err != nil {
+	if !opts.NoLock {
+		lk := er.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return oi, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+	}
+
+	if err = er.checkWritePreconditions(ctx, bucket, object, opts); err != nil {
 		return oi, err
 	}
-	ctx = lkctx.Context()
-	defer lk.Unlock(lkctx.Cancel)
 
 	// Write final `xl.meta` at uploadID location
 	onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, minioMetaMultipartBucket, uploadIDPath, partsMetadata, writeQuorum)
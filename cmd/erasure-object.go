@@ -33,6 +33,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/internal/bucket/lifecycle"
 	"github.com/minio/minio/internal/bucket/replication"
+	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/event"
 	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
@@ -77,6 +78,10 @@ func (er erasureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, d
 		ctx = lkctx.Context()
 		defer lk.Unlock(lkctx.Cancel)
 	}
+	if err = er.checkWritePreconditions(ctx, dstBucket, dstObject, dstOpts); err != nil {
+		return oi, err
+	}
+
 	// Read metadata associated with the object from all disks.
 	storageDisks := er.getDisks()
 	metaArr, errs := readAllFileInfo(ctx, storageDisks, srcBucket, srcObject, srcOpts.VersionID, true)
@@ -137,6 +142,26 @@ func (er erasureObjects) CopyObject(ctx context.Context, srcBucket, srcObject, d
 	return fi.ToObjectInfo(srcBucket, srcObject), nil
 }
 
+// checkWritePreconditions evaluates the preconditions of a write against the
+// latest version of the object, a missing object is passed as an empty ObjectInfo.
+// The caller is expected to hold the namespace write lock of the object.
+func (er erasureObjects) checkWritePreconditions(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, err := er.getObjectInfo(ctx, bucket, object, ObjectOptions{})
+	if err != nil {
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) && !isErrMethodNotAllowed(err) {
+			return err
+		}
+		oi = ObjectInfo{}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
 // GetObjectNInfo - returns object info and an object
 // Read(Closer). When err != nil, the returned reader is always nil.
 func (er erasureObjects) GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (gr *GetObjectReader, err error) {
@@ -687,7 +712,7 @@ func (er erasureObjects) putMetacacheObject(ctx context.Context, key string, r *
 			continue
 		}
 		partsMetadata[i].Data = inlineBuffers[i].Bytes()
-		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize())
+		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize(), "")
 		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 			PartNumber: 1,
 			Algorithm:  DefaultBitrotAlgorithm,
@@ -870,6 +895,7 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	}()
 
 	shardFileSize := erasure.ShardFileSize(data.Size())
+	bitrotAlgo := configuredBitrotAlgorithm()
 	writers := make([]io.Writer, len(onlineDisks))
 	var inlineBuffers []*bytes.Buffer
 	if shardFileSize >= 0 {
@@ -899,10 +925,10 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 				sz = data.ActualSize()
 			}
 			inlineBuffers[i] = bytes.NewBuffer(make([]byte, 0, sz))
-			writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], DefaultBitrotAlgorithm, erasure.ShardSize())
+			writers[i] = newStreamingBitrotWriterBuffer(inlineBuffers[i], bitrotAlgo, erasure.ShardSize())
 			continue
 		}
-		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, shardFileSize, DefaultBitrotAlgorithm, erasure.ShardSize())
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tempErasureObj, shardFileSize, bitrotAlgo, erasure.ShardSize())
 	}
 
 	n, erasureErr := erasure.Encode(ctx, data, writers, buffer, writeQuorum)
@@ -927,6 +953,10 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		defer lk.Unlock(lkctx.Cancel)
 	}
 
+	if err = er.checkWritePreconditions(ctx, bucket, object, opts); err != nil {
+		return ObjectInfo{}, err
+	}
+
 	for i, w := range writers {
 		if w == nil {
 			onlineDisks[i] = nil
@@ -937,16 +967,19 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 		} else {
 			partsMetadata[i].Data = nil
 		}
-		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize())
+		partsMetadata[i].AddObjectPart(1, "", n, data.ActualSize(), "")
 		partsMetadata[i].Erasure.AddChecksumInfo(ChecksumInfo{
 			PartNumber: 1,
-			Algorithm:  DefaultBitrotAlgorithm,
+			Algorithm:  bitrotAlgo,
 			Hash:       bitrotWriterSum(w),
 		})
 	}
 	if opts.UserDefined["etag"] == "" {
 		opts.UserDefined["etag"] = r.MD5CurrentHexString()
 	}
+	if cs := opts.WantChecksum.String(); cs != "" {
+		opts.UserDefined[objectChecksumKey] = cs
+	}
 
 	// Guess content-type from the extension if possible.
 	if opts.UserDefined["content-type"] == "" {
@@ -1003,6 +1036,400 @@ func (er erasureObjects) putObject(ctx context.Context, bucket string, object st
 	return fi.ToObjectInfo(bucket, object), nil
 }
 
+// AppendObject - appends the data read from r to the end of the latest
+// version of an object. The data is written as a new erasure coded part,
+// offset must match the current size of the object.
+func (er erasureObjects) AppendObject(ctx context.Context, bucket, object string, offset int64, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if !opts.NoLock {
+		lk := er.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+	}
+	defer NSUpdated(bucket, object)
+
+	data := r.Reader
+	storageDisks := er.getDisks()
+
+	// Read metadata of the latest version from all disks.
+	metaArr, errs := readAllFileInfo(ctx, storageDisks, bucket, object, "", true)
+
+	readQuorum, writeQuorum, err := objectQuorumFromMeta(ctx, metaArr, errs, er.defaultParityCount)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	if reducedErr := reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum); reducedErr != nil {
+		return ObjectInfo{}, toObjectErr(reducedErr, bucket, object)
+	}
+
+	// List all online disks.
+	onlineDisks, modTime, dataDir := listOnlineDisks(storageDisks, metaArr, errs)
+
+	// Pick latest valid metadata.
+	fi, err := pickValidFileInfo(ctx, metaArr, modTime, dataDir, readQuorum)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+	if fi.Deleted {
+		return ObjectInfo{}, toObjectErr(errFileNotFound, bucket, object)
+	}
+
+	oi := fi.ToObjectInfo(bucket, object)
+	if _, encrypted := crypto.IsEncrypted(oi.UserDefined); encrypted || oi.IsCompressed() {
+		return ObjectInfo{}, NotImplemented{Message: "Appending to encrypted or compressed objects is not supported"}
+	}
+	if fi.IsRemote() {
+		return ObjectInfo{}, InvalidObjectState{Bucket: bucket, Object: object}
+	}
+	if opts.CheckPrecondFn != nil && opts.CheckPrecondFn(oi) {
+		return ObjectInfo{}, PreConditionFailed{}
+	}
+	if offset != fi.Size {
+		return ObjectInfo{}, InvalidWriteOffset{Bucket: bucket, Object: object, Offset: offset, Size: fi.Size}
+	}
+
+	// The whole-object checksum no longer matches the content.
+	metadata := make(map[string]string, len(fi.Metadata))
+	for k, v := range fi.Metadata {
+		switch k {
+		case "etag", objectChecksumKey, ReservedMetadataPrefixLower + "inline-data":
+			continue
+		}
+		metadata[k] = v
+	}
+	modTime = opts.MTime
+	if modTime.IsZero() {
+		modTime = UTCNow()
+	}
+
+	if fi.InlineData() || len(fi.Parts) == 0 {
+		// Small objects are stored inline with their metadata,
+		// write them again as a whole under the same version.
+		var buf bytes.Buffer
+		if err = er.getObjectWithFileInfo(ctx, bucket, object, 0, fi.Size, &buf, fi, metaArr, onlineDisks); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, object)
+		}
+		size := int64(-1)
+		if data.Size() >= 0 {
+			size = fi.Size + data.Size()
+		}
+		hr, err := hash.NewReader(io.MultiReader(&buf, data), size, "", "", size)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		return er.putObject(ctx, bucket, object, NewPutObjReader(hr), ObjectOptions{
+			VersionID:   fi.VersionID,
+			MTime:       modTime,
+			UserDefined: metadata,
+			NoLock:      true,
+		})
+	}
+
+	if len(fi.Parts) >= globalMaxPartID {
+		return ObjectInfo{}, NotImplemented{Message: "The object has reached the maximum number of parts"}
+	}
+
+	// All parts of a version share the bitrot algorithm.
+	bitrotAlgo := fi.Erasure.GetChecksumInfo(fi.Parts[0].Number).Algorithm
+	if fi.XLV1 || !bitrotAlgo.Streaming() {
+		return ObjectInfo{}, NotImplemented{Message: "Appending to objects in a legacy format is not supported"}
+	}
+
+	onlineDisks, metaArr = shuffleDisksAndPartsMetadataByIndex(onlineDisks, metaArr, fi)
+
+	partID := fi.Parts[len(fi.Parts)-1].Number + 1
+	partSuffix := fmt.Sprintf("part.%d", partID)
+	tmpPart := mustGetUUID()
+	tmpPartPath := pathJoin(tmpPart, partSuffix)
+
+	// Delete the temporary object part. If AppendObject succeeds there would be nothing to delete.
+	var online int
+	defer func() {
+		if online != len(onlineDisks) {
+			er.deleteObject(context.Background(), minioMetaTmpBucket, tmpPart, writeQuorum)
+		}
+	}()
+
+	erasure, err := NewErasure(ctx, fi.Erasure.DataBlocks, fi.Erasure.ParityBlocks, fi.Erasure.BlockSize)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Fetch buffer for I/O, returns from the pool if not allocates a new one and returns.
+	var buffer []byte
+	switch size := data.Size(); {
+	case size == 0:
+		buffer = make([]byte, 1) // Allocate atleast a byte to reach EOF
+	case size == -1 || size >= fi.Erasure.BlockSize:
+		buffer = er.bp.Get()
+		defer er.bp.Put(buffer)
+	case size < fi.Erasure.BlockSize:
+		// No need to allocate fully fi.Erasure.BlockSize buffer if the incoming data is smaller.
+		buffer = make([]byte, size, 2*size+int64(fi.Erasure.ParityBlocks+fi.Erasure.DataBlocks-1))
+	}
+
+	if len(buffer) > int(fi.Erasure.BlockSize) {
+		buffer = buffer[:fi.Erasure.BlockSize]
+	}
+	writers := make([]io.Writer, len(onlineDisks))
+	for i, disk := range onlineDisks {
+		if disk == nil {
+			continue
+		}
+		writers[i] = newBitrotWriter(disk, minioMetaTmpBucket, tmpPartPath, erasure.ShardFileSize(data.Size()), bitrotAlgo, erasure.ShardSize())
+	}
+
+	n, err := erasure.Encode(ctx, data, writers, buffer, writeQuorum)
+	closeBitrotWriters(writers)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Should return IncompleteBody{} error when reader has fewer bytes
+	// than specified in request header.
+	if n < data.Size() {
+		return ObjectInfo{}, IncompleteBody{Bucket: bucket, Object: object}
+	}
+
+	for i := range writers {
+		if writers[i] == nil {
+			onlineDisks[i] = nil
+		}
+	}
+
+	// Move the part next to the existing parts, it is not
+	// referenced until `xl.meta` is updated below.
+	partPath := pathJoin(object, fi.DataDir, partSuffix)
+	onlineDisks, err = rename(ctx, onlineDisks, minioMetaTmpBucket, tmpPartPath, bucket, partPath, false, writeQuorum, nil)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	// Objects written by PutObject carry their ETag only in the metadata.
+	if fi.Parts[0].ETag == "" && len(fi.Parts) == 1 {
+		fi.Parts[0].ETag = fi.Metadata["etag"]
+	}
+	fi.AddObjectPart(partID, r.MD5CurrentHexString(), n, data.ActualSize(), "")
+
+	completeParts := make([]CompletePart, len(fi.Parts))
+	for i, part := range fi.Parts {
+		completeParts[i] = CompletePart{PartNumber: part.Number, ETag: part.ETag}
+	}
+	metadata["etag"] = getCompleteMultipartMD5(completeParts)
+
+	for i, disk := range onlineDisks {
+		if disk == OfflineDisk {
+			continue
+		}
+		metaArr[i].Size = fi.Size + n
+		metaArr[i].ModTime = modTime
+		metaArr[i].Metadata = metadata
+		metaArr[i].Parts = fi.Parts
+		metaArr[i].Erasure.AddChecksumInfo(ChecksumInfo{
+			PartNumber: partID,
+			Algorithm:  bitrotAlgo,
+			Hash:       bitrotWriterSum(writers[i]),
+		})
+	}
+
+	// Writes update `xl.meta` format for each disk.
+	if onlineDisks, err = writeUniqueFileInfo(ctx, onlineDisks, bucket, object, metaArr, writeQuorum); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, object)
+	}
+
+	for i := 0; i < len(onlineDisks); i++ {
+		if onlineDisks[i] != nil && onlineDisks[i].IsOnline() {
+			fi = metaArr[i]
+			break
+		}
+	}
+
+	// Whether a disk was initially or becomes offline
+	// during this append, send it to the MRF list.
+	for i := 0; i < len(onlineDisks); i++ {
+		if onlineDisks[i] != nil && onlineDisks[i].IsOnline() {
+			continue
+		}
+		er.addPartial(bucket, object, fi.VersionID, fi.Size)
+		break
+	}
+
+	online = countOnlineDisks(onlineDisks)
+
+	return fi.ToObjectInfo(bucket, object), nil
+}
+
+// RenameObject - renames an object with all its versions within a bucket.
+// The `xl.meta` and the data directories of the object are moved on every
+// drive, the destination must not exist.
+func (er erasureObjects) RenameObject(ctx context.Context, bucket, srcObject, dstObject string, opts ObjectOptions) (ObjectInfo, error) {
+	if !opts.NoLock {
+		lk := er.NewNSLock(bucket, srcObject, dstObject)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+	}
+	defer NSUpdated(bucket, srcObject)
+	defer NSUpdated(bucket, dstObject)
+
+	fi, _, _, err := er.getObjectFileInfo(ctx, bucket, srcObject, ObjectOptions{}, false)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, srcObject)
+	}
+	if _, _, _, err = er.getObjectFileInfo(ctx, bucket, dstObject, ObjectOptions{}, false); err == nil {
+		return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: dstObject}
+	} else if err = toObjectErr(err, bucket, dstObject); !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+		return ObjectInfo{}, err
+	}
+
+	parityDrives := fi.Erasure.ParityBlocks
+	if fi.Deleted {
+		parityDrives = er.defaultParityCount
+	}
+	dataDrives := len(er.getDisks()) - parityDrives
+	writeQuorum := dataDrives
+	if dataDrives == parityDrives {
+		writeQuorum++
+	}
+
+	// Collect the data directories of all versions on each drive.
+	disks := er.getDisks()
+	versions, errs := er.readObjectVersions(ctx, bucket, srcObject)
+	if err = reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, srcObject)
+	}
+
+	dataDirs := make([][]string, len(disks))
+	for index := range versions {
+		for _, version := range versions[index] {
+			// Object keys of encrypted objects are sealed with the object name.
+			if _, encrypted := crypto.IsEncrypted(version.Metadata); encrypted {
+				return ObjectInfo{}, NotImplemented{Message: "Renaming encrypted objects is not supported"}
+			}
+			if version.XLV1 {
+				return ObjectInfo{}, NotImplemented{Message: "Renaming objects in a legacy format is not supported"}
+			}
+			if version.DataDir != "" && !contains(dataDirs[index], version.DataDir) {
+				dataDirs[index] = append(dataDirs[index], version.DataDir)
+			}
+		}
+	}
+
+	if opts.RenameCheck {
+		return fi.ToObjectInfo(bucket, dstObject), nil
+	}
+
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		if errs[index] != nil {
+			continue
+		}
+		index := index
+		g.Go(func() error {
+			return renameObjectDir(ctx, disks[index], bucket, srcObject, dstObject, dataDirs[index])
+		}, index)
+	}
+	errs = g.Wait()
+	if err = reduceWriteQuorumErrs(ctx, errs, objectOpIgnoredErrs, writeQuorum); err != nil {
+		// Move the object back on the drives it was renamed on.
+		for index := range disks {
+			if errs[index] == nil {
+				logger.LogIf(ctx, renameObjectDir(ctx, disks[index], bucket, dstObject, srcObject, dataDirs[index]))
+			}
+		}
+		return ObjectInfo{}, toObjectErr(err, bucket, srcObject)
+	}
+
+	// Drives which missed the rename are healed in the background.
+	for _, err := range errs {
+		if err != nil {
+			er.addPartial(bucket, dstObject, fi.VersionID, fi.Size)
+			break
+		}
+	}
+
+	return fi.ToObjectInfo(bucket, dstObject), nil
+}
+
+// readObjectVersions reads all versions of an object from every drive.
+func (er erasureObjects) readObjectVersions(ctx context.Context, bucket, object string) ([][]FileInfo, []error) {
+	disks := er.getDisks()
+	versions := make([][]FileInfo, len(disks))
+	g := errgroup.WithNErrs(len(disks))
+	for index := range disks {
+		index := index
+		g.Go(func() error {
+			if disks[index] == nil {
+				return errDiskNotFound
+			}
+			buf, err := disks[index].ReadAll(ctx, bucket, pathJoin(object, xlStorageFormatFile))
+			if err != nil {
+				return err
+			}
+			fivs, err := getAllFileInfoVersions(buf, bucket, object)
+			if err != nil {
+				return err
+			}
+			versions[index] = fivs.Versions
+			return nil
+		}, index)
+	}
+	return versions, g.Wait()
+}
+
+// getObjectVersions returns all versions of an object, as listed by at
+// least readQuorum drives.
+func (er erasureObjects) getObjectVersions(ctx context.Context, bucket, object string, readQuorum int) ([]FileInfo, error) {
+	versions, errs := er.readObjectVersions(ctx, bucket, object)
+	if err := reduceReadQuorumErrs(ctx, errs, objectOpIgnoredErrs, readQuorum); err != nil {
+		return nil, toObjectErr(err, bucket, object)
+	}
+
+	counts := make(map[string]int, len(versions))
+	for index := range versions {
+		if errs[index] != nil {
+			continue
+		}
+		key := versionsKey(versions[index])
+		counts[key]++
+		if counts[key] >= readQuorum {
+			return versions[index], nil
+		}
+	}
+	return nil, toObjectErr(errErasureReadQuorum, bucket, object)
+}
+
+// versionsKey identifies a list of versions by their version IDs and
+// modification times.
+func versionsKey(versions []FileInfo) string {
+	var sb strings.Builder
+	for _, version := range versions {
+		sb.WriteString(version.VersionID)
+		sb.WriteString(strconv.FormatInt(version.ModTime.UnixNano(), 10))
+	}
+	return sb.String()
+}
+
+// renameObjectDir moves the data directories and then the `xl.meta` of an
+// object on a drive, nested objects under the source name are left in place.
+func renameObjectDir(ctx context.Context, disk StorageAPI, bucket, srcObject, dstObject string, dataDirs []string) error {
+	for _, dataDir := range dataDirs {
+		err := disk.RenameFile(ctx, bucket, retainSlash(pathJoin(srcObject, dataDir)), bucket, retainSlash(pathJoin(dstObject, dataDir)))
+		// Inlined versions have no data directory.
+		if err != nil && err != errFileNotFound {
+			return err
+		}
+	}
+	return disk.RenameFile(ctx, bucket, pathJoin(srcObject, xlStorageFormatFile), bucket, pathJoin(dstObject, xlStorageFormatFile))
+}
+
 func (er erasureObjects) deleteObjectVersion(ctx context.Context, bucket, object string, writeQuorum int, fi FileInfo, forceDelMarker bool) error {
 	disks := er.getDisks()
 	g := errgroup.WithNErrs(len(disks))
@@ -1243,13 +1670,15 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 	}
 
 	// Acquire a write lock before deleting the object.
-	lk := er.NewNSLock(bucket, object)
-	lkctx, err := lk.GetLock(ctx, globalDeleteOperationTimeout)
-	if err != nil {
-		return ObjectInfo{}, err
+	if !opts.NoLock {
+		lk := er.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalDeleteOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
 	}
-	ctx = lkctx.Context()
-	defer lk.Unlock(lkctx.Cancel)
 
 	versionFound := true
 	objInfo = ObjectInfo{VersionID: opts.VersionID} // version id needed in Delete API response.
@@ -1370,6 +1799,11 @@ func (er erasureObjects) DeleteObject(ctx context.Context, bucket, object string
 		ExpireRestored:                opts.Transition.ExpireRestored,
 	}
 	dfi.SetTierFreeVersionID(fvID)
+	if opts.DataMovement {
+		// Tiered content of this version is now referenced by
+		// the copy in another pool, it must not be freed.
+		dfi.SetSkipTierFreeVersion()
+	}
 	if err = er.deleteObjectVersion(ctx, bucket, object, writeQuorum, dfi, opts.DeleteMarker); err != nil {
 		return objInfo, toObjectErr(err, bucket, object)
 	}
@@ -1606,10 +2040,12 @@ func (er erasureObjects) TransitionObject(ctx context.Context, bucket, object st
 		pw.CloseWithError(err)
 	}()
 
+	globalTierMetrics.transitionStarted(opts.Transition.Tier)
 	var rv remoteVersionID
 	rv, err = tgtClient.Put(ctx, destObj, pr, fi.Size)
 	pr.CloseWithError(err)
 	if err != nil {
+		globalTierMetrics.transitionDone(opts.Transition.Tier, err)
 		logger.LogIf(ctx, fmt.Errorf("Unable to transition %s/%s(%s) to %s tier: %w", bucket, object, opts.VersionID, opts.Transition.Tier, err))
 		return err
 	}
@@ -1630,6 +2066,7 @@ func (er erasureObjects) TransitionObject(ctx context.Context, bucket, object st
 	if err = er.deleteObjectVersion(ctx, bucket, object, writeQuorum, fi, false); err != nil {
 		eventName = event.ObjectTransitionFailed
 	}
+	globalTierMetrics.transitionDone(opts.Transition.Tier, err)
 
 	for _, disk := range storageDisks {
 		if disk != nil && disk.IsOnline() {
@@ -1685,6 +2122,9 @@ func (er erasureObjects) updateRestoreMetadata(ctx context.Context, bucket, obje
 func (er erasureObjects) restoreTransitionedObject(ctx context.Context, bucket string, object string, opts ObjectOptions) error {
 	setRestoreHeaderFn := func(oi ObjectInfo, rerr error) error {
 		er.updateRestoreMetadata(ctx, bucket, object, oi, opts, rerr)
+		if oi.TransitionedObject.Tier != "" {
+			globalTierMetrics.restoreDone(oi.TransitionedObject.Tier, rerr)
+		}
 		return rerr
 	}
 	var oi ObjectInfo
@@ -1749,3 +2189,218 @@ func (er erasureObjects) restoreTransitionedObject(ctx context.Context, bucket s
 		MTime: oi.ModTime})
 	return setRestoreHeaderFn(oi, err)
 }
+
+// MoveTransitionedObject - moves the content of a transitioned object version
+// from fromTier to toTier, or back into this cluster if toTier is empty. The
+// transition metadata of the version is updated in place, its version ID,
+// modification time and ETag are preserved. Versions not transitioned to
+// fromTier are left untouched.
+func (er erasureObjects) MoveTransitionedObject(ctx context.Context, bucket, object, fromTier, toTier string, opts ObjectOptions) error {
+	srcClient, err := globalTierConfigMgr.getDriver(fromTier)
+	if err != nil {
+		return err
+	}
+
+	lk := er.NewNSLock(bucket, object)
+	lkctx, err := lk.GetLock(ctx, globalDeleteOperationTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	fi, _, _, err := er.getObjectFileInfo(ctx, bucket, object, opts, false)
+	if err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	if fi.Deleted || fi.TransitionStatus != lifecycle.TransitionComplete || fi.TransitionTier != fromTier {
+		return nil
+	}
+	defer NSUpdated(bucket, object)
+
+	r, err := srcClient.Get(ctx, fi.TransitionedObjName, remoteVersionID(fi.TransitionVersionID), WarmBackendGetOpts{})
+	if err != nil {
+		return err
+	}
+	if toTier != "" {
+		err = er.retransitionObject(ctx, bucket, object, fi, r, toTier)
+	} else {
+		err = er.recallTransitionedObject(ctx, bucket, object, fi, r)
+	}
+	r.Close()
+	if err != nil {
+		logger.LogIf(ctx, fmt.Errorf("Unable to move %s/%s(%s) from %s tier: %w", bucket, object, fi.VersionID, fromTier, err))
+		return err
+	}
+
+	// The version no longer references its content on fromTier.
+	if err = deleteObjectFromRemoteTier(ctx, fi.TransitionedObjName, fi.TransitionVersionID, fromTier); err != nil {
+		logger.LogIf(ctx, globalTierJournal.AddEntry(jentry{
+			ObjName:   fi.TransitionedObjName,
+			VersionID: fi.TransitionVersionID,
+			TierName:  fromTier,
+		}))
+	}
+	return nil
+}
+
+// retransitionObject uploads the tiered content of fi read from r to toTier
+// and points the version at it. The caller must hold the object write lock.
+func (er erasureObjects) retransitionObject(ctx context.Context, bucket, object string, fi FileInfo, r io.Reader, toTier string) error {
+	tgtClient, err := globalTierConfigMgr.getDriver(toTier)
+	if err != nil {
+		return err
+	}
+	destObj, err := genTransitionObjName(bucket)
+	if err != nil {
+		return err
+	}
+	globalTierMetrics.transitionStarted(toTier)
+	rv, err := tgtClient.Put(ctx, destObj, r, fi.Size)
+	if err != nil {
+		globalTierMetrics.transitionDone(toTier, err)
+		return err
+	}
+
+	// Only the transition metadata of this version is updated.
+	err = er.updateObjectMeta(ctx, bucket, object, FileInfo{
+		VersionID: fi.VersionID,
+		Metadata: map[string]string{
+			ReservedMetadataPrefixLower + TransitionTier:         toTier,
+			ReservedMetadataPrefixLower + TransitionedObjectName: destObj,
+			ReservedMetadataPrefixLower + TransitionedVersionID:  string(rv),
+		},
+	})
+	globalTierMetrics.transitionDone(toTier, err)
+	if err != nil {
+		logger.LogIf(ctx, tgtClient.Remove(ctx, destObj, rv))
+		return toObjectErr(err, bucket, object)
+	}
+	return nil
+}
+
+// recallTransitionedObject writes the tiered content of fi read from r back
+// into this cluster, replacing the transitioned version with a regular one.
+// The caller must hold the object write lock.
+func (er erasureObjects) recallTransitionedObject(ctx context.Context, bucket, object string, fi FileInfo, r io.Reader) error {
+	meta := make(map[string]string, len(fi.Metadata)+1)
+	for k, v := range fi.Metadata {
+		switch k {
+		case xhttp.AmzRestore, xhttp.AmzRestoreExpiryDays, xhttp.AmzRestoreRequestDate,
+			ReservedMetadataPrefixLower + TransitionStatus,
+			ReservedMetadataPrefixLower + TransitionTier,
+			ReservedMetadataPrefixLower + TransitionedObjectName,
+			ReservedMetadataPrefixLower + TransitionedVersionID:
+			continue
+		}
+		meta[k] = v
+	}
+	// The caller removes the tiered content once the version is replaced.
+	meta[ReservedMetadataPrefixLower+tierSkipFVID] = ""
+
+	ropts := ObjectOptions{
+		VersionID:   fi.VersionID,
+		MTime:       fi.ModTime,
+		UserDefined: meta,
+		NoLock:      true,
+	}
+	if len(fi.Parts) <= 1 {
+		actualSize := fi.Size
+		if len(fi.Parts) == 1 && fi.Parts[0].ActualSize > 0 {
+			actualSize = fi.Parts[0].ActualSize
+		}
+		hr, err := hash.NewReader(r, fi.Size, "", "", actualSize)
+		if err != nil {
+			return err
+		}
+		_, err = er.PutObject(ctx, bucket, object, NewPutObjReader(hr), ropts)
+		return err
+	}
+
+	// Rehydrate the parts as per the original xl.meta, see restoreTransitionedObject.
+	uploadID, err := er.NewMultipartUpload(ctx, bucket, object, ropts)
+	if err != nil {
+		return err
+	}
+	uploadedParts := make([]CompletePart, 0, len(fi.Parts))
+	for _, partInfo := range fi.Parts {
+		hr, err := hash.NewReader(r, partInfo.Size, "", "", partInfo.ActualSize)
+		if err == nil {
+			var pInfo PartInfo
+			pInfo, err = er.PutObjectPart(ctx, bucket, object, uploadID, partInfo.Number, NewPutObjReader(hr), ObjectOptions{})
+			if err == nil && pInfo.Size != partInfo.Size {
+				err = InvalidObjectState{Bucket: bucket, Object: object}
+			}
+			uploadedParts = append(uploadedParts, CompletePart{
+				PartNumber: pInfo.PartNumber,
+				ETag:       pInfo.ETag,
+			})
+		}
+		if err != nil {
+			logger.LogIf(ctx, er.AbortMultipartUpload(ctx, bucket, object, uploadID, ObjectOptions{}))
+			return err
+		}
+	}
+	_, err = er.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, ObjectOptions{
+		MTime:       fi.ModTime,
+		UserDefined: map[string]string{"etag": fi.Metadata["etag"]},
+		NoLock:      true,
+	})
+	return err
+}
+
+// DecomTieredObject - writes the metadata of an object version whose content
+// lives on a remote tier, used while moving objects between pools. Only
+// `xl.meta` is written, the remote tier content is left untouched.
+func (er erasureObjects) DecomTieredObject(ctx context.Context, bucket, object string, fi FileInfo, opts ObjectOptions) error {
+	if !opts.NoLock {
+		lk := er.NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+	}
+	defer NSUpdated(bucket, object)
+
+	storageDisks := er.getDisks()
+
+	// Use the parity of this set, the source pool may have a
+	// different erasure layout.
+	parityDrives := globalStorageClass.GetParityForSC(fi.Metadata[xhttp.AmzStorageClass])
+	if parityDrives <= 0 {
+		parityDrives = er.defaultParityCount
+	}
+	dataDrives := len(storageDisks) - parityDrives
+
+	// we now know the number of blocks this object needs for data and parity.
+	// writeQuorum is dataBlocks + 1
+	writeQuorum := dataDrives
+	if dataDrives == parityDrives {
+		writeQuorum++
+	}
+
+	nfi := newFileInfo(pathJoin(bucket, object), dataDrives, parityDrives)
+	nfi.VersionID = fi.VersionID
+	nfi.ModTime = fi.ModTime
+	nfi.Size = fi.Size
+	nfi.Metadata = fi.Metadata
+	nfi.Parts = fi.Parts
+	nfi.TransitionStatus = fi.TransitionStatus
+	nfi.TransitionedObjName = fi.TransitionedObjName
+	nfi.TransitionTier = fi.TransitionTier
+	nfi.TransitionVersionID = fi.TransitionVersionID
+
+	partsMetadata := make([]FileInfo, len(storageDisks))
+	for index := range partsMetadata {
+		partsMetadata[index] = nfi
+	}
+
+	// Order disks according to erasure distribution
+	onlineDisks, partsMetadata := shuffleDisksAndPartsMetadata(storageDisks, partsMetadata, nfi)
+	if _, err := writeUniqueFileInfo(ctx, onlineDisks, bucket, object, partsMetadata, writeQuorum); err != nil {
+		return toObjectErr(err, bucket, object)
+	}
+	return nil
+}
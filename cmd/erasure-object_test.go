@@ -26,9 +26,11 @@ import (
 	"io/ioutil"
 	"os"
 	"strconv"
+	"strings"
 	"testing"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/madmin-go"
 	"github.com/minio/minio/internal/config/storageclass"
 )
 
@@ -802,6 +804,313 @@ func TestPutObjectSmallInlineData(t *testing.T) {
 	}
 }
 
+// Tests that objects are protected with the configured bitrot algorithm
+// and remain readable and healable after the algorithm changes.
+func TestPutObjectBitrotAlgorithm(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	oldStorageClass := globalStorageClass
+	defer globalStorageClass.Update(oldStorageClass)
+
+	bucket := "bucket"
+	object := "object"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	opts := ObjectOptions{Versioned: true}
+	contents := make(map[string][]byte)
+	algos := make(map[string]BitrotAlgorithm)
+	putObject := func(size int, algo BitrotAlgorithm) {
+		t.Helper()
+		data := bytes.Repeat([]byte{byte('a' + len(contents))}, size)
+		oi, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(size), "", ""), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		contents[oi.VersionID] = data
+		algos[oi.VersionID] = algo
+	}
+
+	// An upload started with the default algorithm keeps it.
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, object, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	putObject(4*humanize.MiByte, HighwayHash256S)
+
+	globalStorageClass.Update(storageclass.Config{Bitrot: storageclass.BitrotBLAKE3S})
+	putObject(4*humanize.MiByte, BLAKE3256S)
+	putObject(1024, BLAKE3256S)
+
+	partData := bytes.Repeat([]byte{'z'}, 4*humanize.MiByte)
+	pi, err := obj.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(partData), int64(len(partData)), "", ""), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	oi, err := obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, []CompletePart{{PartNumber: 1, ETag: pi.ETag}}, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, ok := oi.UserDefined[uploadBitrotAlgorithmKey]; ok {
+		t.Fatalf("Expected %s to be removed from the object metadata", uploadBitrotAlgorithmKey)
+	}
+	contents[oi.VersionID] = partData
+	algos[oi.VersionID] = HighwayHash256S
+
+	// Remove all versions from a drive and heal them back.
+	if err = os.RemoveAll(pathJoin(fsDirs[0], bucket, object)); err != nil {
+		t.Fatal(err)
+	}
+	globalStorageClass.Update(storageclass.Config{})
+
+	disks := obj.(*erasureServerPools).serverPools[0].sets[0].getDisks()
+	for versionID, want := range contents {
+		if _, err = obj.HealObject(ctx, bucket, object, versionID, madmin.HealOpts{ScanMode: madmin.HealNormalScan}); err != nil {
+			t.Fatal(err)
+		}
+		fis, errs := readAllFileInfo(ctx, disks, bucket, object, versionID, false)
+		for i := range fis {
+			if errs[i] != nil {
+				t.Fatalf("Expected version %s on drive %d, got %v", versionID, i, errs[i])
+			}
+			if got := fis[i].Erasure.GetChecksumInfo(1).Algorithm; got != algos[versionID] {
+				t.Fatalf("Expected version %s on drive %d to use %s, got %s", versionID, i, algos[versionID], got)
+			}
+		}
+
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, want) {
+			t.Fatalf("Corrupted data found for version %s", versionID)
+		}
+	}
+}
+
+func TestAppendObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	opts := ObjectOptions{Versioned: true}
+
+	checkObject := func(object, versionID string, want []byte) ObjectInfo {
+		t.Helper()
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, want) {
+			t.Fatalf("Unexpected content of %s version %s", object, versionID)
+		}
+		if gr.ObjInfo.Size != int64(len(want)) {
+			t.Fatalf("Expected %s to be of size %d, got %d", object, len(want), gr.ObjInfo.Size)
+		}
+		return gr.ObjInfo
+	}
+
+	testCases := []struct {
+		object  string
+		sizes   []int
+		etagSfx string
+	}{
+		// Inlined objects are written again as a whole.
+		{"small", []int{1024, 1024}, ""},
+		// Inlined objects outgrowing the inline limit.
+		{"grown", []int{1024, 4 * humanize.MiByte}, ""},
+		// Each append adds a part.
+		{"large", []int{4 * humanize.MiByte, humanize.MiByte, 1024}, "-3"},
+	}
+	for i, testCase := range testCases {
+		oldData := bytes.Repeat([]byte{'o'}, 100)
+		old, err := obj.PutObject(ctx, bucket, testCase.object, mustGetPutObjReader(t, bytes.NewReader(oldData), int64(len(oldData)), "", ""), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+
+		var content []byte
+		var versionID string
+		for j, size := range testCase.sizes {
+			data := bytes.Repeat([]byte{byte('a' + j)}, size)
+			r := mustGetPutObjReader(t, bytes.NewReader(data), int64(size), "", "")
+			var oi ObjectInfo
+			if j == 0 {
+				oi, err = obj.PutObject(ctx, bucket, testCase.object, r, opts)
+				versionID = oi.VersionID
+			} else {
+				oi, err = obj.AppendObject(ctx, bucket, testCase.object, int64(len(content)), r, opts)
+			}
+			if err != nil {
+				t.Fatalf("Test %d: append %d: %v", i+1, j, err)
+			}
+			content = append(content, data...)
+			if oi.VersionID != versionID || oi.Size != int64(len(content)) {
+				t.Fatalf("Test %d: Expected version %s of size %d, got %s of size %d", i+1, versionID, len(content), oi.VersionID, oi.Size)
+			}
+		}
+		oi := checkObject(testCase.object, "", content)
+		if !strings.HasSuffix(oi.ETag, testCase.etagSfx) || (testCase.etagSfx == "" && oi.ETag != getMD5Hash(content)) {
+			t.Fatalf("Test %d: Unexpected ETag %s", i+1, oi.ETag)
+		}
+		checkObject(testCase.object, old.VersionID, oldData)
+
+		// The offset must match the size of the object.
+		_, err = obj.AppendObject(ctx, bucket, testCase.object, int64(len(content)-1), mustGetPutObjReader(t, bytes.NewReader([]byte("x")), 1, "", ""), opts)
+		if _, ok := err.(InvalidWriteOffset); !ok {
+			t.Fatalf("Test %d: Expected InvalidWriteOffset, got %v", i+1, err)
+		}
+
+		// Appended parts are healed like any other part.
+		if err = os.RemoveAll(pathJoin(fsDirs[0], bucket, testCase.object)); err != nil {
+			t.Fatal(err)
+		}
+		if _, err = obj.HealObject(ctx, bucket, testCase.object, versionID, madmin.HealOpts{ScanMode: madmin.HealDeepScan}); err != nil {
+			t.Fatal(err)
+		}
+		disks := obj.(*erasureServerPools).serverPools[0].sets[0].getDisks()
+		fis, errs := readAllFileInfo(ctx, disks, bucket, testCase.object, versionID, false)
+		for j := range fis {
+			if errs[j] != nil || fis[j].Size != int64(len(content)) {
+				t.Fatalf("Test %d: Expected healed version on drive %d, got %v", i+1, j, errs[j])
+			}
+		}
+		checkObject(testCase.object, "", content)
+	}
+
+	// Nothing can be appended to a missing object or a delete marker.
+	if _, err = obj.AppendObject(ctx, bucket, "missing", 0, mustGetPutObjReader(t, bytes.NewReader([]byte("x")), 1, "", ""), opts); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected ObjectNotFound, got %v", err)
+	}
+	if _, err = obj.DeleteObject(ctx, bucket, "small", opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.AppendObject(ctx, bucket, "small", 0, mustGetPutObjReader(t, bytes.NewReader([]byte("x")), 1, "", ""), opts); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected ObjectNotFound, got %v", err)
+	}
+}
+
+func TestRenameObject(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+	opts := ObjectOptions{Versioned: true}
+
+	putObject := func(object string, data []byte) ObjectInfo {
+		t.Helper()
+		objInfo, err := obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return objInfo
+	}
+	checkObject := func(object, versionID string, want []byte) {
+		t.Helper()
+		gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, nil, readLock, ObjectOptions{VersionID: versionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, want) {
+			t.Fatalf("Unexpected content of %s version %s", object, versionID)
+		}
+	}
+
+	// An inlined version, a version with a data directory and an object nested below.
+	inlined := bytes.Repeat([]byte{'a'}, 1024)
+	large := bytes.Repeat([]byte{'b'}, 4*humanize.MiByte)
+	nested := bytes.Repeat([]byte{'c'}, 1024)
+	v1 := putObject("dir/object", inlined)
+	v2 := putObject("dir/object", large)
+	putObject("dir/object/nested", nested)
+
+	// Checking a rename leaves the object in place.
+	if _, err := obj.RenameObject(ctx, bucket, "dir/object", "new/object", ObjectOptions{RenameCheck: true}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.GetObjectInfo(ctx, bucket, "new/object", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected the object to not be renamed, got %v", err)
+	}
+
+	objInfo, err := obj.RenameObject(ctx, bucket, "dir/object", "new/object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if objInfo.Name != "new/object" || objInfo.VersionID != v2.VersionID {
+		t.Fatalf("Unexpected object info %s version %s", objInfo.Name, objInfo.VersionID)
+	}
+	checkObject("new/object", v1.VersionID, inlined)
+	checkObject("new/object", v2.VersionID, large)
+	checkObject("dir/object/nested", "", nested)
+	if _, err = obj.GetObjectInfo(ctx, bucket, "dir/object", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected the source to be gone, got %v", err)
+	}
+
+	putObject("exists", inlined)
+	_, err = obj.RenameObject(ctx, bucket, "new/object", "exists", ObjectOptions{})
+	if _, ok := err.(ObjectAlreadyExists); !ok {
+		t.Fatalf("Expected ObjectAlreadyExists, got %v", err)
+	}
+	checkObject("new/object", v2.VersionID, large)
+
+	if _, err = obj.RenameObject(ctx, bucket, "missing", "other", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected ObjectNotFound, got %v", err)
+	}
+
+	// Objects whose latest version is a delete marker keep their history.
+	if _, err = obj.DeleteObject(ctx, bucket, "new/object", opts); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = obj.RenameObject(ctx, bucket, "new/object", "final", ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	checkObject("final", v1.VersionID, inlined)
+	checkObject("final", v2.VersionID, large)
+}
+
 func TestObjectQuorumFromMeta(t *testing.T) {
 	ExecObjectLayerTestWithDirs(t, testObjectQuorumFromMeta)
 }
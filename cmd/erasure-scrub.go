@@ -0,0 +1,454 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/config/scrub"
+	"github.com/minio/minio/internal/logger"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore scrubConfig scrubThrottle
+
+const (
+	scrubMetaPrefix  = "scrub"
+	scrubMetaFmt     = 1
+	scrubMetaVerV1   = 1
+	scrubMetaVersion = scrubMetaVerV1
+
+	// Maximum number of corrupted object versions listed in a scrub
+	// report, further corrupted versions are only counted.
+	scrubReportMaxObjects = 10000
+
+	// Interval at which the erasure sets are checked for a due scrub.
+	scrubCheckInterval = time.Minute
+)
+
+// scrubCorruption is an object version with corrupted or missing shards
+// found by scrub.
+type scrubCorruption struct {
+	Bucket    string    `msg:"bu" json:"bucket"`
+	Object    string    `msg:"ob" json:"object"`
+	VersionID string    `msg:"vid" json:"versionId,omitempty"`
+	Drives    []string  `msg:"dr" json:"drives"` // Drives with corrupted or missing shards
+	Healed    bool      `msg:"he" json:"healed"`
+	Error     string    `msg:"err" json:"error,omitempty"` // Heal error, if any
+	Time      time.Time `msg:"t" json:"time"`
+}
+
+// scrubReport is the outcome of one scrub of an erasure set.
+type scrubReport struct {
+	Started   time.Time         `msg:"st" json:"started"`
+	Finished  time.Time         `msg:"ft" json:"finished"`
+	Versions  uint64            `msg:"nv" json:"versions"`  // Number of object versions verified
+	Bytes     uint64            `msg:"bs" json:"bytes"`     // Number of shard bytes verified
+	Corrupted uint64            `msg:"nc" json:"corrupted"` // Number of object versions with bad shards
+	Healed    uint64            `msg:"nh" json:"healed"`    // Number of those healed
+	Objects   []scrubCorruption `msg:"obs" json:"objects,omitempty"`
+}
+
+func (r *scrubReport) addCorruption(c scrubCorruption) {
+	r.Corrupted++
+	if c.Healed {
+		r.Healed++
+	}
+	if len(r.Objects) < scrubReportMaxObjects {
+		r.Objects = append(r.Objects, c)
+	}
+}
+
+// scrubSetState is the scrub progress of an erasure set, persisted in the
+// meta bucket such that an interrupted scrub resumes where it stopped.
+type scrubSetState struct {
+	Pool    int         `msg:"p" json:"pool"`
+	Set     int         `msg:"s" json:"set"`
+	Bucket  string      `msg:"bu" json:"bucket,omitempty"` // Bucket being scrubbed
+	Object  string      `msg:"ob" json:"object,omitempty"` // Last object scrubbed
+	Current scrubReport `msg:"cur" json:"current"`         // Scrub in progress, if started
+	Last    scrubReport `msg:"last" json:"last"`           // Last completed scrub, if any
+}
+
+func scrubStatePath(poolIdx, setIdx int) string {
+	return pathJoin(scrubMetaPrefix, fmt.Sprintf("pool-%d-set-%d.bin", poolIdx, setIdx))
+}
+
+// load reads the scrub state of an erasure set from store.
+func (s *scrubSetState) load(ctx context.Context, store objectIO, poolIdx, setIdx int) error {
+	data, err := readConfig(ctx, store, scrubStatePath(poolIdx, setIdx))
+	if err != nil {
+		return err
+	}
+	if len(data) <= 4 {
+		return fmt.Errorf("scrubSetState: no data")
+	}
+
+	// Read header
+	switch binary.LittleEndian.Uint16(data[0:2]) {
+	case scrubMetaFmt:
+	default:
+		return fmt.Errorf("scrubSetState: unknown format: %d", binary.LittleEndian.Uint16(data[0:2]))
+	}
+	switch binary.LittleEndian.Uint16(data[2:4]) {
+	case scrubMetaVersion:
+	default:
+		return fmt.Errorf("scrubSetState: unknown version: %d", binary.LittleEndian.Uint16(data[2:4]))
+	}
+
+	// OK, parse data.
+	_, err = s.UnmarshalMsg(data[4:])
+	return err
+}
+
+// save writes the scrub state of an erasure set to store.
+func (s *scrubSetState) save(ctx context.Context, store objectIO) error {
+	data := make([]byte, 4, s.Msgsize()+4)
+
+	// Initialize the header.
+	binary.LittleEndian.PutUint16(data[0:2], scrubMetaFmt)
+	binary.LittleEndian.PutUint16(data[2:4], scrubMetaVersion)
+
+	buf, err := s.MarshalMsg(data)
+	if err != nil {
+		return err
+	}
+
+	return saveConfig(ctx, store, scrubStatePath(s.Pool, s.Set), buf)
+}
+
+// scrubConfig holds the dynamic scrub settings.
+type scrubConfig struct {
+	mu  sync.RWMutex
+	cfg scrub.Config
+}
+
+// Update updates the scrub settings.
+func (c *scrubConfig) Update(cfg scrub.Config) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.cfg = cfg
+}
+
+// Get returns the current scrub settings.
+func (c *scrubConfig) Get() scrub.Config {
+	c.mu.RLock()
+	defer c.mu.RUnlock()
+	return c.cfg
+}
+
+// scrubThrottle limits the number of bytes verified per second by all
+// scrubs running on this node.
+type scrubThrottle struct {
+	mu   sync.Mutex
+	next time.Time
+}
+
+// wait blocks until n more bytes may be verified at a rate of bps bytes
+// per second.
+func (t *scrubThrottle) wait(ctx context.Context, n int64, bps uint64) error {
+	t.mu.Lock()
+	now := time.Now()
+	if t.next.Before(now) {
+		t.next = now
+	}
+	delay := t.next.Sub(now)
+	t.next = t.next.Add(time.Duration(float64(n) / float64(bps) * float64(time.Second)))
+	t.mu.Unlock()
+
+	if delay <= 0 {
+		return nil
+	}
+	timer := time.NewTimer(delay)
+	defer timer.Stop()
+	select {
+	case <-ctx.Done():
+		return ctx.Err()
+	case <-timer.C:
+		return nil
+	}
+}
+
+var (
+	globalScrubConfig = &scrubConfig{cfg: scrub.Config{
+		Bandwidth: 10 << 20,
+		Interval:  30 * 24 * time.Hour,
+	}}
+	globalScrubThrottle = &scrubThrottle{}
+)
+
+// initBackgroundScrub starts the scheduled scrub of the erasure sets
+// whose first drive is local to this node.
+func initBackgroundScrub(ctx context.Context, objAPI ObjectLayer) {
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		return
+	}
+	for poolIdx, pool := range z.serverPools {
+		for setIdx := range pool.sets {
+			if !pool.endpoints[setIdx*pool.setDriveCount].IsLocal {
+				continue
+			}
+			go z.scrubSetLoop(ctx, poolIdx, setIdx)
+		}
+	}
+}
+
+// scrubSetLoop scrubs an erasure set whenever its scrub is due, until ctx
+// is canceled.
+func (z *erasureServerPools) scrubSetLoop(ctx context.Context, poolIdx, setIdx int) {
+	r := rand.New(rand.NewSource(time.Now().UnixNano()))
+	timer := time.NewTimer(time.Duration(r.Float64() * float64(scrubCheckInterval)))
+	defer timer.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-timer.C:
+		}
+
+		cfg := globalScrubConfig.Get()
+		if cfg.Enabled {
+			state := &scrubSetState{Pool: poolIdx, Set: setIdx}
+			err := state.load(ctx, z, poolIdx, setIdx)
+			switch {
+			case err != nil && !errors.Is(err, errConfigNotFound):
+				logger.LogIf(ctx, err)
+			case !state.Current.Started.IsZero(), state.Last.Finished.IsZero(),
+				time.Since(state.Last.Finished) >= cfg.Interval:
+				logger.LogIf(ctx, z.scrubSet(ctx, state))
+			}
+		}
+		timer.Reset(scrubCheckInterval)
+	}
+}
+
+// scrubSet verifies the shards of all object versions of an erasure set,
+// starting or resuming the scrub recorded in state. The scrub stops early,
+// keeping its progress, when ctx is canceled or scrub gets disabled.
+func (z *erasureServerPools) scrubSet(ctx context.Context, state *scrubSetState) error {
+	set := z.serverPools[state.Pool].sets[state.Set]
+	if state.Current.Started.IsZero() {
+		state.Current = scrubReport{Started: UTCNow()}
+		state.Bucket, state.Object = "", ""
+	}
+
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	sort.Slice(buckets, func(i, j int) bool {
+		return buckets[i].Name < buckets[j].Name
+	})
+
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	lastSave := time.Now()
+	for _, bi := range buckets {
+		if bi.Name < state.Bucket {
+			continue
+		}
+		forwardTo := ""
+		if bi.Name == state.Bucket {
+			forwardTo = state.Object
+		} else {
+			state.Bucket, state.Object = bi.Name, ""
+		}
+
+		scrubEntry := func(entry metaCacheEntry) {
+			if entry.isDir() || ctx.Err() != nil {
+				return
+			}
+			// forwardTo is inclusive, the saved object was scrubbed already.
+			if forwardTo != "" && entry.name == forwardTo {
+				return
+			}
+			cfg := globalScrubConfig.Get()
+			if !cfg.Enabled {
+				cancel()
+				return
+			}
+			fivs, err := entry.fileInfoVersions(bi.Name)
+			if err != nil {
+				return
+			}
+			for _, version := range fivs.Versions {
+				if err = set.scrubVersion(ctx, bi.Name, version, &state.Current, cfg.Bandwidth); err != nil {
+					return
+				}
+			}
+			state.Object = entry.name
+			if time.Since(lastSave) > 30*time.Second {
+				logger.LogIf(ctx, state.save(ctx, z))
+				lastSave = time.Now()
+			}
+		}
+
+		disks, _ := set.getOnlineDisksWithHealing()
+		if len(disks) == 0 {
+			return fmt.Errorf("no online disks found for set with endpoints %s", set.getEndpoints())
+		}
+
+		// How to resolve partial results.
+		resolver := metadataResolutionParams{
+			dirQuorum: 1,
+			objQuorum: 1,
+			bucket:    bi.Name,
+		}
+
+		err = listPathRaw(ctx, listPathRawOptions{
+			disks:          disks,
+			bucket:         bi.Name,
+			recursive:      true,
+			forwardTo:      forwardTo,
+			minDisks:       1,
+			reportNotFound: false,
+			agreed:         scrubEntry,
+			partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+				entry, ok := entries.resolve(&resolver)
+				if !ok {
+					entry, _ = entries.firstFound()
+				}
+				if entry != nil {
+					scrubEntry(*entry)
+				}
+			},
+			finished: nil,
+		})
+		if ctx.Err() != nil {
+			// Keep the progress made, the scrub is resumed later.
+			return state.save(GlobalContext, z)
+		}
+		if err != nil && !errors.Is(err, errVolumeNotFound) {
+			logger.LogIf(ctx, state.save(ctx, z))
+			return err
+		}
+	}
+
+	state.Current.Finished = UTCNow()
+	state.Last = state.Current
+	state.Current = scrubReport{}
+	state.Bucket, state.Object = "", ""
+	return state.save(ctx, z)
+}
+
+// scrubVersion verifies the bitrot checksums of the shards of an object
+// version on all drives of the set, and heals the version when shards are
+// corrupted or missing. Findings are recorded in report.
+func (er erasureObjects) scrubVersion(ctx context.Context, bucket string, version FileInfo, report *scrubReport, bandwidth uint64) error {
+	if version.Deleted || version.IsRemote() {
+		return nil
+	}
+
+	shardSize := version.Erasure.ShardFileSize(version.Size)
+	if err := globalScrubThrottle.wait(ctx, shardSize*int64(len(version.Erasure.Distribution)), bandwidth); err != nil {
+		return err
+	}
+
+	lk := er.NewNSLock(bucket, version.Name)
+	lkctx, err := lk.GetRLock(ctx, globalOperationTimeout)
+	if err != nil {
+		// The object is busy, it is verified in the next scrub.
+		return nil
+	}
+	lctx := lkctx.Context()
+
+	disks := er.getDisks()
+	partsMetadata, errs := readAllFileInfo(lctx, disks, bucket, version.Name, version.VersionID, true)
+	var drives []string
+	for i, disk := range disks {
+		if disk == nil {
+			continue
+		}
+		err := errs[i]
+		if err == nil {
+			meta := partsMetadata[i]
+			if meta.Deleted || meta.IsRemote() {
+				continue
+			}
+			if (len(meta.Data) > 0 || meta.Size == 0) && len(meta.Parts) > 0 {
+				checksumInfo := meta.Erasure.GetChecksumInfo(meta.Parts[0].Number)
+				err = bitrotVerify(bytes.NewReader(meta.Data),
+					int64(len(meta.Data)),
+					meta.Erasure.ShardFileSize(meta.Size),
+					checksumInfo.Algorithm,
+					checksumInfo.Hash, meta.Erasure.ShardSize())
+			} else {
+				err = disk.VerifyFile(lctx, bucket, version.Name, meta)
+			}
+			if err == nil {
+				report.Bytes += uint64(meta.Erasure.ShardFileSize(meta.Size))
+			}
+		}
+		switch err {
+		case errFileCorrupt, errFileNotFound, errFileVersionNotFound:
+			drives = append(drives, disk.String())
+		}
+	}
+	lk.RUnlock(lkctx.Cancel)
+	report.Versions++
+
+	if len(drives) == 0 {
+		return nil
+	}
+
+	_, err = er.HealObject(ctx, bucket, version.Name, version.VersionID, madmin.HealOpts{
+		ScanMode: madmin.HealDeepScan,
+		Remove:   healDeleteDangling,
+	})
+	c := scrubCorruption{
+		Bucket:    bucket,
+		Object:    version.Name,
+		VersionID: version.VersionID,
+		Drives:    drives,
+		Healed:    err == nil,
+		Time:      UTCNow(),
+	}
+	if err != nil {
+		c.Error = err.Error()
+	}
+	report.addCorruption(c)
+	return nil
+}
+
+// loadScrubStates returns the scrub state of all erasure sets of the
+// cluster, sets which were never scrubbed have an empty state.
+func (z *erasureServerPools) loadScrubStates(ctx context.Context) ([]scrubSetState, error) {
+	var states []scrubSetState
+	for poolIdx, pool := range z.serverPools {
+		for setIdx := range pool.sets {
+			state := scrubSetState{Pool: poolIdx, Set: setIdx}
+			if err := state.load(ctx, z, poolIdx, setIdx); err != nil && !errors.Is(err, errConfigNotFound) {
+				return nil, err
+			}
+			states = append(states, state)
+		}
+	}
+	return states, nil
+}
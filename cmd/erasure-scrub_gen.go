@@ -0,0 +1,832 @@
+package cmd
+
+// Code generated by github.com/tinylib/msgp DO NOT EDIT.
+
+import (
+	"github.com/tinylib/msgp/msgp"
+)
+
+// DecodeMsg implements msgp.Decodable
+func (z *scrubCorruption) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "bu":
+			z.Bucket, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Bucket")
+				return
+			}
+		case "ob":
+			z.Object, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Object")
+				return
+			}
+		case "vid":
+			z.VersionID, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "VersionID")
+				return
+			}
+		case "dr":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Drives")
+				return
+			}
+			if cap(z.Drives) >= int(zb0002) {
+				z.Drives = (z.Drives)[:zb0002]
+			} else {
+				z.Drives = make([]string, zb0002)
+			}
+			for za0001 := range z.Drives {
+				z.Drives[za0001], err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "Drives", za0001)
+					return
+				}
+			}
+		case "he":
+			z.Healed, err = dc.ReadBool()
+			if err != nil {
+				err = msgp.WrapError(err, "Healed")
+				return
+			}
+		case "err":
+			z.Error, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Error")
+				return
+			}
+		case "t":
+			z.Time, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "Time")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *scrubCorruption) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 7
+	// write "bu"
+	err = en.Append(0x87, 0xa2, 0x62, 0x75)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Bucket)
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	// write "ob"
+	err = en.Append(0xa2, 0x6f, 0x62)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Object)
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	// write "vid"
+	err = en.Append(0xa3, 0x76, 0x69, 0x64)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.VersionID)
+	if err != nil {
+		err = msgp.WrapError(err, "VersionID")
+		return
+	}
+	// write "dr"
+	err = en.Append(0xa2, 0x64, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Drives)))
+	if err != nil {
+		err = msgp.WrapError(err, "Drives")
+		return
+	}
+	for za0001 := range z.Drives {
+		err = en.WriteString(z.Drives[za0001])
+		if err != nil {
+			err = msgp.WrapError(err, "Drives", za0001)
+			return
+		}
+	}
+	// write "he"
+	err = en.Append(0xa2, 0x68, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteBool(z.Healed)
+	if err != nil {
+		err = msgp.WrapError(err, "Healed")
+		return
+	}
+	// write "err"
+	err = en.Append(0xa3, 0x65, 0x72, 0x72)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Error)
+	if err != nil {
+		err = msgp.WrapError(err, "Error")
+		return
+	}
+	// write "t"
+	err = en.Append(0xa1, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.Time)
+	if err != nil {
+		err = msgp.WrapError(err, "Time")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *scrubCorruption) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 7
+	// string "bu"
+	o = append(o, 0x87, 0xa2, 0x62, 0x75)
+	o = msgp.AppendString(o, z.Bucket)
+	// string "ob"
+	o = append(o, 0xa2, 0x6f, 0x62)
+	o = msgp.AppendString(o, z.Object)
+	// string "vid"
+	o = append(o, 0xa3, 0x76, 0x69, 0x64)
+	o = msgp.AppendString(o, z.VersionID)
+	// string "dr"
+	o = append(o, 0xa2, 0x64, 0x72)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Drives)))
+	for za0001 := range z.Drives {
+		o = msgp.AppendString(o, z.Drives[za0001])
+	}
+	// string "he"
+	o = append(o, 0xa2, 0x68, 0x65)
+	o = msgp.AppendBool(o, z.Healed)
+	// string "err"
+	o = append(o, 0xa3, 0x65, 0x72, 0x72)
+	o = msgp.AppendString(o, z.Error)
+	// string "t"
+	o = append(o, 0xa1, 0x74)
+	o = msgp.AppendTime(o, z.Time)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *scrubCorruption) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "bu":
+			z.Bucket, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Bucket")
+				return
+			}
+		case "ob":
+			z.Object, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Object")
+				return
+			}
+		case "vid":
+			z.VersionID, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "VersionID")
+				return
+			}
+		case "dr":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Drives")
+				return
+			}
+			if cap(z.Drives) >= int(zb0002) {
+				z.Drives = (z.Drives)[:zb0002]
+			} else {
+				z.Drives = make([]string, zb0002)
+			}
+			for za0001 := range z.Drives {
+				z.Drives[za0001], bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Drives", za0001)
+					return
+				}
+			}
+		case "he":
+			z.Healed, bts, err = msgp.ReadBoolBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Healed")
+				return
+			}
+		case "err":
+			z.Error, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Error")
+				return
+			}
+		case "t":
+			z.Time, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Time")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *scrubCorruption) Msgsize() (s int) {
+	s = 1 + 3 + msgp.StringPrefixSize + len(z.Bucket) + 3 + msgp.StringPrefixSize + len(z.Object) + 4 + msgp.StringPrefixSize + len(z.VersionID) + 3 + msgp.ArrayHeaderSize
+	for za0001 := range z.Drives {
+		s += msgp.StringPrefixSize + len(z.Drives[za0001])
+	}
+	s += 3 + msgp.BoolSize + 4 + msgp.StringPrefixSize + len(z.Error) + 2 + msgp.TimeSize
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *scrubReport) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "st":
+			z.Started, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "Started")
+				return
+			}
+		case "ft":
+			z.Finished, err = dc.ReadTime()
+			if err != nil {
+				err = msgp.WrapError(err, "Finished")
+				return
+			}
+		case "nv":
+			z.Versions, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Versions")
+				return
+			}
+		case "bs":
+			z.Bytes, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Bytes")
+				return
+			}
+		case "nc":
+			z.Corrupted, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Corrupted")
+				return
+			}
+		case "nh":
+			z.Healed, err = dc.ReadUint64()
+			if err != nil {
+				err = msgp.WrapError(err, "Healed")
+				return
+			}
+		case "obs":
+			var zb0002 uint32
+			zb0002, err = dc.ReadArrayHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "Objects")
+				return
+			}
+			if cap(z.Objects) >= int(zb0002) {
+				z.Objects = (z.Objects)[:zb0002]
+			} else {
+				z.Objects = make([]scrubCorruption, zb0002)
+			}
+			for za0001 := range z.Objects {
+				err = z.Objects[za0001].DecodeMsg(dc)
+				if err != nil {
+					err = msgp.WrapError(err, "Objects", za0001)
+					return
+				}
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *scrubReport) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 7
+	// write "st"
+	err = en.Append(0x87, 0xa2, 0x73, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.Started)
+	if err != nil {
+		err = msgp.WrapError(err, "Started")
+		return
+	}
+	// write "ft"
+	err = en.Append(0xa2, 0x66, 0x74)
+	if err != nil {
+		return
+	}
+	err = en.WriteTime(z.Finished)
+	if err != nil {
+		err = msgp.WrapError(err, "Finished")
+		return
+	}
+	// write "nv"
+	err = en.Append(0xa2, 0x6e, 0x76)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Versions)
+	if err != nil {
+		err = msgp.WrapError(err, "Versions")
+		return
+	}
+	// write "bs"
+	err = en.Append(0xa2, 0x62, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Bytes)
+	if err != nil {
+		err = msgp.WrapError(err, "Bytes")
+		return
+	}
+	// write "nc"
+	err = en.Append(0xa2, 0x6e, 0x63)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Corrupted)
+	if err != nil {
+		err = msgp.WrapError(err, "Corrupted")
+		return
+	}
+	// write "nh"
+	err = en.Append(0xa2, 0x6e, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteUint64(z.Healed)
+	if err != nil {
+		err = msgp.WrapError(err, "Healed")
+		return
+	}
+	// write "obs"
+	err = en.Append(0xa3, 0x6f, 0x62, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteArrayHeader(uint32(len(z.Objects)))
+	if err != nil {
+		err = msgp.WrapError(err, "Objects")
+		return
+	}
+	for za0001 := range z.Objects {
+		err = z.Objects[za0001].EncodeMsg(en)
+		if err != nil {
+			err = msgp.WrapError(err, "Objects", za0001)
+			return
+		}
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *scrubReport) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 7
+	// string "st"
+	o = append(o, 0x87, 0xa2, 0x73, 0x74)
+	o = msgp.AppendTime(o, z.Started)
+	// string "ft"
+	o = append(o, 0xa2, 0x66, 0x74)
+	o = msgp.AppendTime(o, z.Finished)
+	// string "nv"
+	o = append(o, 0xa2, 0x6e, 0x76)
+	o = msgp.AppendUint64(o, z.Versions)
+	// string "bs"
+	o = append(o, 0xa2, 0x62, 0x73)
+	o = msgp.AppendUint64(o, z.Bytes)
+	// string "nc"
+	o = append(o, 0xa2, 0x6e, 0x63)
+	o = msgp.AppendUint64(o, z.Corrupted)
+	// string "nh"
+	o = append(o, 0xa2, 0x6e, 0x68)
+	o = msgp.AppendUint64(o, z.Healed)
+	// string "obs"
+	o = append(o, 0xa3, 0x6f, 0x62, 0x73)
+	o = msgp.AppendArrayHeader(o, uint32(len(z.Objects)))
+	for za0001 := range z.Objects {
+		o, err = z.Objects[za0001].MarshalMsg(o)
+		if err != nil {
+			err = msgp.WrapError(err, "Objects", za0001)
+			return
+		}
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *scrubReport) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "st":
+			z.Started, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Started")
+				return
+			}
+		case "ft":
+			z.Finished, bts, err = msgp.ReadTimeBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Finished")
+				return
+			}
+		case "nv":
+			z.Versions, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Versions")
+				return
+			}
+		case "bs":
+			z.Bytes, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Bytes")
+				return
+			}
+		case "nc":
+			z.Corrupted, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Corrupted")
+				return
+			}
+		case "nh":
+			z.Healed, bts, err = msgp.ReadUint64Bytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Healed")
+				return
+			}
+		case "obs":
+			var zb0002 uint32
+			zb0002, bts, err = msgp.ReadArrayHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Objects")
+				return
+			}
+			if cap(z.Objects) >= int(zb0002) {
+				z.Objects = (z.Objects)[:zb0002]
+			} else {
+				z.Objects = make([]scrubCorruption, zb0002)
+			}
+			for za0001 := range z.Objects {
+				bts, err = z.Objects[za0001].UnmarshalMsg(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "Objects", za0001)
+					return
+				}
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *scrubReport) Msgsize() (s int) {
+	s = 1 + 3 + msgp.TimeSize + 3 + msgp.TimeSize + 3 + msgp.Uint64Size + 3 + msgp.Uint64Size + 3 + msgp.Uint64Size + 3 + msgp.Uint64Size + 4 + msgp.ArrayHeaderSize
+	for za0001 := range z.Objects {
+		s += z.Objects[za0001].Msgsize()
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *scrubSetState) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "p":
+			z.Pool, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "Pool")
+				return
+			}
+		case "s":
+			z.Set, err = dc.ReadInt()
+			if err != nil {
+				err = msgp.WrapError(err, "Set")
+				return
+			}
+		case "bu":
+			z.Bucket, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Bucket")
+				return
+			}
+		case "ob":
+			z.Object, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Object")
+				return
+			}
+		case "cur":
+			err = z.Current.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Current")
+				return
+			}
+		case "last":
+			err = z.Last.DecodeMsg(dc)
+			if err != nil {
+				err = msgp.WrapError(err, "Last")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z *scrubSetState) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 6
+	// write "p"
+	err = en.Append(0x86, 0xa1, 0x70)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.Pool)
+	if err != nil {
+		err = msgp.WrapError(err, "Pool")
+		return
+	}
+	// write "s"
+	err = en.Append(0xa1, 0x73)
+	if err != nil {
+		return
+	}
+	err = en.WriteInt(z.Set)
+	if err != nil {
+		err = msgp.WrapError(err, "Set")
+		return
+	}
+	// write "bu"
+	err = en.Append(0xa2, 0x62, 0x75)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Bucket)
+	if err != nil {
+		err = msgp.WrapError(err, "Bucket")
+		return
+	}
+	// write "ob"
+	err = en.Append(0xa2, 0x6f, 0x62)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Object)
+	if err != nil {
+		err = msgp.WrapError(err, "Object")
+		return
+	}
+	// write "cur"
+	err = en.Append(0xa3, 0x63, 0x75, 0x72)
+	if err != nil {
+		return
+	}
+	err = z.Current.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Current")
+		return
+	}
+	// write "last"
+	err = en.Append(0xa4, 0x6c, 0x61, 0x73, 0x74)
+	if err != nil {
+		return
+	}
+	err = z.Last.EncodeMsg(en)
+	if err != nil {
+		err = msgp.WrapError(err, "Last")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z *scrubSetState) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 6
+	// string "p"
+	o = append(o, 0x86, 0xa1, 0x70)
+	o = msgp.AppendInt(o, z.Pool)
+	// string "s"
+	o = append(o, 0xa1, 0x73)
+	o = msgp.AppendInt(o, z.Set)
+	// string "bu"
+	o = append(o, 0xa2, 0x62, 0x75)
+	o = msgp.AppendString(o, z.Bucket)
+	// string "ob"
+	o = append(o, 0xa2, 0x6f, 0x62)
+	o = msgp.AppendString(o, z.Object)
+	// string "cur"
+	o = append(o, 0xa3, 0x63, 0x75, 0x72)
+	o, err = z.Current.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Current")
+		return
+	}
+	// string "last"
+	o = append(o, 0xa4, 0x6c, 0x61, 0x73, 0x74)
+	o, err = z.Last.MarshalMsg(o)
+	if err != nil {
+		err = msgp.WrapError(err, "Last")
+		return
+	}
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *scrubSetState) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "p":
+			z.Pool, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Pool")
+				return
+			}
+		case "s":
+			z.Set, bts, err = msgp.ReadIntBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Set")
+				return
+			}
+		case "bu":
+			z.Bucket, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Bucket")
+				return
+			}
+		case "ob":
+			z.Object, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Object")
+				return
+			}
+		case "cur":
+			bts, err = z.Current.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Current")
+				return
+			}
+		case "last":
+			bts, err = z.Last.UnmarshalMsg(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Last")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z *scrubSetState) Msgsize() (s int) {
+	s = 1 + 2 + msgp.IntSize + 2 + msgp.IntSize + 3 + msgp.StringPrefixSize + len(z.Bucket) + 3 + msgp.StringPrefixSize + len(z.Object) + 4 + z.Current.Msgsize() + 5 + z.Last.Msgsize()
+	return
+}
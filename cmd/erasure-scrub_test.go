@@ -0,0 +1,154 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/minio/minio/internal/config/scrub"
+)
+
+func TestScrubSet(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+	newAllSubsystems()
+	setObjectLayer(obj)
+	defer resetGlobalObjectAPI()
+
+	oldScrubConfig := globalScrubConfig.Get()
+	globalScrubConfig.Update(scrub.Config{Enabled: true, Bandwidth: 1 << 40, Interval: time.Hour})
+	defer globalScrubConfig.Update(oldScrubConfig)
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	data := bytes.Repeat([]byte("a"), 4<<20)
+	for _, object := range []string{"a", "b", "c"} {
+		if _, err = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	// Corrupt a shard of "b" on one drive and remove a shard of "c" on another.
+	z := obj.(*erasureServerPools)
+	set := z.serverPools[0].sets[0]
+	disks := set.getDisks()
+	shardPath := func(disk StorageAPI, object string) string {
+		fi, err := disk.ReadVersion(ctx, bucket, object, "", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return filepath.Join(disk.String(), bucket, object, fi.DataDir, "part.1")
+	}
+	corruptPath := shardPath(disks[0], "b")
+	if err = os.WriteFile(corruptPath, bytes.Repeat([]byte("x"), 1024), 0o644); err != nil {
+		t.Fatal(err)
+	}
+	missingPath := shardPath(disks[1], "c")
+	if err = os.Remove(missingPath); err != nil {
+		t.Fatal(err)
+	}
+
+	state := &scrubSetState{Pool: 0, Set: 0}
+	if err = z.scrubSet(ctx, state); err != nil {
+		t.Fatal(err)
+	}
+	report := state.Last
+	if report.Finished.IsZero() || !state.Current.Started.IsZero() {
+		t.Fatalf("Expected a completed scrub, got %#v", state)
+	}
+	if report.Versions != 3 || report.Corrupted != 2 || report.Healed != 2 || len(report.Objects) != 2 {
+		t.Fatalf("Unexpected scrub report %#v", report)
+	}
+	expected := map[string]string{"b": disks[0].String(), "c": disks[1].String()}
+	for _, c := range report.Objects {
+		if len(c.Drives) != 1 || c.Drives[0] != expected[c.Object] || !c.Healed {
+			t.Fatalf("Unexpected corruption entry %#v", c)
+		}
+	}
+	for _, object := range []string{"b", "c"} {
+		fi, err := disks[0].ReadVersion(ctx, bucket, object, "", false)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for _, disk := range disks[:2] {
+			if err = disk.VerifyFile(ctx, bucket, object, fi); err != nil {
+				t.Fatalf("Expected %s to be healed on %s, got %v", object, disk, err)
+			}
+		}
+	}
+
+	// The state is persisted for the admin APIs.
+	states, err := z.loadScrubStates(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if len(states) != 1 || states[0].Last.Corrupted != 2 || !states[0].Last.Finished.Equal(report.Finished) {
+		t.Fatalf("Unexpected scrub states %#v", states)
+	}
+
+	// An interrupted scrub resumes after the last scrubbed object.
+	state = &scrubSetState{
+		Pool:    0,
+		Set:     0,
+		Bucket:  bucket,
+		Object:  "b",
+		Current: scrubReport{Started: UTCNow(), Versions: 2},
+	}
+	if err = z.scrubSet(ctx, state); err != nil {
+		t.Fatal(err)
+	}
+	// Only "c" remains to be scrubbed.
+	if state.Last.Versions != 3 || state.Last.Corrupted != 0 {
+		t.Fatalf("Unexpected resumed scrub report %#v", state.Last)
+	}
+}
+
+func TestScrubThrottle(t *testing.T) {
+	var throttle scrubThrottle
+	start := time.Now()
+	for i := 0; i < 3; i++ {
+		if err := throttle.wait(context.Background(), 100, 1000); err != nil {
+			t.Fatal(err)
+		}
+	}
+	// The first wait returns immediately, the others wait 100ms each.
+	if elapsed := time.Since(start); elapsed < 200*time.Millisecond {
+		t.Fatalf("Expected the throttle to wait at least 200ms, waited %s", elapsed)
+	}
+
+	// The next bytes are due in 100ms, a canceled wait returns early.
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	if err := throttle.wait(ctx, 1, 1); err != context.Canceled {
+		t.Fatalf("Expected %v, got %v", context.Canceled, err)
+	}
+}
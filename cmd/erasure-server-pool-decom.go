@@ -0,0 +1,1131 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"net/http"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/hash"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/pkg/wildcard"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+
+// PoolDecommissionInfo currently decommissioning information
+type PoolDecommissionInfo struct {
+	StartTime   time.Time `json:"startTime" msg:"st"`
+	StartSize   int64     `json:"startSize" msg:"ss"`
+	TotalSize   int64     `json:"totalSize" msg:"ts"`
+	CurrentSize int64     `json:"currentSize" msg:"cs"`
+
+	Complete bool `json:"complete" msg:"cmp"`
+	Failed   bool `json:"failed" msg:"fl"`
+	Canceled bool `json:"canceled" msg:"cnl"`
+
+	// Internal information.
+	QueuedBuckets         []string `json:"-" msg:"bkts"`
+	DecommissionedBuckets []string `json:"-" msg:"dbkts"`
+
+	// Last bucket/object decommissioned.
+	Bucket string `json:"-" msg:"bkt"`
+	Object string `json:"-" msg:"obj"`
+
+	// Verbose information
+	ItemsDecommissioned     uint64 `json:"objectsDecommissioned" msg:"id"`
+	ItemsDecommissionFailed uint64 `json:"objectsDecommissionedFailed" msg:"idf"`
+	BytesDone               uint64 `json:"bytesDecommissioned" msg:"bd"`
+	BytesFailed             uint64 `json:"bytesDecommissionedFailed" msg:"bf"`
+}
+
+// bucketPop should be called when a bucket is done decommissioning.
+// Adds the bucket to the list of decommissioned buckets and updates resume numbers.
+func (pd *PoolDecommissionInfo) bucketPop(bucket string) {
+	pd.DecommissionedBuckets = append(pd.DecommissionedBuckets, bucket)
+	for i, b := range pd.QueuedBuckets {
+		if b == bucket {
+			// Bucket is done.
+			pd.QueuedBuckets = append(pd.QueuedBuckets[:i], pd.QueuedBuckets[i+1:]...)
+			// Clear tracker info.
+			if pd.Bucket == bucket {
+				pd.Bucket = "" // empty this out for next bucket
+				pd.Object = "" // empty this out for next object
+			}
+			return
+		}
+	}
+}
+
+func (pd *PoolDecommissionInfo) isBucketDecommissioned(bucket string) bool {
+	for _, b := range pd.DecommissionedBuckets {
+		if b == bucket {
+			return true
+		}
+	}
+	return false
+}
+
+func (pd *PoolDecommissionInfo) bucketPush(bucket string) {
+	if pd.isBucketDecommissioned(bucket) {
+		return
+	}
+	for _, b := range pd.QueuedBuckets {
+		if b == bucket {
+			return
+		}
+	}
+	pd.QueuedBuckets = append(pd.QueuedBuckets, bucket)
+}
+
+// PoolStatus captures current pool status
+type PoolStatus struct {
+	ID           int                   `json:"id" msg:"id"`
+	CmdLine      string                `json:"cmdline" msg:"cl"`
+	LastUpdate   time.Time             `json:"lastUpdate" msg:"lu"`
+	Decommission *PoolDecommissionInfo `json:"decommissionInfo,omitempty" msg:"dec"`
+}
+
+// Clone returns a copy of PoolStatus
+func (ps PoolStatus) Clone() PoolStatus {
+	clone := ps
+	if ps.Decommission != nil {
+		dec := *ps.Decommission
+		dec.QueuedBuckets = append([]string(nil), ps.Decommission.QueuedBuckets...)
+		dec.DecommissionedBuckets = append([]string(nil), ps.Decommission.DecommissionedBuckets...)
+		clone.Decommission = &dec
+	}
+	return clone
+}
+
+type poolMeta struct {
+	Version int          `msg:"v"`
+	Pools   []PoolStatus `msg:"pls"`
+}
+
+// A decommission resumable tells us if decommission is worth
+// resuming upon restart of a cluster.
+func (p *poolMeta) returnResumablePools() []PoolStatus {
+	var newPools []PoolStatus
+	for _, pool := range p.Pools {
+		if pool.Decommission == nil {
+			continue
+		}
+		if pool.Decommission.Complete || pool.Decommission.Canceled || pool.Decommission.Failed {
+			// Do not resume decommission upon startup for
+			// - decommission complete.
+			// - decommission canceled.
+			// - decommission failed, needs to be restarted by the operator.
+			continue
+		} // In all other situations we need to resume
+		newPools = append(newPools, pool)
+	}
+	return newPools
+}
+
+// IsSuspended returns true if the pool at idx must not receive new writes.
+func (p *poolMeta) IsSuspended(idx int) bool {
+	if idx >= len(p.Pools) {
+		// We don't really know if the pool is suspended or not, since it doesn't exist.
+		return false
+	}
+	return p.Pools[idx].Decommission != nil && !p.Pools[idx].Decommission.Canceled
+}
+
+// isDecommissioning returns true if a decommission of any pool is underway.
+func (p *poolMeta) isDecommissioning() bool {
+	for _, pool := range p.Pools {
+		if pool.Decommission == nil {
+			continue
+		}
+		if !pool.Decommission.Complete && !pool.Decommission.Failed && !pool.Decommission.Canceled {
+			return true
+		}
+	}
+	return false
+}
+
+func (p *poolMeta) DecommissionComplete(idx int) bool {
+	if p.Pools[idx].Decommission != nil && !p.Pools[idx].Decommission.Complete {
+		p.Pools[idx].LastUpdate = UTCNow()
+		p.Pools[idx].Decommission.Complete = true
+		p.Pools[idx].Decommission.Failed = false
+		p.Pools[idx].Decommission.Canceled = false
+		return true
+	}
+	return false
+}
+
+func (p *poolMeta) DecommissionFailed(idx int) bool {
+	if p.Pools[idx].Decommission != nil && !p.Pools[idx].Decommission.Failed {
+		p.Pools[idx].LastUpdate = UTCNow()
+		p.Pools[idx].Decommission.StartTime = time.Time{}
+		p.Pools[idx].Decommission.Complete = false
+		p.Pools[idx].Decommission.Failed = true
+		p.Pools[idx].Decommission.Canceled = false
+		return true
+	}
+	return false
+}
+
+func (p *poolMeta) DecommissionCancel(idx int) bool {
+	if p.Pools[idx].Decommission != nil && !p.Pools[idx].Decommission.Canceled {
+		p.Pools[idx].LastUpdate = UTCNow()
+		p.Pools[idx].Decommission.StartTime = time.Time{}
+		p.Pools[idx].Decommission.Complete = false
+		p.Pools[idx].Decommission.Failed = false
+		p.Pools[idx].Decommission.Canceled = true
+		return true
+	}
+	return false
+}
+
+func (p *poolMeta) Decommission(idx int, pi poolSpaceInfo) error {
+	// Return an error when there is decommission on going - the user needs
+	// to explicitly cancel it first in order to restart decommissioning again.
+	if p.isDecommissioning() {
+		return errDecommissionAlreadyRunning
+	}
+	if p.Pools[idx].Decommission != nil && p.Pools[idx].Decommission.Complete {
+		return errDecommissionComplete
+	}
+
+	now := UTCNow()
+	p.Pools[idx].LastUpdate = now
+	p.Pools[idx].Decommission = &PoolDecommissionInfo{
+		StartTime:   now,
+		StartSize:   pi.Free,
+		CurrentSize: pi.Free,
+		TotalSize:   pi.Total,
+	}
+	return nil
+}
+
+func (p *poolMeta) QueueBuckets(idx int, buckets []string) {
+	// add new queued buckets
+	for _, bucket := range buckets {
+		p.Pools[idx].Decommission.bucketPush(bucket)
+	}
+}
+
+func (p *poolMeta) PendingBuckets(idx int) []string {
+	if len(p.Pools) == 0 || p.Pools[idx].Decommission == nil {
+		return nil
+	}
+	return append([]string(nil), p.Pools[idx].Decommission.QueuedBuckets...)
+}
+
+func (p *poolMeta) BucketDone(idx int, bucket string) {
+	if p.Pools[idx].Decommission == nil {
+		// Decommission not in progress.
+		return
+	}
+	p.Pools[idx].Decommission.bucketPop(bucket)
+}
+
+func (p *poolMeta) CountItem(idx int, size int64, failed bool) {
+	pd := p.Pools[idx].Decommission
+	if pd != nil {
+		if failed {
+			pd.ItemsDecommissionFailed++
+			pd.BytesFailed += uint64(size)
+		} else {
+			pd.ItemsDecommissioned++
+			pd.BytesDone += uint64(size)
+		}
+	}
+}
+
+func (p *poolMeta) TrackCurrentBucketObject(idx int, bucket string, object string) {
+	if p.Pools[idx].Decommission == nil {
+		// Decommission not in progress.
+		return
+	}
+	p.Pools[idx].Decommission.Bucket = bucket
+	p.Pools[idx].Decommission.Object = object
+}
+
+// validate reconciles the pools recorded in the pool metadata with the pools
+// the server was started with, it returns true if the metadata needs to be
+// saved again.
+func (p *poolMeta) validate(pools EndpointServerPools) (bool, error) {
+	type poolInfo struct {
+		position  int
+		completed bool
+	}
+
+	rememberedPools := make(map[string]poolInfo)
+	for idx, pool := range p.Pools {
+		complete := false
+		if pool.Decommission != nil && pool.Decommission.Complete {
+			complete = true
+		}
+		rememberedPools[pool.CmdLine] = poolInfo{
+			position:  idx,
+			completed: complete,
+		}
+	}
+
+	specifiedPools := make(map[string]int)
+	for idx, pool := range pools {
+		specifiedPools[pool.CmdLine] = idx
+	}
+
+	// Check if specified pools need to remove decommissioned pool.
+	for _, pool := range pools {
+		pi, ok := rememberedPools[pool.CmdLine]
+		if ok && pi.completed {
+			logger.Info("Decommissioned pool '%s' can be removed from the command line", pool.CmdLine)
+		}
+	}
+
+	update := len(rememberedPools) != len(specifiedPools)
+	if !update {
+		for k, pi := range rememberedPools {
+			pos, ok := specifiedPools[k]
+			if !ok || pos != pi.position {
+				update = true
+				break
+			}
+		}
+	}
+
+	if update {
+		newPools := make([]PoolStatus, len(pools))
+		for idx, pool := range pools {
+			if pi, ok := rememberedPools[pool.CmdLine]; ok {
+				newPools[idx] = p.Pools[pi.position]
+			} else {
+				newPools[idx] = PoolStatus{
+					CmdLine:    pool.CmdLine,
+					LastUpdate: UTCNow(),
+				}
+			}
+			newPools[idx].ID = idx
+		}
+		for k, pi := range rememberedPools {
+			if _, ok := specifiedPools[k]; ok {
+				continue
+			}
+			if !pi.completed {
+				logger.Info("Pool '%s' was removed from the command line without being decommissioned", k)
+			}
+		}
+		p.Pools = newPools
+	}
+	return update, nil
+}
+
+func (p *poolMeta) load(ctx context.Context, pool *erasureSets, pools []*erasureSets) error {
+	data, err := readConfig(ctx, pool, poolMetaName)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) || isErrObjectNotFound(err) {
+			return nil
+		}
+		return err
+	}
+	if len(data) == 0 {
+		// Seems to be empty create a new poolMeta object.
+		return nil
+	}
+	if len(data) <= 4 {
+		return fmt.Errorf("poolMeta: no data")
+	}
+	// Read header
+	switch binary.LittleEndian.Uint16(data[0:2]) {
+	case poolMetaFormat:
+	default:
+		return fmt.Errorf("poolMeta: unknown format: %d", binary.LittleEndian.Uint16(data[0:2]))
+	}
+	switch binary.LittleEndian.Uint16(data[2:4]) {
+	case poolMetaVersion:
+	default:
+		return fmt.Errorf("poolMeta: unknown version: %d", binary.LittleEndian.Uint16(data[2:4]))
+	}
+
+	// OK, parse data.
+	if _, err = p.UnmarshalMsg(data[4:]); err != nil {
+		return err
+	}
+
+	switch p.Version {
+	case poolMetaVersionV1:
+	default:
+		return fmt.Errorf("unexpected pool meta version: %d", p.Version)
+	}
+
+	return nil
+}
+
+func (p *poolMeta) updateAfter(ctx context.Context, idx int, pools []*erasureSets, duration time.Duration) error {
+	if p.Pools[idx].Decommission == nil {
+		return errInvalidArgument
+	}
+	now := UTCNow()
+	if now.Sub(p.Pools[idx].LastUpdate) >= duration {
+		p.Pools[idx].LastUpdate = now
+		return p.save(ctx, pools)
+	}
+	return nil
+}
+
+// save writes the pool metadata to every pool, including pools being
+// decommissioned, so that it survives removal of any one pool.
+func (p poolMeta) save(ctx context.Context, pools []*erasureSets) error {
+	data := make([]byte, 4, p.Msgsize()+4)
+
+	// Initialize the header.
+	binary.LittleEndian.PutUint16(data[0:2], poolMetaFormat)
+	binary.LittleEndian.PutUint16(data[2:4], poolMetaVersion)
+
+	buf, err := p.MarshalMsg(data)
+	if err != nil {
+		return err
+	}
+
+	// Saves on all pools to make sure decommissioning of first pool is allowed.
+	for _, eset := range pools {
+		if err = saveConfig(ctx, eset, poolMetaName, buf); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+const (
+	poolMetaName      = "pool.bin"
+	poolMetaFormat    = 1
+	poolMetaVersionV1 = 1
+	poolMetaVersion   = poolMetaVersionV1
+)
+
+var (
+	// error returned when a decommission is already running
+	errDecommissionAlreadyRunning = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "Decommission is already in progress",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when the pool is already decommissioned
+	errDecommissionComplete = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "Decommission is already complete for this pool",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when a decommission was never started on the pool
+	errDecommissionNotStarted = AdminError{
+		Code:       "XMinioDecommissionNotStarted",
+		Message:    "Decommission is not in progress for this pool",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned for single pool deployments
+	errDecommissionSinglePool = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "Decommission is not allowed on a single pool deployment",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when the requested pool is not found
+	errPoolNotFound = AdminError{
+		Code:       "XMinioAdminPoolNotFound",
+		Message:    "Specified pool was not found",
+		StatusCode: http.StatusNotFound,
+	}
+)
+
+// Init loads the pool and rebalance metadata and resumes any decommission
+// or rebalance that was in progress before the server was restarted.
+func (z *erasureServerPools) Init(ctx context.Context) error {
+	meta := poolMeta{}
+
+	for _, pool := range z.serverPools {
+		if err := meta.load(ctx, pool, z.serverPools); err != nil {
+			return err
+		}
+		if meta.Version > 0 {
+			break
+		}
+	}
+
+	update, err := meta.validate(globalEndpoints)
+	if err != nil {
+		return err
+	}
+
+	// if no update is needed return right away.
+	if !update {
+		z.poolMetaMutex.Lock()
+		z.poolMeta = meta
+		z.poolMetaMutex.Unlock()
+	} else {
+		meta.Version = poolMetaVersion
+		if err = meta.save(ctx, z.serverPools); err != nil {
+			return err
+		}
+		z.poolMetaMutex.Lock()
+		z.poolMeta = meta
+		z.poolMetaMutex.Unlock()
+	}
+
+	for _, pool := range meta.returnResumablePools() {
+		idx := pool.ID
+		// Only the node owning the first drive of the pool runs the
+		// decommission, this avoids running it on multiple nodes.
+		if !globalEndpoints[idx].Endpoints[0].IsLocal {
+			continue
+		}
+		dctx, cancel := context.WithCancel(GlobalContext)
+		z.poolMetaMutex.Lock()
+		z.decommissionCancelers[idx] = cancel
+		z.poolMetaMutex.Unlock()
+		go z.doDecommissionInRoutine(dctx, idx)
+	}
+
+	// Resume any rebalance that was in progress.
+	if err = z.loadRebalanceMeta(ctx); err != nil {
+		return err
+	}
+	z.StartRebalance()
+
+	return nil
+}
+
+// IsSuspended returns true if the pool at idx is being decommissioned,
+// such pools do not accept new objects.
+func (z *erasureServerPools) IsSuspended(idx int) bool {
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+	return z.poolMeta.IsSuspended(idx)
+}
+
+// ReloadPoolMeta reloads the pool metadata from disk, called on
+// peers when the decommission state of a pool changes.
+func (z *erasureServerPools) ReloadPoolMeta(ctx context.Context) (err error) {
+	meta := poolMeta{}
+
+	for _, pool := range z.serverPools {
+		if err = meta.load(ctx, pool, z.serverPools); err != nil {
+			return err
+		}
+		if meta.Version > 0 {
+			break
+		}
+	}
+
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	z.poolMeta = meta
+	return nil
+}
+
+// getPoolIdxByCmdLine returns the index of the pool specified
+// on the command line as cmdLine.
+func (z *erasureServerPools) getPoolIdxByCmdLine(cmdLine string) (int, error) {
+	for idx, pool := range globalEndpoints {
+		if pool.CmdLine == cmdLine && idx < len(z.serverPools) {
+			return idx, nil
+		}
+	}
+	return -1, errPoolNotFound
+}
+
+type poolSpaceInfo struct {
+	Free  int64
+	Total int64
+	Used  int64
+}
+
+func (z *erasureServerPools) getPoolSpaceInfo(idx int) (pi poolSpaceInfo, err error) {
+	if idx < 0 {
+		return pi, errInvalidArgument
+	}
+	if idx+1 > len(z.serverPools) {
+		return pi, errInvalidArgument
+	}
+	info, errs := z.serverPools[idx].StorageInfo(context.Background())
+	for _, err := range errs {
+		if err != nil {
+			return pi, errInvalidArgument
+		}
+	}
+	info.Backend = z.BackendInfo()
+	for _, disk := range info.Disks {
+		pi.Total += int64(disk.TotalSpace)
+		pi.Free += int64(disk.AvailableSpace)
+		pi.Used += int64(disk.UsedSpace)
+	}
+	return pi, nil
+}
+
+// Status returns the current status of the pool at idx.
+func (z *erasureServerPools) Status(ctx context.Context, idx int) (PoolStatus, error) {
+	if idx < 0 {
+		return PoolStatus{}, errInvalidArgument
+	}
+
+	z.poolMetaMutex.RLock()
+	defer z.poolMetaMutex.RUnlock()
+
+	if idx+1 > len(z.poolMeta.Pools) {
+		return PoolStatus{
+			ID:      idx,
+			CmdLine: globalEndpoints[idx].CmdLine,
+		}, nil
+	}
+
+	pi, err := z.getPoolSpaceInfo(idx)
+	if err != nil {
+		return PoolStatus{}, err
+	}
+
+	poolInfo := z.poolMeta.Pools[idx].Clone()
+	if poolInfo.Decommission != nil {
+		poolInfo.Decommission.TotalSize = pi.Total
+		poolInfo.Decommission.CurrentSize = pi.Free
+	} else {
+		poolInfo.Decommission = &PoolDecommissionInfo{
+			TotalSize:   pi.Total,
+			CurrentSize: pi.Free,
+		}
+	}
+	return poolInfo, nil
+}
+
+// Decommission starts decommissioning the pool at idx, all objects
+// on the pool are moved to the remaining pools in the background.
+func (z *erasureServerPools) Decommission(ctx context.Context, idx int) error {
+	if idx < 0 || idx+1 > len(z.serverPools) {
+		return errInvalidArgument
+	}
+
+	if z.SinglePool() {
+		return errDecommissionSinglePool
+	}
+
+	if z.IsRebalanceStarted() {
+		return errDecommissionRebalanceRunning
+	}
+
+	// Make pool unwritable before decommissioning.
+	if err := z.StartDecommission(ctx, idx); err != nil {
+		return err
+	}
+
+	dctx, cancel := context.WithCancel(GlobalContext)
+	z.poolMetaMutex.Lock()
+	z.decommissionCancelers[idx] = cancel
+	z.poolMetaMutex.Unlock()
+
+	go z.doDecommissionInRoutine(dctx, idx)
+	return nil
+}
+
+// StartDecommission marks the pool at idx as being decommissioned
+// and records the list of buckets that need to be moved.
+func (z *erasureServerPools) StartDecommission(ctx context.Context, idx int) (err error) {
+	if idx < 0 {
+		return errInvalidArgument
+	}
+
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+
+	var decomBuckets []string
+	for _, b := range buckets {
+		decomBuckets = append(decomBuckets, b.Name)
+	}
+
+	// Buckets data are dispersed in multiple pools/sets, make
+	// sure to decommission the necessary metadata.
+	decomBuckets = append(decomBuckets, pathJoin(minioMetaBucket, minioConfigPrefix))
+	decomBuckets = append(decomBuckets, pathJoin(minioMetaBucket, bucketMetaPrefix))
+
+	pi, err := z.getPoolSpaceInfo(idx)
+	if err != nil {
+		return err
+	}
+
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	if err = z.poolMeta.Decommission(idx, pi); err != nil {
+		return err
+	}
+	z.poolMeta.QueueBuckets(idx, decomBuckets)
+	if err = z.poolMeta.save(ctx, z.serverPools); err != nil {
+		return err
+	}
+	globalNotificationSys.ReloadPoolMeta(ctx)
+	return nil
+}
+
+// DecommissionCancel cancels an ongoing decommission of the pool at idx,
+// the pool becomes writable again. Objects already moved stay where they are.
+func (z *erasureServerPools) DecommissionCancel(ctx context.Context, idx int) (err error) {
+	if idx < 0 {
+		return errInvalidArgument
+	}
+
+	if z.SinglePool() {
+		return errDecommissionSinglePool
+	}
+
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	if z.poolMeta.Pools[idx].Decommission == nil {
+		return errDecommissionNotStarted
+	}
+
+	if cancel := z.decommissionCancelers[idx]; cancel != nil {
+		cancel()
+		z.decommissionCancelers[idx] = nil
+	}
+
+	if z.poolMeta.DecommissionCancel(idx) {
+		if err = z.poolMeta.save(ctx, z.serverPools); err != nil {
+			return err
+		}
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	return nil
+}
+
+func (z *erasureServerPools) decommissionFailed(ctx context.Context, idx int) (err error) {
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	if z.poolMeta.DecommissionFailed(idx) {
+		if err = z.poolMeta.save(ctx, z.serverPools); err != nil {
+			return err
+		}
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	return nil
+}
+
+func (z *erasureServerPools) decommissionComplete(ctx context.Context, idx int) (err error) {
+	z.poolMetaMutex.Lock()
+	defer z.poolMetaMutex.Unlock()
+
+	if z.poolMeta.DecommissionComplete(idx) {
+		if err = z.poolMeta.save(ctx, z.serverPools); err != nil {
+			return err
+		}
+		globalNotificationSys.ReloadPoolMeta(ctx)
+	}
+	return nil
+}
+
+func (z *erasureServerPools) doDecommissionInRoutine(ctx context.Context, idx int) {
+	if err := z.decommissionInBackground(ctx, idx); err != nil {
+		if errors.Is(err, context.Canceled) {
+			// Decommission was canceled by the user, nothing more to do.
+			return
+		}
+		logger.LogIf(GlobalContext, err)
+		logger.LogIf(GlobalContext, z.decommissionFailed(GlobalContext, idx))
+		return
+	}
+
+	z.poolMetaMutex.RLock()
+	failed := z.poolMeta.Pools[idx].Decommission.ItemsDecommissionFailed > 0
+	z.poolMetaMutex.RUnlock()
+
+	if failed {
+		// Decommission failed indicate as such.
+		logger.LogIf(GlobalContext, z.decommissionFailed(GlobalContext, idx))
+	} else {
+		// Complete the decommission..
+		logger.LogIf(GlobalContext, z.decommissionComplete(GlobalContext, idx))
+	}
+}
+
+func (z *erasureServerPools) decommissionInBackground(ctx context.Context, idx int) error {
+	pool := z.serverPools[idx]
+
+	z.poolMetaMutex.RLock()
+	buckets := z.poolMeta.PendingBuckets(idx)
+	z.poolMetaMutex.RUnlock()
+
+	for _, bucket := range buckets {
+		z.poolMetaMutex.RLock()
+		done := z.poolMeta.Pools[idx].Decommission.isBucketDecommissioned(bucket)
+		z.poolMetaMutex.RUnlock()
+		if done {
+			// Bucket already decommissioned, continue
+			// to next bucket.
+			continue
+		}
+
+		if err := z.decommissionPool(ctx, idx, pool, bucket); err != nil {
+			return err
+		}
+
+		z.poolMetaMutex.Lock()
+		z.poolMeta.BucketDone(idx, bucket)
+		err := z.poolMeta.save(ctx, z.serverPools)
+		z.poolMetaMutex.Unlock()
+		if err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// isDecommissionSkipped returns true for entries of the meta bucket
+// which are ephemeral and need not be moved to another pool.
+func isDecommissionSkipped(bucket, object string) bool {
+	if bucket != minioMetaBucket {
+		return false
+	}
+	return wildcard.Match("buckets/*/.metacache/*", object) ||
+		wildcard.Match("tmp/*", object) ||
+		wildcard.Match("multipart/*", object) ||
+		wildcard.Match("tmp-old/*", object)
+}
+
+// decommissionPool moves all objects of bucket (or a meta bucket prefix)
+// present on the pool at idx to the remaining pools.
+func (z *erasureServerPools) decommissionPool(ctx context.Context, idx int, pool *erasureSets, bucketPath string) error {
+	return walkPoolObjects(ctx, pool, bucketPath, func(bucket string, fivs FileInfoVersions) {
+		z.decommissionEntry(ctx, idx, bucket, fivs)
+	})
+}
+
+// walkPoolObjects lists all objects of bucket (or a meta bucket prefix) on
+// all the sets of pool in parallel, fn is called with all the versions of
+// every object found.
+func walkPoolObjects(ctx context.Context, pool *erasureSets, bucketPath string, fn func(bucket string, fivs FileInfoVersions)) error {
+	bucket, prefix := path2BucketObject(bucketPath)
+
+	var wg sync.WaitGroup
+	errCh := make(chan error, len(pool.sets))
+	for _, set := range pool.sets {
+		disks, _ := set.getOnlineDisksWithHealing()
+		if len(disks) == 0 {
+			logger.LogIf(GlobalContext, fmt.Errorf("no online disks found for set with endpoints %s",
+				set.getEndpoints()))
+			continue
+		}
+
+		walkEntry := func(entry metaCacheEntry) {
+			if entry.isDir() {
+				return
+			}
+			if isDecommissionSkipped(bucket, entry.name) {
+				return
+			}
+			fivs, err := entry.fileInfoVersions(bucket)
+			if err != nil {
+				return
+			}
+			fn(bucket, fivs)
+		}
+
+		// How to resolve partial results.
+		resolver := metadataResolutionParams{
+			dirQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+			objQuorum: len(disks) / 2, // make sure to capture all quorum ratios
+			bucket:    bucket,
+		}
+
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			err := listPathRaw(ctx, listPathRawOptions{
+				disks:          disks,
+				bucket:         bucket,
+				path:           prefix,
+				recursive:      true,
+				forwardTo:      "",
+				minDisks:       len(disks) / 2, // to capture all quorum ratios
+				reportNotFound: false,
+				agreed:         walkEntry,
+				partial: func(entries metaCacheEntries, nAgreed int, errs []error) {
+					entry, ok := entries.resolve(&resolver)
+					if ok {
+						walkEntry(*entry)
+					}
+				},
+				finished: nil,
+			})
+			if err != nil && !errors.Is(err, errVolumeNotFound) {
+				errCh <- err
+			}
+		}()
+	}
+	wg.Wait()
+	close(errCh)
+
+	if err := ctx.Err(); err != nil {
+		return err
+	}
+	for err := range errCh {
+		return err
+	}
+	return nil
+}
+
+// decommissionEntry moves all versions of one object out of the pool at idx.
+func (z *erasureServerPools) decommissionEntry(ctx context.Context, idx int, bucket string, fivs FileInfoVersions) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Serialize with regular uploads of the same object, which
+	// choose the pool to write to holding the same lock.
+	ns := z.NewNSLock(minioMetaMultipartBucket, pathJoin(bucket, fivs.Name, "newMultipartObject.lck"))
+	lkctx, err := ns.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	ctx = lkctx.Context()
+	defer ns.Unlock(lkctx.Cancel)
+
+	dstIdx, err := z.getTargetPoolIdx(ctx, bucket, fivs, func(i int) bool {
+		return i == idx || z.IsSuspended(i)
+	})
+	if err != nil {
+		logger.LogIf(ctx, err)
+		z.poolMetaMutex.Lock()
+		for _, version := range fivs.Versions {
+			z.poolMeta.CountItem(idx, version.Size, true)
+		}
+		z.poolMetaMutex.Unlock()
+		return
+	}
+
+	z.moveObjectVersions(ctx, idx, dstIdx, bucket, fivs, func(version FileInfo, err error) {
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("unable to decommission %s/%s (%s): %w", bucket, version.Name, version.VersionID, err))
+		}
+
+		z.poolMetaMutex.Lock()
+		z.poolMeta.CountItem(idx, version.Size, err != nil)
+		z.poolMeta.TrackCurrentBucketObject(idx, bucket, version.Name)
+		logger.LogIf(ctx, z.poolMeta.updateAfter(ctx, idx, z.serverPools, 30*time.Second))
+		z.poolMetaMutex.Unlock()
+	})
+}
+
+// moveObjectVersions moves all versions of one object from the pool at idx
+// to the pool at dstIdx, versions are moved oldest first such that the
+// latest version on the target pool remains the latest version. done is
+// called for every version with the outcome of the move.
+func (z *erasureServerPools) moveObjectVersions(ctx context.Context, idx, dstIdx int, bucket string, fivs FileInfoVersions, done func(version FileInfo, err error)) {
+	versions := append([]FileInfo(nil), fivs.Versions...)
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].ModTime.Before(versions[j].ModTime)
+	})
+
+	for _, version := range versions {
+		err := z.moveObjectVersion(ctx, idx, dstIdx, bucket, version)
+		if err == nil {
+			vid := version.VersionID
+			if vid == "" {
+				vid = nullVersionID
+			}
+			// Version was moved, remove it from the source pool.
+			_, err = z.serverPools[idx].DeleteObject(ctx, bucket, version.Name, ObjectOptions{
+				VersionID:    vid,
+				DataMovement: true,
+			})
+			if isErrObjectNotFound(err) || isErrVersionNotFound(err) {
+				err = nil
+			}
+		}
+		done(version, err)
+	}
+}
+
+// getTargetPoolIdx returns the pool where all versions of the object should
+// be moved to, pools for which skip returns true are never chosen. If the
+// object already exists in one of the other pools the same pool is chosen
+// to keep all versions together.
+func (z *erasureServerPools) getTargetPoolIdx(ctx context.Context, bucket string, fivs FileInfoVersions, skip func(idx int) bool) (int, error) {
+	var size int64
+	for _, version := range fivs.Versions {
+		size += version.Size
+	}
+	for i, pool := range z.serverPools {
+		if skip(i) {
+			continue
+		}
+		if _, err := pool.GetObjectInfo(ctx, bucket, fivs.Name, ObjectOptions{NoLock: true}); err == nil {
+			return i, nil
+		}
+	}
+	serverPools := z.getServerPoolsAvailableSpace(ctx, bucket, fivs.Name, size)
+	for i := range serverPools {
+		if skip(i) {
+			serverPools[i].Available = 0
+		}
+	}
+	dstIdx := serverPools.getAvailablePoolIdx(ctx)
+	if dstIdx < 0 {
+		return -1, toObjectErr(errDiskFull)
+	}
+	return dstIdx, nil
+}
+
+// moveObjectVersion copies a single version from the pool at idx into
+// the pool at dstIdx preserving version ID, modification time and metadata.
+func (z *erasureServerPools) moveObjectVersion(ctx context.Context, idx, dstIdx int, bucket string, version FileInfo) error {
+	src, dst := z.serverPools[idx], z.serverPools[dstIdx]
+
+	// A newer null version may have been written to another pool while
+	// the decommission was in progress, it must not be overwritten.
+	if version.VersionID == "" && !version.Deleted && version.TransitionStatus != lifecycle.TransitionComplete {
+		oi, err := dst.GetObjectInfo(ctx, bucket, version.Name, ObjectOptions{NoLock: true})
+		if err == nil && oi.ModTime.After(version.ModTime) {
+			return nil
+		}
+	}
+
+	return copyObjectVersion(ctx, src, dst, bucket, version.Name, version, false)
+}
+
+// objectVersionStore is implemented by the pools and the erasure sets
+// object versions are copied between.
+type objectVersionStore interface {
+	GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (reader *GetObjectReader, err error)
+	PutObject(ctx context.Context, bucket, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error)
+	DeleteObject(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error)
+	DecomTieredObject(ctx context.Context, bucket, object string, fi FileInfo, opts ObjectOptions) error
+	NewMultipartUpload(ctx context.Context, bucket, object string, opts ObjectOptions) (uploadID string, err error)
+	PutObjectPart(ctx context.Context, bucket, object, uploadID string, partID int, data *PutObjReader, opts ObjectOptions) (info PartInfo, err error)
+	AbortMultipartUpload(ctx context.Context, bucket, object, uploadID string, opts ObjectOptions) error
+	CompleteMultipartUpload(ctx context.Context, bucket, object, uploadID string, uploadedParts []CompletePart, opts ObjectOptions) (objInfo ObjectInfo, err error)
+}
+
+// copyObjectVersion copies a single version of version.Name from src into
+// dstObject of dst preserving version ID, modification time and metadata.
+// With dstLocked the caller holds the write lock of dstObject on dst.
+func copyObjectVersion(ctx context.Context, src, dst objectVersionStore, bucket, dstObject string, version FileInfo, dstLocked bool) error {
+	if version.Deleted {
+		// Recreate the delete marker with the same version ID on the target pool.
+		_, err := dst.DeleteObject(ctx, bucket, dstObject, ObjectOptions{
+			Versioned:                     true,
+			VersionID:                     version.VersionID,
+			MTime:                         version.ModTime,
+			DeleteMarker:                  true,
+			DeleteMarkerReplicationStatus: version.DeleteMarkerReplicationStatus,
+			VersionPurgeStatus:            version.VersionPurgeStatus,
+			NoLock:                        dstLocked,
+		})
+		return err
+	}
+
+	if version.TransitionStatus == lifecycle.TransitionComplete {
+		// Content lives on the remote tier, only move the metadata.
+		return dst.DecomTieredObject(ctx, bucket, dstObject, version, ObjectOptions{NoLock: dstLocked})
+	}
+
+	vid := version.VersionID
+	if vid == "" {
+		vid = nullVersionID
+	}
+	gr, err := src.GetObjectNInfo(ctx, bucket, version.Name, nil, http.Header{}, noLock, ObjectOptions{
+		VersionID:    vid,
+		NoDecryption: true,
+	})
+	if err != nil {
+		return err
+	}
+	defer gr.Close()
+
+	metadata := make(map[string]string, len(version.Metadata))
+	for k, v := range version.Metadata {
+		switch k {
+		case ReservedMetadataPrefixLower + "inline-data":
+			// the target pool decides whether the data is inlined.
+			continue
+		}
+		metadata[k] = v
+	}
+
+	opts := ObjectOptions{
+		VersionID:   version.VersionID,
+		Versioned:   version.VersionID != "",
+		MTime:       version.ModTime,
+		UserDefined: metadata,
+		MaxParity:   bucket == minioMetaBucket,
+		NoLock:      dstLocked,
+	}
+
+	if len(version.Parts) > 1 {
+		// Preserve the part layout of multipart objects, encrypted
+		// and compressed objects depend on it.
+		etag := metadata["etag"]
+		delete(metadata, "etag")
+		uploadID, err := dst.NewMultipartUpload(ctx, bucket, dstObject, opts)
+		if err != nil {
+			return err
+		}
+		defer func() {
+			if err != nil {
+				dst.AbortMultipartUpload(ctx, bucket, dstObject, uploadID, ObjectOptions{})
+			}
+		}()
+		parts := make([]CompletePart, 0, len(version.Parts))
+		for _, part := range version.Parts {
+			var hr *hash.Reader
+			hr, err = hash.NewReader(gr, part.Size, "", "", part.ActualSize)
+			if err != nil {
+				return err
+			}
+			var pi PartInfo
+			pi, err = dst.PutObjectPart(ctx, bucket, dstObject, uploadID, part.Number, NewPutObjReader(hr), ObjectOptions{})
+			if err != nil {
+				return err
+			}
+			parts = append(parts, CompletePart{
+				PartNumber: pi.PartNumber,
+				ETag:       pi.ETag,
+			})
+		}
+		_, err = dst.CompleteMultipartUpload(ctx, bucket, dstObject, uploadID, parts, ObjectOptions{
+			MTime:       version.ModTime,
+			UserDefined: map[string]string{"etag": etag},
+			NoLock:      dstLocked,
+		})
+		return err
+	}
+
+	actualSize := version.Size
+	if len(version.Parts) == 1 {
+		actualSize = version.Parts[0].ActualSize
+	}
+	hr, err := hash.NewReader(gr, version.Size, "", "", actualSize)
+	if err != nil {
+		return err
+	}
+	_, err = dst.PutObject(ctx, bucket, dstObject, NewPutObjReader(hr), opts)
+	return err
+}
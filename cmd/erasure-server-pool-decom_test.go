@@ -0,0 +1,521 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/dustin/go-humanize"
+)
+
+func prepareErasurePools() (ObjectLayer, []string, error) {
+	nDisks := 8
+	fsDirs, err := getRandomDisks(nDisks)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	pools := EndpointServerPools{}
+	for _, dirs := range [][]string{fsDirs[:4], fsDirs[4:]} {
+		pools = append(pools, PoolEndpoints{
+			SetCount:     1,
+			DrivesPerSet: len(dirs),
+			Endpoints:    mustGetNewEndpoints(dirs...),
+			CmdLine:      strings.Join(dirs, " "),
+		})
+	}
+
+	globalEndpoints = pools
+	objLayer, _, err := initObjectLayer(context.Background(), pools)
+	if err != nil {
+		removeRoots(fsDirs)
+		return nil, nil, err
+	}
+	return objLayer, fsDirs, nil
+}
+
+func TestPoolMetaValidate(t *testing.T) {
+	pools := EndpointServerPools{
+		{CmdLine: "http://server{1...4}/disk{1...4}"},
+		{CmdLine: "http://server{5...8}/disk{1...4}"},
+	}
+
+	testCases := []struct {
+		meta           poolMeta
+		pools          EndpointServerPools
+		expectedUpdate bool
+		expectedPools  []string
+	}{
+		// Test 1: no pools remembered, all specified pools are added.
+		{
+			meta:           poolMeta{},
+			pools:          pools,
+			expectedUpdate: true,
+			expectedPools:  []string{pools[0].CmdLine, pools[1].CmdLine},
+		},
+		// Test 2: remembered pools match the specified pools.
+		{
+			meta: poolMeta{
+				Version: poolMetaVersion,
+				Pools: []PoolStatus{
+					{ID: 0, CmdLine: pools[0].CmdLine},
+					{ID: 1, CmdLine: pools[1].CmdLine},
+				},
+			},
+			pools:          pools,
+			expectedUpdate: false,
+			expectedPools:  []string{pools[0].CmdLine, pools[1].CmdLine},
+		},
+		// Test 3: decommissioned pool removed from the command line.
+		{
+			meta: poolMeta{
+				Version: poolMetaVersion,
+				Pools: []PoolStatus{
+					{ID: 0, CmdLine: pools[0].CmdLine, Decommission: &PoolDecommissionInfo{Complete: true}},
+					{ID: 1, CmdLine: pools[1].CmdLine},
+				},
+			},
+			pools:          pools[1:],
+			expectedUpdate: true,
+			expectedPools:  []string{pools[1].CmdLine},
+		},
+		// Test 4: new pool added to the command line.
+		{
+			meta: poolMeta{
+				Version: poolMetaVersion,
+				Pools: []PoolStatus{
+					{ID: 0, CmdLine: pools[0].CmdLine},
+				},
+			},
+			pools:          pools,
+			expectedUpdate: true,
+			expectedPools:  []string{pools[0].CmdLine, pools[1].CmdLine},
+		},
+	}
+
+	for i, testCase := range testCases {
+		update, err := testCase.meta.validate(testCase.pools)
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if update != testCase.expectedUpdate {
+			t.Errorf("Test %d: expected update %v, got %v", i+1, testCase.expectedUpdate, update)
+		}
+		if len(testCase.meta.Pools) != len(testCase.expectedPools) {
+			t.Fatalf("Test %d: expected %d pools, got %d", i+1, len(testCase.expectedPools), len(testCase.meta.Pools))
+		}
+		for idx, pool := range testCase.meta.Pools {
+			if pool.CmdLine != testCase.expectedPools[idx] || pool.ID != idx {
+				t.Errorf("Test %d: expected pool %d to be %s, got %d:%s", i+1, idx, testCase.expectedPools[idx], pool.ID, pool.CmdLine)
+			}
+		}
+	}
+}
+
+func TestPoolMetaDecommission(t *testing.T) {
+	meta := poolMeta{
+		Version: poolMetaVersion,
+		Pools:   []PoolStatus{{ID: 0}, {ID: 1}},
+	}
+
+	if meta.IsSuspended(0) {
+		t.Fatal("pool must not be suspended before decommission")
+	}
+	if err := meta.Decommission(0, poolSpaceInfo{Free: 10, Total: 100}); err != nil {
+		t.Fatal(err)
+	}
+	if !meta.IsSuspended(0) || meta.IsSuspended(1) {
+		t.Fatal("only the decommissioned pool must be suspended")
+	}
+	if err := meta.Decommission(1, poolSpaceInfo{}); err != errDecommissionAlreadyRunning {
+		t.Fatalf("expected %v, got %v", errDecommissionAlreadyRunning, err)
+	}
+
+	meta.QueueBuckets(0, []string{"bucket1", "bucket2", "bucket1"})
+	if pending := meta.PendingBuckets(0); len(pending) != 2 {
+		t.Fatalf("expected 2 pending buckets, got %v", pending)
+	}
+	meta.BucketDone(0, "bucket1")
+	meta.QueueBuckets(0, []string{"bucket1"})
+	if pending := meta.PendingBuckets(0); len(pending) != 1 || pending[0] != "bucket2" {
+		t.Fatalf("expected only bucket2 to be pending, got %v", pending)
+	}
+	if len(meta.returnResumablePools()) != 1 {
+		t.Fatal("expected decommission to be resumable")
+	}
+
+	if !meta.DecommissionCancel(0) {
+		t.Fatal("expected decommission to be canceled")
+	}
+	if meta.IsSuspended(0) {
+		t.Fatal("pool must be writable after decommission is canceled")
+	}
+	if len(meta.returnResumablePools()) != 0 {
+		t.Fatal("canceled decommission must not be resumed")
+	}
+
+	// Verify the metadata survives a round trip.
+	buf, err := meta.MarshalMsg(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var meta2 poolMeta
+	if _, err = meta2.UnmarshalMsg(buf); err != nil {
+		t.Fatal(err)
+	}
+	if meta2.Pools[0].Decommission == nil || !meta2.Pools[0].Decommission.Canceled {
+		t.Fatal("decommission status was not preserved")
+	}
+}
+
+func TestPoolDecommission(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	mtime := time.Now().UTC().Add(-time.Hour).Truncate(time.Second)
+
+	// Two versions and a delete marker of the same object on the first pool.
+	var versions []ObjectInfo
+	for i := 0; i < 2; i++ {
+		data := bytes.Repeat([]byte{byte('a' + i)}, 1024)
+		oi, err := z.serverPools[0].PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{
+			Versioned: true,
+			MTime:     mtime.Add(time.Duration(i) * time.Minute),
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+		versions = append(versions, oi)
+	}
+	dm, err := z.serverPools[0].DeleteObject(ctx, bucket, "object", ObjectOptions{
+		Versioned: true,
+		MTime:     mtime.Add(2 * time.Minute),
+	})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	// A multipart object on the first pool.
+	uploadID, err := z.serverPools[0].NewMultipartUpload(ctx, bucket, "multipart", ObjectOptions{Versioned: true})
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parts []CompletePart
+	for i := 1; i <= 2; i++ {
+		data := bytes.Repeat([]byte{byte('0' + i)}, 5*humanize.MiByte)
+		pi, err := z.serverPools[0].PutObjectPart(ctx, bucket, "multipart", uploadID, i, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag})
+	}
+	mpOI, err := z.serverPools[0].CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, parts, ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	idx, err := z.getPoolIdxByCmdLine(globalEndpoints[0].CmdLine)
+	if err != nil || idx != 0 {
+		t.Fatalf("unexpected pool index %d: %v", idx, err)
+	}
+
+	if err = z.StartDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if !z.IsSuspended(0) || z.IsSuspended(1) {
+		t.Fatal("only the first pool must be suspended")
+	}
+	if err = z.StartDecommission(ctx, 0); err != errDecommissionAlreadyRunning {
+		t.Fatalf("expected %v, got %v", errDecommissionAlreadyRunning, err)
+	}
+
+	// New objects must not be placed on a suspended pool.
+	for i := 0; i < 10; i++ {
+		if pidx := z.getAvailablePoolIdx(ctx, bucket, "new-object", 1024); pidx != 1 {
+			t.Fatalf("expected new objects to be placed on pool 1, got %d", pidx)
+		}
+	}
+
+	if err = z.decommissionInBackground(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.decommissionComplete(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	status, err := z.Status(ctx, 0)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !status.Decommission.Complete || status.Decommission.ItemsDecommissionFailed != 0 {
+		t.Fatalf("unexpected decommission status %#v", status.Decommission)
+	}
+	// Bucket metadata on the pool is moved as well.
+	if status.Decommission.ItemsDecommissioned < 4 {
+		t.Fatalf("expected at least 4 versions to be decommissioned, got %d", status.Decommission.ItemsDecommissioned)
+	}
+
+	// Nothing must be left on the decommissioned pool.
+	for _, object := range []string{"object", "multipart"} {
+		if _, err = z.serverPools[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("%s: expected object not found on the decommissioned pool, got %v", object, err)
+		}
+	}
+
+	// All versions must be available with the same version ID, mod time and content.
+	for i, version := range versions {
+		gr, err := z.GetObjectNInfo(ctx, bucket, "object", nil, http.Header{}, readLock, ObjectOptions{VersionID: version.VersionID})
+		if err != nil {
+			t.Fatal(err)
+		}
+		data, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatal(err)
+		}
+		if !bytes.Equal(data, bytes.Repeat([]byte{byte('a' + i)}, 1024)) {
+			t.Errorf("version %s: content mismatch", version.VersionID)
+		}
+		if !gr.ObjInfo.ModTime.Equal(version.ModTime) {
+			t.Errorf("version %s: expected mod time %v, got %v", version.VersionID, version.ModTime, gr.ObjInfo.ModTime)
+		}
+		if gr.ObjInfo.ETag != version.ETag {
+			t.Errorf("version %s: expected etag %s, got %s", version.VersionID, version.ETag, gr.ObjInfo.ETag)
+		}
+	}
+
+	// Latest version must still be the delete marker.
+	if _, err = z.GetObjectInfo(ctx, bucket, "object", ObjectOptions{}); !isErrObjectNotFound(err) {
+		t.Fatalf("expected latest version to be a delete marker, got %v", err)
+	}
+	oi, err := z.GetObjectInfo(ctx, bucket, "object", ObjectOptions{VersionID: dm.VersionID})
+	if err == nil || !oi.DeleteMarker {
+		t.Fatalf("expected delete marker %s to be preserved, got %v", dm.VersionID, err)
+	}
+
+	oi, err = z.GetObjectInfo(ctx, bucket, "multipart", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if oi.VersionID != mpOI.VersionID || oi.ETag != mpOI.ETag || len(oi.Parts) != 2 {
+		t.Fatalf("multipart object was not preserved, expected %s/%s, got %s/%s (%d parts)",
+			mpOI.VersionID, mpOI.ETag, oi.VersionID, oi.ETag, len(oi.Parts))
+	}
+
+	// Decommission status must survive a reload from disk.
+	if err = z.ReloadPoolMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !z.IsSuspended(0) || !z.poolMeta.Pools[0].Decommission.Complete {
+		t.Fatal("decommission status was not persisted")
+	}
+	if err = z.StartDecommission(ctx, 0); err != errDecommissionComplete {
+		t.Fatalf("expected %v, got %v", errDecommissionComplete, err)
+	}
+}
+
+func TestPoolSuspendedWritePreconditions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 1024)
+	oi, err := z.serverPools[0].PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = z.StartDecommission(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	// Appends could be lost when the object is moved concurrently.
+	_, err = obj.AppendObject(ctx, bucket, "object", oi.Size, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+	if _, ok := err.(NotImplemented); !ok {
+		t.Fatalf("expected NotImplemented for an append on a suspended pool, got %v", err)
+	}
+
+	// The object only exists on the suspended pool, new versions are
+	// written to the other pool but must see the existing object.
+	ifNoneMatch := ObjectOptions{CheckPrecondFn: func(oi ObjectInfo) bool { return oi.ETag != "" }}
+	ifMatch := func(etag string) ObjectOptions {
+		return ObjectOptions{CheckPrecondFn: func(oi ObjectInfo) bool { return oi.ETag != etag }}
+	}
+
+	_, err = obj.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ifNoneMatch)
+	if _, ok := err.(PreConditionFailed); !ok {
+		t.Fatalf("expected PreConditionFailed for If-None-Match, got %v", err)
+	}
+	_, err = obj.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ifMatch("mismatch"))
+	if _, ok := err.(PreConditionFailed); !ok {
+		t.Fatalf("expected PreConditionFailed for If-Match, got %v", err)
+	}
+
+	srcInfo, err := obj.GetObjectInfo(ctx, bucket, "object", ObjectOptions{})
+	if err != nil {
+		t.Fatal(err)
+	}
+	copyObject := func(dst string, opts ObjectOptions) (ObjectInfo, error) {
+		gr, err := obj.GetObjectNInfo(ctx, bucket, "object", nil, http.Header{}, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer gr.Close()
+		srcInfo.PutObjReader = mustGetPutObjReader(t, gr, srcInfo.Size, "", "")
+		return obj.CopyObject(ctx, bucket, "object", bucket, dst, srcInfo, ObjectOptions{}, opts)
+	}
+	if _, err = copyObject("object", ifNoneMatch); err == nil {
+		t.Fatal("expected the conditional copy onto an existing object to fail")
+	} else if _, ok := err.(PreConditionFailed); !ok {
+		t.Fatalf("expected PreConditionFailed for If-None-Match copy, got %v", err)
+	}
+	if _, err = copyObject("copy", ifNoneMatch); err != nil {
+		t.Fatalf("expected the conditional copy onto a new object to succeed, got %v", err)
+	}
+
+	noi, err := obj.PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ifMatch(oi.ETag))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if _, err = z.serverPools[1].GetObjectInfo(ctx, bucket, "object", ObjectOptions{}); err != nil {
+		t.Fatalf("expected the new version on the active pool, got %v", err)
+	}
+	if noi.ETag != oi.ETag {
+		t.Fatalf("unexpected ETag %s", noi.ETag)
+	}
+}
+
+func TestPoolCompleteMultipartPreconditions(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+
+	data := bytes.Repeat([]byte("a"), 1024)
+	ifNoneMatch := ObjectOptions{CheckPrecondFn: func(oi ObjectInfo) bool { return oi.ETag != "" }}
+	newUpload := func(object string, pool int) (string, []CompletePart) {
+		uploadID, err := z.serverPools[pool].NewMultipartUpload(ctx, bucket, object, ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		pi, err := obj.PutObjectPart(ctx, bucket, object, uploadID, 1, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatal(err)
+		}
+		return uploadID, []CompletePart{{PartNumber: 1, ETag: pi.ETag}}
+	}
+
+	// The object exists on another pool than the upload.
+	if _, err = z.serverPools[1].PutObject(ctx, bucket, "object", mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	uploadID, parts := newUpload("object", 0)
+	_, err = obj.CompleteMultipartUpload(ctx, bucket, "object", uploadID, parts, ifNoneMatch)
+	if _, ok := err.(PreConditionFailed); !ok {
+		t.Fatalf("expected PreConditionFailed for If-None-Match, got %v", err)
+	}
+
+	// Conditional uploads racing with conditional writes to any pool,
+	// only one of them may create the object.
+	for i := 0; i < 10; i++ {
+		object := fmt.Sprintf("race-%d", i)
+		uploadID, parts := newUpload(object, i%2)
+
+		var wg sync.WaitGroup
+		errs := make([]error, 2)
+		wg.Add(2)
+		go func() {
+			defer wg.Done()
+			_, errs[0] = obj.CompleteMultipartUpload(ctx, bucket, object, uploadID, parts, ifNoneMatch)
+		}()
+		go func() {
+			defer wg.Done()
+			_, errs[1] = obj.PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ifNoneMatch)
+		}()
+		wg.Wait()
+
+		var created int
+		for _, err := range errs {
+			switch err.(type) {
+			case nil:
+				created++
+			case PreConditionFailed:
+			default:
+				t.Fatalf("%s: unexpected error %v", object, err)
+			}
+		}
+		if created != 1 {
+			t.Fatalf("%s: expected one conditional write to succeed, got %d", object, created)
+		}
+	}
+}
@@ -0,0 +1,668 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math/rand"
+	"net/http"
+	"sync"
+	"time"
+
+	"github.com/minio/minio/internal/config/rebalance"
+	"github.com/minio/minio/internal/logger"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore rebalanceConfig
+
+// rebalStatus is the status of rebalancing a pool
+type rebalStatus uint8
+
+const (
+	rebalNone rebalStatus = iota
+	rebalStarted
+	rebalCompleted
+	rebalStopped
+	rebalFailed
+)
+
+func (rs rebalStatus) String() string {
+	switch rs {
+	case rebalStarted:
+		return "Started"
+	case rebalCompleted:
+		return "Completed"
+	case rebalStopped:
+		return "Stopped"
+	case rebalFailed:
+		return "Failed"
+	default:
+		return "None"
+	}
+}
+
+type rebalanceInfo struct {
+	StartTime time.Time   `msg:"startTs"` // Time at which rebalance-start was issued
+	EndTime   time.Time   `msg:"stopTs"`  // Time at which rebalance operation completed or rebalance-stop was called
+	Status    rebalStatus `msg:"status"`  // Current state of rebalance operation
+}
+
+// rebalanceStats contains the rebalance progress of a single pool.
+type rebalanceStats struct {
+	InitFreeSpace     uint64        `msg:"ifs"` // Pool free space at the start of rebalance
+	InitCapacity      uint64        `msg:"ic"`  // Pool capacity at the start of rebalance
+	Buckets           []string      `msg:"bus"` // buckets being rebalanced or to be rebalanced
+	RebalancedBuckets []string      `msg:"rbs"` // buckets rebalanced
+	Bucket            string        `msg:"bu"`  // Last rebalanced bucket
+	Object            string        `msg:"ob"`  // Last rebalanced object
+	NumObjects        uint64        `msg:"no"`  // Number of objects rebalanced
+	NumVersions       uint64        `msg:"nv"`  // Number of versions rebalanced
+	Bytes             uint64        `msg:"bs"`  // Number of bytes rebalanced
+	Participating     bool          `msg:"par"` // Whether the pool moves objects out
+	Info              rebalanceInfo `msg:"inf"`
+}
+
+func (rs *rebalanceStats) update(bucket string, version FileInfo) {
+	rs.NumVersions++
+	rs.Bytes += uint64(version.Size)
+	rs.Bucket = bucket
+	rs.Object = version.Name
+}
+
+// rebalanceMeta is the rebalance state persisted in the meta bucket,
+// shared by all the nodes of the cluster.
+type rebalanceMeta struct {
+	cancel          context.CancelFunc `msg:"-"`      // to be invoked on rebalance-stop
+	StoppedAt       time.Time          `msg:"stopTs"` // Time when rebalance-stop was issued.
+	ID              string             `msg:"id"`     // ID of the ongoing rebalance operation
+	PercentFreeGoal float64            `msg:"pf"`     // Computed from total free space and capacity at the start of rebalance
+	PoolStats       []*rebalanceStats  `msg:"rss"`    // Per-pool rebalance stats keyed by pool index
+}
+
+const (
+	rebalMetaName    = "rebalance.bin"
+	rebalMetaFmt     = 1
+	rebalMetaVerV1   = 1
+	rebalMetaVersion = rebalMetaVerV1
+)
+
+// load reads the rebalance metadata from store.
+func (r *rebalanceMeta) load(ctx context.Context, store objectIO) error {
+	data, err := readConfig(ctx, store, rebalMetaName)
+	if err != nil {
+		return err
+	}
+	if len(data) <= 4 {
+		return fmt.Errorf("rebalanceMeta: no data")
+	}
+
+	// Read header
+	switch binary.LittleEndian.Uint16(data[0:2]) {
+	case rebalMetaFmt:
+	default:
+		return fmt.Errorf("rebalanceMeta: unknown format: %d", binary.LittleEndian.Uint16(data[0:2]))
+	}
+	switch binary.LittleEndian.Uint16(data[2:4]) {
+	case rebalMetaVersion:
+	default:
+		return fmt.Errorf("rebalanceMeta: unknown version: %d", binary.LittleEndian.Uint16(data[2:4]))
+	}
+
+	// OK, parse data.
+	if _, err = r.UnmarshalMsg(data[4:]); err != nil {
+		return err
+	}
+	return nil
+}
+
+// save writes the rebalance metadata to store.
+func (r *rebalanceMeta) save(ctx context.Context, store objectIO) error {
+	data := make([]byte, 4, r.Msgsize()+4)
+
+	// Initialize the header.
+	binary.LittleEndian.PutUint16(data[0:2], rebalMetaFmt)
+	binary.LittleEndian.PutUint16(data[2:4], rebalMetaVersion)
+
+	buf, err := r.MarshalMsg(data)
+	if err != nil {
+		return err
+	}
+
+	return saveConfig(ctx, store, rebalMetaName, buf)
+}
+
+// rebalanceConfig holds the dynamic rebalance settings.
+type rebalanceConfig struct {
+	mu        sync.RWMutex
+	threshold float64
+}
+
+// Update updates the rebalance settings and the throttling of running rebalance.
+func (r *rebalanceConfig) Update(cfg rebalance.Config) error {
+	r.mu.Lock()
+	r.threshold = cfg.Threshold
+	r.mu.Unlock()
+	return rebalanceSleeper.Update(cfg.Delay, cfg.MaxWait)
+}
+
+// Threshold returns the allowed difference between the free space
+// ratio of a pool and the cluster average, as a fraction.
+func (r *rebalanceConfig) Threshold() float64 {
+	r.mu.RLock()
+	defer r.mu.RUnlock()
+	return r.threshold / 100
+}
+
+var (
+	globalRebalanceConfig = &rebalanceConfig{threshold: 5}
+	rebalanceSleeper      = newDynamicSleeper(1, time.Second)
+)
+
+var (
+	// error returned when a rebalance is already running
+	errRebalanceAlreadyRunning = AdminError{
+		Code:       "XMinioRebalanceAlreadyRunning",
+		Message:    "Rebalance is already in progress",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when no rebalance was started
+	errRebalanceNotStarted = AdminError{
+		Code:       "XMinioRebalanceNotStarted",
+		Message:    "Rebalance is not in progress",
+		StatusCode: http.StatusNotFound,
+	}
+	// error returned when the pools are already balanced
+	errRebalanceNotNeeded = AdminError{
+		Code:       "XMinioRebalanceNotNeeded",
+		Message:    "Free space of all pools is within the rebalance threshold",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when a decommission is running
+	errRebalanceDecommissionRunning = AdminError{
+		Code:       "XMinioRebalanceNotAllowed",
+		Message:    "Rebalance is not allowed while a pool is being decommissioned",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when a rebalance is running
+	errDecommissionRebalanceRunning = AdminError{
+		Code:       "XMinioDecommissionNotAllowed",
+		Message:    "Decommission is not allowed while rebalance is in progress",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// initRebalanceMeta computes the free space goal and the pools which need
+// to move objects out, and persists the new rebalance metadata.
+func (z *erasureServerPools) initRebalanceMeta(ctx context.Context, buckets []string) (string, error) {
+	z.poolMetaMutex.RLock()
+	decommissioning := z.poolMeta.isDecommissioning()
+	z.poolMetaMutex.RUnlock()
+	if decommissioning {
+		return "", errRebalanceDecommissionRunning
+	}
+
+	if z.IsRebalanceStarted() {
+		return "", errRebalanceAlreadyRunning
+	}
+
+	// Fetch disk capacity and available space.
+	var totalCap, totalFree uint64
+	poolSpaces := make([]poolSpaceInfo, len(z.serverPools))
+	for idx := range z.serverPools {
+		pi, err := z.getPoolSpaceInfo(idx)
+		if err != nil {
+			return "", err
+		}
+		poolSpaces[idx] = pi
+		totalCap += uint64(pi.Total)
+		totalFree += uint64(pi.Free)
+	}
+	if totalCap == 0 {
+		return "", errRebalanceNotNeeded
+	}
+	percentFreeGoal := float64(totalFree) / float64(totalCap)
+	threshold := globalRebalanceConfig.Threshold()
+
+	now := time.Now()
+	participating := false
+	poolStats := make([]*rebalanceStats, len(z.serverPools))
+	for idx, pi := range poolSpaces {
+		if z.IsSuspended(idx) {
+			// Pools being decommissioned neither give nor receive objects.
+			poolStats[idx] = &rebalanceStats{}
+			continue
+		}
+		ps := &rebalanceStats{
+			InitFreeSpace: uint64(pi.Free),
+			InitCapacity:  uint64(pi.Total),
+		}
+		if pi.Total > 0 && float64(pi.Free)/float64(pi.Total) < percentFreeGoal-threshold {
+			ps.Participating = true
+			ps.Buckets = append([]string(nil), buckets...)
+			ps.Info = rebalanceInfo{
+				StartTime: now,
+				Status:    rebalStarted,
+			}
+			participating = true
+		}
+		poolStats[idx] = ps
+	}
+	if !participating {
+		return "", errRebalanceNotNeeded
+	}
+
+	r := &rebalanceMeta{
+		ID:              mustGetUUID(),
+		PercentFreeGoal: percentFreeGoal,
+		PoolStats:       poolStats,
+	}
+	if err := r.save(ctx, z); err != nil {
+		return "", err
+	}
+
+	z.rebalMu.Lock()
+	z.rebalMeta = r
+	z.rebalMu.Unlock()
+	return r.ID, nil
+}
+
+// loadRebalanceMeta loads the rebalance metadata from disk.
+func (z *erasureServerPools) loadRebalanceMeta(ctx context.Context) error {
+	r := &rebalanceMeta{}
+	err := r.load(ctx, z)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return nil
+		}
+		return err
+	}
+
+	z.rebalMu.Lock()
+	if len(r.PoolStats) == len(z.serverPools) {
+		if cur := z.rebalMeta; cur != nil && cur.ID == r.ID {
+			// Keep the state of pools rebalanced by this node,
+			// it is more recent than the persisted state.
+			r.cancel = cur.cancel
+			for idx, ps := range cur.PoolStats {
+				if ps.Participating && globalEndpoints[idx].Endpoints[0].IsLocal {
+					r.PoolStats[idx] = ps
+				}
+			}
+		}
+		z.rebalMeta = r
+	} else {
+		// Pools were added or removed since, the
+		// rebalance metadata is no longer valid.
+		z.rebalMeta = nil
+	}
+	z.rebalMu.Unlock()
+	return nil
+}
+
+// IsRebalanceStarted returns true if a rebalance is in progress.
+func (z *erasureServerPools) IsRebalanceStarted() bool {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	if r := z.rebalMeta; r != nil {
+		if !r.StoppedAt.IsZero() {
+			return false
+		}
+		for _, ps := range r.PoolStats {
+			if ps.Participating && ps.Info.Status == rebalStarted {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// IsPoolRebalancing returns true if the pool at idx is moving objects out.
+func (z *erasureServerPools) IsPoolRebalancing(idx int) bool {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	if r := z.rebalMeta; r != nil {
+		if !r.StoppedAt.IsZero() {
+			return false
+		}
+		ps := r.PoolStats[idx]
+		return ps.Participating && ps.Info.Status == rebalStarted
+	}
+	return false
+}
+
+// StartRebalance starts rebalancing of all participating pools whose first
+// drive is local to this node, this avoids running it on multiple nodes.
+func (z *erasureServerPools) StartRebalance() {
+	z.rebalMu.Lock()
+	if z.rebalMeta == nil || !z.rebalMeta.StoppedAt.IsZero() { // rebalance not running, nothing to do
+		z.rebalMu.Unlock()
+		return
+	}
+	ctx, cancel := context.WithCancel(GlobalContext)
+	z.rebalMeta.cancel = cancel // to be used when rebalance-stop is called
+
+	var pools []int
+	for idx, ps := range z.rebalMeta.PoolStats {
+		if !ps.Participating || ps.Info.Status != rebalStarted {
+			continue
+		}
+		if !globalEndpoints[idx].Endpoints[0].IsLocal {
+			continue
+		}
+		pools = append(pools, idx)
+	}
+	z.rebalMu.Unlock()
+
+	for _, idx := range pools {
+		go func(idx int) {
+			logger.LogIf(ctx, z.rebalanceBuckets(ctx, idx))
+		}(idx)
+	}
+}
+
+// StopRebalance cancels the rebalance routines running on this node,
+// the caller is responsible to persist the stopped state.
+func (z *erasureServerPools) StopRebalance() error {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	r := z.rebalMeta
+	if r == nil { // rebalance not running in this node, nothing to do
+		return nil
+	}
+	if r.StoppedAt.IsZero() {
+		r.StoppedAt = time.Now()
+	}
+	if cancel := r.cancel; cancel != nil {
+		cancel()
+		r.cancel = nil
+	}
+	return nil
+}
+
+type rebalSaveOpts uint8
+
+const (
+	rebalSaveStats rebalSaveOpts = iota
+	rebalSaveStoppedAt
+)
+
+// saveRebalanceStats merges the in-memory state of the pool at poolIdx into
+// the rebalance metadata on disk, every node only updates the pools it runs.
+func (z *erasureServerPools) saveRebalanceStats(ctx context.Context, poolIdx int, opts rebalSaveOpts) error {
+	// Reading and writing rebalance.bin locks the object itself,
+	// serialize the read-modify-write cycle with a separate lock.
+	lock := z.NewNSLock(minioMetaBucket, rebalMetaName+".lck")
+	lkCtx, err := lock.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	defer lock.Unlock(lkCtx.Cancel)
+	ctx = lkCtx.Context()
+
+	r := &rebalanceMeta{}
+	if err = r.load(ctx, z); err != nil {
+		return err
+	}
+
+	z.rebalMu.Lock()
+	if z.rebalMeta == nil || z.rebalMeta.ID != r.ID || len(r.PoolStats) != len(z.rebalMeta.PoolStats) {
+		// A different rebalance was started since.
+		z.rebalMu.Unlock()
+		return nil
+	}
+	switch opts {
+	case rebalSaveStoppedAt:
+		if r.StoppedAt.IsZero() {
+			r.StoppedAt = z.rebalMeta.StoppedAt
+		}
+		for _, ps := range r.PoolStats {
+			if ps.Participating && ps.Info.Status == rebalStarted {
+				ps.Info.Status = rebalStopped
+				ps.Info.EndTime = r.StoppedAt
+			}
+		}
+	case rebalSaveStats:
+		ps := *z.rebalMeta.PoolStats[poolIdx]
+		if !r.StoppedAt.IsZero() && ps.Info.Status == rebalStarted {
+			ps.Info.Status = rebalStopped
+			ps.Info.EndTime = r.StoppedAt
+		}
+		r.PoolStats[poolIdx] = &ps
+	}
+	z.rebalMu.Unlock()
+
+	return r.save(ctx, z)
+}
+
+// nextRebalBucket returns the next bucket to be rebalanced on the pool at poolIdx.
+func (z *erasureServerPools) nextRebalBucket(poolIdx int) (string, bool) {
+	z.rebalMu.RLock()
+	defer z.rebalMu.RUnlock()
+
+	ps := z.rebalMeta.PoolStats[poolIdx]
+	if ps.Info.Status != rebalStarted {
+		return "", false
+	}
+	if len(ps.Buckets) == 0 {
+		return "", false
+	}
+	return ps.Buckets[0], true
+}
+
+// bucketRebalanceDone marks bucket as rebalanced on the pool at poolIdx.
+func (z *erasureServerPools) bucketRebalanceDone(bucket string, poolIdx int) {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	ps := z.rebalMeta.PoolStats[poolIdx]
+	for i, b := range ps.Buckets {
+		if b == bucket {
+			ps.Buckets = append(ps.Buckets[:i], ps.Buckets[i+1:]...)
+			ps.RebalancedBuckets = append(ps.RebalancedBuckets, bucket)
+			break
+		}
+	}
+}
+
+// checkIfRebalanceDone returns true if the pool at poolIdx reached the
+// free space goal. The free space is estimated from the initial free space
+// and the bytes moved, which avoids querying the drives for every object.
+func (z *erasureServerPools) checkIfRebalanceDone(poolIdx int) bool {
+	z.rebalMu.Lock()
+	defer z.rebalMu.Unlock()
+
+	ps := z.rebalMeta.PoolStats[poolIdx]
+	if ps.Info.Status == rebalCompleted {
+		return true
+	}
+	if ps.InitCapacity == 0 {
+		return false
+	}
+
+	// Moved bytes free up space for data and parity on the drives.
+	pool := z.serverPools[poolIdx]
+	rawBytes := ps.Bytes
+	if dataDrives := pool.setDriveCount - pool.defaultParityCount; dataDrives > 0 {
+		rawBytes = ps.Bytes * uint64(pool.setDriveCount) / uint64(dataDrives)
+	}
+	pfi := float64(ps.InitFreeSpace+rawBytes) / float64(ps.InitCapacity)
+	if pfi >= z.rebalMeta.PercentFreeGoal {
+		ps.Info.Status = rebalCompleted
+		ps.Info.EndTime = time.Now()
+		return true
+	}
+	return false
+}
+
+// rebalanceBuckets moves objects out of the pool at poolIdx until it
+// reaches the free space goal or all buckets were processed.
+func (z *erasureServerPools) rebalanceBuckets(ctx context.Context, poolIdx int) (err error) {
+	doneCh := make(chan struct{})
+	defer close(doneCh)
+
+	// Save rebalance stats periodically.
+	go func() {
+		timer := time.NewTimer(randomRebalanceSaveInterval())
+		defer timer.Stop()
+		for {
+			select {
+			case <-doneCh:
+				return
+			case <-ctx.Done():
+				return
+			case <-timer.C:
+				logger.LogIf(ctx, z.saveRebalanceStats(ctx, poolIdx, rebalSaveStats))
+				timer.Reset(randomRebalanceSaveInterval())
+			}
+		}
+	}()
+
+	for {
+		bucket, ok := z.nextRebalBucket(poolIdx)
+		if !ok {
+			break
+		}
+
+		if err = z.rebalanceBucket(ctx, bucket, poolIdx); err != nil {
+			if errors.Is(err, context.Canceled) {
+				if ctx.Err() != nil {
+					// Rebalance was stopped or the server is shutting
+					// down, persist the progress of the pool, it is
+					// resumed upon restart unless it was stopped.
+					z.rebalMu.Lock()
+					if stoppedAt := z.rebalMeta.StoppedAt; !stoppedAt.IsZero() {
+						ps := z.rebalMeta.PoolStats[poolIdx]
+						ps.Info.Status = rebalStopped
+						ps.Info.EndTime = stoppedAt
+					}
+					z.rebalMu.Unlock()
+					return z.saveRebalanceStats(GlobalContext, poolIdx, rebalSaveStats)
+				}
+				// Pool reached the free space goal.
+				break
+			}
+			logger.LogIf(ctx, err)
+			z.rebalMu.Lock()
+			ps := z.rebalMeta.PoolStats[poolIdx]
+			ps.Info.Status = rebalFailed
+			ps.Info.EndTime = time.Now()
+			z.rebalMu.Unlock()
+			return z.saveRebalanceStats(GlobalContext, poolIdx, rebalSaveStats)
+		}
+		z.bucketRebalanceDone(bucket, poolIdx)
+		logger.LogIf(ctx, z.saveRebalanceStats(ctx, poolIdx, rebalSaveStats))
+	}
+
+	z.rebalMu.Lock()
+	ps := z.rebalMeta.PoolStats[poolIdx]
+	if ps.Info.Status == rebalStarted {
+		ps.Info.Status = rebalCompleted
+		ps.Info.EndTime = time.Now()
+	}
+	z.rebalMu.Unlock()
+	if err = z.saveRebalanceStats(GlobalContext, poolIdx, rebalSaveStats); err != nil {
+		return err
+	}
+
+	// Let the other nodes know this pool is done.
+	globalNotificationSys.LoadRebalanceMeta(GlobalContext, false)
+	return nil
+}
+
+func randomRebalanceSaveInterval() time.Duration {
+	return 30*time.Second + time.Duration(float64(10*time.Second)*rand.Float64())
+}
+
+// rebalanceBucket moves objects of bucket out of the pool at poolIdx, the
+// walk is stopped with context.Canceled once the pool reached the goal.
+func (z *erasureServerPools) rebalanceBucket(ctx context.Context, bucket string, poolIdx int) error {
+	if z.checkIfRebalanceDone(poolIdx) {
+		return context.Canceled
+	}
+
+	wctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	err := walkPoolObjects(wctx, z.serverPools[poolIdx], bucket, func(bucket string, fivs FileInfoVersions) {
+		if z.checkIfRebalanceDone(poolIdx) {
+			cancel()
+			return
+		}
+		z.rebalanceEntry(wctx, poolIdx, bucket, fivs)
+	})
+	if err == nil && z.checkIfRebalanceDone(poolIdx) {
+		return context.Canceled
+	}
+	return err
+}
+
+// rebalanceEntry moves all versions of one object out of the pool at poolIdx
+// into one of the pools which are not over-utilized.
+func (z *erasureServerPools) rebalanceEntry(ctx context.Context, poolIdx int, bucket string, fivs FileInfoVersions) {
+	if ctx.Err() != nil {
+		return
+	}
+
+	// Throttle rebalance relative to the time spent moving the object.
+	wait := rebalanceSleeper.Timer(ctx)
+	defer wait()
+
+	// Serialize with regular uploads of the same object, which
+	// choose the pool to write to holding the same lock.
+	ns := z.NewNSLock(minioMetaMultipartBucket, pathJoin(bucket, fivs.Name, "newMultipartObject.lck"))
+	lkctx, err := ns.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+	ctx = lkctx.Context()
+	defer ns.Unlock(lkctx.Cancel)
+
+	dstIdx, err := z.getTargetPoolIdx(ctx, bucket, fivs, func(i int) bool {
+		return i == poolIdx || z.IsSuspended(i) || z.IsPoolRebalancing(i)
+	})
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return
+	}
+
+	moved := false
+	z.moveObjectVersions(ctx, poolIdx, dstIdx, bucket, fivs, func(version FileInfo, err error) {
+		if err != nil {
+			logger.LogIf(ctx, fmt.Errorf("unable to rebalance %s/%s (%s): %w", bucket, version.Name, version.VersionID, err))
+			return
+		}
+		moved = true
+		z.rebalMu.Lock()
+		z.rebalMeta.PoolStats[poolIdx].update(bucket, version)
+		z.rebalMu.Unlock()
+	})
+
+	if moved {
+		z.rebalMu.Lock()
+		z.rebalMeta.PoolStats[poolIdx].NumObjects++
+		z.rebalMu.Unlock()
+	}
+}
@@ -0,0 +1,199 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"testing"
+	"time"
+)
+
+func TestRebalanceDone(t *testing.T) {
+	obj, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	pool := z.serverPools[0]
+	dataDrives := uint64(pool.setDriveCount - pool.defaultParityCount)
+
+	testCases := []struct {
+		freeSpace uint64
+		bytes     uint64
+		done      bool
+	}{
+		// Test 1: nothing moved yet.
+		{freeSpace: 400, bytes: 0, done: false},
+		// Test 2: moved bytes don't free enough space.
+		{freeSpace: 400, bytes: dataDrives, done: false},
+		// Test 3: moved bytes including parity reach the goal.
+		{freeSpace: 400, bytes: 100 * dataDrives, done: true},
+		// Test 4: pool already at the goal.
+		{freeSpace: 500, bytes: 0, done: true},
+	}
+
+	for i, testCase := range testCases {
+		z.rebalMeta = &rebalanceMeta{
+			PercentFreeGoal: 0.5,
+			PoolStats: []*rebalanceStats{
+				{
+					InitFreeSpace: testCase.freeSpace,
+					InitCapacity:  1000,
+					Bytes:         testCase.bytes,
+					Participating: true,
+					Info:          rebalanceInfo{Status: rebalStarted},
+				},
+				{},
+			},
+		}
+		if done := z.checkIfRebalanceDone(0); done != testCase.done {
+			t.Errorf("Test %d: expected done %v, got %v", i+1, testCase.done, done)
+		}
+		if testCase.done && z.rebalMeta.PoolStats[0].Info.Status != rebalCompleted {
+			t.Errorf("Test %d: expected pool to be marked completed", i+1)
+		}
+	}
+}
+
+func TestPoolRebalance(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasurePools()
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	z := obj.(*erasureServerPools)
+	if err = z.Init(ctx); err != nil {
+		t.Fatal(err)
+	}
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// Both pools share the same filesystem, so they are always balanced.
+	if _, err = z.initRebalanceMeta(ctx, []string{bucket}); err != errRebalanceNotNeeded {
+		t.Fatalf("expected %v, got %v", errRebalanceNotNeeded, err)
+	}
+
+	// Objects with two versions each, all on the first pool.
+	objects := make(map[string][]ObjectInfo)
+	for i := 0; i < 5; i++ {
+		object := fmt.Sprintf("object-%d", i)
+		for v := 0; v < 2; v++ {
+			data := bytes.Repeat([]byte{byte('a' + v)}, 1024)
+			oi, err := z.serverPools[0].PutObject(ctx, bucket, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{
+				Versioned: true,
+			})
+			if err != nil {
+				t.Fatal(err)
+			}
+			objects[object] = append(objects[object], oi)
+		}
+	}
+
+	// Force the first pool to move all of its objects, the goal
+	// can't be reached with the tiny objects of this test.
+	r := &rebalanceMeta{
+		ID:              mustGetUUID(),
+		PercentFreeGoal: 1,
+		PoolStats: []*rebalanceStats{
+			{
+				InitCapacity:  1 << 40,
+				Buckets:       []string{bucket},
+				Participating: true,
+				Info:          rebalanceInfo{StartTime: time.Now(), Status: rebalStarted},
+			},
+			{},
+		},
+	}
+	if err = r.save(ctx, z); err != nil {
+		t.Fatal(err)
+	}
+	if err = z.loadRebalanceMeta(ctx); err != nil {
+		t.Fatal(err)
+	}
+	if !z.IsRebalanceStarted() || !z.IsPoolRebalancing(0) || z.IsPoolRebalancing(1) {
+		t.Fatal("only the first pool must be rebalancing")
+	}
+
+	// Appends could be lost when the object is moved concurrently.
+	_, err = obj.AppendObject(ctx, bucket, "object-0", objects["object-0"][1].Size, mustGetPutObjReader(t, bytes.NewReader([]byte("x")), 1, "", ""), ObjectOptions{Versioned: true})
+	if _, ok := err.(NotImplemented); !ok {
+		t.Fatalf("expected NotImplemented for an append on a rebalancing pool, got %v", err)
+	}
+	if _, err = z.initRebalanceMeta(ctx, []string{bucket}); err != errRebalanceAlreadyRunning {
+		t.Fatalf("expected %v, got %v", errRebalanceAlreadyRunning, err)
+	}
+	if err = z.Decommission(ctx, 0); err != errDecommissionRebalanceRunning {
+		t.Fatalf("expected %v, got %v", errDecommissionRebalanceRunning, err)
+	}
+
+	if err = z.rebalanceBuckets(ctx, 0); err != nil {
+		t.Fatal(err)
+	}
+
+	rs, err := rebalanceStatus(ctx, z)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rs.ID != r.ID || rs.Pools[0].Status != rebalCompleted.String() || rs.Pools[0].Progress == nil {
+		t.Fatalf("unexpected rebalance status %#v", rs)
+	}
+	if progress := rs.Pools[0].Progress; progress.NumObjects != 5 || progress.NumVersions != 10 {
+		t.Fatalf("expected 5 objects and 10 versions to be rebalanced, got %d and %d", progress.NumObjects, progress.NumVersions)
+	}
+	if z.IsPoolRebalancing(0) {
+		t.Fatal("first pool must not be rebalancing anymore")
+	}
+
+	for object, versions := range objects {
+		if _, err = z.serverPools[0].GetObjectInfo(ctx, bucket, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("%s: expected object not found on the rebalanced pool, got %v", object, err)
+		}
+		for i, version := range versions {
+			gr, err := z.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{VersionID: version.VersionID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := ioutil.ReadAll(gr)
+			gr.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, bytes.Repeat([]byte{byte('a' + i)}, 1024)) {
+				t.Errorf("%s version %s: content mismatch", object, version.VersionID)
+			}
+			if !gr.ObjInfo.ModTime.Equal(version.ModTime) {
+				t.Errorf("%s version %s: expected mod time %v, got %v", object, version.VersionID, version.ModTime, gr.ObjInfo.ModTime)
+			}
+		}
+	}
+}
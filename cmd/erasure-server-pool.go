@@ -41,10 +41,18 @@ import (
 type erasureServerPools struct {
 	GatewayUnsupported
 
-	serverPools []*erasureSets
+	poolMetaMutex sync.RWMutex
+	poolMeta      poolMeta
+	serverPools   []*erasureSets
 
 	// Shut down async operations
 	shutdown context.CancelFunc
+
+	// Active decommission canceler
+	decommissionCancelers []context.CancelFunc
+
+	rebalMu   sync.RWMutex
+	rebalMeta *rebalanceMeta
 }
 
 func (z *erasureServerPools) SinglePool() bool {
@@ -61,7 +69,10 @@ func newErasureServerPools(ctx context.Context, endpointServerPools EndpointServ
 
 		formats      = make([]*formatErasureV3, len(endpointServerPools))
 		storageDisks = make([][]StorageAPI, len(endpointServerPools))
-		z            = &erasureServerPools{serverPools: make([]*erasureSets, len(endpointServerPools))}
+		z            = &erasureServerPools{
+			serverPools:           make([]*erasureSets, len(endpointServerPools)),
+			decommissionCancelers: make([]context.CancelFunc, len(endpointServerPools)),
+		}
 	)
 
 	var localDrives []string
@@ -207,7 +218,12 @@ func (p serverPoolsAvailableSpace) TotalAvailable() uint64 {
 // getAvailablePoolIdx will return an index that can hold size bytes.
 // -1 is returned if no serverPools have available space for the size given.
 func (z *erasureServerPools) getAvailablePoolIdx(ctx context.Context, bucket, object string, size int64) int {
-	serverPools := z.getServerPoolsAvailableSpace(ctx, bucket, object, size)
+	return z.getServerPoolsAvailableSpace(ctx, bucket, object, size).getAvailablePoolIdx(ctx)
+}
+
+// getAvailablePoolIdx will return a random index weighted by available space.
+// -1 is returned if no serverPools have available space.
+func (serverPools serverPoolsAvailableSpace) getAvailablePoolIdx(ctx context.Context) int {
 	total := serverPools.TotalAvailable()
 	if total == 0 {
 		return -1
@@ -248,6 +264,11 @@ func (z *erasureServerPools) getServerPoolsAvailableSpace(ctx context.Context, b
 
 	for i, zinfo := range storageInfos {
 		var available uint64
+		if z.IsSuspended(i) {
+			// Pools being decommissioned accept no new objects.
+			serverPools[i] = poolAvailableSpace{Index: i}
+			continue
+		}
 		if !isMinioMetaBucketName(bucket) && !hasSpaceFor(zinfo, size) {
 			serverPools[i] = poolAvailableSpace{Index: i}
 			continue
@@ -282,6 +303,13 @@ func (z *erasureServerPools) getPoolIdxExistingWithOpts(ctx context.Context, buc
 
 	var wg sync.WaitGroup
 	for i, pool := range z.serverPools {
+		if opts.SkipDecommissioned && z.IsSuspended(i) {
+			poolObjInfos[i] = poolObjInfo{
+				PoolIndex: i,
+				Err:       toObjectErr(errFileNotFound, bucket, object),
+			}
+			continue
+		}
 		wg.Add(1)
 		go func(i int, pool *erasureSets) {
 			defer wg.Done()
@@ -339,10 +367,35 @@ func (z *erasureServerPools) getPoolIdxExisting(ctx context.Context, bucket, obj
 	return z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{})
 }
 
+// checkWritePreconditions evaluates the preconditions of a write against
+// the latest version of the object in any pool, including the pools being
+// decommissioned which getPoolIdx does not return. A missing object is
+// passed as an empty ObjectInfo. The caller is expected to hold the
+// namespace write lock of the object.
+func (z *erasureServerPools) checkWritePreconditions(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, _, err := z.getLatestObjectInfoWithIdx(ctx, bucket, object, ObjectOptions{NoLock: true})
+	if err != nil {
+		if !isErrObjectNotFound(err) && !isErrVersionNotFound(err) && !isErrMethodNotAllowed(err) {
+			return err
+		}
+		oi = ObjectInfo{}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
 // getPoolIdx returns the found previous object and its corresponding pool idx,
-// if none are found falls back to most available space pool.
+// if none are found falls back to most available space pool, pools being
+// decommissioned are never returned.
 func (z *erasureServerPools) getPoolIdx(ctx context.Context, bucket, object string, size int64) (idx int, err error) {
-	idx, err = z.getPoolIdxExisting(ctx, bucket, object)
+	idx, err = z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{
+		SkipDecommissioned: true,
+	})
 	if err != nil && !isErrObjectNotFound(err) {
 		return idx, err
 	}
@@ -790,6 +843,7 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 		}
 		return z.serverPools[0].PutObject(ctx, bucket, object, data, opts)
 	}
+	var lockObject bool
 	if !opts.NoLock {
 		ns := z.NewNSLock(minioMetaMultipartBucket, pathJoin(bucket, object, "newMultipartObject.lck"))
 		lkctx, err := ns.GetLock(ctx, globalOperationTimeout)
@@ -799,6 +853,7 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 		ctx = lkctx.Context()
 		defer ns.Unlock(lkctx.Cancel)
 		opts.NoLock = true
+		lockObject = opts.CheckPrecondFn != nil
 	}
 
 	idx, err := z.getPoolIdx(ctx, bucket, object, data.Size())
@@ -806,10 +861,102 @@ func (z *erasureServerPools) PutObject(ctx context.Context, bucket string, objec
 		return ObjectInfo{}, err
 	}
 
+	// Conditional writes are evaluated under the write lock of the
+	// object, newMultipartObject.lck serializes them with multipart
+	// completions and writes to other pools.
+	if lockObject {
+		lk := z.serverPools[idx].NewNSLock(bucket, object)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+
+		if err = z.checkWritePreconditions(ctx, bucket, object, opts); err != nil {
+			return ObjectInfo{}, err
+		}
+		opts.CheckPrecondFn = nil
+	}
+
 	// Overwrite the object at the right pool
 	return z.serverPools[idx].PutObject(ctx, bucket, object, data, opts)
 }
 
+// AppendObject - appends data to an existing object in the pool holding it.
+func (z *erasureServerPools) AppendObject(ctx context.Context, bucket, object string, offset int64, data *PutObjReader, opts ObjectOptions) (ObjectInfo, error) {
+	// Validate put object input args.
+	if err := checkPutObjectArgs(ctx, bucket, object, z); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	object = encodeDirObject(object)
+	if z.SinglePool() {
+		return z.serverPools[0].AppendObject(ctx, bucket, object, offset, data, opts)
+	}
+
+	// Serialize with decommissioning and rebalancing moving the object
+	// to another pool, see PutObject.
+	ns := z.NewNSLock(minioMetaMultipartBucket, pathJoin(bucket, object, "newMultipartObject.lck"))
+	lkctx, err := ns.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	ctx = lkctx.Context()
+	defer ns.Unlock(lkctx.Cancel)
+
+	idx, err := z.getPoolIdxExisting(ctx, bucket, object)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if z.IsSuspended(idx) || z.IsPoolRebalancing(idx) {
+		// The object may have been moved to another pool already.
+		return ObjectInfo{}, NotImplemented{Message: "Objects on a pool being decommissioned or rebalanced can not be appended to"}
+	}
+
+	return z.serverPools[idx].AppendObject(ctx, bucket, object, offset, data, opts)
+}
+
+// RenameObject - renames an object within the pool holding it.
+func (z *erasureServerPools) RenameObject(ctx context.Context, bucket, srcObject, dstObject string, opts ObjectOptions) (ObjectInfo, error) {
+	// Validate put object input args.
+	if err := checkPutObjectArgs(ctx, bucket, dstObject, z); err != nil {
+		return ObjectInfo{}, err
+	}
+
+	srcObject = encodeDirObject(srcObject)
+	dstObject = encodeDirObject(dstObject)
+	if z.SinglePool() {
+		return z.serverPools[0].RenameObject(ctx, bucket, srcObject, dstObject, opts)
+	}
+
+	// Serialize with writes choosing a pool for the new name, see PutObject.
+	ns := z.NewNSLock(minioMetaMultipartBucket, pathJoin(bucket, dstObject, "newMultipartObject.lck"))
+	lkctx, err := ns.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	ctx = lkctx.Context()
+	defer ns.Unlock(lkctx.Cancel)
+
+	if _, err = z.getPoolIdxExisting(ctx, bucket, dstObject); err == nil {
+		return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: dstObject}
+	} else if !isErrObjectNotFound(err) {
+		return ObjectInfo{}, err
+	}
+
+	idx, err := z.getPoolIdxExisting(ctx, bucket, srcObject)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	if z.IsSuspended(idx) {
+		// The decommission may have listed past the new name already.
+		return ObjectInfo{}, NotImplemented{Message: "Objects on a pool being decommissioned can not be renamed"}
+	}
+
+	return z.serverPools[idx].RenameObject(ctx, bucket, srcObject, dstObject, opts)
+}
+
 func (z *erasureServerPools) deletePrefix(ctx context.Context, bucket string, prefix string) error {
 	for _, zone := range z.serverPools {
 		_, err := zone.DeleteObject(ctx, bucket, prefix, ObjectOptions{DeletePrefix: true})
@@ -938,6 +1085,7 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 
+	var lockObject bool
 	if !dstOpts.NoLock {
 		ns := z.NewNSLock(minioMetaMultipartBucket, pathJoin(dstBucket, dstObject, "newMultipartObject.lck"))
 		lkctx, err := ns.GetLock(ctx, globalOperationTimeout)
@@ -947,6 +1095,7 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 		ctx = lkctx.Context()
 		defer ns.Unlock(lkctx.Cancel)
 		dstOpts.NoLock = true
+		lockObject = dstOpts.CheckPrecondFn != nil
 	}
 
 	poolIdx, err := z.getPoolIdx(ctx, dstBucket, dstObject, srcInfo.Size)
@@ -954,7 +1103,29 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 		return objInfo, err
 	}
 
+	// Conditional copies are evaluated under the write lock of the object,
+	// on a single pool by the pool itself.
+	checkedPrecond := lockObject && !z.SinglePool()
+	if checkedPrecond {
+		lk := z.serverPools[poolIdx].NewNSLock(dstBucket, dstObject)
+		lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer lk.Unlock(lkctx.Cancel)
+
+		if err = z.checkWritePreconditions(ctx, dstBucket, dstObject, dstOpts); err != nil {
+			return ObjectInfo{}, err
+		}
+		dstOpts.CheckPrecondFn = nil
+	}
+
 	if cpSrcDstSame && srcInfo.metadataOnly {
+		// Conditional metadata updates are evaluated under the write lock of the object.
+		if lockObject && !checkedPrecond {
+			dstOpts.NoLock = false
+		}
 		// Version ID is set for the destination and source == destination version ID.
 		if dstOpts.VersionID != "" && srcOpts.VersionID == dstOpts.VersionID {
 			return z.serverPools[poolIdx].CopyObject(ctx, srcBucket, srcObject, dstBucket, dstObject, srcInfo, srcOpts, dstOpts)
@@ -982,6 +1153,8 @@ func (z *erasureServerPools) CopyObject(ctx context.Context, srcBucket, srcObjec
 		Versioned:            dstOpts.Versioned,
 		VersionID:            dstOpts.VersionID,
 		MTime:                dstOpts.MTime,
+		CheckPrecondFn:       dstOpts.CheckPrecondFn,
+		NoLock:               checkedPrecond,
 	}
 
 	return z.serverPools[poolIdx].PutObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
@@ -1171,6 +1344,9 @@ func (z *erasureServerPools) NewMultipartUpload(ctx context.Context, bucket, obj
 	defer ns.Unlock(lkctx.Cancel)
 
 	for idx, pool := range z.serverPools {
+		if z.IsSuspended(idx) {
+			continue
+		}
 		result, err := pool.ListMultipartUploads(ctx, bucket, object, "", "", "", maxUploadsList)
 		if err != nil {
 			return "", err
@@ -1327,11 +1503,43 @@ func (z *erasureServerPools) CompleteMultipartUpload(ctx context.Context, bucket
 		return z.serverPools[0].CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 	}
 
+	// Completions hold the same lock as PutObject, so that conditional
+	// writes to the object in other pools are serialized with them.
+	var lockObject bool
+	if !opts.NoLock {
+		ns := z.NewNSLock(minioMetaMultipartBucket, pathJoin(bucket, object, "newMultipartObject.lck"))
+		lkctx, err := ns.GetLock(ctx, globalOperationTimeout)
+		if err != nil {
+			return ObjectInfo{}, err
+		}
+		ctx = lkctx.Context()
+		defer ns.Unlock(lkctx.Cancel)
+		lockObject = opts.CheckPrecondFn != nil
+	}
+
 	for _, pool := range z.serverPools {
 		_, err := pool.GetMultipartInfo(ctx, bucket, object, uploadID, opts)
-		if err == nil {
-			return pool.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
+		if err != nil {
+			continue
 		}
+		// Preconditions are evaluated against the object in all
+		// pools, not only in the pool holding the upload.
+		if lockObject {
+			lk := pool.NewNSLock(bucket, object)
+			lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+			if err != nil {
+				return ObjectInfo{}, err
+			}
+			ctx = lkctx.Context()
+			defer lk.Unlock(lkctx.Cancel)
+
+			if err = z.checkWritePreconditions(ctx, bucket, object, opts); err != nil {
+				return ObjectInfo{}, err
+			}
+			opts.CheckPrecondFn = nil
+			opts.NoLock = true
+		}
+		return pool.CompleteMultipartUpload(ctx, bucket, object, uploadID, uploadedParts, opts)
 	}
 
 	return objInfo, InvalidUploadID{
@@ -1987,6 +2195,22 @@ func (z *erasureServerPools) TransitionObject(ctx context.Context, bucket, objec
 	return z.serverPools[idx].TransitionObject(ctx, bucket, object, opts)
 }
 
+// MoveTransitionedObject - moves transitioned object content from fromTier to
+// toTier, or back into this cluster if toTier is empty.
+func (z *erasureServerPools) MoveTransitionedObject(ctx context.Context, bucket, object, fromTier, toTier string, opts ObjectOptions) error {
+	object = encodeDirObject(object)
+	if z.SinglePool() {
+		return z.serverPools[0].MoveTransitionedObject(ctx, bucket, object, fromTier, toTier, opts)
+	}
+
+	idx, err := z.getPoolIdxExistingWithOpts(ctx, bucket, object, ObjectOptions{VersionID: opts.VersionID})
+	if err != nil {
+		return err
+	}
+
+	return z.serverPools[idx].MoveTransitionedObject(ctx, bucket, object, fromTier, toTier, opts)
+}
+
 // RestoreTransitionedObject - restore transitioned object content locally on this cluster.
 func (z *erasureServerPools) RestoreTransitionedObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
 	object = encodeDirObject(object)
@@ -885,6 +885,113 @@ func (s *erasureSets) PutObject(ctx context.Context, bucket string, object strin
 	return set.PutObject(ctx, bucket, object, data, opts)
 }
 
+// AppendObject - appends data to an existing object on the hashedSet based on object name.
+func (s *erasureSets) AppendObject(ctx context.Context, bucket, object string, offset int64, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	set := s.getHashedSet(object)
+	auditObjectErasureSet(ctx, object, set)
+	return set.AppendObject(ctx, bucket, object, offset, data, opts)
+}
+
+// RenameObject - renames an object within its erasure set, when the new name
+// hashes to another set all versions are copied to that set and removed from
+// the set of the old name.
+func (s *erasureSets) RenameObject(ctx context.Context, bucket, srcObject, dstObject string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	srcIdx, dstIdx := s.getHashedSetIndex(srcObject), s.getHashedSetIndex(dstObject)
+	set := s.sets[srcIdx]
+	auditObjectErasureSet(ctx, srcObject, set)
+	if srcIdx == dstIdx {
+		return set.RenameObject(ctx, bucket, srcObject, dstObject, opts)
+	}
+	return s.renameObjectAcrossSets(ctx, bucket, srcIdx, dstIdx, srcObject, dstObject, opts)
+}
+
+// renameObjectAcrossSets copies all versions of srcObject oldest first into
+// dstObject of the set at dstIdx, and removes them from the set at srcIdx
+// once all of them are copied. Both names are locked for the whole move.
+func (s *erasureSets) renameObjectAcrossSets(ctx context.Context, bucket string, srcIdx, dstIdx int, srcObject, dstObject string, opts ObjectOptions) (ObjectInfo, error) {
+	src, dst := s.sets[srcIdx], s.sets[dstIdx]
+	if !opts.NoLock {
+		// Lock the names in the order of their sets, renames in the
+		// opposite direction would otherwise wait on each other.
+		locks := []RWLocker{src.NewNSLock(bucket, srcObject), dst.NewNSLock(bucket, dstObject)}
+		if dstIdx < srcIdx {
+			locks[0], locks[1] = locks[1], locks[0]
+		}
+		for _, lk := range locks {
+			lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+			if err != nil {
+				return ObjectInfo{}, err
+			}
+			ctx = lkctx.Context()
+			defer lk.Unlock(lkctx.Cancel)
+		}
+	}
+
+	if _, _, _, err := dst.getObjectFileInfo(ctx, bucket, dstObject, ObjectOptions{}, false); err == nil {
+		return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: dstObject}
+	} else if err = toObjectErr(err, bucket, dstObject); !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+		return ObjectInfo{}, err
+	}
+
+	// Rejects the objects which can not be renamed, such as encrypted ones.
+	objInfo, err := src.RenameObject(ctx, bucket, srcObject, dstObject, ObjectOptions{RenameCheck: true, NoLock: true})
+	if err != nil || opts.RenameCheck {
+		return objInfo, err
+	}
+
+	fi, _, _, err := src.getObjectFileInfo(ctx, bucket, srcObject, ObjectOptions{}, false)
+	if err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, srcObject)
+	}
+	parityDrives := fi.Erasure.ParityBlocks
+	if fi.Deleted {
+		parityDrives = src.defaultParityCount
+	}
+	versions, err := src.getObjectVersions(ctx, bucket, srcObject, len(src.getDisks())-parityDrives)
+	if err != nil {
+		return ObjectInfo{}, err
+	}
+	sort.SliceStable(versions, func(i, j int) bool {
+		return versions[i].ModTime.Before(versions[j].ModTime)
+	})
+
+	deleteVersions := func(set *erasureObjects, object string, versions []FileInfo) error {
+		for _, version := range versions {
+			vid := version.VersionID
+			if vid == "" {
+				vid = nullVersionID
+			}
+			_, err := set.DeleteObject(ctx, bucket, object, ObjectOptions{
+				VersionID:    vid,
+				DataMovement: true,
+				NoLock:       true,
+			})
+			if err != nil && !isErrObjectNotFound(err) && !isErrVersionNotFound(err) {
+				return err
+			}
+		}
+		return nil
+	}
+
+	copied := make([]FileInfo, 0, len(versions))
+	for _, version := range versions {
+		if version.TierFreeVersion() {
+			continue
+		}
+		if err = copyObjectVersion(ctx, src, dst, bucket, dstObject, version, true); err != nil {
+			// Remove the versions copied so far, the object keeps its name.
+			logger.LogIf(ctx, deleteVersions(dst, dstObject, copied))
+			return ObjectInfo{}, toObjectErr(err, bucket, srcObject)
+		}
+		copied = append(copied, version)
+	}
+	if err = deleteVersions(src, srcObject, copied); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, srcObject)
+	}
+
+	return objInfo, nil
+}
+
 // GetObjectInfo - reads object metadata from the hashedSet based on the object name.
 func (s *erasureSets) GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
 	set := s.getHashedSet(object)
@@ -1010,6 +1117,7 @@ func (s *erasureSets) CopyObject(ctx context.Context, srcBucket, srcObject, dstB
 		Versioned:            dstOpts.Versioned,
 		VersionID:            dstOpts.VersionID,
 		MTime:                dstOpts.MTime,
+		CheckPrecondFn:       dstOpts.CheckPrecondFn,
 	}
 
 	return dstSet.putObject(ctx, dstBucket, dstObject, srcInfo.PutObjReader, putOpts)
@@ -1376,6 +1484,16 @@ func (s *erasureSets) TransitionObject(ctx context.Context, bucket, object strin
 	return s.getHashedSet(object).TransitionObject(ctx, bucket, object, opts)
 }
 
+// DecomTieredObject - writes the metadata of a tiered object version, see erasureObjects.DecomTieredObject.
+func (s *erasureSets) DecomTieredObject(ctx context.Context, bucket, object string, fi FileInfo, opts ObjectOptions) error {
+	return s.getHashedSet(object).DecomTieredObject(ctx, bucket, object, fi, opts)
+}
+
+// MoveTransitionedObject - moves transitioned object content between tiers, see erasureObjects.MoveTransitionedObject.
+func (s *erasureSets) MoveTransitionedObject(ctx context.Context, bucket, object, fromTier, toTier string, opts ObjectOptions) error {
+	return s.getHashedSet(object).MoveTransitionedObject(ctx, bucket, object, fromTier, toTier, opts)
+}
+
 // RestoreTransitionedObject - restore transitioned object content locally on this cluster.
 func (s *erasureSets) RestoreTransitionedObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
 	return s.getHashedSet(object).RestoreTransitionedObject(ctx, bucket, object, opts)
@@ -40,6 +40,7 @@ import (
 	"github.com/minio/minio-go/v7/pkg/tags"
 	"github.com/minio/minio/internal/color"
 	"github.com/minio/minio/internal/config"
+	"github.com/minio/minio/internal/crypto"
 	xhttp "github.com/minio/minio/internal/http"
 	xioutil "github.com/minio/minio/internal/ioutil"
 	"github.com/minio/minio/internal/lock"
@@ -644,7 +645,8 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 	defer NSUpdated(dstBucket, dstObject)
 
-	if !cpSrcDstSame {
+	// Conditional copies onto the same object are evaluated under the write lock.
+	if !cpSrcDstSame || dstOpts.CheckPrecondFn != nil {
 		objectDWLock := fs.NewNSLock(dstBucket, dstObject)
 		lkctx, err := objectDWLock.GetLock(ctx, globalOperationTimeout)
 		if err != nil {
@@ -663,6 +665,10 @@ func (fs *FSObjects) CopyObject(ctx context.Context, srcBucket, srcObject, dstBu
 		return oi, toObjectErr(err, srcBucket)
 	}
 
+	if err := fs.checkWritePreconditions(ctx, dstBucket, dstObject, dstOpts); err != nil {
+		return oi, err
+	}
+
 	if cpSrcDstSame && srcInfo.metadataOnly {
 		fsMetaPath := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, srcBucket, srcObject, fs.metaJSONFile)
 		wlk, err := fs.rwPool.Write(fsMetaPath)
@@ -1052,9 +1058,33 @@ func (fs *FSObjects) PutObject(ctx context.Context, bucket string, object string
 		atomic.AddInt64(&fs.activeIOCount, -1)
 	}()
 
+	if err = fs.checkWritePreconditions(ctx, bucket, object, opts); err != nil {
+		return objInfo, err
+	}
+
 	return fs.putObject(ctx, bucket, object, r, opts)
 }
 
+// checkWritePreconditions evaluates the preconditions of a write against the
+// object, a missing object is passed as an empty ObjectInfo. The caller is
+// expected to hold the namespace write lock of the object.
+func (fs *FSObjects) checkWritePreconditions(ctx context.Context, bucket, object string, opts ObjectOptions) error {
+	if opts.CheckPrecondFn == nil {
+		return nil
+	}
+	oi, err := fs.getObjectInfo(ctx, bucket, object)
+	if err != nil {
+		if err = toObjectErr(err, bucket, object); !isErrObjectNotFound(err) {
+			return err
+		}
+		oi = ObjectInfo{}
+	}
+	if opts.CheckPrecondFn(oi) {
+		return PreConditionFailed{}
+	}
+	return nil
+}
+
 // putObject - wrapper for PutObject
 func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string, r *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, retErr error) {
 	data := r.Reader
@@ -1138,6 +1168,9 @@ func (fs *FSObjects) putObject(ctx context.Context, bucket string, object string
 		return ObjectInfo{}, toObjectErr(err, bucket, object)
 	}
 	fsMeta.Meta["etag"] = r.MD5CurrentHexString()
+	if cs := opts.WantChecksum.String(); cs != "" {
+		fsMeta.Meta[objectChecksumKey] = cs
+	}
 
 	// Should return IncompleteBody{} error when reader has fewer
 	// bytes than specified in request header.
@@ -1495,6 +1528,88 @@ func (fs *FSObjects) ReadHealth(ctx context.Context) bool {
 	return err == nil
 }
 
+// AppendObject - append data to the end of an existing object.
+func (fs *FSObjects) AppendObject(ctx context.Context, bucket, object string, offset int64, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return objInfo, NotImplemented{}
+}
+
+// RenameObject - renames an object within a bucket, the destination
+// must not exist.
+func (fs *FSObjects) RenameObject(ctx context.Context, bucket, srcObject, dstObject string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	if HasSuffix(srcObject, SlashSeparator) != HasSuffix(dstObject, SlashSeparator) {
+		return objInfo, ObjectNameInvalid{Bucket: bucket, Object: dstObject}
+	}
+	if err = checkPutObjectArgs(ctx, bucket, dstObject, fs); err != nil {
+		return objInfo, err
+	}
+
+	defer NSUpdated(bucket, srcObject)
+	defer NSUpdated(bucket, dstObject)
+
+	lk := fs.NewNSLock(bucket, srcObject, dstObject)
+	lkctx, err := lk.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return objInfo, err
+	}
+	ctx = lkctx.Context()
+	defer lk.Unlock(lkctx.Cancel)
+
+	atomic.AddInt64(&fs.activeIOCount, 1)
+	defer func() {
+		atomic.AddInt64(&fs.activeIOCount, -1)
+	}()
+
+	if _, err = fs.statBucketDir(ctx, bucket); err != nil {
+		return objInfo, toObjectErr(err, bucket)
+	}
+	objInfo, err = fs.getObjectInfo(ctx, bucket, srcObject)
+	if err != nil {
+		return objInfo, toObjectErr(err, bucket, srcObject)
+	}
+	if _, encrypted := crypto.IsEncrypted(objInfo.UserDefined); encrypted {
+		return ObjectInfo{}, NotImplemented{Message: "Renaming encrypted objects is not supported"}
+	}
+	if _, err = fs.getObjectInfo(ctx, bucket, dstObject); err == nil {
+		return ObjectInfo{}, ObjectAlreadyExists{Bucket: bucket, Object: dstObject}
+	} else if err = toObjectErr(err, bucket, dstObject); !isErrObjectNotFound(err) {
+		return ObjectInfo{}, err
+	}
+	if opts.RenameCheck {
+		objInfo.Name = dstObject
+		return objInfo, nil
+	}
+
+	bucketDir := pathJoin(fs.fsPath, bucket)
+	srcPath, dstPath := pathJoin(bucketDir, srcObject), pathJoin(bucketDir, dstObject)
+	if HasSuffix(srcObject, SlashSeparator) {
+		// Directory objects carry no metadata, the source directory is
+		// removed once the objects below it are renamed as well.
+		if err = mkdirAll(dstPath, 0777); err != nil {
+			return ObjectInfo{}, toObjectErr(err, bucket, dstObject)
+		}
+		fsDeleteFile(ctx, bucketDir, srcPath)
+		objInfo.Name = dstObject
+		return objInfo, nil
+	}
+	if err = fsRenameFile(ctx, srcPath, dstPath); err != nil {
+		return ObjectInfo{}, toObjectErr(err, bucket, srcObject)
+	}
+
+	metaDir := pathJoin(fs.fsPath, minioMetaBucket, bucketMetaPrefix, bucket)
+	srcMetaPath := pathJoin(metaDir, srcObject, fs.metaJSONFile)
+	if err = fsRenameFile(ctx, srcMetaPath, pathJoin(metaDir, dstObject, fs.metaJSONFile)); err != nil && err != errFileNotFound {
+		logger.LogIf(ctx, fsRenameFile(ctx, dstPath, srcPath))
+		return ObjectInfo{}, toObjectErr(err, bucket, srcObject)
+	}
+
+	// Remove the parent directories left empty.
+	fsDeleteFile(ctx, bucketDir, path.Dir(srcPath))
+	fsDeleteFile(ctx, metaDir, path.Dir(srcMetaPath))
+
+	objInfo.Name = dstObject
+	return objInfo, nil
+}
+
 // TransitionObject - transition object content to target tier.
 func (fs *FSObjects) TransitionObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
 	return NotImplemented{}
@@ -274,6 +274,16 @@ func (a GatewayUnsupported) ReadHealth(_ context.Context) bool {
 	return true
 }
 
+// AppendObject - append data to the end of an existing object.
+func (a GatewayUnsupported) AppendObject(ctx context.Context, bucket, object string, offset int64, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return objInfo, NotImplemented{}
+}
+
+// RenameObject - rename an object within a bucket.
+func (a GatewayUnsupported) RenameObject(ctx context.Context, bucket, srcObject, dstObject string, opts ObjectOptions) (objInfo ObjectInfo, err error) {
+	return objInfo, NotImplemented{}
+}
+
 // TransitionObject - transition object content to target tier.
 func (a GatewayUnsupported) TransitionObject(ctx context.Context, bucket, object string, opts ObjectOptions) error {
 	return NotImplemented{}
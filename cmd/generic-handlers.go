@@ -22,14 +22,17 @@ import (
 	"net"
 	"net/http"
 	"path"
+	"strconv"
 	"strings"
 	"sync/atomic"
 	"time"
 
+	"github.com/minio/minio-go/v7/pkg/s3utils"
 	"github.com/minio/minio-go/v7/pkg/set"
 	xnet "github.com/minio/pkg/net"
 
 	humanize "github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/bucket/cors"
 	"github.com/minio/minio/internal/config/dns"
 	"github.com/minio/minio/internal/crypto"
 	xhttp "github.com/minio/minio/internal/http"
@@ -161,8 +164,10 @@ func guessIsBrowserReq(r *http.Request) bool {
 func setBrowserRedirectHandler(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		read := r.Method == http.MethodGet || r.Method == http.MethodHead
-		// Re-direction is handled specifically for browser requests.
-		if guessIsBrowserReq(r) && read {
+		_, website := getWebsiteBucket(r)
+		// Re-direction is handled specifically for browser requests,
+		// website requests are served by the website endpoint.
+		if guessIsBrowserReq(r) && read && !website {
 			// Fetch the redirect location if any.
 			if u := getRedirectLocation(r); u != nil {
 				// Employ a temporary re-direct.
@@ -318,7 +323,8 @@ func parseAmzDateHeader(req *http.Request) (time.Time, APIErrorCode) {
 func setTimeValidityHandler(h http.Handler) http.Handler {
 	return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		aType := getRequestAuthType(r)
-		if aType == authTypeSigned || aType == authTypeSignedV2 || aType == authTypeStreamingSigned {
+		if aType == authTypeSigned || aType == authTypeSignedV2 || aType == authTypeStreamingSigned ||
+			aType == authTypeStreamingSignedTrailer || aType == authTypeStreamingUnsignedTrailer {
 			// Verify if date headers are set, if not reject the request
 			amzDate, errCode := parseAmzDateHeader(r)
 			if errCode != ErrNone {
@@ -550,3 +556,87 @@ func setSSETLSHandler(h http.Handler) http.Handler {
 		h.ServeHTTP(w, r)
 	})
 }
+
+// getBucketCorsConfig returns the CORS configuration of the bucket
+// addressed by a cross-origin request, if the bucket has one.
+func getBucketCorsConfig(r *http.Request) (*cors.Config, bool) {
+	if r.Header.Get(xhttp.Origin) == "" {
+		return nil, false
+	}
+	bucket, website := getWebsiteBucket(r)
+	if !website {
+		if strings.HasPrefix(r.URL.Path, minioReservedBucketPath) {
+			return nil, false
+		}
+		bucket, _ = request2BucketObjectName(r)
+	}
+	if bucket == "" || isMinioMetaBucket(bucket) || s3utils.CheckValidBucketName(bucket) != nil {
+		return nil, false
+	}
+	config, err := globalBucketMetadataSys.GetCorsConfigForRequest(bucket)
+	if err != nil {
+		return nil, false
+	}
+	return config, true
+}
+
+// serveBucketCors evaluates a cross-origin request against the CORS
+// rules of the bucket. Preflight requests are answered right away,
+// actual requests get the CORS headers of the matching rule, if any.
+func serveBucketCors(w http.ResponseWriter, r *http.Request, config *cors.Config, h http.Handler) {
+	origin := r.Header.Get(xhttp.Origin)
+
+	if r.Method == http.MethodOptions && r.Header.Get(xhttp.AccessControlRequestMethod) != "" {
+		var reqHeaders []string
+		for _, header := range strings.Split(r.Header.Get(xhttp.AccessControlRequestHeaders), ",") {
+			if header = strings.TrimSpace(header); header != "" {
+				reqHeaders = append(reqHeaders, header)
+			}
+		}
+
+		w.Header().Add(xhttp.Vary, xhttp.Origin)
+		w.Header().Add(xhttp.Vary, xhttp.AccessControlRequestMethod)
+		w.Header().Add(xhttp.Vary, xhttp.AccessControlRequestHeaders)
+
+		rule, ok := config.Match(origin, r.Header.Get(xhttp.AccessControlRequestMethod), reqHeaders)
+		if !ok {
+			writeErrorResponse(r.Context(), w, APIError{
+				Code:           "AccessForbidden",
+				Description:    "CORSResponse: This CORS request is not allowed. This is usually because the evaluation of Origin, request method / Access-Control-Request-Method or Access-Control-Request-Headers are not whitelisted by the resource's CORS spec.",
+				HTTPStatusCode: http.StatusForbidden,
+			}, r.URL)
+			return
+		}
+
+		setCorsResponseHeaders(w, origin, rule)
+		if len(reqHeaders) > 0 {
+			w.Header().Set(xhttp.AccessControlAllowHeaders, strings.Join(reqHeaders, ", "))
+		}
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	w.Header().Add(xhttp.Vary, xhttp.Origin)
+	if rule, ok := config.Match(origin, r.Method, nil); ok {
+		setCorsResponseHeaders(w, origin, rule)
+	}
+	h.ServeHTTP(w, r)
+}
+
+// setCorsResponseHeaders sets the CORS response headers for a request
+// from origin allowed by rule.
+func setCorsResponseHeaders(w http.ResponseWriter, origin string, rule cors.Rule) {
+	if rule.AllowsAnyOrigin() {
+		w.Header().Set(xhttp.AccessControlAllowOrigin, "*")
+	} else {
+		w.Header().Set(xhttp.AccessControlAllowOrigin, origin)
+		w.Header().Set(xhttp.AccessControlAllowCredentials, "true")
+	}
+	w.Header().Set(xhttp.AccessControlAllowMethods, strings.Join(rule.AllowedMethods, ", "))
+	if len(rule.ExposeHeaders) > 0 {
+		w.Header().Set(xhttp.AccessControlExposeHeaders, strings.Join(rule.ExposeHeaders, ", "))
+	}
+	if rule.MaxAgeSeconds != nil {
+		w.Header().Set(xhttp.AccessControlMaxAge, strconv.Itoa(*rule.MaxAgeSeconds))
+	}
+}
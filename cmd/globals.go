@@ -238,8 +238,9 @@ var (
 
 	globalPublicCerts []*x509.Certificate
 
-	globalDomainNames []string      // Root domains for virtual host style requests
-	globalDomainIPs   set.StringSet // Root domain IP address(s) for a distributed MinIO deployment
+	globalDomainNames        []string      // Root domains for virtual host style requests
+	globalWebsiteDomainNames []string      // Root domains for static website requests
+	globalDomainIPs          set.StringSet // Root domain IP address(s) for a distributed MinIO deployment
 
 	globalOperationTimeout       = newDynamicTimeout(10*time.Minute, 5*time.Minute) // default timeout for general ops
 	globalDeleteOperationTimeout = newDynamicTimeout(5*time.Minute, 1*time.Minute)  // default time for delete ops
@@ -317,8 +318,12 @@ var (
 
 	globalTierConfigMgr *TierConfigMgr
 
+	globalSiteReplicationSys *SiteReplicationSys
+
 	globalTierJournal *tierJournal
 
+	globalTierMetrics = newTierMetrics()
+
 	globalConsoleSrv *restapi.Server
 
 	globalDebugRemoteTiersImmediately []string
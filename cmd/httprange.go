@@ -18,14 +18,21 @@
 package cmd
 
 import (
+	"bytes"
 	"errors"
 	"fmt"
+	"mime/multipart"
+	"net/textproto"
 	"strconv"
 	"strings"
 )
 
 const (
 	byteRangePrefix = "bytes="
+
+	// Maximum number of ranges served in a multipart/byteranges
+	// response, requests with more ranges get the whole object.
+	maxRangeSpecs = 100
 )
 
 // HTTPRangeSpec represents a range specification as supported by S3 GET
@@ -174,3 +181,92 @@ func (h *HTTPRangeSpec) String(resourceSize int64) string {
 	}
 	return fmt.Sprintf("%d-%d", off, off+length-1)
 }
+
+// Parse a HTTP range header value with one or more comma separated
+// ranges, e.g. "bytes=0-9,20-29", into a list of HTTPRangeSpec
+func parseRequestRangeSpecs(rangeString string) (ranges []*HTTPRangeSpec, err error) {
+	// Return error if given range string doesn't start with byte range prefix.
+	if !strings.HasPrefix(rangeString, byteRangePrefix) {
+		return nil, fmt.Errorf("'%s' does not start with '%s'", rangeString, byteRangePrefix)
+	}
+
+	for _, spec := range strings.Split(strings.TrimPrefix(rangeString, byteRangePrefix), ",") {
+		rs, err := parseRequestRangeSpec(byteRangePrefix + strings.TrimSpace(spec))
+		if err != nil {
+			return nil, err
+		}
+		ranges = append(ranges, rs)
+	}
+	return ranges, nil
+}
+
+// resolveRangeSpecs returns the ranges of a multi range request which can
+// be satisfied for a resource of the given size. Following net/http, nil is
+// returned when the whole resource should be sent instead, i.e. when the
+// ranges add up to more than the resource itself.
+func resolveRangeSpecs(ranges []*HTTPRangeSpec, resourceSize int64) ([]*HTTPRangeSpec, error) {
+	if len(ranges) > maxRangeSpecs {
+		return nil, nil
+	}
+
+	var satisfiable []*HTTPRangeSpec
+	var totalLength int64
+	for _, rs := range ranges {
+		length, err := rs.GetLength(resourceSize)
+		if err == errInvalidRange {
+			// Ranges starting beyond the end are ignored.
+			continue
+		}
+		if err != nil {
+			return nil, err
+		}
+		if length == 0 {
+			continue
+		}
+		totalLength += length
+		satisfiable = append(satisfiable, rs)
+	}
+	if len(satisfiable) == 0 {
+		return nil, errInvalidRange
+	}
+	if totalLength > resourceSize {
+		return nil, nil
+	}
+	return satisfiable, nil
+}
+
+// mimeHeader returns the header of the part of a multipart/byteranges
+// response carrying the range.
+func (h *HTTPRangeSpec) mimeHeader(contentType string, resourceSize int64) textproto.MIMEHeader {
+	header := textproto.MIMEHeader{
+		"Content-Range": {"bytes " + h.String(resourceSize) + "/" + strconv.FormatInt(resourceSize, 10)},
+	}
+	if contentType != "" {
+		header.Set("Content-Type", contentType)
+	}
+	return header
+}
+
+// multipartRangesSize returns the size of the multipart/byteranges body
+// for the ranges with the given boundary.
+func multipartRangesSize(boundary, contentType string, ranges []*HTTPRangeSpec, resourceSize int64) (size int64, err error) {
+	var buf bytes.Buffer
+	mw := multipart.NewWriter(&buf)
+	if err = mw.SetBoundary(boundary); err != nil {
+		return 0, err
+	}
+	for _, rs := range ranges {
+		length, err := rs.GetLength(resourceSize)
+		if err != nil {
+			return 0, err
+		}
+		if _, err = mw.CreatePart(rs.mimeHeader(contentType, resourceSize)); err != nil {
+			return 0, err
+		}
+		size += length
+	}
+	if err = mw.Close(); err != nil {
+		return 0, err
+	}
+	return size + int64(buf.Len()), nil
+}
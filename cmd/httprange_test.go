@@ -65,7 +65,7 @@ func TestHTTPRequestRangeSpec(t *testing.T) {
 		"bytes=-1-10",
 		"bytes=0-+3",
 		"bytes=+3-+5",
-		"bytes=10-11,12-10", // Unsupported by S3/MinIO (valid in RFC)
+		"bytes=10-11,12-10", // Lists are parsed by parseRequestRangeSpecs
 	}
 	for i, urs := range unparsableRangeSpecs {
 		rs, err := parseRequestRangeSpec(urs)
@@ -101,3 +101,59 @@ func TestHTTPRequestRangeSpec(t *testing.T) {
 		t.Errorf("Case %d: Expected errInvalidRange but: %v %v %d %d %v", i, rs, err1, o, l, err2)
 	}
 }
+
+func TestHTTPRequestRangeSpecs(t *testing.T) {
+	resourceSize := int64(10)
+	testCases := []struct {
+		spec string
+		// Offsets and lengths of the ranges to send, nil for the whole resource.
+		expRanges [][2]int64
+		expErr    error
+	}{
+		{"bytes=0-0,-1", [][2]int64{{0, 1}, {9, 1}}, nil},
+		{"bytes=-1, 0-0", [][2]int64{{9, 1}, {0, 1}}, nil},
+		{"bytes=0-1,2-3,4-5", [][2]int64{{0, 2}, {2, 2}, {4, 2}}, nil},
+		// Ranges starting beyond the end are ignored.
+		{"bytes=0-1,10-,100-200", [][2]int64{{0, 2}}, nil},
+		// Ranges larger than the resource in total.
+		{"bytes=0-,0-", nil, nil},
+		{"bytes=10-,100-", nil, errInvalidRange},
+	}
+	for i, testCase := range testCases {
+		ranges, err := parseRequestRangeSpecs(testCase.spec)
+		if err != nil {
+			t.Fatalf("Case %d: unexpected err: %v", i, err)
+		}
+		ranges, err = resolveRangeSpecs(ranges, resourceSize)
+		if err != testCase.expErr {
+			t.Fatalf("Case %d: expected err %v, got %v", i, testCase.expErr, err)
+		}
+		if len(ranges) != len(testCase.expRanges) {
+			t.Fatalf("Case %d: expected %d ranges, got %d", i, len(testCase.expRanges), len(ranges))
+		}
+		for j, rs := range ranges {
+			o, l, err := rs.GetOffsetLength(resourceSize)
+			if err != nil {
+				t.Fatalf("Case %d: unexpected err: %v", i, err)
+			}
+			if o != testCase.expRanges[j][0] || l != testCase.expRanges[j][1] {
+				t.Errorf("Case %d: got bad offset/length: %d,%d expected: %d,%d",
+					i, o, l, testCase.expRanges[j][0], testCase.expRanges[j][1])
+			}
+		}
+	}
+
+	unparsableRangeSpecs := []string{
+		"bytes=0-1,",
+		"bytes=0-1,aa",
+		"bytes=0-1;2-3",
+	}
+	for i, urs := range unparsableRangeSpecs {
+		if ranges, err := parseRequestRangeSpecs(urs); err == nil {
+			t.Errorf("Case %d: Did not get an expected error - got %v", i, ranges)
+		}
+	}
+	if _, err := parseRequestRangeSpecs("bytes=0-1,5-3"); err != errInvalidRange {
+		t.Errorf("Expected errInvalidRange, got %v", err)
+	}
+}
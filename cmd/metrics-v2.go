@@ -22,6 +22,7 @@ import (
 	"fmt"
 	"net/http"
 	"runtime"
+	"strconv"
 	"strings"
 	"sync"
 	"time"
@@ -69,6 +70,8 @@ const (
 	sysCallSubsystem          MetricSubsystem = "syscall"
 	usageSubsystem            MetricSubsystem = "usage"
 	ilmSubsystem              MetricSubsystem = "ilm"
+	rebalanceSubsystem        MetricSubsystem = "rebalance"
+	tierSubsystem             MetricSubsystem = "tier"
 )
 
 // MetricName are the individual names for the metric.
@@ -126,6 +129,20 @@ const (
 	expiryPendingTasks     MetricName = "expiry_pending_tasks"
 	transitionPendingTasks MetricName = "transition_pending_tasks"
 	transitionActiveTasks  MetricName = "transition_active_tasks"
+
+	journalPendingTasks     MetricName = "journal_pending_tasks"
+	journalOldestTaskAgeSec MetricName = "journal_oldest_task_age_seconds"
+
+	rebalancedObjectsTotal  MetricName = "objects_total"
+	rebalancedVersionsTotal MetricName = "versions_total"
+	rebalancedBytesTotal    MetricName = "bytes_total"
+	rebalanceActive         MetricName = "active"
+
+	tierVersionTotal        MetricName = "version_total"
+	tierTransitionsInFlight MetricName = "transitions_in_flight"
+	tierTransitionFailures  MetricName = "transition_failures_total"
+	tierRestoreRequests     MetricName = "restore_requests_total"
+	tierRestoreFailures     MetricName = "restore_failures_total"
 )
 
 const (
@@ -232,6 +249,7 @@ func GetGlobalGenerators() []MetricsGenerator {
 		getMinioHealingMetrics,
 		getNodeHealthMetrics,
 		getClusterStorageMetrics,
+		getTierUsageMetrics,
 	}
 	return g
 }
@@ -255,6 +273,9 @@ func GetGeneratorsForPeer() []MetricsGenerator {
 		getNetworkMetrics,
 		getS3TTFBMetric,
 		getILMNodeMetrics,
+		getReplicationJournalNodeMetrics,
+		getRebalanceNodeMetrics,
+		getTierNodeMetrics,
 	}
 	return g
 }
@@ -1066,6 +1087,285 @@ func getILMNodeMetrics() MetricsGroup {
 	}
 }
 
+func getReplicationJournalPendingTasksMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      journalPendingTasks,
+		Help:      "Number of replication tasks persisted in the on-disk journal awaiting completion.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getReplicationJournalOldestTaskAgeMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: replicationSubsystem,
+		Name:      journalOldestTaskAgeSec,
+		Help:      "Age in seconds of the oldest replication task in the on-disk journal.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getReplicationJournalNodeMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "ReplicationJournalNodeMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) []Metric {
+			pending := Metric{
+				Description: getReplicationJournalPendingTasksMD(),
+			}
+			oldestAge := Metric{
+				Description: getReplicationJournalOldestTaskAgeMD(),
+			}
+			if globalReplicationPool != nil {
+				count, oldest := globalReplicationPool.journal.Backlog()
+				pending.Value = float64(count)
+				if count > 0 && !oldest.IsZero() {
+					oldestAge.Value = time.Since(oldest).Seconds()
+				}
+			}
+			return []Metric{
+				pending,
+				oldestAge,
+			}
+		},
+	}
+}
+
+func getRebalanceObjectsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: rebalanceSubsystem,
+		Name:      rebalancedObjectsTotal,
+		Help:      "Total number of objects moved out of the pool by rebalance.",
+		Type:      counterMetric,
+	}
+}
+
+func getRebalanceVersionsTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: rebalanceSubsystem,
+		Name:      rebalancedVersionsTotal,
+		Help:      "Total number of object versions moved out of the pool by rebalance.",
+		Type:      counterMetric,
+	}
+}
+
+func getRebalanceBytesTotalMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: rebalanceSubsystem,
+		Name:      rebalancedBytesTotal,
+		Help:      "Total number of bytes moved out of the pool by rebalance.",
+		Type:      counterMetric,
+	}
+}
+
+func getRebalanceActiveMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: rebalanceSubsystem,
+		Name:      rebalanceActive,
+		Help:      "Indicates if the pool is being rebalanced, 1 if rebalance is in progress.",
+		Type:      gaugeMetric,
+	}
+}
+
+// getRebalanceNodeMetrics reports the progress of the pools
+// rebalanced by this node.
+func getRebalanceNodeMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "RebalanceNodeMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) (metrics []Metric) {
+			objLayer := newObjectLayerFn()
+			// Service not initialized yet
+			if objLayer == nil || !globalIsErasure {
+				return
+			}
+			z, ok := objLayer.(*erasureServerPools)
+			if !ok {
+				return
+			}
+			z.rebalMu.RLock()
+			defer z.rebalMu.RUnlock()
+			if z.rebalMeta == nil {
+				return
+			}
+			for idx, ps := range z.rebalMeta.PoolStats {
+				if !ps.Participating || !globalEndpoints[idx].Endpoints[0].IsLocal {
+					continue
+				}
+				labels := map[string]string{"pool": strconv.Itoa(idx)}
+				active := 0.0
+				if ps.Info.Status == rebalStarted && z.rebalMeta.StoppedAt.IsZero() {
+					active = 1
+				}
+				metrics = append(metrics, Metric{
+					Description:    getRebalanceObjectsTotalMD(),
+					Value:          float64(ps.NumObjects),
+					VariableLabels: labels,
+				}, Metric{
+					Description:    getRebalanceVersionsTotalMD(),
+					Value:          float64(ps.NumVersions),
+					VariableLabels: labels,
+				}, Metric{
+					Description:    getRebalanceBytesTotalMD(),
+					Value:          float64(ps.Bytes),
+					VariableLabels: labels,
+				}, Metric{
+					Description:    getRebalanceActiveMD(),
+					Value:          active,
+					VariableLabels: labels,
+				})
+			}
+			return
+		},
+	}
+}
+
+func getTierUsageObjectsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      objectTotal,
+		Help:      "Total number of objects whose latest version is on the tier.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getTierUsageVersionsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      tierVersionTotal,
+		Help:      "Total number of object versions on the tier.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getTierUsageBytesMD() MetricDescription {
+	return MetricDescription{
+		Namespace: clusterMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      totalBytes,
+		Help:      "Total size of the object versions on the tier in bytes.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getTierTransitionsInFlightMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      tierTransitionsInFlight,
+		Help:      "Number of transitions to the tier in progress.",
+		Type:      gaugeMetric,
+	}
+}
+
+func getTierTransitionFailuresMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      tierTransitionFailures,
+		Help:      "Total number of failed transitions to the tier.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierRestoreRequestsMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      tierRestoreRequests,
+		Help:      "Total number of restore requests of objects on the tier.",
+		Type:      counterMetric,
+	}
+}
+
+func getTierRestoreFailuresMD() MetricDescription {
+	return MetricDescription{
+		Namespace: nodeMetricNamespace,
+		Subsystem: tierSubsystem,
+		Name:      tierRestoreFailures,
+		Help:      "Total number of failed restore requests of objects on the tier.",
+		Type:      counterMetric,
+	}
+}
+
+// getTierNodeMetrics reports the transition and restore statistics of
+// the remote tiers on this node.
+func getTierNodeMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "TierNodeMetrics",
+		cachedRead: cachedRead,
+		read: func(_ context.Context) (metrics []Metric) {
+			for tier, ts := range globalTierMetrics.report() {
+				labels := map[string]string{"tier": tier}
+				metrics = append(metrics, Metric{
+					Description:    getTierTransitionsInFlightMD(),
+					Value:          float64(ts.InFlight),
+					VariableLabels: labels,
+				}, Metric{
+					Description:    getTierTransitionFailuresMD(),
+					Value:          float64(ts.TransitionFailures),
+					VariableLabels: labels,
+				}, Metric{
+					Description:    getTierRestoreRequestsMD(),
+					Value:          float64(ts.RestoreRequests),
+					VariableLabels: labels,
+				}, Metric{
+					Description:    getTierRestoreFailuresMD(),
+					Value:          float64(ts.RestoreFailures),
+					VariableLabels: labels,
+				})
+			}
+			return
+		},
+	}
+}
+
+// getTierUsageMetrics reports the usage of the remote tiers as accounted
+// by the scanner.
+func getTierUsageMetrics() MetricsGroup {
+	return MetricsGroup{
+		id:         "TierUsageMetrics",
+		cachedRead: cachedRead,
+		read: func(ctx context.Context) (metrics []Metric) {
+			objLayer := newObjectLayerFn()
+			// Service not initialized yet
+			if objLayer == nil || globalIsGateway {
+				return
+			}
+
+			usage, err := loadTierUsageFromBackend(ctx, objLayer)
+			if err != nil {
+				return
+			}
+			for tier, u := range usage {
+				labels := map[string]string{"tier": tier}
+				metrics = append(metrics, Metric{
+					Description:    getTierUsageObjectsMD(),
+					Value:          float64(u.Objects),
+					VariableLabels: labels,
+				}, Metric{
+					Description:    getTierUsageVersionsMD(),
+					Value:          float64(u.Versions),
+					VariableLabels: labels,
+				}, Metric{
+					Description:    getTierUsageBytesMD(),
+					Value:          float64(u.Size),
+					VariableLabels: labels,
+				})
+			}
+			return
+		},
+	}
+}
+
 func getMinioVersionMetrics() MetricsGroup {
 	return MetricsGroup{
 		id:         "MinioVersionMetrics",
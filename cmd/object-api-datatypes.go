@@ -227,6 +227,8 @@ type ReplicateObjectInfo struct {
 	OpType     replication.Type
 	RetryCount uint32
 	ResetID    string
+
+	journalSeg *replicationJournalSegment // set if read from the replication journal
 }
 
 // MultipartInfo captures metadata information about the uploadId
@@ -460,6 +462,9 @@ type PartInfo struct {
 
 	// Decompressed Size.
 	ActualSize int64
+
+	// Checksum of the part in <ALGORITHM>:<value> format, if any.
+	Checksum string
 }
 
 // CompletePart - represents the part that was completed, this is sent by the client
@@ -471,6 +476,28 @@ type CompletePart struct {
 
 	// Entity tag returned when the part was uploaded.
 	ETag string
+
+	// Checksums returned when the part was uploaded, if any.
+	ChecksumCRC32  string
+	ChecksumCRC32C string
+	ChecksumSHA1   string
+	ChecksumSHA256 string
+}
+
+// checksum returns the part checksum of type t, nil if not set.
+func (p CompletePart) checksum(t hash.ChecksumType) *hash.Checksum {
+	var value string
+	switch {
+	case t.Is(hash.ChecksumCRC32):
+		value = p.ChecksumCRC32
+	case t.Is(hash.ChecksumCRC32C):
+		value = p.ChecksumCRC32C
+	case t.Is(hash.ChecksumSHA1):
+		value = p.ChecksumSHA1
+	case t.Is(hash.ChecksumSHA256):
+		value = p.ChecksumSHA256
+	}
+	return hash.NewChecksumString(t.String(), value)
 }
 
 // CompletedParts - is a collection satisfying sort.Interface.
@@ -365,6 +365,34 @@ func (e BucketTaggingNotFound) Error() string {
 	return "No bucket tags found for bucket: " + e.Bucket
 }
 
+// BucketCorsConfigNotFound - no bucket CORS config found
+type BucketCorsConfigNotFound GenericError
+
+func (e BucketCorsConfigNotFound) Error() string {
+	return "No bucket CORS configuration found for bucket: " + e.Bucket
+}
+
+// BucketWebsiteConfigNotFound - no bucket website config found
+type BucketWebsiteConfigNotFound GenericError
+
+func (e BucketWebsiteConfigNotFound) Error() string {
+	return "No bucket website configuration found for bucket: " + e.Bucket
+}
+
+// BucketLoggingConfigNotFound - no bucket logging config found
+type BucketLoggingConfigNotFound GenericError
+
+func (e BucketLoggingConfigNotFound) Error() string {
+	return "No bucket logging configuration found for bucket: " + e.Bucket
+}
+
+// BucketLambdaConfigNotFound - no bucket object lambda config found
+type BucketLambdaConfigNotFound GenericError
+
+func (e BucketLambdaConfigNotFound) Error() string {
+	return "No bucket object lambda configuration found for bucket: " + e.Bucket
+}
+
 // BucketObjectLockConfigNotFound - no bucket object lock config found
 type BucketObjectLockConfigNotFound GenericError
 
@@ -621,6 +649,18 @@ func (e PartTooBig) Error() string {
 	return "Part size bigger than the allowed limit"
 }
 
+// InvalidWriteOffset - the offset of an append does not match the size of the object.
+type InvalidWriteOffset struct {
+	Bucket string
+	Object string
+	Offset int64
+	Size   int64
+}
+
+func (e InvalidWriteOffset) Error() string {
+	return fmt.Sprintf("Write offset %d does not match the size %d of %s/%s", e.Offset, e.Size, e.Bucket, e.Object)
+}
+
 // InvalidETag error returned when the etag has changed on disk
 type InvalidETag struct{}
 
@@ -26,6 +26,7 @@ import (
 	"github.com/minio/madmin-go"
 	"github.com/minio/minio-go/v7/pkg/encrypt"
 	"github.com/minio/minio-go/v7/pkg/tags"
+	"github.com/minio/minio/internal/hash"
 	"github.com/minio/pkg/bucket/policy"
 )
 
@@ -62,6 +63,13 @@ type ObjectOptions struct {
 
 	// Use the maximum parity (N/2), used when saving server configuration files
 	MaxParity bool
+
+	NoDecryption       bool // indicates if the stream must be read as-is without decryption or decompression.
+	SkipDecommissioned bool // set true to skip pools which are being decommissioned when choosing a pool for writes.
+	DataMovement       bool // set true when the object is being moved between pools, tiered content is not freed on delete.
+	RenameCheck        bool // set true to only verify that RenameObject can rename the object, nothing is renamed.
+
+	WantChecksum *hash.Checksum // x-amz-checksum-XXX checksum sent for PutObject/PutObjectPart, only has a value once the content is read.
 }
 
 // ExpirationOptions represents object options for object expiration at objectLayer.
@@ -137,6 +145,8 @@ type ObjectLayer interface {
 	GetObjectNInfo(ctx context.Context, bucket, object string, rs *HTTPRangeSpec, h http.Header, lockType LockType, opts ObjectOptions) (reader *GetObjectReader, err error)
 	GetObjectInfo(ctx context.Context, bucket, object string, opts ObjectOptions) (objInfo ObjectInfo, err error)
 	PutObject(ctx context.Context, bucket, object string, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error)
+	AppendObject(ctx context.Context, bucket, object string, offset int64, data *PutObjReader, opts ObjectOptions) (objInfo ObjectInfo, err error)
+	RenameObject(ctx context.Context, bucket, srcObject, dstObject string, opts ObjectOptions) (objInfo ObjectInfo, err error)
 	CopyObject(ctx context.Context, srcBucket, srcObject, destBucket, destObject string, srcInfo ObjectInfo, srcOpts, dstOpts ObjectOptions) (objInfo ObjectInfo, err error)
 	DeleteObject(ctx context.Context, bucket, object string, opts ObjectOptions) (ObjectInfo, error)
 	DeleteObjects(ctx context.Context, bucket string, objects []ObjectToDelete, opts ObjectOptions) ([]DeletedObject, []error)
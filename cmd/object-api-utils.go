@@ -73,6 +73,15 @@ const (
 	compReadAheadBuffers = 5
 	// Size of each buffer.
 	compReadAheadBufSize = 1 << 20
+
+	// Object checksum in <ALGORITHM>:<value> format.
+	objectChecksumKey = ReservedMetadataPrefix + "checksum"
+	// Checksum algorithm of a multipart upload.
+	uploadChecksumTypeKey = ReservedMetadataPrefix + "checksum-type"
+	// Bucket and object of a multipart upload in <bucket>/<object> format.
+	uploadObjectKey = ReservedMetadataPrefix + "upload-object"
+	// Bitrot algorithm protecting the parts of a multipart upload.
+	uploadBitrotAlgorithmKey = ReservedMetadataPrefix + "bitrot-algorithm"
 )
 
 // isMinioBucket returns true if given bucket is a MinIO internal
@@ -407,6 +416,12 @@ func (o ObjectInfo) IsCompressedOK() (bool, error) {
 	return true, fmt.Errorf("unknown compression scheme: %s", scheme)
 }
 
+// Checksum returns the x-amz-checksum-XXX checksum of the object,
+// nil if the object was uploaded without checksum.
+func (o ObjectInfo) Checksum() *hash.Checksum {
+	return hash.ParseChecksum(o.UserDefined[objectChecksumKey])
+}
+
 // GetActualETag - returns the actual etag of the stored object
 // decrypts SSE objects.
 func (o ObjectInfo) GetActualETag(h http.Header) string {
@@ -607,8 +622,9 @@ func NewGetObjectReader(rs *HTTPRangeSpec, oi ObjectInfo, opts ObjectOptions) (
 		return nil, 0, 0, err
 	}
 
-	// if object is encrypted and it is a restore request, fetch content without decrypting.
-	if opts.Transition.RestoreRequest != nil {
+	// if object is encrypted and it is a restore request or the caller
+	// asked for the raw stream, fetch content without decrypting.
+	if opts.Transition.RestoreRequest != nil || opts.NoDecryption {
 		isEncrypted = false
 		isCompressed = false
 	}
@@ -24,6 +24,7 @@ import (
 	"strconv"
 	"time"
 
+	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
 )
 
@@ -219,6 +220,52 @@ func checkPreconditions(ctx context.Context, w http.ResponseWriter, r *http.Requ
 	return false
 }
 
+// Validates the preconditions of a write against the current object. Returns true
+// if PutObject, CopyObject or CompleteMultipartUpload should not proceed, a missing
+// object is passed as an empty ObjectInfo. Preconditions supported are:
+//  If-Match
+//  If-None-Match
+func checkPreconditionsPUT(r *http.Request, objInfo ObjectInfo) bool {
+	exists := !objInfo.ModTime.IsZero()
+
+	// If-Match : Write the object only if it exists and its entity tag (ETag) is the
+	// same as the one specified, "*" matches any object.
+	ifMatchETagHeader := r.Header.Get(xhttp.IfMatch)
+	if ifMatchETagHeader != "" {
+		if !exists || (ifMatchETagHeader != "*" && !isETagEqual(objInfo.ETag, ifMatchETagHeader)) {
+			return true
+		}
+	}
+
+	// If-None-Match : Write the object only if its entity tag (ETag) is different from
+	// the one specified, "*" only writes the object if it does not exist.
+	ifNoneMatchETagHeader := r.Header.Get(xhttp.IfNoneMatch)
+	if ifNoneMatchETagHeader != "" && exists {
+		if ifNoneMatchETagHeader == "*" || isETagEqual(objInfo.ETag, ifNoneMatchETagHeader) {
+			return true
+		}
+	}
+	return false
+}
+
+// newPutPrecondFn returns the function evaluating the preconditions of a write
+// under the write lock of the object, nil if the request has no preconditions.
+func newPutPrecondFn(r *http.Request, objAPI ObjectLayer) CheckPreconditionFn {
+	if r.Header.Get(xhttp.IfMatch) == "" && r.Header.Get(xhttp.IfNoneMatch) == "" {
+		return nil
+	}
+	return func(oi ObjectInfo) bool {
+		if objAPI.IsEncryptionSupported() {
+			// The ETag of an encrypted object can not be
+			// compared without decrypting it first.
+			if _, err := DecryptObjectInfo(&oi, r); err != nil {
+				return true
+			}
+		}
+		return checkPreconditionsPUT(r, oi)
+	}
+}
+
 // returns true if object was modified after givenTime.
 func ifModifiedSince(objTime time.Time, givenTime time.Time) bool {
 	// The Date-Modified header truncates sub-second precision, so
@@ -263,4 +310,36 @@ func setPutObjHeaders(w http.ResponseWriter, objInfo ObjectInfo, delete bool) {
 			lc.SetPredictionHeaders(w, objInfo.ToLifecycleOpts())
 		}
 	}
+
+	if !delete {
+		hash.AddChecksumHeader(w, objInfo.Checksum().AsMap())
+	}
+}
+
+// addRequestChecksum adds the x-amz-checksum-XXX checksum sent with the
+// request, as header or trailer, to be verified while reading hr. If no
+// checksum is sent but t is set, a checksum of type t is computed instead.
+// The returned checksum only has a value once hr has been read.
+func addRequestChecksum(hr *hash.Reader, r *http.Request, t hash.ChecksumType) (*hash.Checksum, error) {
+	cs, err := hash.GetContentChecksum(r)
+	if err != nil {
+		return nil, err
+	}
+	if cs == nil {
+		if !t.IsSet() {
+			return nil, nil
+		}
+		cs = &hash.Checksum{Type: t}
+		if err = hr.AddNonTrailingChecksum(cs, true); err != nil {
+			return nil, err
+		}
+		return cs, nil
+	}
+	if t.IsSet() && cs.Type.String() != t.String() {
+		return nil, hash.ErrInvalidChecksum
+	}
+	if err = hr.AddChecksum(r, false); err != nil {
+		return nil, err
+	}
+	return hr.Checksum(), nil
 }
@@ -25,6 +25,7 @@ import (
 	"errors"
 	"fmt"
 	"io"
+	"mime/multipart"
 	"net/http"
 	"net/http/httptest"
 	"net/url"
@@ -45,7 +46,6 @@ import (
 	objectlock "github.com/minio/minio/internal/bucket/object/lock"
 	"github.com/minio/minio/internal/bucket/replication"
 	"github.com/minio/minio/internal/config/dns"
-	"github.com/minio/minio/internal/config/storageclass"
 	"github.com/minio/minio/internal/crypto"
 	"github.com/minio/minio/internal/etag"
 	"github.com/minio/minio/internal/event"
@@ -370,12 +370,14 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		getObjectNInfo = api.CacheAPI().GetObjectNInfo
 	}
 
-	// Get request range.
+	// Get request range, multiple ranges are served as a
+	// multipart/byteranges response.
 	var rs *HTTPRangeSpec
+	var ranges []*HTTPRangeSpec
 	var rangeErr error
 	rangeHeader := r.Header.Get(xhttp.Range)
 	if rangeHeader != "" {
-		rs, rangeErr = parseRequestRangeSpec(rangeHeader)
+		ranges, rangeErr = parseRequestRangeSpecs(rangeHeader)
 		// Handle only errInvalidRange. Ignore other
 		// parse error and treat it as regular Get
 		// request like Amazon S3.
@@ -386,10 +388,13 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		if rangeErr != nil {
 			logger.LogIf(ctx, rangeErr, logger.Application)
 		}
+		if len(ranges) == 1 {
+			rs, ranges = ranges[0], nil
+		}
 	}
 
 	// Both 'bytes' and 'partNumber' cannot be specified at the same time
-	if rs != nil && opts.PartNumber > 0 {
+	if (rs != nil || ranges != nil) && opts.PartNumber > 0 {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidRangePartNumber), r.URL)
 		return
 	}
@@ -406,6 +411,7 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		return checkPreconditions(ctx, w, r, oi, opts)
 	}
 
+	var proxied bool
 	gr, err := getObjectNInfo(ctx, bucket, object, rs, r.Header, readLock, opts)
 	if err != nil {
 		var (
@@ -417,6 +423,7 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 			reader, proxy = proxyGetToReplicationTarget(ctx, bucket, object, rs, r.Header, opts)
 			if reader != nil && proxy {
 				gr = reader
+				proxied = true
 			}
 		}
 		if reader == nil || !proxy {
@@ -465,6 +472,18 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		}
 	}
 
+	if ranges != nil {
+		size, err := objInfo.GetActualSize()
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		if ranges, err = resolveRangeSpecs(ranges, size); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
+
 	// filter object lock metadata if permission does not permit
 	getRetPerms := checkRequestAuthType(ctx, r, policy.GetObjectRetentionAction, bucket, object)
 	legalHoldPerms := checkRequestAuthType(ctx, r, policy.GetObjectLegalHoldAction, bucket, object)
@@ -499,37 +518,68 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 		setPartsCountHeaders(w, objInfo)
 	}
 
-	setHeadGetRespHeaders(w, r.Form)
-
-	statusCodeWritten := false
-	httpWriter := ioutil.WriteOnClose(w)
-	if rs != nil || opts.PartNumber > 0 {
-		statusCodeWritten = true
-		w.WriteHeader(http.StatusPartialContent)
+	// Set the checksum of the object, only if the whole object is requested.
+	if r.Header.Get(xhttp.AmzChecksumMode) == "ENABLED" && rs == nil && opts.PartNumber == 0 {
+		hash.AddChecksumHeader(w, objInfo.Checksum().AsMap())
 	}
 
-	// Write object content to response body
-	if _, err = io.Copy(httpWriter, gr); err != nil {
-		if !httpWriter.HasWritten() && !statusCodeWritten {
-			// write error response only if no data or headers has been written to client yet
-			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+	setHeadGetRespHeaders(w, r.Form)
+
+	if ranges != nil {
+		// Read every range from the version of the object the
+		// headers were sent for.
+		rangeOpts := opts
+		rangeOpts.VersionID = objInfo.VersionID
+		rangeOpts.CheckPrecondFn = func(oi ObjectInfo) bool {
+			return !oi.ModTime.Equal(objInfo.ModTime)
+		}
+		openRange := func(rs *HTTPRangeSpec) (*GetObjectReader, error) {
+			if proxied {
+				reader, proxy := proxyGetToReplicationTarget(ctx, bucket, object, rs, r.Header, rangeOpts)
+				if reader == nil || !proxy {
+					return nil, errors.New("Unable to proxy the range to the replication target")
+				}
+				return reader, nil
+			}
+			return getObjectNInfo(ctx, bucket, object, rs, r.Header, readLock, rangeOpts)
+		}
+		if err = writeObjectRanges(w, objInfo, ranges, openRange); err != nil {
+			if !xnet.IsNetworkOrHostDown(err, true) { // do not need to log disconnected clients
+				logger.LogIf(ctx, fmt.Errorf("Unable to write all the ranges to client %w", err))
+			}
 			return
 		}
-		if !xnet.IsNetworkOrHostDown(err, true) { // do not need to log disconnected clients
-			logger.LogIf(ctx, fmt.Errorf("Unable to write all the data to client %w", err))
+	} else {
+		statusCodeWritten := false
+		httpWriter := ioutil.WriteOnClose(w)
+		if rs != nil || opts.PartNumber > 0 {
+			statusCodeWritten = true
+			w.WriteHeader(http.StatusPartialContent)
 		}
-		return
-	}
 
-	if err = httpWriter.Close(); err != nil {
-		if !httpWriter.HasWritten() && !statusCodeWritten { // write error response only if no data or headers has been written to client yet
-			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		// Write object content to response body
+		if _, err = io.Copy(httpWriter, gr); err != nil {
+			if !httpWriter.HasWritten() && !statusCodeWritten {
+				// write error response only if no data or headers has been written to client yet
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+				return
+			}
+			if !xnet.IsNetworkOrHostDown(err, true) { // do not need to log disconnected clients
+				logger.LogIf(ctx, fmt.Errorf("Unable to write all the data to client %w", err))
+			}
 			return
 		}
-		if !xnet.IsNetworkOrHostDown(err, true) { // do not need to log disconnected clients
-			logger.LogIf(ctx, fmt.Errorf("Unable to write all the data to client %w", err))
+
+		if err = httpWriter.Close(); err != nil {
+			if !httpWriter.HasWritten() && !statusCodeWritten { // write error response only if no data or headers has been written to client yet
+				writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+				return
+			}
+			if !xnet.IsNetworkOrHostDown(err, true) { // do not need to log disconnected clients
+				logger.LogIf(ctx, fmt.Errorf("Unable to write all the data to client %w", err))
+			}
+			return
 		}
-		return
 	}
 
 	// Notify object accessed via a GET request.
@@ -544,6 +594,42 @@ func (api objectAPIHandlers) getObjectHandler(ctx context.Context, objectAPI Obj
 	})
 }
 
+// writeObjectRanges writes the ranges of an object as a multipart/byteranges
+// response, the content of each range is read with its own reader.
+func writeObjectRanges(w http.ResponseWriter, objInfo ObjectInfo, ranges []*HTTPRangeSpec, openRange func(rs *HTTPRangeSpec) (*GetObjectReader, error)) error {
+	size, err := objInfo.GetActualSize()
+	if err != nil {
+		return err
+	}
+
+	contentType := w.Header().Get(xhttp.ContentType)
+	mw := multipart.NewWriter(w)
+	length, err := multipartRangesSize(mw.Boundary(), contentType, ranges, size)
+	if err != nil {
+		return err
+	}
+	w.Header().Set(xhttp.ContentType, "multipart/byteranges; boundary="+mw.Boundary())
+	w.Header().Set(xhttp.ContentLength, strconv.FormatInt(length, 10))
+	w.WriteHeader(http.StatusPartialContent)
+
+	for _, rs := range ranges {
+		part, err := mw.CreatePart(rs.mimeHeader(contentType, size))
+		if err != nil {
+			return err
+		}
+		gr, err := openRange(rs)
+		if err != nil {
+			return err
+		}
+		_, err = io.Copy(part, gr)
+		gr.Close()
+		if err != nil {
+			return err
+		}
+	}
+	return mw.Close()
+}
+
 // GetObjectHandler - GET Object
 // ----------
 // This implementation of the GET operation retrieves object. To use GET,
@@ -754,6 +840,11 @@ func (api objectAPIHandlers) headObjectHandler(ctx context.Context, objectAPI Ob
 		setPartsCountHeaders(w, objInfo)
 	}
 
+	// Set the checksum of the object, only if the whole object is requested.
+	if r.Header.Get(xhttp.AmzChecksumMode) == "ENABLED" && rs == nil && opts.PartNumber == 0 {
+		hash.AddChecksumHeader(w, objInfo.Checksum().AsMap())
+	}
+
 	// Set any additional requested response headers.
 	setHeadGetRespHeaders(w, r.Form)
 
@@ -804,6 +895,120 @@ func (api objectAPIHandlers) HeadObjectHandler(w http.ResponseWriter, r *http.Re
 	}
 }
 
+// GetObjectAttributesHandler - GET Object?attributes
+// -----------
+// This operation retrieves the attributes requested in the
+// x-amz-object-attributes header, including the part layout
+// of multipart objects, without returning the object itself.
+func (api objectAPIHandlers) GetObjectAttributesHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "GetObjectAttributes")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	if crypto.S3.IsRequested(r.Header) || crypto.S3KMS.IsRequested(r.Header) { // If SSE-S3 or SSE-KMS present -> AWS fails with undefined error
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL)
+		return
+	}
+	if _, ok := crypto.IsRequested(r.Header); !objectAPI.IsEncryptionSupported() && ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrBadRequest), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	object, err := unescapePath(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	attributes, partNumberMarker, maxParts, s3Error := getObjectAttributesArgs(r.Header)
+	if s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+	if maxParts > maxPartsList {
+		maxParts = maxPartsList
+	}
+
+	opts, err := getOpts(ctx, r, bucket, object)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.GetObjectAction, bucket, object); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	getObjectInfo := objectAPI.GetObjectInfo
+	if api.CacheAPI() != nil {
+		getObjectInfo = api.CacheAPI().GetObjectInfo
+	}
+
+	objInfo, err := getObjectInfo(ctx, bucket, object, opts)
+	if err != nil {
+		if globalBucketVersioningSys.Enabled(bucket) {
+			if objInfo.VersionID != "" && objInfo.DeleteMarker {
+				w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+				w.Header()[xhttp.AmzDeleteMarker] = []string{strconv.FormatBool(objInfo.DeleteMarker)}
+			}
+		}
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	// Parts are only returned for objects uploaded using multipart,
+	// must be evaluated before the ETag is decrypted.
+	isMultipart := len(objInfo.Parts) > 0 && (crypto.IsMultiPart(objInfo.UserDefined) || strings.Contains(objInfo.ETag, "-"))
+
+	if objectAPI.IsEncryptionSupported() {
+		if _, err = DecryptObjectInfo(&objInfo, r); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
+
+	response := GetObjectAttributesResponse{}
+	if attributes["ETag"] {
+		response.ETag = objInfo.ETag
+	}
+	if attributes["Checksum"] {
+		if cs := objInfo.Checksum(); cs.Valid() {
+			checksum := generateChecksumResponse(cs)
+			response.Checksum = &checksum
+		}
+	}
+	if attributes["ObjectParts"] && isMultipart {
+		response.ObjectParts = generateObjectAttributesParts(objInfo.Parts, partNumberMarker, maxParts)
+	}
+	if attributes["StorageClass"] {
+		response.StorageClass = objInfo.StorageClass
+	}
+	if attributes["ObjectSize"] {
+		size, err := objInfo.GetActualSize()
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		response.ObjectSize = &size
+	}
+
+	w.Header().Set(xhttp.LastModified, objInfo.ModTime.UTC().Format(http.TimeFormat))
+	if objInfo.VersionID != "" {
+		w.Header()[xhttp.AmzVersionID] = []string{objInfo.VersionID}
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(response))
+}
+
 // Extract metadata relevant for an CopyObject operation based on conditional
 // header values specified in X-Amz-Metadata-Directive.
 func getCpObjMetadataFromHeader(ctx context.Context, r *http.Request, userMeta map[string]string) (map[string]string, error) {
@@ -990,7 +1195,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 
 	// Validate storage class metadata if present
 	dstSc := r.Header.Get(xhttp.AmzStorageClass)
-	if dstSc != "" && !storageclass.IsValid(dstSc) {
+	if dstSc != "" && !globalStorageClass.IsValid(dstSc) {
 		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL)
 		return
 	}
@@ -1021,6 +1226,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	dstOpts.CheckPrecondFn = newPutPrecondFn(r, objectAPI)
 	cpSrcDstSame := isStringEqual(pathJoin(srcBucket, srcObject), pathJoin(dstBucket, dstObject))
 
 	getObjectNInfo := objectAPI.GetObjectNInfo
@@ -1323,7 +1529,9 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	if rs := r.Header.Get(xhttp.AmzBucketReplicationStatus); rs != "" {
 		srcInfo.UserDefined[xhttp.AmzBucketReplicationStatus] = rs
 	}
-	if ok, _ := mustReplicate(ctx, dstBucket, dstObject, getMustReplicateOptions(srcInfo, replication.UnsetReplicationType)); ok {
+	// Metadata replicated by a peer is not replicated back.
+	replicaCopy := isReplicationRequest(r)
+	if ok, _ := mustReplicate(ctx, dstBucket, dstObject, getMustReplicateOptions(srcInfo, replication.UnsetReplicationType)); ok && !replicaCopy {
 		srcInfo.UserDefined[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
 	}
 	// Store the preserved compression metadata.
@@ -1431,7 +1639,7 @@ func (api objectAPIHandlers) CopyObjectHandler(w http.ResponseWriter, r *http.Re
 	response := generateCopyObjectResponse(objInfo.ETag, objInfo.ModTime)
 	encodedSuccessResponse := encodeResponse(response)
 
-	if replicate, sync := mustReplicate(ctx, dstBucket, dstObject, getMustReplicateOptions(objInfo, replication.UnsetReplicationType)); replicate {
+	if replicate, sync := mustReplicate(ctx, dstBucket, dstObject, getMustReplicateOptions(objInfo, replication.UnsetReplicationType)); replicate && !replicaCopy {
 		scheduleReplication(ctx, objInfo.Clone(), objectAPI, sync, replication.ObjectReplicationType)
 	}
 
@@ -1506,7 +1714,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 
 	// Validate storage class metadata if present
 	if sc := r.Header.Get(xhttp.AmzStorageClass); sc != "" {
-		if !storageclass.IsValid(sc) {
+		if !globalStorageClass.IsValid(sc) {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL)
 			return
 		}
@@ -1518,10 +1726,21 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		return
 	}
 
+	// Appends add the request body to the end of an existing object.
+	appendOffset := int64(-1)
+	if offset := r.Header.Get(xhttp.MinIOAppendOffset); offset != "" {
+		appendOffset, err = strconv.ParseInt(offset, 10, 64)
+		if err != nil || appendOffset < 0 {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidWriteOffset), r.URL)
+			return
+		}
+	}
+
 	/// if Content-Length is unknown/missing, deny the request
 	size := r.ContentLength
 	rAuthType := getRequestAuthType(r)
-	if rAuthType == authTypeStreamingSigned {
+	switch rAuthType {
+	case authTypeStreamingSigned, authTypeStreamingSignedTrailer, authTypeStreamingUnsignedTrailer:
 		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
 			if sizeStr[0] == "" {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
@@ -1550,6 +1769,7 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	setBucketDefaultStorageClass(bucket, metadata)
 
 	if objTags := r.Header.Get(xhttp.AmzObjectTagging); objTags != "" {
 		if !objectAPI.IsTaggingSupported() {
@@ -1580,9 +1800,16 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	}
 
 	switch rAuthType {
-	case authTypeStreamingSigned:
+	case authTypeStreamingSigned, authTypeStreamingSignedTrailer:
 		// Initialize stream signature verifier.
-		reader, s3Err = newSignV4ChunkedReader(r)
+		reader, s3Err = newSignV4ChunkedReader(r, rAuthType == authTypeStreamingSignedTrailer)
+		if s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+			return
+		}
+	case authTypeStreamingUnsignedTrailer:
+		// Initialize stream chunked reader with trailers.
+		reader, s3Err = newUnsignedV4ChunkedReader(r)
 		if s3Err != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
 			return
@@ -1613,8 +1840,9 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	sseConfig, _ := globalBucketSSEConfigSys.Get(bucket)
 	sseConfig.Apply(r.Header, globalAutoEncryption)
 
+	var wantChecksum *hash.Checksum
 	actualSize := size
-	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
+	if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 && appendOffset < 0 {
 		// Storing the compression metadata.
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
 		metadata[ReservedMetadataPrefix+"actual-size"] = strconv.FormatInt(size, 10)
@@ -1624,6 +1852,10 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 			return
 		}
+		if wantChecksum, err = addRequestChecksum(actualReader, r, hash.ChecksumNone); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
 
 		// Set compression metrics.
 		s2c := newS2CompressReader(actualReader, actualSize)
@@ -1639,6 +1871,12 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if wantChecksum == nil {
+		if wantChecksum, err = addRequestChecksum(hashReader, r, hash.ChecksumNone); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
 
 	rawReader := hashReader
 	pReader := NewPutObjReader(rawReader)
@@ -1650,6 +1888,13 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	opts.WantChecksum = wantChecksum
+	opts.CheckPrecondFn = newPutPrecondFn(r, objectAPI)
+
+	if appendOffset >= 0 {
+		appendObject(ctx, w, r, objectAPI, bucket, object, appendOffset, pReader, opts)
+		return
+	}
 
 	if api.CacheAPI() != nil {
 		putObject = api.CacheAPI().PutObject
@@ -1685,6 +1930,14 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
 			return
 		}
+		if replicaIsStale(ctx, objectAPI, bucket, object, opts) {
+			// This site already has the version, or a newer write of it.
+			writeSuccessResponseHeadersOnly(w)
+			return
+		}
+		if origin := r.Header.Get(xhttp.MinIOSourceOrigin); origin != "" {
+			metadata[replicationOriginKey] = origin
+		}
 	}
 	var objectEncryptionKey crypto.ObjectKey
 	if objectAPI.IsEncryptionSupported() {
@@ -1797,6 +2050,244 @@ func (api objectAPIHandlers) PutObjectHandler(w http.ResponseWriter, r *http.Req
 	}
 }
 
+// appendObject - appends the body of a PutObject request carrying
+// the x-minio-append-offset header to the end of an existing object.
+func appendObject(ctx context.Context, w http.ResponseWriter, r *http.Request, objectAPI ObjectLayer, bucket, object string, offset int64, pReader *PutObjReader, opts ObjectOptions) {
+	// The content of encrypted objects, of objects in buckets with object
+	// locking and of replicated objects is never changed in place.
+	if _, ok := crypto.IsRequested(r.Header); ok {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+	if rcfg, _ := globalBucketObjectLockSys.Get(bucket); rcfg.LockEnabled {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+	if _, err := getReplicationConfig(ctx, bucket); err == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objInfo, err := objectAPI.AppendObject(ctx, bucket, object, offset, pReader, opts)
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	setPutObjHeaders(w, objInfo, false)
+
+	writeSuccessResponseHeadersOnly(w)
+
+	// Notify object created event.
+	sendEvent(eventArgs{
+		EventName:    event.ObjectCreatedPut,
+		BucketName:   bucket,
+		Object:       objInfo,
+		ReqParams:    extractReqParams(r),
+		RespElements: extractRespElements(w),
+		UserAgent:    r.UserAgent(),
+		Host:         handlers.GetSourceIP(r),
+	})
+}
+
+// RenameObjectHandler - renames an object with all its versions, or every
+// object under a prefix, within a bucket. This is a MinIO extension, the
+// source is named by the `x-minio-rename-source` header and the request
+// path is the destination. Prefixes end with a slash.
+func (api objectAPIHandlers) RenameObjectHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RenameObject")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	objectAPI := api.ObjectAPI()
+	if objectAPI == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	bucket := vars["bucket"]
+	dstObject, err := unescapePath(vars["object"])
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	if s3Error := checkRequestAuthType(ctx, r, policy.PutObjectAction, bucket, dstObject); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	srcObject, err := url.PathUnescape(r.Header.Get(xhttp.MinIORenameSource))
+	if err != nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidRenameSource), r.URL)
+		return
+	}
+	srcObject = strings.TrimPrefix(srcObject, SlashSeparator)
+	isPrefix := HasSuffix(srcObject, SlashSeparator)
+	if srcObject == "" || srcObject == dstObject || isPrefix != HasSuffix(dstObject, SlashSeparator) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidRenameSource), r.URL)
+		return
+	}
+	// Renaming a prefix into itself would never terminate.
+	if isPrefix && (HasPrefix(srcObject, dstObject) || HasPrefix(dstObject, srcObject)) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidRenameSource), r.URL)
+		return
+	}
+
+	// Object lock and replication rely on object names which never change.
+	if rcfg, _ := globalBucketObjectLockSys.Get(bucket); rcfg.LockEnabled {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+	if _, err := getReplicationConfig(ctx, bucket); err == nil {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	authType := getRequestAuthType(r)
+	checkAccess := func(object, newObject string) error {
+		for _, check := range []struct {
+			object string
+			action iampolicy.Action
+		}{
+			{object, iampolicy.GetObjectAction},
+			{object, iampolicy.DeleteObjectAction},
+			{newObject, iampolicy.PutObjectAction},
+		} {
+			if s3Error := isPutActionAllowed(ctx, authType, bucket, check.object, r, check.action); s3Error != ErrNone {
+				return PrefixAccessDenied{Bucket: bucket, Object: check.object}
+			}
+		}
+		return nil
+	}
+
+	renameObject := func(object string) (ObjectInfo, error) {
+		newObject := dstObject + strings.TrimPrefix(object, srcObject)
+		if err := checkAccess(object, newObject); err != nil {
+			return ObjectInfo{}, err
+		}
+		objInfo, err := objectAPI.RenameObject(ctx, bucket, object, newObject, ObjectOptions{})
+		if err != nil {
+			return objInfo, err
+		}
+
+		srcObjInfo := objInfo
+		srcObjInfo.Name = object
+		for _, e := range []struct {
+			name    event.Name
+			objInfo ObjectInfo
+		}{
+			{event.ObjectRemovedDelete, srcObjInfo},
+			{event.ObjectCreatedCopy, objInfo},
+		} {
+			sendEvent(eventArgs{
+				EventName:    e.name,
+				BucketName:   bucket,
+				Object:       e.objInfo,
+				ReqParams:    extractReqParams(r),
+				RespElements: extractRespElements(w),
+				UserAgent:    r.UserAgent(),
+				Host:         handlers.GetSourceIP(r),
+			})
+		}
+		return objInfo, nil
+	}
+
+	if !isPrefix {
+		objInfo, err := renameObject(srcObject)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		setPutObjHeaders(w, objInfo, false)
+		writeSuccessResponseHeadersOnly(w)
+		return
+	}
+
+	if s3Error := isPutActionAllowed(ctx, authType, bucket, srcObject, r, iampolicy.ListBucketAction); s3Error != ErrNone {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+		return
+	}
+
+	// Check that every object under the prefix can be renamed before
+	// renaming any of them, objects changed in between may still fail.
+	err = walkObjectNames(ctx, objectAPI, bucket, srcObject, func(objects []string) error {
+		for _, object := range objects {
+			newObject := dstObject + strings.TrimPrefix(object, srcObject)
+			if err := checkAccess(object, newObject); err != nil {
+				return err
+			}
+			if _, err := objectAPI.RenameObject(ctx, bucket, object, newObject, ObjectOptions{RenameCheck: true}); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	var renamed int
+	err = walkObjectNames(ctx, objectAPI, bucket, srcObject, func(objects []string) error {
+		for _, object := range objects {
+			if _, err := renameObject(object); err != nil {
+				return err
+			}
+			renamed++
+		}
+		return nil
+	})
+	if err != nil {
+		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseXML(w, encodeResponse(RenamePrefixResponse{Renamed: renamed}))
+}
+
+// renamePrefixBatch is the number of object names under a prefix
+// handled at once when renaming a prefix.
+const renamePrefixBatch = 1000
+
+// walkObjectNames calls fn with batches of the names of the objects,
+// including the ones with only delete markers, under a prefix.
+func walkObjectNames(ctx context.Context, objectAPI ObjectLayer, bucket, prefix string, fn func(objects []string) error) error {
+	ctx, cancel := context.WithCancel(ctx)
+	objInfoCh := make(chan ObjectInfo)
+	if err := objectAPI.Walk(ctx, bucket, prefix, objInfoCh, ObjectOptions{WalkVersions: true}); err != nil {
+		cancel()
+		return err
+	}
+	defer func() {
+		// Stop the walk and release the blocked sender.
+		cancel()
+		for range objInfoCh {
+		}
+	}()
+
+	objects := make([]string, 0, renamePrefixBatch)
+	var last string
+	for objInfo := range objInfoCh {
+		if objInfo.Name == last {
+			continue
+		}
+		last = objInfo.Name
+		objects = append(objects, objInfo.Name)
+		if len(objects) == renamePrefixBatch {
+			if err := fn(objects); err != nil {
+				return err
+			}
+			objects = objects[:0]
+		}
+	}
+	if len(objects) == 0 {
+		return nil
+	}
+	return fn(objects)
+}
+
 // PutObjectExtractHandler - PUT Object extract is an extended API
 // based off from AWS Snowball feature to auto extract compressed
 // stream will be extracted in the same directory it is stored in
@@ -1847,7 +2338,7 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 	// Validate storage class metadata if present
 	sc := r.Header.Get(xhttp.AmzStorageClass)
 	if sc != "" {
-		if !storageclass.IsValid(sc) {
+		if !globalStorageClass.IsValid(sc) {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL)
 			return
 		}
@@ -1862,7 +2353,8 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 	/// if Content-Length is unknown/missing, deny the request
 	size := r.ContentLength
 	rAuthType := getRequestAuthType(r)
-	if rAuthType == authTypeStreamingSigned {
+	switch rAuthType {
+	case authTypeStreamingSigned, authTypeStreamingSignedTrailer, authTypeStreamingUnsignedTrailer:
 		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
 			if sizeStr[0] == "" {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
@@ -1902,9 +2394,16 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 	}
 
 	switch rAuthType {
-	case authTypeStreamingSigned:
+	case authTypeStreamingSigned, authTypeStreamingSignedTrailer:
 		// Initialize stream signature verifier.
-		reader, s3Err = newSignV4ChunkedReader(r)
+		reader, s3Err = newSignV4ChunkedReader(r, rAuthType == authTypeStreamingSignedTrailer)
+		if s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+			return
+		}
+	case authTypeStreamingUnsignedTrailer:
+		// Initialize stream chunked reader with trailers.
+		reader, s3Err = newUnsignedV4ChunkedReader(r)
 		if s3Err != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
 			return
@@ -1958,6 +2457,7 @@ func (api objectAPIHandlers) PutObjectExtractHandler(w http.ResponseWriter, r *h
 		metadata := map[string]string{
 			xhttp.AmzStorageClass: sc,
 		}
+		setBucketDefaultStorageClass(bucket, metadata)
 
 		actualSize := size
 		if objectAPI.IsCompressionSupported() && isCompressible(r.Header, object) && size > 0 {
@@ -2135,7 +2635,7 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 
 	// Validate storage class metadata if present
 	if sc := r.Header.Get(xhttp.AmzStorageClass); sc != "" {
-		if !storageclass.IsValid(sc) {
+		if !globalStorageClass.IsValid(sc) {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidStorageClass), r.URL)
 			return
 		}
@@ -2161,6 +2661,7 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	setBucketDefaultStorageClass(bucket, metadata)
 
 	retPerms := isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.PutObjectRetentionAction)
 	holdPerms := isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.PutObjectLegalHoldAction)
@@ -2187,6 +2688,15 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 	}, replication.ObjectReplicationType)); ok {
 		metadata[xhttp.AmzBucketReplicationStatus] = replication.Pending.String()
 	}
+	if r.Header.Get(xhttp.AmzBucketReplicationStatus) == replication.Replica.String() {
+		if s3Err = isPutActionAllowed(ctx, getRequestAuthType(r), bucket, object, r, iampolicy.ReplicateObjectAction); s3Err != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Err), r.URL)
+			return
+		}
+		if origin := r.Header.Get(xhttp.MinIOSourceOrigin); origin != "" {
+			metadata[replicationOriginKey] = origin
+		}
+	}
 	// We need to preserve the encryption headers set in EncryptRequest,
 	// so we do not want to override them, copy them instead.
 	for k, v := range encMetadata {
@@ -2201,6 +2711,15 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		metadata[ReservedMetadataPrefix+"compression"] = compressionAlgorithmV2
 	}
 
+	checksumType := hash.NewChecksumType(r.Header.Get(xhttp.AmzChecksumAlgo))
+	if checksumType.Is(hash.ChecksumInvalid) {
+		writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrInvalidChecksum), r.URL)
+		return
+	}
+	if checksumType.IsSet() {
+		metadata[uploadChecksumTypeKey] = checksumType.String()
+	}
+
 	opts, err := putOpts(ctx, r, bucket, object, metadata)
 	if err != nil {
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
@@ -2214,6 +2733,10 @@ func (api objectAPIHandlers) NewMultipartUploadHandler(w http.ResponseWriter, r
 		return
 	}
 
+	if checksumType.IsSet() {
+		w.Header().Set(xhttp.AmzChecksumAlgo, checksumType.String())
+	}
+
 	response := generateInitiateMultipartUploadResponse(bucket, object, uploadID)
 	encodedSuccessResponse := encodeResponse(response)
 
@@ -2443,6 +2966,22 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 		return
 	}
 
+	// Parts of uploads initiated with a checksum algorithm always get a checksum.
+	var wantChecksum *hash.Checksum
+	if checksumType := hash.NewChecksumType(mi.UserDefined[uploadChecksumTypeKey]); checksumType.IsSet() {
+		checksumReader, err := hash.NewReader(reader, length, "", "", actualPartSize)
+		if err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		wantChecksum = &hash.Checksum{Type: checksumType}
+		if err = checksumReader.AddNonTrailingChecksum(wantChecksum, true); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+		reader = checksumReader
+	}
+
 	// Read compression metadata preserved in the init multipart for the decision.
 	_, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
 	// Compress only if the compression is enabled during initial multipart.
@@ -2521,6 +3060,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	}
 
 	srcInfo.PutObjReader = pReader
+	dstOpts.WantChecksum = wantChecksum
 	// Copy source object to destination, if source and destination
 	// object is same then only metadata is updated.
 	partInfo, err := objectAPI.CopyObjectPart(ctx, srcBucket, srcObject, dstBucket, dstObject, uploadID, partID,
@@ -2535,6 +3075,7 @@ func (api objectAPIHandlers) CopyObjectPartHandler(w http.ResponseWriter, r *htt
 	}
 
 	response := generateCopyObjectPartResponse(partInfo.ETag, partInfo.LastModified)
+	response.ChecksumResponse = generateChecksumResponse(wantChecksum)
 	encodedSuccessResponse := encodeResponse(response)
 
 	// Write success response.
@@ -2592,7 +3133,8 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 
 	rAuthType := getRequestAuthType(r)
 	// For auth type streaming signature, we need to gather a different content length.
-	if rAuthType == authTypeStreamingSigned {
+	switch rAuthType {
+	case authTypeStreamingSigned, authTypeStreamingSignedTrailer, authTypeStreamingUnsignedTrailer:
 		if sizeStr, ok := r.Header[xhttp.AmzDecodedContentLength]; ok {
 			if sizeStr[0] == "" {
 				writeErrorResponse(ctx, w, errorCodes.ToAPIErr(ErrMissingContentLength), r.URL)
@@ -2643,9 +3185,16 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	}
 
 	switch rAuthType {
-	case authTypeStreamingSigned:
+	case authTypeStreamingSigned, authTypeStreamingSignedTrailer:
 		// Initialize stream signature verifier.
-		reader, s3Error = newSignV4ChunkedReader(r)
+		reader, s3Error = newSignV4ChunkedReader(r, rAuthType == authTypeStreamingSignedTrailer)
+		if s3Error != ErrNone {
+			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
+			return
+		}
+	case authTypeStreamingUnsignedTrailer:
+		// Initialize stream chunked reader with trailers.
+		reader, s3Error = newUnsignedV4ChunkedReader(r)
 		if s3Error != ErrNone {
 			writeErrorResponse(ctx, w, errorCodes.ToAPIErr(s3Error), r.URL)
 			return
@@ -2692,12 +3241,20 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	// Read compression metadata preserved in the init multipart for the decision.
 	_, isCompressed := mi.UserDefined[ReservedMetadataPrefix+"compression"]
 
+	// Parts of uploads initiated with a checksum algorithm always get a checksum.
+	checksumType := hash.NewChecksumType(mi.UserDefined[uploadChecksumTypeKey])
+	var wantChecksum *hash.Checksum
+
 	if objectAPI.IsCompressionSupported() && isCompressed {
 		actualReader, err := hash.NewReader(reader, size, md5hex, sha256hex, actualSize)
 		if err != nil {
 			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 			return
 		}
+		if wantChecksum, err = addRequestChecksum(actualReader, r, checksumType); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
 
 		// Set compression metrics.
 		s2c := newS2CompressReader(actualReader, actualSize)
@@ -2713,6 +3270,12 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 		writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
 		return
 	}
+	if !objectAPI.IsCompressionSupported() || !isCompressed {
+		if wantChecksum, err = addRequestChecksum(hashReader, r, checksumType); err != nil {
+			writeErrorResponse(ctx, w, toAPIError(ctx, err), r.URL)
+			return
+		}
+	}
 	rawReader := hashReader
 	pReader := NewPutObjReader(rawReader)
 
@@ -2779,6 +3342,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 
 	putObjectPart := objectAPI.PutObjectPart
 
+	opts.WantChecksum = wantChecksum
 	partInfo, err := putObjectPart(ctx, bucket, object, uploadID, partID, pReader, opts)
 	if err != nil {
 		// Verify if the underlying error is signature mismatch.
@@ -2807,6 +3371,7 @@ func (api objectAPIHandlers) PutObjectPartHandler(w http.ResponseWriter, r *http
 	// clients expect the ETag header key to be literally "ETag" - not "Etag" (case-sensitive).
 	// Therefore, we have to set the ETag directly as map entry.
 	w.Header()[xhttp.ETag] = []string{"\"" + etag + "\""}
+	hash.AddChecksumHeader(w, wantChecksum.AsMap())
 
 	writeSuccessResponseHeadersOnly(w)
 }
@@ -3159,6 +3724,7 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	if _, ok := opts.UserDefined["etag"]; !ok {
 		opts.UserDefined["etag"] = s3MD5
 	}
+	opts.CheckPrecondFn = newPutPrecondFn(r, objectAPI)
 
 	w = &whiteSpaceWriter{ResponseWriter: w, Flusher: w.(http.Flusher)}
 	completeDoneCh := sendWhiteSpace(w)
@@ -3179,6 +3745,7 @@ func (api objectAPIHandlers) CompleteMultipartUploadHandler(w http.ResponseWrite
 	location := getObjectLocation(r, globalDomainNames, bucket, object)
 	// Generate complete multipart response.
 	response := generateCompleteMultpartUploadResponse(bucket, object, location, objInfo.ETag)
+	response.ChecksumResponse = generateChecksumResponse(objInfo.Checksum())
 	var encodedSuccessResponse []byte
 	if !headerWritten {
 		encodedSuccessResponse = encodeResponse(response)
@@ -3285,6 +3852,11 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 	}
 
 	replicateDel, replicateSync := checkReplicateDelete(ctx, bucket, ObjectToDelete{ObjectName: object, VersionID: opts.VersionID}, goi, gerr)
+	replicaDel := r.Header.Get(xhttp.AmzBucketReplicationStatus) == replication.Replica.String()
+	if replicaDel {
+		// Deletes replicated by a peer are not replicated back.
+		replicateDel = false
+	}
 	if replicateDel {
 		if opts.VersionID != "" {
 			opts.VersionPurgeStatus = Pending
@@ -3294,11 +3866,16 @@ func (api objectAPIHandlers) DeleteObjectHandler(w http.ResponseWriter, r *http.
 	}
 
 	vID := opts.VersionID
-	if r.Header.Get(xhttp.AmzBucketReplicationStatus) == replication.Replica.String() {
+	if replicaDel {
 		opts.DeleteMarkerReplicationStatus = replication.Replica.String()
 		if opts.VersionPurgeStatus.Empty() {
 			// opts.VersionID holds delete marker version ID to replicate and not yet present on disk
 			vID = ""
+			if opts.DeleteMarker && replicaIsStale(ctx, objectAPI, bucket, object, opts) {
+				// This site already has the delete marker.
+				writeSuccessNoContent(w)
+				return
+			}
 		}
 	}
 
@@ -26,6 +26,8 @@ import (
 	"encoding/xml"
 	"fmt"
 	"io"
+	"mime"
+	"mime/multipart"
 	"path"
 	"runtime"
 	"strings"
@@ -40,6 +42,7 @@ import (
 
 	humanize "github.com/dustin/go-humanize"
 	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/hash"
 	xhttp "github.com/minio/minio/internal/http"
 	ioutilx "github.com/minio/minio/internal/ioutil"
 )
@@ -772,6 +775,70 @@ func testAPIGetObjectWithMPHandler(obj ObjectLayer, instanceType, bucketName str
 		}
 	}
 
+	mkMultiRangeGetReq := func(oi ObjectInput, byteRange string, i int, mkSignedReq testSignedReqFn) {
+		object := oi.objectName
+		rec := httptest.NewRecorder()
+		req, err := mkSignedReq(http.MethodGet, getGetObjectURL("", bucketName, object),
+			0, nil, credentials.AccessKey, credentials.SecretKey, oi.metaData)
+		if err != nil {
+			t.Fatalf("Object: %s Case %d ByteRange: %s: Failed to create HTTP request for Get Object: <ERROR> %v",
+				object, i+1, byteRange, err)
+		}
+		req.Header.Set("Range", byteRange)
+
+		apiRouter.ServeHTTP(rec, req)
+
+		if rec.Code != http.StatusPartialContent {
+			t.Fatalf("%s Object: %s Case %d ByteRange: %s: Got response status `%d` and body: %s",
+				instanceType, object, i+1, byteRange, rec.Code, rec.Body.String())
+		}
+		if contentLength := strconv.Itoa(rec.Body.Len()); rec.Header().Get(xhttp.ContentLength) != contentLength {
+			t.Fatalf("%s Object: %s Case %d ByteRange: %s: Expected Content-Length %s, got %s",
+				instanceType, object, i+1, byteRange, contentLength, rec.Header().Get(xhttp.ContentLength))
+		}
+		mediaType, params, err := mime.ParseMediaType(rec.Header().Get(xhttp.ContentType))
+		if err != nil || mediaType != "multipart/byteranges" {
+			t.Fatalf("%s Object: %s Case %d ByteRange: %s: Unexpected Content-Type %s",
+				instanceType, object, i+1, byteRange, rec.Header().Get(xhttp.ContentType))
+		}
+
+		ranges, err := parseRequestRangeSpecs(byteRange)
+		if err != nil {
+			t.Fatalf("Object: %s Case %d ByteRange: %s: Unexpected err: %v", object, i+1, byteRange, err)
+		}
+		if ranges, err = resolveRangeSpecs(ranges, objectLength(oi)); err != nil {
+			t.Fatalf("Object: %s Case %d ByteRange: %s: Unexpected err: %v", object, i+1, byteRange, err)
+		}
+
+		mr := multipart.NewReader(rec.Body, params["boundary"])
+		for _, rs := range ranges {
+			part, err := mr.NextPart()
+			if err != nil {
+				t.Fatalf("%s Object: %s Case %d ByteRange: %s: Unexpected err: %v", instanceType, object, i+1, byteRange, err)
+			}
+			off, length, _ := rs.GetOffsetLength(objectLength(oi))
+			contentRange := fmt.Sprintf("bytes %d-%d/%d", off, off+length-1, objectLength(oi))
+			if part.Header.Get(xhttp.ContentRange) != contentRange {
+				t.Fatalf("%s Object: %s Case %d ByteRange: %s: Expected Content-Range %s, got %s",
+					instanceType, object, i+1, byteRange, contentRange, part.Header.Get(xhttp.ContentRange))
+			}
+
+			readers := []io.Reader{}
+			cumulativeSum := int64(0)
+			for _, p := range oi.partLengths {
+				readers = append(readers, NewDummyDataGen(p, cumulativeSum))
+				cumulativeSum += p
+			}
+			refReader := io.LimitReader(ioutilx.NewSkipReader(io.MultiReader(readers...), off), length)
+			if ok, msg := cmpReaders(refReader, part); !ok {
+				t.Fatalf("(%s) Object: %s Case %d ByteRange: %s --> data mismatch! (msg: %s)", instanceType, oi.objectName, i+1, byteRange, msg)
+			}
+		}
+		if _, err = mr.NextPart(); err != io.EOF {
+			t.Fatalf("%s Object: %s Case %d ByteRange: %s: Expected the end of the parts, got %v", instanceType, object, i+1, byteRange, err)
+		}
+	}
+
 	// Iterate over each uploaded object and do a bunch of get
 	// requests on them.
 	caseNumber := 0
@@ -814,6 +881,22 @@ func testAPIGetObjectWithMPHandler(obj ObjectLayer, instanceType, bucketName str
 				mkGetReq(oi, rangeHdr, caseNumber, sf)
 				caseNumber++
 			}
+
+			// Multiple ranges - all are valid!
+			multiRangeHdrs := []string{
+				// Read first and last byte of object
+				"bytes=0-0,-1",
+				// Read last and first byte of object
+				"bytes=-1,0-0",
+				// Read first and last quarter of object
+				fmt.Sprintf("bytes=0-%d, %d-", objLen/4, objLen*3/4+1),
+				// Read first byte of object and a range beyond the end
+				fmt.Sprintf("bytes=0-0,%d-", objLen),
+			}
+			for _, rangeHdr := range multiRangeHdrs {
+				mkMultiRangeGetReq(oi, rangeHdr, caseNumber, sf)
+				caseNumber++
+			}
 		}
 
 	}
@@ -4054,3 +4137,768 @@ func testAPIListObjectPartsHandler(obj ObjectLayer, instanceType, bucketName str
 	// `ExecObjectLayerAPINilTest` sets the Object Layer to `nil` and calls the handler.
 	ExecObjectLayerAPINilTest(t, nilBucket, nilObject, instanceType, apiRouter, nilReq)
 }
+
+// Wrapper for calling the checksum tests of the PutObject and multipart API handlers.
+func TestAPIObjectChecksumHandlers(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIObjectChecksumHandlers,
+		[]string{"NewMultipart", "PutObjectPart", "CompleteMultipart", "PutObject", "HeadObject"})
+}
+
+func testAPIObjectChecksumHandlers(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	data := []byte("hello, checksums")
+	crc32Sum := hash.NewChecksumFromData(hash.ChecksumCRC32, data)
+
+	putTestCases := []struct {
+		objectName string
+		header     map[string]string
+		expectCode int
+		expectErr  string
+	}{
+		// Test case - 1.
+		// Valid checksum.
+		{"crc32", map[string]string{xhttp.AmzChecksumCRC32: crc32Sum.Encoded}, http.StatusOK, ""},
+		// Test case - 2.
+		// Checksum not matching the content.
+		{"sha256", map[string]string{xhttp.AmzChecksumSHA256: hash.NewChecksumFromData(hash.ChecksumSHA256, []byte("other")).Encoded}, http.StatusBadRequest, "BadDigest"},
+		// Test case - 3.
+		// Checksum of invalid length.
+		{"sha1", map[string]string{xhttp.AmzChecksumSHA1: crc32Sum.Encoded}, http.StatusBadRequest, "InvalidArgument"},
+		// Test case - 4.
+		// More than one checksum.
+		{"crc32c", map[string]string{
+			xhttp.AmzChecksumCRC32:  crc32Sum.Encoded,
+			xhttp.AmzChecksumCRC32C: hash.NewChecksumFromData(hash.ChecksumCRC32C, data).Encoded,
+		}, http.StatusBadRequest, "InvalidArgument"},
+	}
+	for i, testCase := range putTestCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, testCase.objectName),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, testCase.header)
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for PutObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.expectCode {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`: %s", i+1, instanceType, testCase.expectCode, rec.Code, rec.Body)
+		}
+		if testCase.expectErr != "" {
+			errResp := APIErrorResponse{}
+			if err = xml.Unmarshal(rec.Body.Bytes(), &errResp); err != nil {
+				t.Fatalf("Test %d: %s: Failed parsing error response: <ERROR> %v", i+1, instanceType, err)
+			}
+			if errResp.Code != testCase.expectErr {
+				t.Errorf("Test %d: %s: Expected error `%s`, but instead found `%s`", i+1, instanceType, testCase.expectErr, errResp.Code)
+			}
+			continue
+		}
+		if got := rec.Header().Get(xhttp.AmzChecksumCRC32); got != crc32Sum.Encoded {
+			t.Errorf("Test %d: %s: Expected checksum `%s` in the response, but instead found `%s`", i+1, instanceType, crc32Sum.Encoded, got)
+		}
+	}
+
+	// The checksum is only returned by HeadObject if requested.
+	for _, mode := range []string{"", "ENABLED"} {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodHead, getHeadObjectURL("", bucketName, "crc32"),
+			0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.AmzChecksumMode: mode})
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for HeadObject: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		want := ""
+		if mode != "" {
+			want = crc32Sum.Encoded
+		}
+		if got := rec.Header().Get(xhttp.AmzChecksumCRC32); got != want {
+			t.Errorf("%s: checksum mode `%s`: Expected checksum `%s`, but instead found `%s`", instanceType, mode, want, got)
+		}
+	}
+
+	// Multipart upload initiated with a checksum algorithm.
+	objectName := "multipart"
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodPost, getNewMultipartURL("", bucketName, objectName),
+		0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.AmzChecksumAlgo: "CRC32C"})
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for NewMultipartUpload: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	multipartResponse := &InitiateMultipartUploadResponse{}
+	if err = xml.NewDecoder(rec.Body).Decode(multipartResponse); err != nil {
+		t.Fatalf("%s: Error decoding the recorded response Body: <ERROR> %v", instanceType, err)
+	}
+	uploadID := multipartResponse.UploadID
+
+	partsData := [][]byte{bytes.Repeat([]byte("a"), 5*humanize.MiByte), []byte("b")}
+	var completeParts []CompletePart
+	var partChecksums []*hash.Checksum
+	for i, partData := range partsData {
+		partChecksum := hash.NewChecksumFromData(hash.ChecksumCRC32C, partData)
+		rec = httptest.NewRecorder()
+		// Parts are uploaded without checksum, the server computes them.
+		req, err = newTestSignedRequestV4(http.MethodPut, getPutObjectPartURL("", bucketName, objectName, uploadID, strconv.Itoa(i+1)),
+			int64(len(partData)), bytes.NewReader(partData), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for PutObjectPart: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+		}
+		if got := rec.Header().Get(xhttp.AmzChecksumCRC32C); got != partChecksum.Encoded {
+			t.Fatalf("%s: part %d: Expected checksum `%s`, but instead found `%s`", instanceType, i+1, partChecksum.Encoded, got)
+		}
+		completeParts = append(completeParts, CompletePart{
+			PartNumber:     i + 1,
+			ETag:           canonicalizeETag(strings.Join(rec.Header()[xhttp.ETag], "")),
+			ChecksumCRC32C: partChecksum.Encoded,
+		})
+		partChecksums = append(partChecksums, partChecksum)
+	}
+
+	// Part checksums are not kept in FS mode.
+	if instanceType == FSTestStr {
+		return
+	}
+
+	completeMultipart := func(parts []CompletePart) *httptest.ResponseRecorder {
+		completeBytes, err := xml.Marshal(&CompleteMultipartUpload{Parts: parts})
+		if err != nil {
+			t.Fatalf("%s: Error XML encoding of parts: <ERROR> %v", instanceType, err)
+		}
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPost, getCompleteMultipartUploadURL("", bucketName, objectName, uploadID),
+			int64(len(completeBytes)), bytes.NewReader(completeBytes), credentials.AccessKey, credentials.SecretKey, nil)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for CompleteMultipartUpload: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	// Part checksums not matching the uploaded parts are rejected.
+	badParts := append([]CompletePart{}, completeParts...)
+	badParts[1].ChecksumCRC32C = partChecksums[0].Encoded
+	if rec = completeMultipart(badParts); rec.Code != http.StatusBadRequest {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+
+	rec = completeMultipart(completeParts)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`: %s", instanceType, http.StatusOK, rec.Code, rec.Body)
+	}
+	completeResponse := CompleteMultipartUploadResponse{}
+	if err = xml.Unmarshal(rec.Body.Bytes(), &completeResponse); err != nil {
+		t.Fatalf("%s: Error decoding the recorded response Body: <ERROR> %v", instanceType, err)
+	}
+	want := hash.NewCompositeChecksum(hash.ChecksumCRC32C, partChecksums)
+	if completeResponse.ChecksumCRC32C != want.Encoded {
+		t.Errorf("%s: Expected checksum `%s`, but instead found `%s`", instanceType, want.Encoded, completeResponse.ChecksumCRC32C)
+	}
+
+	objInfo, err := obj.GetObjectInfo(context.Background(), bucketName, objectName, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: <ERROR> %v", instanceType, err)
+	}
+	if got := objInfo.Checksum(); !got.Equal(want) {
+		t.Errorf("%s: Expected object checksum `%s`, but instead found `%s`", instanceType, want, got)
+	}
+}
+
+// Wrapper for calling GetObjectAttributes API handler tests for both Erasure multiple disks and FS single drive setup.
+func TestAPIGetObjectAttributesHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIGetObjectAttributesHandler,
+		[]string{"NewMultipart", "PutObjectPart", "CompleteMultipart", "PutObject", "GetObjectAttributes"})
+}
+
+func testAPIGetObjectAttributesHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	data := []byte("hello, attributes")
+	crc32Sum := hash.NewChecksumFromData(hash.ChecksumCRC32, data)
+
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, "small"),
+		int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey,
+		map[string]string{xhttp.AmzChecksumCRC32: crc32Sum.Encoded})
+	if err != nil {
+		t.Fatalf("%s: Failed to create HTTP request for PutObject: <ERROR> %v", instanceType, err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	oneMiB := int64(humanize.MiByte)
+	uploadTestObject(t, apiRouter, credentials, bucketName, "multipart", []int64{5 * oneMiB, 5 * oneMiB, 1}, nil, true)
+
+	getAttributes := func(object string, header map[string]string) (*httptest.ResponseRecorder, GetObjectAttributesResponse) {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodGet, getGetObjectAttributesURL("", bucketName, object),
+			0, nil, credentials.AccessKey, credentials.SecretKey, header)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for GetObjectAttributes: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		var response GetObjectAttributesResponse
+		if rec.Code == http.StatusOK {
+			if err = xml.Unmarshal(rec.Body.Bytes(), &response); err != nil {
+				t.Fatalf("%s: Error decoding the recorded response Body: <ERROR> %v", instanceType, err)
+			}
+		}
+		return rec, response
+	}
+
+	// Single part object, with all attributes.
+	rec, response := getAttributes("small", map[string]string{
+		xhttp.AmzObjectAttributes: "ETag,Checksum,ObjectParts,StorageClass,ObjectSize",
+	})
+	if rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+	if response.ETag != getMD5Hash(data) {
+		t.Errorf("%s: Expected ETag `%s`, but instead found `%s`", instanceType, getMD5Hash(data), response.ETag)
+	}
+	if response.Checksum == nil || response.Checksum.ChecksumCRC32 != crc32Sum.Encoded {
+		t.Errorf("%s: Expected checksum `%s`, but instead found `%v`", instanceType, crc32Sum.Encoded, response.Checksum)
+	}
+	if response.ObjectSize == nil || *response.ObjectSize != int64(len(data)) {
+		t.Errorf("%s: Expected object size `%d`, but instead found `%v`", instanceType, len(data), response.ObjectSize)
+	}
+	if response.StorageClass != globalMinioDefaultStorageClass {
+		t.Errorf("%s: Expected storage class `%s`, but instead found `%s`", instanceType, globalMinioDefaultStorageClass, response.StorageClass)
+	}
+	if response.ObjectParts != nil {
+		t.Errorf("%s: Expected no object parts for a single part object", instanceType)
+	}
+
+	// Only requested attributes are returned.
+	_, response = getAttributes("small", map[string]string{xhttp.AmzObjectAttributes: "ObjectSize"})
+	if response.ETag != "" || response.Checksum != nil || response.StorageClass != "" {
+		t.Errorf("%s: Expected only the object size, but instead found `%v`", instanceType, response)
+	}
+
+	// Object parts are paginated.
+	testCases := []struct {
+		partNumberMarker string
+		maxParts         string
+		partNumbers      []int
+		isTruncated      bool
+	}{
+		// Test case - 1.
+		{"", "", []int{1, 2, 3}, false},
+		// Test case - 2.
+		{"", "2", []int{1, 2}, true},
+		// Test case - 3.
+		{"2", "2", []int{3}, false},
+	}
+	for i, testCase := range testCases {
+		header := map[string]string{xhttp.AmzObjectAttributes: "ObjectParts"}
+		if testCase.partNumberMarker != "" {
+			header[xhttp.AmzPartNumberMarker] = testCase.partNumberMarker
+		}
+		if testCase.maxParts != "" {
+			header[xhttp.AmzMaxParts] = testCase.maxParts
+		}
+		rec, response = getAttributes("multipart", header)
+		if rec.Code != http.StatusOK {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, http.StatusOK, rec.Code)
+		}
+		parts := response.ObjectParts
+		if parts == nil {
+			t.Fatalf("Test %d: %s: Expected object parts", i+1, instanceType)
+		}
+		if parts.PartsCount != 3 {
+			t.Errorf("Test %d: %s: Expected parts count `3`, but instead found `%d`", i+1, instanceType, parts.PartsCount)
+		}
+		if parts.IsTruncated != testCase.isTruncated {
+			t.Errorf("Test %d: %s: Expected truncated `%v`, but instead found `%v`", i+1, instanceType, testCase.isTruncated, parts.IsTruncated)
+		}
+		if len(parts.Parts) != len(testCase.partNumbers) {
+			t.Fatalf("Test %d: %s: Expected `%d` parts, but instead found `%d`", i+1, instanceType, len(testCase.partNumbers), len(parts.Parts))
+		}
+		for j, part := range parts.Parts {
+			if part.PartNumber != testCase.partNumbers[j] {
+				t.Errorf("Test %d: %s: Expected part number `%d`, but instead found `%d`", i+1, instanceType, testCase.partNumbers[j], part.PartNumber)
+			}
+			wantSize := 5 * oneMiB
+			if part.PartNumber == 3 {
+				wantSize = 1
+			}
+			if part.Size != wantSize {
+				t.Errorf("Test %d: %s: Expected part size `%d`, but instead found `%d`", i+1, instanceType, wantSize, part.Size)
+			}
+		}
+	}
+
+	// Invalid attribute names are rejected.
+	if rec, _ = getAttributes("small", map[string]string{xhttp.AmzObjectAttributes: "ETag,Owner"}); rec.Code != http.StatusBadRequest {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusBadRequest, rec.Code)
+	}
+
+	// Missing objects.
+	if rec, _ = getAttributes("missing", map[string]string{xhttp.AmzObjectAttributes: "ETag"}); rec.Code != http.StatusNotFound {
+		t.Errorf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotFound, rec.Code)
+	}
+}
+
+// Tests the If-Match and If-None-Match preconditions of PutObject,
+// CopyObject and CompleteMultipartUpload.
+func TestAPIConditionalWriteHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIConditionalWriteHandler,
+		[]string{"NewMultipart", "PutObjectPart", "CompleteMultipart", "CopyObject", "PutObject"})
+}
+
+func testAPIConditionalWriteHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	putObject := func(object string, data []byte, header map[string]string) int {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, object),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, header)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for PutObject: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	checkContent := func(object string, want []byte) {
+		t.Helper()
+		gr, err := obj.GetObjectNInfo(context.Background(), bucketName, object, nil, http.Header{}, readLock, ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: Failed to read %s: <ERROR> %v", instanceType, object, err)
+		}
+		data, err := ioutil.ReadAll(gr)
+		gr.Close()
+		if err != nil {
+			t.Fatalf("%s: Failed to read %s: <ERROR> %v", instanceType, object, err)
+		}
+		if !bytes.Equal(data, want) {
+			t.Fatalf("%s: Unexpected content of %s: %q", instanceType, object, data)
+		}
+	}
+
+	first, second := []byte("first"), []byte("second")
+	testCases := []struct {
+		data   []byte
+		header map[string]string
+		status int
+	}{
+		// Create if absent.
+		{first, map[string]string{xhttp.IfNoneMatch: "*"}, http.StatusOK},
+		{second, map[string]string{xhttp.IfNoneMatch: "*"}, http.StatusPreconditionFailed},
+		{second, map[string]string{xhttp.IfNoneMatch: getMD5Hash(first)}, http.StatusPreconditionFailed},
+		// Compare and swap.
+		{second, map[string]string{xhttp.IfMatch: getMD5Hash(second)}, http.StatusPreconditionFailed},
+		{second, map[string]string{xhttp.IfMatch: "\"" + getMD5Hash(first) + "\""}, http.StatusOK},
+		{first, map[string]string{xhttp.IfMatch: "*", xhttp.IfNoneMatch: getMD5Hash(first)}, http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		if status := putObject("object", testCase.data, testCase.header); status != testCase.status {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.status, status)
+		}
+	}
+	checkContent("object", first)
+
+	// If-Match never matches a missing object.
+	if status := putObject("missing", first, map[string]string{xhttp.IfMatch: "*"}); status != http.StatusPreconditionFailed {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusPreconditionFailed, status)
+	}
+
+	// The preconditions of CopyObject apply to the destination.
+	copyObject := func(object string, header map[string]string) int {
+		header[xhttp.AmzCopySource] = url.QueryEscape(SlashSeparator + bucketName + SlashSeparator + "object")
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getCopyObjectURL("", bucketName, object),
+			0, nil, credentials.AccessKey, credentials.SecretKey, header)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for CopyObject: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Code
+	}
+	if status := copyObject("copy", map[string]string{xhttp.IfNoneMatch: "*"}); status != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, status)
+	}
+	if status := copyObject("copy", map[string]string{xhttp.IfNoneMatch: "*"}); status != http.StatusPreconditionFailed {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusPreconditionFailed, status)
+	}
+	checkContent("copy", first)
+
+	// The preconditions of CompleteMultipartUpload keep the upload on failure.
+	uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, "object", ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: <ERROR> %v", instanceType, err)
+	}
+	pi, err := obj.PutObjectPart(context.Background(), bucketName, "object", uploadID, 1,
+		mustGetPutObjReader(t, bytes.NewReader(second), int64(len(second)), "", ""), ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: <ERROR> %v", instanceType, err)
+	}
+	completeBytes, err := xml.Marshal(&CompleteMultipartUpload{Parts: []CompletePart{{PartNumber: 1, ETag: pi.ETag}}})
+	if err != nil {
+		t.Fatalf("%s: <ERROR> %v", instanceType, err)
+	}
+	completeUpload := func(header map[string]string) string {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPost, getCompleteMultipartUploadURL("", bucketName, "object", uploadID),
+			int64(len(completeBytes)), bytes.NewReader(completeBytes), credentials.AccessKey, credentials.SecretKey, header)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for CompleteMultipartUpload: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec.Body.String()
+	}
+	if body := completeUpload(map[string]string{xhttp.IfNoneMatch: "*"}); !strings.Contains(body, "<Code>PreconditionFailed</Code>") {
+		t.Fatalf("%s: Expected PreconditionFailed, but instead found `%s`", instanceType, body)
+	}
+	if body := completeUpload(map[string]string{xhttp.IfMatch: getMD5Hash(first)}); !strings.Contains(body, "<CompleteMultipartUploadResult") {
+		t.Fatalf("%s: Expected CompleteMultipartUploadResult, but instead found `%s`", instanceType, body)
+	}
+	checkContent("object", second)
+}
+
+// Tests appending to objects with the x-minio-append-offset header.
+func TestAPIAppendObjectHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIAppendObjectHandler, []string{"PutObject"})
+}
+
+func testAPIAppendObjectHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	putObject := func(data []byte, header map[string]string) *httptest.ResponseRecorder {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, "object"),
+			int64(len(data)), bytes.NewReader(data), credentials.AccessKey, credentials.SecretKey, header)
+		if err != nil {
+			t.Fatalf("%s: Failed to create HTTP request for PutObject: <ERROR> %v", instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		return rec
+	}
+
+	if rec := putObject([]byte("hello"), nil); rec.Code != http.StatusOK {
+		t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusOK, rec.Code)
+	}
+
+	if instanceType == FSTestStr {
+		if rec := putObject([]byte(" world"), map[string]string{xhttp.MinIOAppendOffset: "5"}); rec.Code != http.StatusNotImplemented {
+			t.Fatalf("%s: Expected the response status to be `%d`, but instead found `%d`", instanceType, http.StatusNotImplemented, rec.Code)
+		}
+		return
+	}
+
+	testCases := []struct {
+		data   string
+		offset string
+		status int
+	}{
+		{" world", "5", http.StatusOK},
+		{"!", "5", http.StatusBadRequest},
+		{"!", "-1", http.StatusBadRequest},
+		{"!", "eleven", http.StatusBadRequest},
+		{"!", "11", http.StatusOK},
+	}
+	for i, testCase := range testCases {
+		rec := putObject([]byte(testCase.data), map[string]string{xhttp.MinIOAppendOffset: testCase.offset})
+		if rec.Code != testCase.status {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.status, rec.Code)
+		}
+		if rec.Code == http.StatusBadRequest && !strings.Contains(rec.Body.String(), "<Code>InvalidWriteOffset</Code>") {
+			t.Fatalf("Test %d: %s: Expected InvalidWriteOffset, but instead found `%s`", i+1, instanceType, rec.Body.String())
+		}
+	}
+
+	gr, err := obj.GetObjectNInfo(context.Background(), bucketName, "object", nil, http.Header{}, readLock, ObjectOptions{})
+	if err != nil {
+		t.Fatalf("%s: <ERROR> %v", instanceType, err)
+	}
+	data, err := ioutil.ReadAll(gr)
+	gr.Close()
+	if err != nil {
+		t.Fatalf("%s: <ERROR> %v", instanceType, err)
+	}
+	if string(data) != "hello world!" {
+		t.Fatalf("%s: Unexpected content `%s`", instanceType, data)
+	}
+}
+
+func TestAPIRenameObjectHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecObjectLayerAPITest(t, testAPIRenameObjectHandler, []string{"RenameObject", "PutObject"})
+}
+
+func testAPIRenameObjectHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	for _, object := range []string{"object", "src/a", "src/b/c", "exists"} {
+		data := []byte(object)
+		_, err := obj.PutObject(context.Background(), bucketName, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{})
+		if err != nil {
+			t.Fatalf("%s: <ERROR> %v", instanceType, err)
+		}
+	}
+
+	testCases := []struct {
+		source      string
+		destination string
+		status      int
+		renamed     []string
+	}{
+		{"object", "renamed", http.StatusOK, []string{"renamed"}},
+		{"src/", "dst/", http.StatusOK, []string{"dst/a", "dst/b/c"}},
+		// The source must exist.
+		{"object", "other", http.StatusNotFound, nil},
+		// The destination must not exist.
+		{"renamed", "exists", http.StatusMethodNotAllowed, nil},
+		// Invalid sources.
+		{"renamed", "renamed", http.StatusBadRequest, nil},
+		{"dst/", "renamed", http.StatusBadRequest, nil},
+		{"dst/", "dst/nested/", http.StatusBadRequest, nil},
+		{"dst/b/", "dst/", http.StatusBadRequest, nil},
+	}
+	for i, testCase := range testCases {
+		rec := httptest.NewRecorder()
+		req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, testCase.destination),
+			0, nil, credentials.AccessKey, credentials.SecretKey, map[string]string{xhttp.MinIORenameSource: testCase.source})
+		if err != nil {
+			t.Fatalf("Test %d: %s: Failed to create HTTP request for RenameObject: <ERROR> %v", i+1, instanceType, err)
+		}
+		apiRouter.ServeHTTP(rec, req)
+		if rec.Code != testCase.status {
+			t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`", i+1, instanceType, testCase.status, rec.Code)
+		}
+		if HasSuffix(testCase.source, SlashSeparator) && rec.Code == http.StatusOK {
+			var resp RenamePrefixResponse
+			if err = xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+				t.Fatalf("Test %d: %s: <ERROR> %v", i+1, instanceType, err)
+			}
+			if resp.Renamed != len(testCase.renamed) {
+				t.Fatalf("Test %d: %s: Expected %d objects to be renamed, but instead found %d", i+1, instanceType, len(testCase.renamed), resp.Renamed)
+			}
+		}
+		for _, object := range testCase.renamed {
+			if _, err = obj.GetObjectInfo(context.Background(), bucketName, object, ObjectOptions{}); err != nil {
+				t.Fatalf("Test %d: %s: Expected %s to exist: <ERROR> %v", i+1, instanceType, object, err)
+			}
+		}
+	}
+
+	for _, object := range []string{"object", "src/a", "src/b/c"} {
+		if _, err := obj.GetObjectInfo(context.Background(), bucketName, object, ObjectOptions{}); !isErrObjectNotFound(err) {
+			t.Fatalf("%s: Expected %s to be renamed, but instead found %v", instanceType, object, err)
+		}
+	}
+}
+
+func TestAPIRenamePrefixAcrossSets(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	resetTestGlobals()
+	// Versioning can only be configured on erasure coded backends.
+	globalIsErasure = true
+	defer resetGlobalIsErasure()
+
+	obj, fsDirs, err := prepareErasureSets32(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+
+	bucketName, apiRouter, err := initAPIHandlerTest(obj, []string{"RenameObject"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = newTestConfig(globalMinioDefaultRegion, obj); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalBucketMetadataSys.Update(bucketName, bucketVersioningConfig, enabledBucketVersioningConfig); err != nil {
+		t.Fatal(err)
+	}
+
+	// One object whose new name stays on its erasure set, the other one
+	// moves to another set.
+	sets := obj.(*erasureServerPools).serverPools[0]
+	var same, other string
+	for i := 0; same == "" || other == ""; i++ {
+		name := strconv.Itoa(i)
+		if sets.getHashedSetIndex("src/"+name) == sets.getHashedSetIndex("dst/"+name) {
+			same = name
+		} else {
+			other = name
+		}
+	}
+	for _, object := range []string{"src/" + same, "src/" + other, "src/" + other} {
+		data := []byte(object + strconv.Itoa(len(object)))
+		if _, err = obj.PutObject(ctx, bucketName, object, mustGetPutObjReader(t, bytes.NewReader(data), int64(len(data)), "", ""), ObjectOptions{Versioned: true}); err != nil {
+			t.Fatal(err)
+		}
+	}
+	if _, err = obj.DeleteObject(ctx, bucketName, "src/"+other, ObjectOptions{Versioned: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	listVersions := func(object string) []ObjectInfo {
+		result, err := obj.ListObjectVersions(ctx, bucketName, object, "", "", "", 10)
+		if err != nil {
+			t.Fatal(err)
+		}
+		return result.Objects
+	}
+	before := map[string][]ObjectInfo{same: listVersions("src/" + same), other: listVersions("src/" + other)}
+	if len(before[other]) != 3 {
+		t.Fatalf("Expected 3 versions of src/%s, but instead found %d", other, len(before[other]))
+	}
+
+	rec := httptest.NewRecorder()
+	req, err := newTestSignedRequestV4(http.MethodPut, getPutObjectURL("", bucketName, "dst/"),
+		0, nil, globalActiveCred.AccessKey, globalActiveCred.SecretKey, map[string]string{xhttp.MinIORenameSource: "src/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	apiRouter.ServeHTTP(rec, req)
+	if rec.Code != http.StatusOK {
+		t.Fatalf("Expected the response status to be `%d`, but instead found `%d`", http.StatusOK, rec.Code)
+	}
+	var resp RenamePrefixResponse
+	if err = xml.Unmarshal(rec.Body.Bytes(), &resp); err != nil {
+		t.Fatal(err)
+	}
+	if resp.Renamed != 2 {
+		t.Fatalf("Expected 2 objects to be renamed, but instead found %d", resp.Renamed)
+	}
+
+	// All versions are moved with their version IDs, ETags and
+	// modification times.
+	for _, name := range []string{same, other} {
+		if versions := listVersions("src/" + name); len(versions) != 0 {
+			t.Fatalf("Expected src/%s to be renamed, but instead found %d versions", name, len(versions))
+		}
+		after := listVersions("dst/" + name)
+		if len(after) != len(before[name]) {
+			t.Fatalf("Expected %d versions of dst/%s, but instead found %d", len(before[name]), name, len(after))
+		}
+		for i, version := range after {
+			want := before[name][i]
+			if version.VersionID != want.VersionID || !version.ModTime.Equal(want.ModTime) || version.ETag != want.ETag || version.DeleteMarker != want.DeleteMarker {
+				t.Fatalf("dst/%s: Expected version %+v, but instead found %+v", name, want, version)
+			}
+			if version.DeleteMarker {
+				continue
+			}
+			gr, err := obj.GetObjectNInfo(ctx, bucketName, "dst/"+name, nil, http.Header{}, readLock, ObjectOptions{VersionID: version.VersionID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := ioutil.ReadAll(gr)
+			gr.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if want := "src/" + name + strconv.Itoa(len("src/"+name)); string(data) != want {
+				t.Fatalf("dst/%s: Expected content %q, but instead found %q", name, want, data)
+			}
+		}
+	}
+}
+
+// Wrapper for calling PutObject and PutObjectPart tests with trailing checksums
+// for both Erasure multiple disks and FS single drive setup.
+func TestAPIPutObjectTrailerHandler(t *testing.T) {
+	defer DetectTestLeak(t)()
+	ExecExtendedObjectLayerAPITest(t, testAPIPutObjectTrailerHandler, []string{"NewMultipart", "PutObjectPart", "PutObject"})
+}
+
+func testAPIPutObjectTrailerHandler(obj ObjectLayer, instanceType, bucketName string, apiRouter http.Handler,
+	credentials auth.Credentials, t *testing.T) {
+	data := bytes.Repeat([]byte("a"), 100*humanize.KiByte+1)
+	crc32 := hash.NewChecksumFromData(hash.ChecksumCRC32, data).Encoded
+	wrongCRC32 := hash.NewChecksumFromData(hash.ChecksumCRC32, data[1:]).Encoded
+
+	testCases := []struct {
+		object  string
+		data    []byte
+		trailer map[string]string
+		signed  bool
+		// Breaks the request after it has been signed.
+		tamper func(req *http.Request)
+		status int
+	}{
+		{"signed", data, map[string]string{xhttp.AmzChecksumCRC32: crc32}, true, nil, http.StatusOK},
+		{"unsigned", data, map[string]string{xhttp.AmzChecksumCRC32: crc32}, false, nil, http.StatusOK},
+		{"signed-empty", nil, map[string]string{xhttp.AmzChecksumCRC32: "AAAAAA=="}, true, nil, http.StatusOK},
+		{"unsigned-empty", nil, map[string]string{xhttp.AmzChecksumCRC32: "AAAAAA=="}, false, nil, http.StatusOK},
+		// Checksum mismatch.
+		{"signed-bad-checksum", data, map[string]string{xhttp.AmzChecksumCRC32: wrongCRC32}, true, nil, http.StatusBadRequest},
+		{"unsigned-bad-checksum", data, map[string]string{xhttp.AmzChecksumCRC32: wrongCRC32}, false, nil, http.StatusBadRequest},
+		// Unsigned payloads must be protected by exactly one checksum.
+		{"unsigned-no-checksum", data, nil, false, nil, http.StatusBadRequest},
+		{"unsigned-other-trailer", data, map[string]string{"x-amz-meta-trailer": "value"}, false, nil, http.StatusBadRequest},
+		{"unsigned-two-checksums", data, map[string]string{xhttp.AmzChecksumCRC32: crc32, xhttp.AmzChecksumSHA256: hash.NewChecksumFromData(hash.ChecksumSHA256, data).Encoded}, false, nil, http.StatusBadRequest},
+		// Trailer signature mismatch.
+		{"signed-bad-trailer", data, map[string]string{xhttp.AmzChecksumCRC32: crc32}, true, func(req *http.Request) {
+			body, _ := ioutil.ReadAll(req.Body)
+			req.Body = ioutil.NopCloser(bytes.NewReader(bytes.Replace(body, []byte(trailerSignatureKey+":"), []byte(trailerSignatureKey+":0"), 1)))
+		}, http.StatusForbidden},
+		// The headers of unsigned payloads are still signed.
+		{"unsigned-bad-signature", data, map[string]string{xhttp.AmzChecksumCRC32: crc32}, false, func(req *http.Request) {
+			req.Header.Set(xhttp.AmzTrailer, xhttp.AmzChecksumCRC32C)
+		}, http.StatusForbidden},
+	}
+	for i, testCase := range testCases {
+		for _, part := range []bool{false, true} {
+			reqURL := getPutObjectURL("", bucketName, testCase.object)
+			if part {
+				uploadID, err := obj.NewMultipartUpload(context.Background(), bucketName, testCase.object, ObjectOptions{})
+				if err != nil {
+					t.Fatalf("%s: <ERROR> %v", instanceType, err)
+				}
+				reqURL = getPutObjectPartURL("", bucketName, testCase.object, uploadID, "1")
+			}
+
+			req, err := newTestStreamingTrailerRequest(http.MethodPut, reqURL, int64(len(testCase.data)), 64*humanize.KiByte,
+				bytes.NewReader(testCase.data), credentials.AccessKey, credentials.SecretKey, testCase.trailer, testCase.signed)
+			if err != nil {
+				t.Fatalf("Test %d: %s: Failed to create HTTP request: <ERROR> %v", i+1, instanceType, err)
+			}
+			if testCase.tamper != nil {
+				testCase.tamper(req)
+			}
+			rec := httptest.NewRecorder()
+			apiRouter.ServeHTTP(rec, req)
+			if rec.Code != testCase.status {
+				t.Fatalf("Test %d: %s: Expected the response status to be `%d`, but instead found `%d`: %s",
+					i+1, instanceType, testCase.status, rec.Code, rec.Body.String())
+			}
+			if rec.Code != http.StatusOK || part {
+				continue
+			}
+
+			gr, err := obj.GetObjectNInfo(context.Background(), bucketName, testCase.object, nil, http.Header{}, readLock, ObjectOptions{})
+			if err != nil {
+				t.Fatalf("Test %d: %s: <ERROR> %v", i+1, instanceType, err)
+			}
+			content, err := ioutil.ReadAll(gr)
+			gr.Close()
+			if err != nil {
+				t.Fatalf("Test %d: %s: <ERROR> %v", i+1, instanceType, err)
+			}
+			if !bytes.Equal(content, testCase.data) {
+				t.Fatalf("Test %d: %s: Unexpected content of %s", i+1, instanceType, testCase.object)
+			}
+			if cs := gr.ObjInfo.Checksum(); cs == nil || cs.Encoded != testCase.trailer[xhttp.AmzChecksumCRC32] {
+				t.Fatalf("Test %d: %s: Expected checksum %s, got %v", i+1, instanceType, testCase.trailer[xhttp.AmzChecksumCRC32], cs)
+			}
+		}
+	}
+}
@@ -492,6 +492,18 @@ func (client *peerRESTClient) GetBucketStats(bucket string) (BucketStats, error)
 	return bs, msgp.Decode(respBody, &bs)
 }
 
+// GetTierStats - load remote tier statistics
+func (client *peerRESTClient) GetTierStats() (tierStatsMap, error) {
+	respBody, err := client.call(peerRESTMethodGetTierStats, nil, nil, -1)
+	if err != nil {
+		return nil, err
+	}
+
+	var ts tierStatsMap
+	defer http.DrainBody(respBody)
+	return ts, msgp.Decode(respBody, &ts)
+}
+
 // LoadBucketMetadata - load bucket metadata
 func (client *peerRESTClient) LoadBucketMetadata(bucket string) error {
 	values := make(url.Values)
@@ -752,6 +764,53 @@ func (client *peerRESTClient) LoadTransitionTierConfig(ctx context.Context) erro
 	return nil
 }
 
+// ReloadSiteReplicationConfig - reload the site replication state
+func (client *peerRESTClient) ReloadSiteReplicationConfig(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadSiteReplication, nil, nil, 0)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// ReloadPoolMeta - reload pool metadata
+func (client *peerRESTClient) ReloadPoolMeta(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodReloadPoolMeta, nil, nil, 0)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// LoadRebalanceMeta - reload rebalance metadata, starting rebalance
+// of the pools local to the peer if startRebalance is true.
+func (client *peerRESTClient) LoadRebalanceMeta(ctx context.Context, startRebalance bool) error {
+	values := make(url.Values)
+	values.Set(peerRESTStartRebalance, strconv.FormatBool(startRebalance))
+	respBody, err := client.callWithContext(ctx, peerRESTMethodLoadRebalanceMeta, values, nil, -1)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
+// StopRebalance - stop rebalance routines running on the peer
+func (client *peerRESTClient) StopRebalance(ctx context.Context) error {
+	respBody, err := client.callWithContext(ctx, peerRESTMethodStopRebalance, nil, nil, 0)
+	if err != nil {
+		logger.LogIf(ctx, err)
+		return err
+	}
+	defer http.DrainBody(respBody)
+	return nil
+}
+
 func (client *peerRESTClient) doTrace(traceCh chan interface{}, doneCh <-chan struct{}, traceOpts madmin.ServiceTraceOpts) {
 	values := make(url.Values)
 	values.Set(peerRESTTraceErr, strconv.FormatBool(traceOpts.OnlyErrors))
@@ -18,7 +18,7 @@
 package cmd
 
 const (
-	peerRESTVersion       = "v15" // Add LoadTransitionTierConfig
+	peerRESTVersion       = "v18" // Add GetTierStats
 	peerRESTVersionPrefix = SlashSeparator + peerRESTVersion
 	peerRESTPrefix        = minioReservedBucketPath + "/peer"
 	peerRESTPath          = peerRESTPrefix + peerRESTVersionPrefix
@@ -66,6 +66,11 @@ const (
 	peerRESTMethodGetPeerMetrics           = "/peermetrics"
 	peerRESTMethodLoadTransitionTierConfig = "/loadtransitiontierconfig"
 	peerRESTMethodSpeedtest                = "/speedtest"
+	peerRESTMethodReloadPoolMeta           = "/reloadpoolmeta"
+	peerRESTMethodLoadRebalanceMeta        = "/loadrebalancemeta"
+	peerRESTMethodStopRebalance            = "/stoprebalance"
+	peerRESTMethodReloadSiteReplication    = "/reloadsitereplication"
+	peerRESTMethodGetTierStats             = "/gettierstats"
 )
 
 const (
@@ -88,6 +93,7 @@ const (
 	peerRESTSize           = "size"
 	peerRESTConcurrent     = "concurrent"
 	peerRESTDuration       = "duration"
+	peerRESTStartRebalance = "start-rebalance"
 
 	peerRESTListenBucket = "bucket"
 	peerRESTListenPrefix = "prefix"
@@ -257,6 +257,9 @@ func newAllSubsystems() {
 
 	// Create new ILM tier configuration subsystem
 	globalTierConfigMgr = NewTierConfigMgr()
+
+	// Create new site replication subsystem
+	globalSiteReplicationSys = NewSiteReplicationSys()
 }
 
 func configRetriableErrors(err error) bool {
@@ -423,6 +426,11 @@ func initAllSubsystems(ctx context.Context, newObject ObjectLayer) (err error) {
 		if err != nil {
 			return err
 		}
+
+		// Initialize site replication state
+		if err = globalSiteReplicationSys.Init(ctx, newObject); err != nil {
+			return err
+		}
 	}
 	return nil
 }
@@ -558,14 +566,24 @@ func serverMain(ctx *cli.Context) {
 		logger.LogIf(GlobalContext, err)
 	}
 
+	if globalIsErasure {
+		if z, ok := newObject.(*erasureServerPools); ok {
+			// Load pool metadata and resume any pending decommission.
+			logger.LogIf(GlobalContext, z.Init(GlobalContext))
+		}
+	}
+
 	// Initialize users credentials and policies in background right after config has initialized.
 	go globalIAMSys.Init(GlobalContext, newObject)
 
 	initDataScanner(GlobalContext, newObject)
 
+	initBucketAccessLogging(GlobalContext, newObject)
+
 	if globalIsErasure { // to be done after config init
 		initBackgroundReplication(GlobalContext, newObject)
 		initBackgroundTransition(GlobalContext, newObject)
+		initBackgroundScrub(GlobalContext, newObject)
 		globalTierJournal, err = initTierDeletionJournal(GlobalContext)
 		if err != nil {
 			logger.FatalIf(err, "Unable to initialize remote tier pending deletes journal")
@@ -0,0 +1,1625 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"path"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio-go/v7/pkg/signer"
+	"github.com/minio/minio/internal/auth"
+	"github.com/minio/minio/internal/logger"
+	"github.com/minio/minio/internal/sync/errgroup"
+	iampolicy "github.com/minio/pkg/iam/policy"
+	xnet "github.com/minio/pkg/net"
+)
+
+const (
+	// Access key of the service account site replication uses to
+	// call peer sites, it exists with the same secret on all sites.
+	siteReplicatorSvcAcc = "site-replicator-0"
+
+	// Site replication state file name.
+	siteReplicationStateFile = "site-replication.json"
+
+	srStateFormatVersion1 = 1
+
+	// File name of the update times of the replicated entities.
+	siteReplicationUpdatesFile = "site-replication-updates.json"
+
+	srUpdatesFormatVersion1 = 1
+
+	// Operations queued for a peer site, further operations are
+	// dropped and the peer is resynced instead.
+	srQueueSize = 10000
+
+	// Attempts to apply an operation on a peer site.
+	srOpAttempts = 5
+
+	// Delay before a peer site which missed operations is resynced.
+	srResyncInterval = 5 * time.Minute
+)
+
+var (
+	siteReplicationStatePath   = path.Join(minioConfigPrefix, siteReplicationStateFile)
+	siteReplicationUpdatesPath = path.Join(minioConfigPrefix, siteReplicationUpdatesFile)
+)
+
+var (
+	errSRAlreadyEnabled = AdminError{
+		Code:       "XMinioSiteReplicationAlreadyEnabled",
+		Message:    "Site replication is already enabled",
+		StatusCode: http.StatusBadRequest,
+	}
+	errSRInvalidSites = AdminError{
+		Code:       "XMinioSiteReplicationInvalidSites",
+		Message:    "At least two sites with unique names, one of them this site, must be specified",
+		StatusCode: http.StatusBadRequest,
+	}
+)
+
+// errSRPeer wraps an error returned by a peer site.
+func errSRPeer(peer PeerInfo, err error) AdminError {
+	return AdminError{
+		Code:       "XMinioSiteReplicationPeerError",
+		Message:    fmt.Sprintf("Site replication failed on peer %s (%s): %v", peer.Name, peer.Endpoint, err),
+		StatusCode: http.StatusBadRequest,
+	}
+}
+
+// PeerSite - a site to link, with the root credentials of the site.
+type PeerSite struct {
+	Name      string `json:"name"`
+	Endpoint  string `json:"endpoint"`
+	AccessKey string `json:"accessKey"`
+	SecretKey string `json:"secretKey"`
+}
+
+// PeerInfo - a site linked with site replication.
+type PeerInfo struct {
+	Name         string `json:"name"`
+	Endpoint     string `json:"endpoint"`
+	DeploymentID string `json:"deploymentID"`
+}
+
+// SiteReplicationInfo - the sites linked with this site.
+type SiteReplicationInfo struct {
+	Enabled                 bool       `json:"enabled"`
+	Name                    string     `json:"name,omitempty"`
+	Sites                   []PeerInfo `json:"sites,omitempty"`
+	ServiceAccountAccessKey string     `json:"serviceAccountAccessKey,omitempty"`
+}
+
+// srPeerJoinReq - sent to every peer site when sites are linked.
+type srPeerJoinReq struct {
+	SvcAcctAccessKey string              `json:"svcAcctAccessKey"`
+	SvcAcctSecretKey string              `json:"svcAcctSecretKey"`
+	Peers            map[string]PeerInfo `json:"peers"`
+}
+
+// srPeerLeaveReq - sent to the peer sites which joined when linking
+// the sites failed.
+type srPeerLeaveReq struct {
+	Peers map[string]PeerInfo `json:"peers"`
+}
+
+// srState - persisted site replication state.
+type srState struct {
+	Version int `json:"version"`
+	// Name of this site.
+	Name string `json:"name"`
+	// Peers by deployment ID, this site included.
+	Peers            map[string]PeerInfo `json:"peers"`
+	ServiceAccountAK string              `json:"serviceAccountAK"`
+}
+
+// SiteReplicationSys - manages the propagation of buckets, bucket
+// configuration and IAM between linked sites.
+type SiteReplicationSys struct {
+	sync.RWMutex
+
+	enabled bool
+	state   srState
+
+	// Operations pending for each peer site by deployment ID.
+	queues map[string]*srPeerQueue
+}
+
+// NewSiteReplicationSys - creates a new site replication subsystem.
+func NewSiteReplicationSys() *SiteReplicationSys {
+	return &SiteReplicationSys{
+		queues: make(map[string]*srPeerQueue),
+	}
+}
+
+// Init - loads the site replication state. Changes queued by this
+// node before a restart are lost, so the peers are resynced once IAM
+// is loaded.
+func (c *SiteReplicationSys) Init(ctx context.Context, objAPI ObjectLayer) error {
+	if err := c.Reload(ctx, objAPI); err != nil {
+		return err
+	}
+	if c.isEnabled() {
+		time.AfterFunc(srResyncInterval, c.resyncPeers)
+	}
+	return nil
+}
+
+func (c *SiteReplicationSys) load(ctx context.Context, objAPI ObjectLayer) error {
+	data, err := readConfig(ctx, objAPI, siteReplicationStatePath)
+	if err != nil {
+		return err
+	}
+
+	var state srState
+	if err = json.Unmarshal(data, &state); err != nil {
+		return err
+	}
+	if state.Version != srStateFormatVersion1 {
+		return fmt.Errorf("unexpected site replication state version %d", state.Version)
+	}
+
+	c.Lock()
+	defer c.Unlock()
+	c.state = state
+	c.enabled = len(state.Peers) > 0
+	return nil
+}
+
+func (c *SiteReplicationSys) save(ctx context.Context, objAPI ObjectLayer, state srState) error {
+	state.Version = srStateFormatVersion1
+	data, err := json.Marshal(state)
+	if err != nil {
+		return err
+	}
+	if err = saveConfig(ctx, objAPI, siteReplicationStatePath, data); err != nil {
+		return err
+	}
+
+	c.Lock()
+	c.state = state
+	c.enabled = len(state.Peers) > 0
+	c.Unlock()
+
+	// Notify the other nodes of this site.
+	globalNotificationSys.ReloadSiteReplicationConfig(ctx)
+	return nil
+}
+
+// Reload - reloads the site replication state, called when it is
+// updated through another node of this site.
+func (c *SiteReplicationSys) Reload(ctx context.Context, objAPI ObjectLayer) error {
+	err := c.load(ctx, objAPI)
+	if errors.Is(err, errConfigNotFound) {
+		c.Lock()
+		c.state = srState{}
+		c.enabled = false
+		c.Unlock()
+		return nil
+	}
+	return err
+}
+
+// isEnabled returns whether site replication is enabled.
+func (c *SiteReplicationSys) isEnabled() bool {
+	if c == nil {
+		return false
+	}
+	c.RLock()
+	defer c.RUnlock()
+	return c.enabled
+}
+
+// GetInfo - returns the sites linked with this site.
+func (c *SiteReplicationSys) GetInfo() SiteReplicationInfo {
+	c.RLock()
+	defer c.RUnlock()
+
+	if !c.enabled {
+		return SiteReplicationInfo{}
+	}
+	info := SiteReplicationInfo{
+		Enabled:                 true,
+		Name:                    c.state.Name,
+		ServiceAccountAccessKey: c.state.ServiceAccountAK,
+	}
+	for _, peer := range c.state.Peers {
+		info.Sites = append(info.Sites, peer)
+	}
+	sort.Slice(info.Sites, func(i, j int) bool {
+		return info.Sites[i].Name < info.Sites[j].Name
+	})
+	return info
+}
+
+// AddPeerClusters - links this site with the given sites, which must
+// include this site. The sites must not be linked already. Existing
+// buckets and IAM of this site are copied to the peers.
+func (c *SiteReplicationSys) AddPeerClusters(ctx context.Context, objAPI ObjectLayer, sites []PeerSite) (SiteReplicationInfo, error) {
+	if c.isEnabled() {
+		return SiteReplicationInfo{}, errSRAlreadyEnabled
+	}
+
+	peers := make(map[string]PeerInfo, len(sites))
+	names := make(map[string]struct{}, len(sites))
+	var localName string
+	for _, site := range sites {
+		if site.Name == "" {
+			return SiteReplicationInfo{}, errSRInvalidSites
+		}
+		if _, ok := names[site.Name]; ok {
+			return SiteReplicationInfo{}, errSRInvalidSites
+		}
+		names[site.Name] = struct{}{}
+
+		deploymentID, err := getPeerDeploymentID(ctx, site)
+		if err != nil {
+			return SiteReplicationInfo{}, errSRPeer(PeerInfo{Name: site.Name, Endpoint: site.Endpoint}, err)
+		}
+		if _, ok := peers[deploymentID]; ok {
+			return SiteReplicationInfo{}, errSRInvalidSites
+		}
+		if deploymentID == globalDeploymentID {
+			localName = site.Name
+		}
+		peers[deploymentID] = PeerInfo{Name: site.Name, Endpoint: site.Endpoint, DeploymentID: deploymentID}
+	}
+	if localName == "" || len(peers) < 2 {
+		return SiteReplicationInfo{}, errSRInvalidSites
+	}
+
+	// Create the service account peers use to call this site,
+	// the same credentials are created on every peer.
+	svcCred, err := auth.GetNewCredentials()
+	if err != nil {
+		return SiteReplicationInfo{}, err
+	}
+	svcCred.AccessKey = siteReplicatorSvcAcc
+	if err = c.createSvcAcct(ctx, svcCred.AccessKey, svcCred.SecretKey); err != nil {
+		return SiteReplicationInfo{}, err
+	}
+
+	if err = c.linkPeers(ctx, objAPI, localName, sites, peers, svcCred); err != nil {
+		return SiteReplicationInfo{}, err
+	}
+
+	// Copy the buckets and IAM of this site to the peers.
+	c.resyncPeers()
+	return c.GetInfo(), nil
+}
+
+// linkPeers sends the join request to all peer sites and saves the
+// state of this site. When a peer fails to join, the peers which
+// joined are asked to leave again and the service account is removed,
+// so that linking the sites can be retried.
+func (c *SiteReplicationSys) linkPeers(ctx context.Context, objAPI ObjectLayer, localName string, sites []PeerSite, peers map[string]PeerInfo, svcCred auth.Credentials) (err error) {
+	var joined []*srPeerClient
+	defer func() {
+		if err == nil {
+			return
+		}
+		leaveReq := srPeerLeaveReq{Peers: peers}
+		for _, client := range joined {
+			if lerr := client.callEncrypted(ctx, http.MethodPut, "/site-replication/peer/leave", nil, leaveReq); lerr != nil {
+				logger.LogIf(ctx, errSRPeer(client.peer, lerr))
+			}
+		}
+		logger.LogIf(ctx, c.deleteSvcAcct(ctx, svcCred.AccessKey))
+	}()
+
+	joinReq := srPeerJoinReq{
+		SvcAcctAccessKey: svcCred.AccessKey,
+		SvcAcctSecretKey: svcCred.SecretKey,
+		Peers:            peers,
+	}
+	for _, site := range sites {
+		peer := peers[deploymentIDOf(peers, site.Name)]
+		if peer.DeploymentID == globalDeploymentID {
+			continue
+		}
+		client := newSRPeerClient(peer.Endpoint, site.AccessKey, site.SecretKey)
+		client.peer = peer
+		// The peer may have joined even if the response was lost.
+		joined = append(joined, client)
+		if err = client.callEncrypted(ctx, http.MethodPut, "/site-replication/peer/join", nil, joinReq); err != nil {
+			return errSRPeer(peer, err)
+		}
+	}
+
+	return c.save(ctx, objAPI, srState{
+		Name:             localName,
+		Peers:            peers,
+		ServiceAccountAK: svcCred.AccessKey,
+	})
+}
+
+// PeerJoinReq - links this site with the peers, called by the
+// site site replication was enabled on.
+func (c *SiteReplicationSys) PeerJoinReq(ctx context.Context, objAPI ObjectLayer, req srPeerJoinReq) error {
+	if c.isEnabled() {
+		return errSRAlreadyEnabled
+	}
+	peer, ok := req.Peers[globalDeploymentID]
+	if !ok {
+		return errSRInvalidSites
+	}
+	if err := c.createSvcAcct(ctx, req.SvcAcctAccessKey, req.SvcAcctSecretKey); err != nil {
+		return err
+	}
+	return c.save(ctx, objAPI, srState{
+		Name:             peer.Name,
+		Peers:            req.Peers,
+		ServiceAccountAK: req.SvcAcctAccessKey,
+	})
+}
+
+// PeerLeaveReq - unlinks this site from the peers, called by the site
+// linking the sites when another peer failed to join. Leaving is a
+// no-op when this site is not linked.
+func (c *SiteReplicationSys) PeerLeaveReq(ctx context.Context, objAPI ObjectLayer, req srPeerLeaveReq) error {
+	c.RLock()
+	enabled, state := c.enabled, c.state
+	c.RUnlock()
+	if !enabled {
+		return nil
+	}
+	// Only the sites linked by the failed request are unlinked.
+	if len(state.Peers) != len(req.Peers) {
+		return errSRInvalidSites
+	}
+	for id := range req.Peers {
+		if _, ok := state.Peers[id]; !ok {
+			return errSRInvalidSites
+		}
+	}
+
+	if err := deleteConfig(ctx, objAPI, siteReplicationStatePath); err != nil && !errors.Is(err, errConfigNotFound) {
+		return err
+	}
+	c.Lock()
+	c.state = srState{}
+	c.enabled = false
+	c.Unlock()
+	globalNotificationSys.ReloadSiteReplicationConfig(ctx)
+
+	return c.deleteSvcAcct(ctx, state.ServiceAccountAK)
+}
+
+func deploymentIDOf(peers map[string]PeerInfo, name string) string {
+	for id, peer := range peers {
+		if peer.Name == name {
+			return id
+		}
+	}
+	return ""
+}
+
+// createSvcAcct creates the site replicator service account for the
+// root user of this site, replacing an existing one.
+func (c *SiteReplicationSys) createSvcAcct(ctx context.Context, accessKey, secretKey string) error {
+	if _, ok := globalIAMSys.GetUser(accessKey); ok {
+		if err := globalIAMSys.DeleteServiceAccount(ctx, accessKey); err != nil {
+			return err
+		}
+	}
+	_, err := globalIAMSys.NewServiceAccount(ctx, globalActiveCred.AccessKey, nil, newServiceAccountOpts{
+		accessKey: accessKey,
+		secretKey: secretKey,
+	})
+	if err != nil {
+		return err
+	}
+	for _, nerr := range globalNotificationSys.LoadServiceAccount(accessKey) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+	return nil
+}
+
+// deleteSvcAcct removes the site replicator service account.
+func (c *SiteReplicationSys) deleteSvcAcct(ctx context.Context, accessKey string) error {
+	if _, ok := globalIAMSys.GetUser(accessKey); !ok {
+		return nil
+	}
+	if err := globalIAMSys.DeleteServiceAccount(ctx, accessKey); err != nil {
+		return err
+	}
+	for _, nerr := range globalNotificationSys.DeleteServiceAccount(accessKey) {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+	return nil
+}
+
+// getPeerDeploymentID returns the deployment ID of a site.
+func getPeerDeploymentID(ctx context.Context, site PeerSite) (string, error) {
+	u, err := url.Parse(site.Endpoint)
+	if err != nil {
+		return "", err
+	}
+	client, err := madmin.New(u.Host, site.AccessKey, site.SecretKey, u.Scheme == "https")
+	if err != nil {
+		return "", err
+	}
+	client.SetCustomTransport(NewRemoteTargetHTTPTransport())
+	info, err := client.ServerInfo(ctx)
+	if err != nil {
+		return "", err
+	}
+	return info.DeploymentID, nil
+}
+
+// peerClients returns clients for all peer sites but this one.
+func (c *SiteReplicationSys) peerClients() (map[string]*srPeerClient, error) {
+	c.RLock()
+	defer c.RUnlock()
+
+	if !c.enabled {
+		return nil, nil
+	}
+	cred, ok := globalIAMSys.GetUser(c.state.ServiceAccountAK)
+	if !ok {
+		return nil, errNoSuchServiceAccount
+	}
+	clients := make(map[string]*srPeerClient, len(c.state.Peers))
+	for id, peer := range c.state.Peers {
+		if id == globalDeploymentID {
+			continue
+		}
+		client := newSRPeerClient(peer.Endpoint, cred.AccessKey, cred.SecretKey)
+		client.peer = peer
+		clients[id] = client
+	}
+	return clients, nil
+}
+
+// srOp - an operation applied on peer sites in the background.
+type srOp struct {
+	desc string
+	fn   func(ctx context.Context, client *srPeerClient) error
+}
+
+// srPeerQueue - the operations pending for a peer site.
+type srPeerQueue struct {
+	ops    chan srOp
+	resync chan struct{}
+}
+
+// scheduleResync requests a resync of the peer site, requests are
+// coalesced until the resync starts.
+func (q *srPeerQueue) scheduleResync() {
+	select {
+	case q.resync <- struct{}{}:
+	default:
+	}
+}
+
+// peerQueue returns the queue of a peer site, starting the routine
+// applying its operations if needed.
+func (c *SiteReplicationSys) peerQueue(id string) *srPeerQueue {
+	c.Lock()
+	defer c.Unlock()
+
+	q, ok := c.queues[id]
+	if !ok {
+		q = &srPeerQueue{
+			ops:    make(chan srOp, srQueueSize),
+			resync: make(chan struct{}, 1),
+		}
+		c.queues[id] = q
+		go c.processPeerQueue(GlobalContext, id, q)
+	}
+	return q
+}
+
+// peerIDs returns the deployment IDs of all peer sites but this one.
+func (c *SiteReplicationSys) peerIDs() []string {
+	c.RLock()
+	defer c.RUnlock()
+
+	if !c.enabled {
+		return nil
+	}
+	ids := make([]string, 0, len(c.state.Peers))
+	for id := range c.state.Peers {
+		if id != globalDeploymentID {
+			ids = append(ids, id)
+		}
+	}
+	return ids
+}
+
+// enqueue queues an operation for all peer sites, it is applied in
+// the background so that requests to this site do not wait on peers.
+func (c *SiteReplicationSys) enqueue(op srOp) {
+	for _, id := range c.peerIDs() {
+		q := c.peerQueue(id)
+		select {
+		case q.ops <- op:
+		default:
+			// The peer is not keeping up, it catches
+			// up with a resync instead.
+			logger.LogIf(GlobalContext, fmt.Errorf("site replication queue of peer %s is full, dropped %s", id, op.desc))
+			q.scheduleResync()
+		}
+	}
+}
+
+// resyncPeers schedules a resync of all peer sites.
+func (c *SiteReplicationSys) resyncPeers() {
+	for _, id := range c.peerIDs() {
+		c.peerQueue(id).scheduleResync()
+	}
+}
+
+// processPeerQueue applies the queued operations on a peer site in
+// order. Operations which keep failing are dropped and the peer is
+// resynced later on.
+func (c *SiteReplicationSys) processPeerQueue(ctx context.Context, id string, q *srPeerQueue) {
+	for {
+		var err error
+		select {
+		case <-ctx.Done():
+			return
+		case op := <-q.ops:
+			err = c.applyOnPeer(ctx, id, op)
+		case <-q.resync:
+			err = c.resyncPeer(ctx, id)
+		}
+		if err != nil {
+			logger.LogIf(ctx, err)
+			time.AfterFunc(srResyncInterval, q.scheduleResync)
+		}
+	}
+}
+
+// peerClient returns a client for a peer site, nil if the site is
+// not linked.
+func (c *SiteReplicationSys) peerClient(id string) (*srPeerClient, error) {
+	clients, err := c.peerClients()
+	if err != nil {
+		return nil, err
+	}
+	return clients[id], nil
+}
+
+// applyOnPeer applies an operation on a peer site, failed attempts
+// are retried with an exponential backoff.
+func (c *SiteReplicationSys) applyOnPeer(ctx context.Context, id string, op srOp) error {
+	backoff := time.Second
+	for attempt := 1; ; attempt++ {
+		client, err := c.peerClient(id)
+		if err != nil {
+			return err
+		}
+		if client == nil {
+			return nil
+		}
+		if err = op.fn(ctx, client); err == nil {
+			return nil
+		}
+		if attempt == srOpAttempts {
+			return errSRPeer(client.peer, fmt.Errorf("%s: %w", op.desc, err))
+		}
+		select {
+		case <-ctx.Done():
+			return ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+}
+
+// resyncPeer applies the buckets, bucket configuration and IAM of
+// this site on a peer site, repairing the changes the peer missed.
+// Deletions are only applied by the queued operations. Entities
+// changed on the peer since they were last changed here are kept
+// by the peer, see updateEntity.
+func (c *SiteReplicationSys) resyncPeer(ctx context.Context, id string) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+	client, err := c.peerClient(id)
+	if err != nil || client == nil {
+		return err
+	}
+	ops, err := c.localOps(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+
+	var failed int
+	for _, op := range ops {
+		if err = op.fn(ctx, client); err != nil {
+			if xnet.IsNetworkOrHostDown(err, false) {
+				return errSRPeer(client.peer, fmt.Errorf("resync: %w", err))
+			}
+			logger.LogIf(ctx, errSRPeer(client.peer, fmt.Errorf("%s: %w", op.desc, err)))
+			failed++
+		}
+	}
+	if failed > 0 {
+		return errSRPeer(client.peer, fmt.Errorf("resync: %d of %d operations failed", failed, len(ops)))
+	}
+	return nil
+}
+
+// srUpdates - the time each replicated entity was last changed, on
+// this site or on a peer. Changes of an entity are applied only if
+// they are newer than its last change, the last change wins.
+type srUpdates struct {
+	Version int `json:"version"`
+	// Update times by entity, see srBucketEntity, srBucketMeta.entity
+	// and srIAMItem.entity.
+	Entities map[string]time.Time `json:"entities"`
+}
+
+func loadSRUpdates(ctx context.Context, objAPI ObjectLayer) (srUpdates, error) {
+	updates := srUpdates{Entities: make(map[string]time.Time)}
+	data, err := readConfig(ctx, objAPI, siteReplicationUpdatesPath)
+	if err != nil {
+		if errors.Is(err, errConfigNotFound) {
+			return updates, nil
+		}
+		return updates, err
+	}
+	if err = json.Unmarshal(data, &updates); err != nil {
+		return updates, err
+	}
+	if updates.Version != srUpdatesFormatVersion1 {
+		return updates, fmt.Errorf("unexpected site replication updates version %d", updates.Version)
+	}
+	if updates.Entities == nil {
+		updates.Entities = make(map[string]time.Time)
+	}
+	return updates, nil
+}
+
+// updateSRUpdates calls fn with the update times of the entities while
+// holding a lock on them, they are saved if fn returns true.
+func updateSRUpdates(ctx context.Context, objAPI ObjectLayer, fn func(entities map[string]time.Time) (bool, error)) error {
+	// Reading and writing the config locks the object itself,
+	// serialize the read-modify-write cycle with a separate lock.
+	lock := objAPI.NewNSLock(minioMetaBucket, siteReplicationUpdatesPath+".lck")
+	lkctx, err := lock.GetLock(ctx, globalOperationTimeout)
+	if err != nil {
+		return err
+	}
+	ctx = lkctx.Context()
+	defer lock.Unlock(lkctx.Cancel)
+
+	updates, err := loadSRUpdates(ctx, objAPI)
+	if err != nil {
+		return err
+	}
+	changed, err := fn(updates.Entities)
+	if err != nil || !changed {
+		return err
+	}
+	updates.Version = srUpdatesFormatVersion1
+	data, err := json.Marshal(updates)
+	if err != nil {
+		return err
+	}
+	return saveConfig(ctx, objAPI, siteReplicationUpdatesPath, data)
+}
+
+// localUpdate records a change of an entity made on this site and
+// returns its update time, which is sent to the peers along with the
+// change. Local changes are newer than any change known for the entity.
+func (c *SiteReplicationSys) localUpdate(ctx context.Context, entity string) time.Time {
+	updatedAt := UTCNow()
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return updatedAt
+	}
+	logger.LogIf(ctx, updateSRUpdates(ctx, objAPI, func(entities map[string]time.Time) (bool, error) {
+		if last := entities[entity]; !updatedAt.After(last) {
+			updatedAt = last.Add(time.Nanosecond)
+		}
+		entities[entity] = updatedAt
+		return true, nil
+	}))
+	return updatedAt
+}
+
+// updateEntity applies a change of an entity made on a peer at
+// updatedAt. Changes older than the last change of the entity are
+// ignored, such as changes sent by a resync of a peer which missed
+// the later change. A zero update time is sent by resyncs for entities
+// not changed since site replication tracks changes, such changes are
+// only applied to entities with no known change.
+func (c *SiteReplicationSys) updateEntity(ctx context.Context, objAPI ObjectLayer, entity string, updatedAt time.Time, apply func() error) error {
+	return updateSRUpdates(ctx, objAPI, func(entities map[string]time.Time) (bool, error) {
+		if last, ok := entities[entity]; ok && !updatedAt.After(last) {
+			return false, nil
+		}
+		if err := apply(); err != nil {
+			return false, err
+		}
+		if updatedAt.IsZero() {
+			return false, nil
+		}
+		entities[entity] = updatedAt
+		return true, nil
+	})
+}
+
+// srBucketEntity returns the entity name of a bucket.
+func srBucketEntity(bucket string) string {
+	return "bucket/" + bucket
+}
+
+// MakeBucketHook - creates the bucket on all peers.
+func (c *SiteReplicationSys) MakeBucketHook(ctx context.Context, bucket string, opts BucketOptions) {
+	if !c.isEnabled() {
+		return
+	}
+	c.enqueue(srMakeBucketOp(bucket, opts, c.localUpdate(ctx, srBucketEntity(bucket))))
+}
+
+// DeleteBucketHook - deletes the bucket on all peers.
+func (c *SiteReplicationSys) DeleteBucketHook(ctx context.Context, bucket string, forceDelete bool) {
+	if !c.isEnabled() {
+		return
+	}
+	query := url.Values{}
+	query.Set("bucket", bucket)
+	query.Set("operation", srBucketOpDelete)
+	query.Set("forceDelete", strconv.FormatBool(forceDelete))
+	query.Set("updatedAt", c.localUpdate(ctx, srBucketEntity(bucket)).Format(time.RFC3339Nano))
+	c.enqueue(srOp{
+		desc: "delete bucket " + bucket,
+		fn: func(ctx context.Context, client *srPeerClient) error {
+			return client.call(ctx, http.MethodPut, "/site-replication/peer/bucket-ops", query, nil, nil)
+		},
+	})
+}
+
+func srMakeBucketOp(bucket string, opts BucketOptions, updatedAt time.Time) srOp {
+	query := url.Values{}
+	query.Set("bucket", bucket)
+	query.Set("operation", srBucketOpMake)
+	query.Set("lockEnabled", strconv.FormatBool(opts.LockEnabled))
+	query.Set("versioningEnabled", strconv.FormatBool(opts.VersioningEnabled))
+	query.Set("location", opts.Location)
+	if !updatedAt.IsZero() {
+		query.Set("updatedAt", updatedAt.Format(time.RFC3339Nano))
+	}
+	return srOp{
+		desc: "make bucket " + bucket,
+		fn: func(ctx context.Context, client *srPeerClient) error {
+			return client.call(ctx, http.MethodPut, "/site-replication/peer/bucket-ops", query, nil, nil)
+		},
+	}
+}
+
+// Bucket operations propagated to peers.
+const (
+	srBucketOpMake   = "make"
+	srBucketOpDelete = "delete"
+)
+
+// PeerBucketMake - creates a bucket created on a peer site.
+func (c *SiteReplicationSys) PeerBucketMake(ctx context.Context, objAPI ObjectLayer, bucket string, opts BucketOptions, updatedAt time.Time) error {
+	return c.updateEntity(ctx, objAPI, srBucketEntity(bucket), updatedAt, func() error {
+		err := objAPI.MakeBucketWithLocation(ctx, bucket, opts)
+		if err != nil {
+			if _, ok := err.(BucketExists); ok {
+				return nil
+			}
+			if _, ok := err.(BucketAlreadyOwnedByYou); ok {
+				return nil
+			}
+			return err
+		}
+		globalNotificationSys.LoadBucketMetadata(GlobalContext, bucket)
+		return nil
+	})
+}
+
+// PeerBucketDelete - deletes a bucket deleted on a peer site.
+func (c *SiteReplicationSys) PeerBucketDelete(ctx context.Context, objAPI ObjectLayer, bucket string, forceDelete bool, updatedAt time.Time) error {
+	return c.updateEntity(ctx, objAPI, srBucketEntity(bucket), updatedAt, func() error {
+		err := objAPI.DeleteBucket(ctx, bucket, forceDelete)
+		if err != nil {
+			if _, ok := err.(BucketNotFound); ok {
+				return nil
+			}
+			return err
+		}
+		globalNotificationSys.DeleteBucketMetadata(ctx, bucket)
+		return nil
+	})
+}
+
+// Bucket configuration files propagated to peers.
+var srBucketConfigFiles = map[string]string{
+	bucketPolicyConfig:     "bucket-policy",
+	bucketTaggingConfig:    "bucket-tagging",
+	bucketLifecycleConfig:  "bucket-lifecycle",
+	objectLockConfig:       "bucket-object-lock",
+	bucketSSEConfig:        "bucket-sse",
+	bucketVersioningConfig: "bucket-versioning",
+}
+
+// srBucketMeta - a bucket configuration change propagated to peers.
+type srBucketMeta struct {
+	Bucket     string `json:"bucket"`
+	ConfigFile string `json:"configFile"`
+	// Configuration data, empty if the configuration was removed.
+	Data []byte `json:"data,omitempty"`
+	// Time of the change, see updateEntity.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// entity returns the entity name of the bucket configuration.
+func (item srBucketMeta) entity() string {
+	return path.Join("bucket-meta", item.Bucket, item.ConfigFile)
+}
+
+// BucketMetaHook - propagates a bucket configuration change to all
+// peers, data is nil if the configuration was removed.
+func (c *SiteReplicationSys) BucketMetaHook(ctx context.Context, bucket, configFile string, data []byte) {
+	if !c.isEnabled() {
+		return
+	}
+	item := srBucketMeta{Bucket: bucket, ConfigFile: configFile, Data: data}
+	item.UpdatedAt = c.localUpdate(ctx, item.entity())
+	c.enqueue(srBucketMetaOp(item))
+}
+
+func srBucketMetaOp(item srBucketMeta) srOp {
+	return srOp{
+		desc: fmt.Sprintf("update %s of bucket %s", srBucketConfigFiles[item.ConfigFile], item.Bucket),
+		fn: func(ctx context.Context, client *srPeerClient) error {
+			return client.callEncrypted(ctx, http.MethodPut, "/site-replication/peer/bucket-meta", nil, item)
+		},
+	}
+}
+
+// PeerBucketMetaUpdate - applies a bucket configuration change of a peer.
+func (c *SiteReplicationSys) PeerBucketMetaUpdate(ctx context.Context, item srBucketMeta) error {
+	if _, ok := srBucketConfigFiles[item.ConfigFile]; !ok {
+		return errInvalidArgument
+	}
+	if len(item.Data) == 0 {
+		item.Data = nil
+	}
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+	return c.updateEntity(ctx, objAPI, item.entity(), item.UpdatedAt, func() error {
+		return globalBucketMetadataSys.Update(item.Bucket, item.ConfigFile, item.Data)
+	})
+}
+
+// IAM changes propagated to peers.
+const (
+	// Canned policy, removed if the policy is empty.
+	srIAMItemPolicy = "policy"
+	// User, removed if the user info is empty.
+	srIAMItemUser          = "user"
+	srIAMItemUserStatus    = "user-status"
+	srIAMItemGroupMembers  = "group-members"
+	srIAMItemGroupStatus   = "group-status"
+	srIAMItemPolicyMapping = "policy-mapping"
+	srIAMItemSvcAcct       = "service-account"
+	srIAMItemSvcAcctUpdate = "service-account-update"
+	srIAMItemSvcAcctDelete = "service-account-delete"
+)
+
+// srPolicyMapping - policies mapped to a user or group.
+type srPolicyMapping struct {
+	UserOrGroup string `json:"userOrGroup"`
+	IsGroup     bool   `json:"isGroup"`
+	Policy      string `json:"policy"`
+}
+
+// srSvcAcct - a service account created or updated on a peer.
+type srSvcAcct struct {
+	Parent        string          `json:"parent,omitempty"`
+	Groups        []string        `json:"groups,omitempty"`
+	AccessKey     string          `json:"accessKey"`
+	SecretKey     string          `json:"secretKey,omitempty"`
+	SessionPolicy json.RawMessage `json:"sessionPolicy,omitempty"`
+	Status        string          `json:"status,omitempty"`
+	LDAPUsername  string          `json:"ldapUsername,omitempty"`
+}
+
+// srIAMItem - an IAM change propagated to peers.
+type srIAMItem struct {
+	Type string `json:"type"`
+	// Name of the policy, user, group or service account.
+	Name          string                 `json:"name"`
+	Policy        json.RawMessage        `json:"policy,omitempty"`
+	UserInfo      *madmin.UserInfo       `json:"userInfo,omitempty"`
+	GroupInfo     *madmin.GroupAddRemove `json:"groupInfo,omitempty"`
+	GroupStatus   string                 `json:"groupStatus,omitempty"`
+	PolicyMapping *srPolicyMapping       `json:"policyMapping,omitempty"`
+	SvcAcct       *srSvcAcct             `json:"svcAcct,omitempty"`
+	// Time of the change, see updateEntity.
+	UpdatedAt time.Time `json:"updatedAt"`
+}
+
+// entity returns the name of the IAM entity changed by the item.
+func (item srIAMItem) entity() string {
+	switch item.Type {
+	case srIAMItemUser, srIAMItemUserStatus:
+		return "user/" + item.Name
+	case srIAMItemSvcAcct, srIAMItemSvcAcctUpdate, srIAMItemSvcAcctDelete:
+		return "service-account/" + item.Name
+	case srIAMItemPolicyMapping:
+		if item.PolicyMapping != nil && item.PolicyMapping.IsGroup {
+			return "group-policy-mapping/" + item.Name
+		}
+		return "user-policy-mapping/" + item.Name
+	}
+	return item.Type + "/" + item.Name
+}
+
+// IAMChangeHook - propagates an IAM change to all peers.
+func (c *SiteReplicationSys) IAMChangeHook(ctx context.Context, item srIAMItem) {
+	if !c.isEnabled() || !srIAMItemReplicated(item) {
+		return
+	}
+	item.UpdatedAt = c.localUpdate(ctx, item.entity())
+	c.enqueue(srIAMItemOp(item))
+}
+
+func srIAMItemOp(item srIAMItem) srOp {
+	return srOp{
+		desc: fmt.Sprintf("update %s %s", item.Type, item.Name),
+		fn: func(ctx context.Context, client *srPeerClient) error {
+			return client.callEncrypted(ctx, http.MethodPut, "/site-replication/peer/iam-item", nil, item)
+		},
+	}
+}
+
+// srIAMItemReplicated returns whether an IAM change is propagated to
+// peers. Service accounts of the root user belong to the site, as do
+// the accounts of the site replicator. Changes of service accounts
+// whose parent is not known are not propagated either.
+func srIAMItemReplicated(item srIAMItem) bool {
+	switch item.Type {
+	case srIAMItemSvcAcct, srIAMItemSvcAcctUpdate, srIAMItemSvcAcctDelete:
+		if item.SvcAcct == nil || item.SvcAcct.Parent == "" {
+			return false
+		}
+		if item.SvcAcct.Parent == globalActiveCred.AccessKey {
+			return false
+		}
+		if item.Name == siteReplicatorSvcAcc || item.SvcAcct.AccessKey == siteReplicatorSvcAcc {
+			return false
+		}
+	}
+	return true
+}
+
+// srLocalSvcAcct returns whether a service account belongs to this
+// site and may not be changed by peers.
+func srLocalSvcAcct(ctx context.Context, accessKey string) bool {
+	if accessKey == siteReplicatorSvcAcc {
+		return true
+	}
+	cred, _, err := globalIAMSys.GetServiceAccount(ctx, accessKey)
+	return err == nil && cred.ParentUser == globalActiveCred.AccessKey
+}
+
+// PeerIAMItemUpdate - applies an IAM change of a peer.
+func (c *SiteReplicationSys) PeerIAMItemUpdate(ctx context.Context, item srIAMItem) error {
+	objAPI := newObjectLayerFn()
+	if objAPI == nil {
+		return errServerNotInitialized
+	}
+	return c.updateEntity(ctx, objAPI, item.entity(), item.UpdatedAt, func() error {
+		return c.applyIAMItem(ctx, item)
+	})
+}
+
+func (c *SiteReplicationSys) applyIAMItem(ctx context.Context, item srIAMItem) error {
+	var notify []NotificationPeerErr
+	switch item.Type {
+	case srIAMItemPolicy:
+		if len(item.Policy) == 0 {
+			if err := globalIAMSys.DeletePolicy(item.Name); err != nil {
+				return err
+			}
+			notify = globalNotificationSys.DeletePolicy(item.Name)
+			break
+		}
+		p, err := iampolicy.ParseConfig(bytes.NewReader(item.Policy))
+		if err != nil {
+			return err
+		}
+		if err = globalIAMSys.SetPolicy(item.Name, *p); err != nil {
+			return err
+		}
+		notify = globalNotificationSys.LoadPolicy(item.Name)
+	case srIAMItemUser:
+		if item.UserInfo == nil {
+			if err := globalIAMSys.DeleteUser(item.Name); err != nil {
+				return err
+			}
+			notify = globalNotificationSys.DeleteUser(item.Name)
+			break
+		}
+		if err := globalIAMSys.CreateUser(item.Name, *item.UserInfo); err != nil {
+			return err
+		}
+		notify = globalNotificationSys.LoadUser(item.Name, false)
+	case srIAMItemUserStatus:
+		if item.UserInfo == nil {
+			return errInvalidArgument
+		}
+		if err := globalIAMSys.SetUserStatus(item.Name, item.UserInfo.Status); err != nil {
+			return err
+		}
+		notify = globalNotificationSys.LoadUser(item.Name, false)
+	case srIAMItemGroupMembers:
+		if item.GroupInfo == nil {
+			return errInvalidArgument
+		}
+		var err error
+		if item.GroupInfo.IsRemove {
+			err = globalIAMSys.RemoveUsersFromGroup(item.Name, item.GroupInfo.Members)
+		} else {
+			err = globalIAMSys.AddUsersToGroup(item.Name, item.GroupInfo.Members)
+		}
+		if err != nil {
+			return err
+		}
+		notify = globalNotificationSys.LoadGroup(item.Name)
+	case srIAMItemGroupStatus:
+		if err := globalIAMSys.SetGroupStatus(item.Name, item.GroupStatus == statusEnabled); err != nil {
+			return err
+		}
+		notify = globalNotificationSys.LoadGroup(item.Name)
+	case srIAMItemPolicyMapping:
+		if item.PolicyMapping == nil {
+			return errInvalidArgument
+		}
+		m := item.PolicyMapping
+		if err := globalIAMSys.PolicyDBSet(m.UserOrGroup, m.Policy, m.IsGroup); err != nil {
+			return err
+		}
+		notify = globalNotificationSys.LoadPolicyMapping(m.UserOrGroup, m.IsGroup)
+	case srIAMItemSvcAcct:
+		if item.SvcAcct == nil {
+			return errInvalidArgument
+		}
+		if srLocalSvcAcct(ctx, item.SvcAcct.AccessKey) {
+			return errIAMActionNotAllowed
+		}
+		sa := item.SvcAcct
+		opts := newServiceAccountOpts{
+			accessKey:    sa.AccessKey,
+			secretKey:    sa.SecretKey,
+			ldapUsername: sa.LDAPUsername,
+		}
+		if len(sa.SessionPolicy) > 0 {
+			p, err := iampolicy.ParseConfig(bytes.NewReader(sa.SessionPolicy))
+			if err != nil {
+				return err
+			}
+			opts.sessionPolicy = p
+		}
+		if _, err := globalIAMSys.NewServiceAccount(ctx, sa.Parent, sa.Groups, opts); err != nil {
+			return err
+		}
+		notify = globalNotificationSys.LoadServiceAccount(sa.AccessKey)
+	case srIAMItemSvcAcctUpdate:
+		if item.SvcAcct == nil {
+			return errInvalidArgument
+		}
+		if srLocalSvcAcct(ctx, item.SvcAcct.AccessKey) {
+			return errIAMActionNotAllowed
+		}
+		sa := item.SvcAcct
+		opts := updateServiceAccountOpts{
+			secretKey: sa.SecretKey,
+			status:    sa.Status,
+		}
+		if len(sa.SessionPolicy) > 0 {
+			p, err := iampolicy.ParseConfig(bytes.NewReader(sa.SessionPolicy))
+			if err != nil {
+				return err
+			}
+			opts.sessionPolicy = p
+		}
+		if err := globalIAMSys.UpdateServiceAccount(ctx, sa.AccessKey, opts); err != nil {
+			return err
+		}
+		notify = globalNotificationSys.LoadServiceAccount(sa.AccessKey)
+	case srIAMItemSvcAcctDelete:
+		if srLocalSvcAcct(ctx, item.Name) {
+			return errIAMActionNotAllowed
+		}
+		if err := globalIAMSys.DeleteServiceAccount(ctx, item.Name); err != nil {
+			return err
+		}
+		notify = globalNotificationSys.DeleteServiceAccount(item.Name)
+	default:
+		return errInvalidArgument
+	}
+
+	for _, nerr := range notify {
+		if nerr.Err != nil {
+			logger.GetReqInfo(ctx).SetTags("peerAddress", nerr.Host.String())
+			logger.LogIf(ctx, nerr.Err)
+		}
+	}
+	return nil
+}
+
+// localOps returns the operations copying the buckets, bucket
+// configuration and IAM of this site to a peer.
+func (c *SiteReplicationSys) localOps(ctx context.Context, objAPI ObjectLayer) ([]srOp, error) {
+	updates, err := loadSRUpdates(ctx, objAPI)
+	if err != nil {
+		return nil, err
+	}
+
+	var ops []srOp
+	addIAMItem := func(item srIAMItem) {
+		if srIAMItemReplicated(item) {
+			item.UpdatedAt = updates.Entities[item.entity()]
+			ops = append(ops, srIAMItemOp(item))
+		}
+	}
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return nil, err
+	}
+	for _, bi := range buckets {
+		meta, err := globalBucketMetadataSys.GetConfig(bi.Name)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		ops = append(ops, srMakeBucketOp(bi.Name, BucketOptions{
+			LockEnabled:       meta.LockEnabled,
+			VersioningEnabled: globalBucketVersioningSys.Enabled(bi.Name),
+		}, updates.Entities[srBucketEntity(bi.Name)]))
+		for configFile, data := range srBucketConfigData(meta) {
+			if len(data) > 0 {
+				item := srBucketMeta{Bucket: bi.Name, ConfigFile: configFile, Data: data}
+				item.UpdatedAt = updates.Entities[item.entity()]
+				ops = append(ops, srBucketMetaOp(item))
+			}
+		}
+	}
+
+	policies, err := globalIAMSys.ListPolicies("")
+	if err != nil {
+		return nil, err
+	}
+	for name, p := range policies {
+		data, err := json.Marshal(p)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		addIAMItem(srIAMItem{Type: srIAMItemPolicy, Name: name, Policy: data})
+	}
+
+	users, err := globalIAMSys.ListUsers()
+	if err != nil {
+		return nil, err
+	}
+	for accessKey, info := range users {
+		cred, ok := globalIAMSys.GetUser(accessKey)
+		if !ok {
+			continue
+		}
+		addIAMItem(srIAMItem{
+			Type:     srIAMItemUser,
+			Name:     accessKey,
+			UserInfo: &madmin.UserInfo{SecretKey: cred.SecretKey, Status: info.Status},
+		})
+		if info.PolicyName != "" {
+			addIAMItem(srIAMItem{
+				Type:          srIAMItemPolicyMapping,
+				Name:          accessKey,
+				PolicyMapping: &srPolicyMapping{UserOrGroup: accessKey, Policy: info.PolicyName},
+			})
+		}
+	}
+
+	groups, err := globalIAMSys.ListGroups()
+	if err != nil {
+		return nil, err
+	}
+	for _, group := range groups {
+		desc, err := globalIAMSys.GetGroupDescription(group)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		addIAMItem(srIAMItem{
+			Type:      srIAMItemGroupMembers,
+			Name:      group,
+			GroupInfo: &madmin.GroupAddRemove{Group: group, Members: desc.Members},
+		})
+		addIAMItem(srIAMItem{Type: srIAMItemGroupStatus, Name: group, GroupStatus: desc.Status})
+		if desc.Policy != "" {
+			addIAMItem(srIAMItem{
+				Type:          srIAMItemPolicyMapping,
+				Name:          group,
+				PolicyMapping: &srPolicyMapping{UserOrGroup: group, IsGroup: true, Policy: desc.Policy},
+			})
+		}
+	}
+
+	for accessKey := range users {
+		svcAccts, err := globalIAMSys.ListServiceAccounts(ctx, accessKey)
+		if err != nil {
+			logger.LogIf(ctx, err)
+			continue
+		}
+		for _, sa := range svcAccts {
+			cred, policy, err := globalIAMSys.GetServiceAccount(ctx, sa.AccessKey)
+			if err != nil {
+				logger.LogIf(ctx, err)
+				continue
+			}
+			item := srSvcAcct{
+				Parent:    cred.ParentUser,
+				Groups:    cred.Groups,
+				AccessKey: cred.AccessKey,
+				SecretKey: cred.SecretKey,
+			}
+			if policy != nil {
+				if item.SessionPolicy, err = json.Marshal(policy); err != nil {
+					logger.LogIf(ctx, err)
+					continue
+				}
+			}
+			addIAMItem(srIAMItem{Type: srIAMItemSvcAcct, Name: cred.AccessKey, SvcAcct: &item})
+		}
+	}
+	return ops, nil
+}
+
+// srBucketConfigData returns the propagated configuration of a
+// bucket by configuration file name.
+func srBucketConfigData(meta BucketMetadata) map[string][]byte {
+	return map[string][]byte{
+		bucketPolicyConfig:     meta.PolicyConfigJSON,
+		bucketTaggingConfig:    meta.TaggingConfigXML,
+		bucketLifecycleConfig:  meta.LifecycleConfigXML,
+		objectLockConfig:       meta.ObjectLockConfigXML,
+		bucketSSEConfig:        meta.EncryptionConfigXML,
+		bucketVersioningConfig: meta.VersioningConfigXML,
+	}
+}
+
+// srSiteMeta - digests of the replicated entities of a site, by
+// kind and name, used to detect drift between sites.
+type srSiteMeta struct {
+	DeploymentID string                       `json:"deploymentID"`
+	Entities     map[string]map[string]string `json:"entities"`
+}
+
+func (m *srSiteMeta) add(kind, name string, v interface{}) error {
+	var data []byte
+	switch v := v.(type) {
+	case []byte:
+		data = v
+	default:
+		var err error
+		if data, err = json.Marshal(v); err != nil {
+			return err
+		}
+	}
+	if m.Entities[kind] == nil {
+		m.Entities[kind] = make(map[string]string)
+	}
+	sum := sha256.Sum256(data)
+	m.Entities[kind][name] = hex.EncodeToString(sum[:])
+	return nil
+}
+
+// GetSiteMeta - returns the digests of the replicated entities of
+// this site.
+func (c *SiteReplicationSys) GetSiteMeta(ctx context.Context, objAPI ObjectLayer) (srSiteMeta, error) {
+	meta := srSiteMeta{
+		DeploymentID: globalDeploymentID,
+		Entities:     make(map[string]map[string]string),
+	}
+
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return meta, err
+	}
+	for _, bi := range buckets {
+		bmeta, err := globalBucketMetadataSys.GetConfig(bi.Name)
+		if err != nil {
+			return meta, err
+		}
+		meta.add("bucket", bi.Name, []byte(bi.Name))
+		for configFile, data := range srBucketConfigData(bmeta) {
+			if len(data) > 0 {
+				meta.add(srBucketConfigFiles[configFile], bi.Name, data)
+			}
+		}
+	}
+
+	policies, err := globalIAMSys.ListPolicies("")
+	if err != nil {
+		return meta, err
+	}
+	for name, p := range policies {
+		if err = meta.add("policy", name, p); err != nil {
+			return meta, err
+		}
+	}
+
+	users, err := globalIAMSys.ListUsers()
+	if err != nil {
+		return meta, err
+	}
+	for accessKey, info := range users {
+		sort.Strings(info.MemberOf)
+		if err = meta.add("user", accessKey, info); err != nil {
+			return meta, err
+		}
+		svcAccts, err := globalIAMSys.ListServiceAccounts(ctx, accessKey)
+		if err != nil {
+			return meta, err
+		}
+		for _, sa := range svcAccts {
+			_, policy, err := globalIAMSys.GetServiceAccount(ctx, sa.AccessKey)
+			if err != nil {
+				return meta, err
+			}
+			if err = meta.add("service-account", sa.AccessKey, struct {
+				Parent string
+				Status string
+				Policy *iampolicy.Policy
+			}{sa.ParentUser, sa.Status, policy}); err != nil {
+				return meta, err
+			}
+		}
+	}
+
+	groups, err := globalIAMSys.ListGroups()
+	if err != nil {
+		return meta, err
+	}
+	for _, group := range groups {
+		desc, err := globalIAMSys.GetGroupDescription(group)
+		if err != nil {
+			return meta, err
+		}
+		sort.Strings(desc.Members)
+		if err = meta.add("group", group, desc); err != nil {
+			return meta, err
+		}
+	}
+	return meta, nil
+}
+
+// SRDriftEntry - a replicated entity which differs between sites.
+type SRDriftEntry struct {
+	Kind string `json:"kind"`
+	Name string `json:"name"`
+	// Digest of the entity by deployment ID, sites
+	// the entity is missing on are omitted.
+	Digests map[string]string `json:"digests"`
+}
+
+// SRStatusInfo - site replication status, lists the replicated
+// entities which differ between sites.
+type SRStatusInfo struct {
+	Enabled bool                `json:"enabled"`
+	Sites   map[string]PeerInfo `json:"sites,omitempty"`
+	// Number of replicated entities of this site by kind.
+	Counts map[string]int `json:"counts,omitempty"`
+	Drift  []SRDriftEntry `json:"drift,omitempty"`
+	// Sites the status could not be obtained from.
+	Errors map[string]string `json:"errors,omitempty"`
+}
+
+// GetStatus - returns the drift between the linked sites.
+func (c *SiteReplicationSys) GetStatus(ctx context.Context, objAPI ObjectLayer) (SRStatusInfo, error) {
+	if !c.isEnabled() {
+		return SRStatusInfo{}, nil
+	}
+
+	local, err := c.GetSiteMeta(ctx, objAPI)
+	if err != nil {
+		return SRStatusInfo{}, err
+	}
+
+	clients, err := c.peerClients()
+	if err != nil {
+		return SRStatusInfo{}, err
+	}
+
+	status := SRStatusInfo{
+		Enabled: true,
+		Sites:   make(map[string]PeerInfo),
+		Counts:  make(map[string]int),
+	}
+	ids := make([]string, 0, len(clients))
+	for id := range clients {
+		ids = append(ids, id)
+	}
+	peerMetas := make([]srSiteMeta, len(ids))
+	g := errgroup.WithNErrs(len(ids))
+	for index := range ids {
+		index := index
+		g.Go(func() error {
+			return clients[ids[index]].call(ctx, http.MethodGet, "/site-replication/peer/metainfo", nil, nil, &peerMetas[index])
+		}, index)
+	}
+	metas := []srSiteMeta{local}
+	for index, err := range g.Wait() {
+		if err != nil {
+			if status.Errors == nil {
+				status.Errors = make(map[string]string)
+			}
+			status.Errors[ids[index]] = err.Error()
+			continue
+		}
+		peerMetas[index].DeploymentID = ids[index]
+		metas = append(metas, peerMetas[index])
+	}
+
+	for _, peer := range c.GetInfo().Sites {
+		status.Sites[peer.DeploymentID] = peer
+	}
+	for kind, entities := range local.Entities {
+		status.Counts[kind] = len(entities)
+	}
+	status.Drift = srDrift(metas)
+	return status, nil
+}
+
+// srDrift returns the entities which are missing on some sites or
+// differ between sites.
+func srDrift(metas []srSiteMeta) []SRDriftEntry {
+	type entityKey struct{ kind, name string }
+	digests := make(map[entityKey]map[string]string)
+	for _, meta := range metas {
+		for kind, entities := range meta.Entities {
+			for name, digest := range entities {
+				k := entityKey{kind, name}
+				if digests[k] == nil {
+					digests[k] = make(map[string]string, len(metas))
+				}
+				digests[k][meta.DeploymentID] = digest
+			}
+		}
+	}
+
+	var drift []SRDriftEntry
+	for k, sites := range digests {
+		inSync := len(sites) == len(metas)
+		for _, digest := range sites {
+			for _, other := range sites {
+				if digest != other {
+					inSync = false
+				}
+			}
+		}
+		if !inSync {
+			drift = append(drift, SRDriftEntry{Kind: k.kind, Name: k.name, Digests: sites})
+		}
+	}
+	sort.Slice(drift, func(i, j int) bool {
+		if drift[i].Kind != drift[j].Kind {
+			return drift[i].Kind < drift[j].Kind
+		}
+		return drift[i].Name < drift[j].Name
+	})
+	return drift
+}
+
+// srPeerClient - calls the site replication admin APIs of a peer site.
+type srPeerClient struct {
+	peer       PeerInfo
+	endpoint   string
+	accessKey  string
+	secretKey  string
+	httpClient *http.Client
+}
+
+func newSRPeerClient(endpoint, accessKey, secretKey string) *srPeerClient {
+	return &srPeerClient{
+		peer:       PeerInfo{Endpoint: endpoint},
+		endpoint:   endpoint,
+		accessKey:  accessKey,
+		secretKey:  secretKey,
+		httpClient: &http.Client{Transport: NewRemoteTargetHTTPTransport(), Timeout: time.Minute},
+	}
+}
+
+// callEncrypted sends v as JSON encrypted with the secret key.
+func (client *srPeerClient) callEncrypted(ctx context.Context, method, relPath string, query url.Values, v interface{}) error {
+	data, err := json.Marshal(v)
+	if err != nil {
+		return err
+	}
+	body, err := madmin.EncryptData(client.secretKey, data)
+	if err != nil {
+		return err
+	}
+	return client.call(ctx, method, relPath, query, body, nil)
+}
+
+// call sends a signed admin request to the peer and decodes a
+// JSON response into result, if not nil.
+func (client *srPeerClient) call(ctx context.Context, method, relPath string, query url.Values, body []byte, result interface{}) error {
+	u := client.endpoint + adminPathPrefix + adminAPIVersionPrefix + relPath
+	if len(query) > 0 {
+		u += "?" + query.Encode()
+	}
+	req, err := http.NewRequestWithContext(ctx, method, u, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	sum := sha256.Sum256(body)
+	req.Header.Set("X-Amz-Content-Sha256", hex.EncodeToString(sum[:]))
+	req.ContentLength = int64(len(body))
+	req = signer.SignV4(*req, client.accessKey, client.secretKey, "", "")
+
+	resp, err := client.httpClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	data, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return err
+	}
+	if resp.StatusCode != http.StatusOK && resp.StatusCode != http.StatusNoContent {
+		var errResp APIErrorResponse
+		if json.Unmarshal(data, &errResp) == nil && errResp.Code != "" {
+			return fmt.Errorf("%s: %s", errResp.Code, errResp.Message)
+		}
+		return fmt.Errorf("unexpected response %s", resp.Status)
+	}
+	if result != nil {
+		return json.Unmarshal(data, result)
+	}
+	return nil
+}
@@ -0,0 +1,515 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"reflect"
+	"strings"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/minio/madmin-go"
+	"github.com/minio/minio/internal/auth"
+)
+
+func TestSRDrift(t *testing.T) {
+	site1 := srSiteMeta{
+		DeploymentID: "site1",
+		Entities: map[string]map[string]string{
+			"bucket":        {"photos": "a", "docs": "b"},
+			"bucket-policy": {"photos": "c"},
+			"policy":        {"readonly": "d"},
+		},
+	}
+	site2 := srSiteMeta{
+		DeploymentID: "site2",
+		Entities: map[string]map[string]string{
+			"bucket":        {"photos": "a", "docs": "b"},
+			"bucket-policy": {"photos": "x"},
+			"policy":        {"readonly": "d", "writeonly": "e"},
+		},
+	}
+
+	site1Copy := site1
+	site1Copy.DeploymentID = "site2"
+
+	testCases := []struct {
+		metas    []srSiteMeta
+		expected []SRDriftEntry
+	}{
+		// Test 1: a single site never drifts.
+		{
+			metas: []srSiteMeta{site1},
+		},
+		// Test 2: sites in sync.
+		{
+			metas: []srSiteMeta{site1, site1Copy},
+		},
+		// Test 3: differing and missing entities.
+		{
+			metas: []srSiteMeta{site1, site2},
+			expected: []SRDriftEntry{
+				{Kind: "bucket-policy", Name: "photos", Digests: map[string]string{"site1": "c", "site2": "x"}},
+				{Kind: "policy", Name: "writeonly", Digests: map[string]string{"site2": "e"}},
+			},
+		},
+	}
+
+	for i, tc := range testCases {
+		if got := srDrift(tc.metas); !reflect.DeepEqual(got, tc.expected) {
+			t.Errorf("Test %d: expected %v, got %v", i+1, tc.expected, got)
+		}
+	}
+}
+
+// srTestPeer - a peer site recording the site replication requests
+// it receives.
+type srTestPeer struct {
+	*httptest.Server
+
+	mu        sync.Mutex
+	secretKey string
+	// Number of requests to fail before succeeding.
+	failures int
+	// First request waits for release to be closed.
+	release  chan struct{}
+	received chan string
+}
+
+func newSRTestPeer(secretKey string) *srTestPeer {
+	peer := &srTestPeer{
+		secretKey: secretKey,
+		received:  make(chan string, 100),
+	}
+	peer.Server = httptest.NewServer(http.HandlerFunc(peer.serveHTTP))
+	return peer
+}
+
+func (peer *srTestPeer) serveHTTP(w http.ResponseWriter, r *http.Request) {
+	peer.mu.Lock()
+	release := peer.release
+	peer.release = nil
+	fail := peer.failures > 0
+	if fail {
+		peer.failures--
+	}
+	secretKey := peer.secretKey
+	peer.mu.Unlock()
+
+	if release != nil {
+		<-release
+	}
+
+	var request string
+	op := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+	switch op {
+	case "bucket-ops":
+		request = op + ":" + r.URL.Query().Get("operation") + ":" + r.URL.Query().Get("bucket")
+	default:
+		body, err := ioutil.ReadAll(r.Body)
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		data, err := madmin.DecryptData(secretKey, strings.NewReader(string(body)))
+		if err != nil {
+			w.WriteHeader(http.StatusBadRequest)
+			return
+		}
+		switch op {
+		case "iam-item":
+			var item srIAMItem
+			if err = json.Unmarshal(data, &item); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			request = op + ":" + item.Type + ":" + item.Name
+		case "bucket-meta":
+			var item srBucketMeta
+			if err = json.Unmarshal(data, &item); err != nil {
+				w.WriteHeader(http.StatusBadRequest)
+				return
+			}
+			request = op + ":" + item.ConfigFile + ":" + item.Bucket
+		default:
+			request = op
+		}
+	}
+	peer.received <- request
+	if fail {
+		w.WriteHeader(http.StatusServiceUnavailable)
+	}
+}
+
+// next returns the next request received by the peer.
+func (peer *srTestPeer) next(t *testing.T) string {
+	t.Helper()
+	select {
+	case request := <-peer.received:
+		return request
+	case <-time.After(10 * time.Second):
+		t.Fatal("timed out waiting for a site replication request")
+	}
+	return ""
+}
+
+// prepareSRTestBed links this site with the given peers, without
+// calling them.
+func prepareSRTestBed(ctx context.Context, t *testing.T, peers ...*srTestPeer) (*adminErasureTestBed, *SiteReplicationSys) {
+	adminTestBed, err := prepareAdminErasureTestBed(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	deploymentID := globalDeploymentID
+	globalDeploymentID = mustGetUUID()
+	t.Cleanup(func() {
+		globalDeploymentID = deploymentID
+		adminTestBed.TearDown()
+	})
+
+	if err = globalIAMSys.Load(ctx, globalIAMSys.store); err != nil {
+		t.Fatal(err)
+	}
+
+	c := NewSiteReplicationSys()
+	if len(peers) == 0 {
+		return adminTestBed, c
+	}
+
+	svcCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	srTestAddUser(auth.Credentials{
+		AccessKey:  siteReplicatorSvcAcc,
+		SecretKey:  svcCred.SecretKey,
+		ParentUser: globalActiveCred.AccessKey,
+		Status:     auth.AccountOn,
+	})
+	state := srState{
+		Name:             "local",
+		Peers:            map[string]PeerInfo{globalDeploymentID: {Name: "local", DeploymentID: globalDeploymentID}},
+		ServiceAccountAK: siteReplicatorSvcAcc,
+	}
+	for i, peer := range peers {
+		peer.secretKey = svcCred.SecretKey
+		id := "peer" + string(rune('1'+i))
+		state.Peers[id] = PeerInfo{Name: id, Endpoint: peer.URL, DeploymentID: id}
+	}
+	if err = c.save(ctx, adminTestBed.objLayer, state); err != nil {
+		t.Fatal(err)
+	}
+	return adminTestBed, c
+}
+
+// srTestAddUser adds credentials to IAM in memory only.
+func srTestAddUser(cred auth.Credentials) {
+	globalIAMSys.store.lock()
+	globalIAMSys.iamUsersMap[cred.AccessKey] = cred
+	globalIAMSys.store.unlock()
+}
+
+// Tests that changes are applied on peers in the background and
+// retried when they fail.
+func TestSRHooks(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peer := newSRTestPeer("")
+	defer peer.Close()
+	_, c := prepareSRTestBed(ctx, t, peer)
+
+	// The first request is held and fails, hooks must not wait on it.
+	release := make(chan struct{})
+	peer.mu.Lock()
+	peer.release = release
+	peer.failures = 1
+	peer.mu.Unlock()
+
+	c.MakeBucketHook(ctx, "photos", BucketOptions{})
+	c.BucketMetaHook(ctx, "photos", bucketPolicyConfig, []byte(`{}`))
+	close(release)
+
+	expected := []string{
+		"bucket-ops:make:photos",
+		// Retry of the failed request, in order.
+		"bucket-ops:make:photos",
+		"bucket-meta:" + bucketPolicyConfig + ":photos",
+	}
+	for i, want := range expected {
+		if got := peer.next(t); got != want {
+			t.Errorf("Test %d: expected request %s, got %s", i+1, want, got)
+		}
+	}
+
+	// Changes of service accounts of the root user are never
+	// propagated, neither are changes of unknown parents.
+	c.IAMChangeHook(ctx, srIAMItem{
+		Type:    srIAMItemSvcAcctDelete,
+		Name:    "root-svc",
+		SvcAcct: &srSvcAcct{Parent: globalActiveCred.AccessKey, AccessKey: "root-svc"},
+	})
+	c.IAMChangeHook(ctx, srIAMItem{Type: srIAMItemSvcAcctDelete, Name: "unknown-svc"})
+	c.IAMChangeHook(ctx, srIAMItem{
+		Type:    srIAMItemSvcAcctUpdate,
+		Name:    siteReplicatorSvcAcc,
+		SvcAcct: &srSvcAcct{Parent: "alice", AccessKey: siteReplicatorSvcAcc},
+	})
+	c.IAMChangeHook(ctx, srIAMItem{
+		Type:    srIAMItemSvcAcctDelete,
+		Name:    "alice-svc",
+		SvcAcct: &srSvcAcct{Parent: "alice", AccessKey: "alice-svc"},
+	})
+	if got, want := peer.next(t), "iam-item:"+srIAMItemSvcAcctDelete+":alice-svc"; got != want {
+		t.Errorf("expected request %s, got %s", want, got)
+	}
+
+	// Peers refuse to change service accounts of their root user.
+	item := srIAMItem{
+		Type:    srIAMItemSvcAcctDelete,
+		Name:    siteReplicatorSvcAcc,
+		SvcAcct: &srSvcAcct{Parent: "alice", AccessKey: siteReplicatorSvcAcc},
+	}
+	if err := c.PeerIAMItemUpdate(ctx, item); err != errIAMActionNotAllowed {
+		t.Errorf("expected %v, got %v", errIAMActionNotAllowed, err)
+	}
+}
+
+// Tests that a resync copies the buckets and IAM of this site.
+func TestSRResyncPeer(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peer := newSRTestPeer("")
+	defer peer.Close()
+	adminTestBed, c := prepareSRTestBed(ctx, t, peer)
+
+	if err := adminTestBed.objLayer.MakeBucketWithLocation(ctx, "photos", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	srTestAddUser(auth.Credentials{AccessKey: "alice", SecretKey: "alice-secret", Status: auth.AccountOn})
+
+	if err := c.resyncPeer(ctx, "peer1"); err != nil {
+		t.Fatal(err)
+	}
+	close(peer.received)
+	received := make(map[string]bool)
+	for request := range peer.received {
+		received[request] = true
+	}
+	for _, want := range []string{"bucket-ops:make:photos", "iam-item:" + srIAMItemUser + ":alice"} {
+		if !received[want] {
+			t.Errorf("expected request %s, got %v", want, received)
+		}
+	}
+	for request := range received {
+		if strings.Contains(request, siteReplicatorSvcAcc) {
+			t.Errorf("unexpected request %s", request)
+		}
+	}
+}
+
+// Tests that changes older than the last change of an entity, such as
+// the changes sent by a resync of a peer, are not applied.
+func TestSRLastWriterWins(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peer := newSRTestPeer("")
+	defer peer.Close()
+	adminTestBed, c := prepareSRTestBed(ctx, t, peer)
+	objAPI := adminTestBed.objLayer
+
+	if err := objAPI.MakeBucketWithLocation(ctx, "photos", BucketOptions{}); err != nil {
+		t.Fatal(err)
+	}
+	tagging := func() string {
+		t.Helper()
+		meta, err := globalBucketMetadataSys.GetConfig("photos")
+		if err != nil {
+			t.Fatal(err)
+		}
+		return string(meta.TaggingConfigXML)
+	}
+	const tags = `<Tagging><TagSet><Tag><Key>a</Key><Value>b</Value></Tag></TagSet></Tagging>`
+	const staleTags = `<Tagging><TagSet><Tag><Key>c</Key><Value>d</Value></Tag></TagSet></Tagging>`
+
+	now := UTCNow()
+	later := now.Add(time.Hour)
+	for i, tc := range []struct {
+		data      string
+		updatedAt time.Time
+		expected  string
+	}{
+		{tags, later, tags},
+		// An older removal is ignored.
+		{"", now, tags},
+		// So is a resync of a configuration never changed on the peer.
+		{staleTags, time.Time{}, tags},
+		{"", later.Add(time.Second), ""},
+	} {
+		item := srBucketMeta{Bucket: "photos", ConfigFile: bucketTaggingConfig, Data: []byte(tc.data), UpdatedAt: tc.updatedAt}
+		if err := c.PeerBucketMetaUpdate(ctx, item); err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if got := tagging(); got != tc.expected {
+			t.Errorf("Test %d: expected tagging %q, got %q", i+1, tc.expected, got)
+		}
+	}
+
+	// Local changes are newer than any known change, even one made
+	// on a peer with a clock ahead of this site.
+	c.BucketMetaHook(ctx, "photos", bucketTaggingConfig, []byte(tags))
+	if got, want := peer.next(t), "bucket-meta:"+bucketTaggingConfig+":photos"; got != want {
+		t.Errorf("expected request %s, got %s", want, got)
+	}
+	updates, err := loadSRUpdates(ctx, objAPI)
+	if err != nil {
+		t.Fatal(err)
+	}
+	entity := srBucketMeta{Bucket: "photos", ConfigFile: bucketTaggingConfig}.entity()
+	if !updates.Entities[entity].After(later.Add(time.Second)) {
+		t.Errorf("expected the local change to be the last change, got %s", updates.Entities[entity])
+	}
+
+	// A bucket deleted on a peer is not created again by a stale resync.
+	if err = c.PeerBucketMake(ctx, objAPI, "docs", BucketOptions{}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.PeerBucketDelete(ctx, objAPI, "docs", false, now); err != nil {
+		t.Fatal(err)
+	}
+	if err = c.PeerBucketMake(ctx, objAPI, "docs", BucketOptions{}, time.Time{}); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = objAPI.GetBucketInfo(ctx, "docs"); !isErrBucketNotFound(err) {
+		t.Errorf("expected the bucket to stay deleted, got %v", err)
+	}
+	if err = c.PeerBucketMake(ctx, objAPI, "docs", BucketOptions{}, later); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = objAPI.GetBucketInfo(ctx, "docs"); err != nil {
+		t.Errorf("expected the bucket to be created again, got %v", err)
+	}
+}
+
+// Tests that the peers which joined leave again when linking the
+// sites fails.
+func TestSRLinkPeersRollback(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	const secretKey = "peer-secret-key"
+	peer1, peer2 := newSRTestPeer(secretKey), newSRTestPeer(secretKey)
+	defer peer1.Close()
+	defer peer2.Close()
+	peer2.failures = 1
+
+	adminTestBed, c := prepareSRTestBed(ctx, t)
+
+	svcCred, err := auth.GetNewCredentials()
+	if err != nil {
+		t.Fatal(err)
+	}
+	svcCred.AccessKey = siteReplicatorSvcAcc
+	svcCred.ParentUser = globalActiveCred.AccessKey
+	srTestAddUser(svcCred)
+
+	sites := []PeerSite{
+		{Name: "local"},
+		{Name: "peer1", Endpoint: peer1.URL, AccessKey: "minio", SecretKey: secretKey},
+		{Name: "peer2", Endpoint: peer2.URL, AccessKey: "minio", SecretKey: secretKey},
+	}
+	peers := map[string]PeerInfo{
+		globalDeploymentID: {Name: "local", DeploymentID: globalDeploymentID},
+		"peer1":            {Name: "peer1", Endpoint: peer1.URL, DeploymentID: "peer1"},
+		"peer2":            {Name: "peer2", Endpoint: peer2.URL, DeploymentID: "peer2"},
+	}
+	if err = c.linkPeers(ctx, adminTestBed.objLayer, "local", sites, peers, svcCred); err == nil {
+		t.Fatal("expected linking the sites to fail")
+	}
+
+	for _, tc := range []struct {
+		peer     *srTestPeer
+		expected []string
+	}{
+		{peer1, []string{"join", "leave"}},
+		{peer2, []string{"join", "leave"}},
+	} {
+		for _, want := range tc.expected {
+			if got := tc.peer.next(t); got != want {
+				t.Errorf("%s: expected request %s, got %s", tc.peer.URL, want, got)
+			}
+		}
+	}
+	if c.isEnabled() {
+		t.Error("expected site replication to be disabled")
+	}
+	if _, ok := globalIAMSys.GetUser(siteReplicatorSvcAcc); ok {
+		t.Error("expected the site replicator service account to be removed")
+	}
+}
+
+// Tests leaving a failed link request on a peer.
+func TestSRPeerLeaveReq(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	peer := newSRTestPeer("")
+	defer peer.Close()
+	adminTestBed, c := prepareSRTestBed(ctx, t, peer)
+
+	c.RLock()
+	peers := c.state.Peers
+	c.RUnlock()
+
+	// Only the sites of the failed request are unlinked.
+	err := c.PeerLeaveReq(ctx, adminTestBed.objLayer, srPeerLeaveReq{Peers: map[string]PeerInfo{"other": {}}})
+	if !errors.Is(err, errSRInvalidSites) {
+		t.Fatalf("expected %v, got %v", errSRInvalidSites, err)
+	}
+	if !c.isEnabled() {
+		t.Fatal("expected site replication to be enabled")
+	}
+
+	if err = c.PeerLeaveReq(ctx, adminTestBed.objLayer, srPeerLeaveReq{Peers: peers}); err != nil {
+		t.Fatal(err)
+	}
+	if c.isEnabled() {
+		t.Error("expected site replication to be disabled")
+	}
+	if _, ok := globalIAMSys.GetUser(siteReplicatorSvcAcc); ok {
+		t.Error("expected the site replicator service account to be removed")
+	}
+	if _, err = readConfig(ctx, adminTestBed.objLayer, siteReplicationStatePath); !errors.Is(err, errConfigNotFound) {
+		t.Errorf("expected the state to be removed, got %v", err)
+	}
+
+	// Leaving again is a no-op.
+	if err = c.PeerLeaveReq(ctx, adminTestBed.objLayer, srPeerLeaveReq{Peers: peers}); err != nil {
+		t.Fatal(err)
+	}
+}
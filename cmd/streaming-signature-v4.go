@@ -28,6 +28,8 @@ import (
 	"hash"
 	"io"
 	"net/http"
+	"strconv"
+	"strings"
 	"time"
 
 	humanize "github.com/dustin/go-humanize"
@@ -37,10 +39,14 @@ import (
 
 // Streaming AWS Signature Version '4' constants.
 const (
-	emptySHA256              = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
-	streamingContentSHA256   = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
-	signV4ChunkedAlgorithm   = "AWS4-HMAC-SHA256-PAYLOAD"
-	streamingContentEncoding = "aws-chunked"
+	emptySHA256                   = "e3b0c44298fc1c149afbf4c8996fb92427ae41e4649b934ca495991b7852b855"
+	streamingContentSHA256        = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD"
+	streamingContentSHA256Trailer = "STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER"
+	signV4ChunkedAlgorithm        = "AWS4-HMAC-SHA256-PAYLOAD"
+	signV4ChunkedAlgorithmTrailer = "AWS4-HMAC-SHA256-TRAILER"
+	streamingContentEncoding      = "aws-chunked"
+	unsignedPayloadTrailer        = "STREAMING-UNSIGNED-PAYLOAD-TRAILER"
+	trailerSignatureKey           = "x-amz-trailer-signature"
 )
 
 // getChunkSignature - get chunk signature.
@@ -62,6 +68,21 @@ func getChunkSignature(cred auth.Credentials, seedSignature string, region strin
 	return newSignature
 }
 
+// getTrailerChunkSignature - get the signature of the trailing headers.
+func getTrailerChunkSignature(cred auth.Credentials, seedSignature string, region string, date time.Time, hashedTrailer string) string {
+	// Calculate string to sign.
+	stringToSign := signV4ChunkedAlgorithmTrailer + "\n" +
+		date.Format(iso8601Format) + "\n" +
+		getScope(date, region) + "\n" +
+		seedSignature + "\n" +
+		hashedTrailer
+
+	// Get hmac signing key.
+	signingKey := getSigningKey(cred.SecretKey, date, region, serviceS3)
+
+	return getSignature(signingKey, stringToSign)
+}
+
 // calculateSeedSignature - Calculate seed signature in accordance with
 //     - http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-streaming.html
 // returns signature, error otherwise if the signature mismatches or any other
@@ -80,10 +101,11 @@ func calculateSeedSignature(r *http.Request) (cred auth.Credentials, signature s
 	}
 
 	// Payload streaming.
-	payload := streamingContentSHA256
+	payload := req.Header.Get(xhttp.AmzContentSha256)
 
 	// Payload for STREAMING signature should be 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD'
-	if payload != req.Header.Get(xhttp.AmzContentSha256) {
+	// or 'STREAMING-AWS4-HMAC-SHA256-PAYLOAD-TRAILER'
+	if payload != streamingContentSHA256 && payload != streamingContentSHA256Trailer {
 		return cred, "", "", time.Time{}, ErrContentSHA256Mismatch
 	}
 
@@ -154,16 +176,21 @@ var errChunkTooBig = errors.New("chunk too big: choose chunk size <= 16MiB")
 // newSignV4ChunkedReader returns a new s3ChunkedReader that translates the data read from r
 // out of HTTP "chunked" format before returning it.
 // The s3ChunkedReader returns io.EOF when the final 0-length chunk is read.
+// If trailer is true the final chunk is followed by signed trailing headers,
+// which are stored in req.Trailer.
 //
 // NewChunkedReader is not needed by normal applications. The http package
 // automatically decodes chunking when reading response bodies.
-func newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
+func newSignV4ChunkedReader(req *http.Request, trailer bool) (io.ReadCloser, APIErrorCode) {
 	cred, seedSignature, region, seedDate, errCode := calculateSeedSignature(req)
 	if errCode != ErrNone {
 		return nil, errCode
 	}
+	if trailer != (req.Header.Get(xhttp.AmzContentSha256) == streamingContentSHA256Trailer) {
+		return nil, ErrContentSHA256Mismatch
+	}
 
-	return &s3ChunkedReader{
+	cr := &s3ChunkedReader{
 		reader:            bufio.NewReader(req.Body),
 		cred:              cred,
 		seedSignature:     seedSignature,
@@ -171,7 +198,46 @@ func newSignV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
 		region:            region,
 		chunkSHA256Writer: sha256.New(),
 		buffer:            make([]byte, 64*1024),
-	}, ErrNone
+	}
+	if trailer {
+		if errCode = cr.setTrailers(req); errCode != ErrNone {
+			return nil, errCode
+		}
+	}
+	return cr, ErrNone
+}
+
+// newUnsignedV4ChunkedReader returns a new s3ChunkedReader for a
+// 'STREAMING-UNSIGNED-PAYLOAD-TRAILER' request. The chunks carry no
+// signature, the request headers are signed as for any other request
+// and the content is protected by the checksum sent as trailer, the
+// request must announce exactly one x-amz-checksum-* trailer.
+func newUnsignedV4ChunkedReader(req *http.Request) (io.ReadCloser, APIErrorCode) {
+	if errCode := reqSignatureV4Verify(req, globalServerRegion, serviceS3); errCode != ErrNone {
+		return nil, errCode
+	}
+
+	// Nothing else protects the content, exactly one checksum
+	// must be announced as trailer.
+	var checksums int
+	for _, key := range strings.Split(req.Header.Get(xhttp.AmzTrailer), ",") {
+		if strings.HasPrefix(strings.ToLower(strings.TrimSpace(key)), "x-amz-checksum-") {
+			checksums++
+		}
+	}
+	if checksums != 1 {
+		return nil, ErrInvalidChecksum
+	}
+
+	cr := &s3ChunkedReader{
+		reader:   bufio.NewReader(req.Body),
+		buffer:   make([]byte, 64*1024),
+		unsigned: true,
+	}
+	if errCode := cr.setTrailers(req); errCode != ErrNone {
+		return nil, errCode
+	}
+	return cr, ErrNone
 }
 
 // Represents the overall state that is required for decoding a
@@ -183,6 +249,15 @@ type s3ChunkedReader struct {
 	seedDate      time.Time
 	region        string
 
+	// The chunks of unsigned payloads carry no signature.
+	unsigned bool
+	// Trailing headers announced by x-amz-trailer, nil if the
+	// payload has no trailers.
+	trailers     http.Header
+	wantTrailers map[string]bool
+	// Size of the content not read yet, -1 if unknown.
+	remaining int64
+
 	chunkSHA256Writer hash.Hash // Calculates sha256 of chunk data.
 	buffer            []byte
 	offset            int
@@ -193,6 +268,34 @@ func (cr *s3ChunkedReader) Close() (err error) {
 	return nil
 }
 
+// setTrailers prepares the reader to store the trailing headers
+// announced by the request in req.Trailer.
+func (cr *s3ChunkedReader) setTrailers(req *http.Request) APIErrorCode {
+	cr.wantTrailers = make(map[string]bool)
+	for _, key := range strings.Split(req.Header.Get(xhttp.AmzTrailer), ",") {
+		if key = strings.ToLower(strings.TrimSpace(key)); key != "" {
+			cr.wantTrailers[key] = true
+		}
+	}
+	if req.Trailer == nil {
+		req.Trailer = make(http.Header)
+	}
+	cr.trailers = req.Trailer
+
+	cr.remaining = -1
+	if size, err := strconv.ParseInt(req.Header.Get(xhttp.AmzDecodedContentLength), 10, 64); err == nil && size >= 0 {
+		cr.remaining = size
+	}
+	// Readers stop at the decoded content length, see Read.
+	if cr.remaining == 0 {
+		if err := cr.readLastChunk(); err != io.EOF {
+			return toAPIErrorCode(req.Context(), err)
+		}
+		cr.err = io.EOF
+	}
+	return ErrNone
+}
+
 // Now, we read one chunk from the underlying reader.
 // A chunk has the following format:
 //   <chunk-size-as-hex> + ";chunk-signature=" + <signature-as-hex> + "\r\n" + <payload> + "\r\n"
@@ -207,6 +310,14 @@ func (cr *s3ChunkedReader) Close() (err error) {
 // The last chunk is *always* 0-sized. So, we must only return io.EOF if we have encountered
 // a chunk with a chunk size = 0. However, this chunk still has a signature and we must
 // verify it.
+//
+// Chunks of unsigned payloads have the format:
+//   <chunk-size-as-hex> + "\r\n" + <payload> + "\r\n"
+//
+// If the payload has trailers the last chunk is followed by the trailing
+// headers instead of "\r\n", one "<key>:<value>\r\n" per header, the
+// "x-amz-trailer-signature:<signature-as-hex>\r\n" of signed payloads and
+// a final "\r\n".
 const maxChunkSize = 16 << 20 // 16 MiB
 
 // Read - implements `io.Reader`, which transparently decodes
@@ -223,7 +334,51 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 		cr.offset = 0
 		buf = buf[n:]
 	}
+	if cr.err != nil {
+		return n, cr.err
+	}
+
+	cr.buffer, err = cr.readChunk(cr.buffer)
+	if err != nil {
+		cr.err = err
+		return n, cr.err
+	}
+
+	// If the chunk size is zero we return io.EOF. As specified by AWS,
+	// only the last chunk is zero-sized.
+	if len(cr.buffer) == 0 {
+		cr.err = io.EOF
+		if cr.trailers != nil {
+			if err = cr.readTrailers(); err != nil {
+				cr.err = err
+			}
+		}
+		return n, cr.err
+	}
+
+	// Readers of the content, e.g. hash.Reader, stop at the decoded
+	// content length. The trailers must be read with the last byte.
+	if cr.trailers != nil && cr.remaining >= 0 {
+		cr.remaining -= int64(len(cr.buffer))
+		if cr.remaining < 0 {
+			cr.err = errMalformedEncoding
+			return n, cr.err
+		}
+		if cr.remaining == 0 {
+			if cr.err = cr.readLastChunk(); cr.err != io.EOF {
+				return n, cr.err
+			}
+		}
+	}
+
+	cr.offset = copy(buf, cr.buffer)
+	n += cr.offset
+	return n, nil
+}
 
+// readChunk reads the next chunk into buf, growing it if needed,
+// verifies its signature and returns the payload.
+func (cr *s3ChunkedReader) readChunk(buf []byte) ([]byte, error) {
 	var size int
 	for {
 		b, err := cr.reader.ReadByte()
@@ -231,12 +386,17 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 			err = io.ErrUnexpectedEOF
 		}
 		if err != nil {
-			cr.err = err
-			return n, cr.err
+			return nil, err
 		}
 		if b == ';' { // separating character
 			break
 		}
+		if b == '\r' && cr.unsigned {
+			if err = cr.reader.UnreadByte(); err != nil {
+				return nil, err
+			}
+			break
+		}
 
 		// Manually deserialize the size since AWS specified
 		// the chunk size to be of variable width. In particular,
@@ -250,12 +410,10 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 		case b >= 'A' && b <= 'F':
 			size = size<<4 | int(b-('A'-10))
 		default:
-			cr.err = errMalformedEncoding
-			return n, cr.err
+			return nil, errMalformedEncoding
 		}
 		if size > maxChunkSize {
-			cr.err = errChunkTooBig
-			return n, cr.err
+			return nil, errChunkTooBig
 		}
 	}
 
@@ -264,98 +422,153 @@ func (cr *s3ChunkedReader) Read(buf []byte) (n int, err error) {
 	//
 	// The signature is 64 bytes long (hex-encoded SHA256 hash) and
 	// starts with a 16 byte header: len("chunk-signature=") + 64 == 80.
+	// Chunk extensions of unsigned payloads are ignored.
 	var signature [80]byte
-	_, err = io.ReadFull(cr.reader, signature[:])
-	if err == io.EOF {
-		err = io.ErrUnexpectedEOF
-	}
-	if err != nil {
-		cr.err = err
-		return n, cr.err
-	}
-	if !bytes.HasPrefix(signature[:], []byte("chunk-signature=")) {
-		cr.err = errMalformedEncoding
-		return n, cr.err
-	}
-	b, err := cr.reader.ReadByte()
-	if err == io.EOF {
-		err = io.ErrUnexpectedEOF
-	}
-	if err != nil {
-		cr.err = err
-		return n, cr.err
-	}
-	if b != '\r' {
-		cr.err = errMalformedEncoding
-		return n, cr.err
-	}
-	b, err = cr.reader.ReadByte()
-	if err == io.EOF {
-		err = io.ErrUnexpectedEOF
-	}
-	if err != nil {
-		cr.err = err
-		return n, cr.err
+	if cr.unsigned {
+		if _, err := cr.reader.ReadSlice('\r'); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			} else if err == bufio.ErrBufferFull {
+				err = errLineTooLong
+			}
+			return nil, err
+		}
+		if err := cr.reader.UnreadByte(); err != nil {
+			return nil, err
+		}
+	} else {
+		_, err := io.ReadFull(cr.reader, signature[:])
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		if err != nil {
+			return nil, err
+		}
+		if !bytes.HasPrefix(signature[:], []byte("chunk-signature=")) {
+			return nil, errMalformedEncoding
+		}
 	}
-	if b != '\n' {
-		cr.err = errMalformedEncoding
-		return n, cr.err
+	if err := readCRLF(cr.reader); err != nil {
+		if err == io.EOF {
+			err = io.ErrUnexpectedEOF
+		}
+		return nil, err
 	}
 
-	if cap(cr.buffer) < size {
-		cr.buffer = make([]byte, size)
+	if cap(buf) < size {
+		buf = make([]byte, size)
 	} else {
-		cr.buffer = cr.buffer[:size]
+		buf = buf[:size]
 	}
 
 	// Now, we read the payload and compute its SHA-256 hash.
-	_, err = io.ReadFull(cr.reader, cr.buffer)
+	_, err := io.ReadFull(cr.reader, buf)
 	if err == io.EOF && size != 0 {
 		err = io.ErrUnexpectedEOF
 	}
 	if err != nil && err != io.EOF {
-		cr.err = err
-		return n, cr.err
-	}
-	b, err = cr.reader.ReadByte()
-	if b != '\r' {
-		cr.err = errMalformedEncoding
-		return n, cr.err
-	}
-	b, err = cr.reader.ReadByte()
-	if err == io.EOF {
-		err = io.ErrUnexpectedEOF
-	}
-	if err != nil {
-		cr.err = err
-		return n, cr.err
+		return nil, err
+	}
+	// The last chunk of a payload with trailers is not terminated.
+	if size != 0 || cr.trailers == nil {
+		if err = readCRLF(cr.reader); err != nil {
+			if err == io.EOF {
+				err = io.ErrUnexpectedEOF
+			}
+			return nil, err
+		}
 	}
-	if b != '\n' {
-		cr.err = errMalformedEncoding
-		return n, cr.err
+
+	if cr.unsigned {
+		return buf, nil
 	}
 
 	// Once we have read the entire chunk successfully, we verify
 	// that the received signature matches our computed signature.
-	cr.chunkSHA256Writer.Write(cr.buffer)
+	cr.chunkSHA256Writer.Write(buf)
 	newSignature := getChunkSignature(cr.cred, cr.seedSignature, cr.region, cr.seedDate, hex.EncodeToString(cr.chunkSHA256Writer.Sum(nil)))
+	cr.chunkSHA256Writer.Reset()
 	if !compareSignatureV4(string(signature[16:]), newSignature) {
-		cr.err = errSignatureMismatch
-		return n, cr.err
+		return nil, errSignatureMismatch
 	}
 	cr.seedSignature = newSignature
-	cr.chunkSHA256Writer.Reset()
+	return buf, nil
+}
 
-	// If the chunk size is zero we return io.EOF. As specified by AWS,
-	// only the last chunk is zero-sized.
-	if size == 0 {
-		cr.err = io.EOF
-		return n, cr.err
+// readLastChunk reads the last, zero-sized, chunk and the trailers
+// following it. It returns io.EOF on success.
+func (cr *s3ChunkedReader) readLastChunk() error {
+	buf, err := cr.readChunk(nil)
+	if err != nil {
+		return err
+	}
+	if len(buf) != 0 {
+		// More content than announced by x-amz-decoded-content-length.
+		return errMalformedEncoding
+	}
+	if err = cr.readTrailers(); err != nil {
+		return err
 	}
+	return io.EOF
+}
 
-	cr.offset = copy(buf, cr.buffer)
-	n += cr.offset
-	return n, err
+// readTrailers reads the trailing headers following the last chunk,
+// verifies their signature and stores them in cr.trailers.
+func (cr *s3ChunkedReader) readTrailers() error {
+	// The trailers are signed in their canonical form "<key>:<value>\n".
+	var canonical bytes.Buffer
+	var signature string
+	for {
+		line, err := cr.reader.ReadSlice('\n')
+		if err == io.EOF && len(line) == 0 {
+			// Some clients omit the final "\r\n".
+			break
+		}
+		if err == bufio.ErrBufferFull {
+			return errLineTooLong
+		}
+		if err != nil && err != io.EOF {
+			return err
+		}
+		line = trimTrailingWhitespace(line)
+		if len(line) == 0 {
+			break
+		}
+		if signature != "" {
+			// The signature is the last trailer.
+			return errMalformedEncoding
+		}
+
+		sep := bytes.IndexByte(line, ':')
+		if sep <= 0 {
+			return errMalformedEncoding
+		}
+		key := strings.ToLower(string(bytes.TrimSpace(line[:sep])))
+		value := string(bytes.TrimSpace(line[sep+1:]))
+		if key == trailerSignatureKey && !cr.unsigned {
+			signature = value
+			continue
+		}
+		if !cr.wantTrailers[key] {
+			return errMalformedEncoding
+		}
+		cr.trailers.Set(key, value)
+		canonical.WriteString(key + ":" + value + "\n")
+	}
+
+	if cr.unsigned {
+		return nil
+	}
+	if signature == "" {
+		return errMalformedEncoding
+	}
+	cr.chunkSHA256Writer.Write(canonical.Bytes())
+	newSignature := getTrailerChunkSignature(cr.cred, cr.seedSignature, cr.region, cr.seedDate, hex.EncodeToString(cr.chunkSHA256Writer.Sum(nil)))
+	cr.chunkSHA256Writer.Reset()
+	if !compareSignatureV4(signature, newSignature) {
+		return errSignatureMismatch
+	}
+	return nil
 }
 
 // readCRLF - check if reader only has '\r\n' CRLF character.
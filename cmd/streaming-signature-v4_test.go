@@ -22,8 +22,12 @@ import (
 	"bytes"
 	"fmt"
 	"io"
+	"io/ioutil"
+	"net/http"
 	"strings"
 	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
 )
 
 // Test read chunk line.
@@ -161,6 +165,60 @@ func TestReadCRLF(t *testing.T) {
 	}
 }
 
+// Test reading unsigned chunked payloads with trailers.
+func TestUnsignedChunkedReaderTrailers(t *testing.T) {
+	type testCase struct {
+		body string
+		// Decoded content length, -1 if unknown.
+		size        int64
+		expected    string
+		expectedCRC string
+		expectedErr error
+	}
+	tests := []testCase{
+		// Test - 1 valid payload.
+		{"5\r\nhello\r\n0\r\nx-amz-checksum-crc32:NhCmhg==\r\n\r\n", -1, "hello", "NhCmhg==", nil},
+		// Test - 2 valid payload read up to the decoded content length.
+		{"5\r\nhello\r\n0\r\nx-amz-checksum-crc32:NhCmhg==\r\n\r\n", 5, "hello", "NhCmhg==", nil},
+		// Test - 3 chunk extensions are ignored.
+		{"3;ext=1\r\nhel\r\n2\r\nlo\r\n0\r\nx-amz-checksum-crc32:NhCmhg==\r\n\r\n", 5, "hello", "NhCmhg==", nil},
+		// Test - 4 missing final CRLF.
+		{"5\r\nhello\r\n0\r\nx-amz-checksum-crc32:NhCmhg==\r\n", 5, "hello", "NhCmhg==", nil},
+		// Test - 5 trailer not announced.
+		{"5\r\nhello\r\n0\r\nx-amz-checksum-sha1:NhCmhg==\r\n\r\n", 5, "", "", errMalformedEncoding},
+		// Test - 6 more content than announced.
+		{"6\r\nhello!\r\n0\r\nx-amz-checksum-crc32:NhCmhg==\r\n\r\n", 5, "", "", errMalformedEncoding},
+		// Test - 7 truncated payload.
+		{"5\r\nhel", -1, "", "", io.ErrUnexpectedEOF},
+	}
+	for i, tt := range tests {
+		cr := &s3ChunkedReader{
+			reader:       bufio.NewReader(strings.NewReader(tt.body)),
+			unsigned:     true,
+			trailers:     make(http.Header),
+			wantTrailers: map[string]bool{xhttp.AmzChecksumCRC32: true},
+			remaining:    tt.size,
+		}
+		var r io.Reader = cr
+		if tt.size >= 0 {
+			r = io.LimitReader(cr, tt.size)
+		}
+		data, err := ioutil.ReadAll(r)
+		if err != tt.expectedErr {
+			t.Fatalf("Test %d: Expected %v, got %v", i+1, tt.expectedErr, err)
+		}
+		if err != nil {
+			continue
+		}
+		if string(data) != tt.expected {
+			t.Errorf("Test %d: Expected %q, got %q", i+1, tt.expected, data)
+		}
+		if crc := cr.trailers.Get(xhttp.AmzChecksumCRC32); crc != tt.expectedCRC {
+			t.Errorf("Test %d: Expected trailer %q, got %q", i+1, tt.expectedCRC, crc)
+		}
+	}
+}
+
 // Tests parsing hex number into its uint64 decimal equivalent.
 func TestParseHexUint(t *testing.T) {
 	type testCase struct {
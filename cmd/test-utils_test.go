@@ -816,6 +816,72 @@ func newTestStreamingSignedRequest(method, urlStr string, contentLength, chunkSi
 	return req, err
 }
 
+// Returns new HTTP request object with an aws-chunked body followed by the
+// given trailing headers. The chunks and trailers are signed with streaming
+// signature v4 if signed is true, else the payload is unsigned.
+func newTestStreamingTrailerRequest(method, urlStr string, dataLength, chunkSize int64, body io.ReadSeeker, accessKey, secretKey string, trailer map[string]string, signed bool) (*http.Request, error) {
+	req, err := newTestStreamingRequest(method, urlStr, dataLength, chunkSize, body)
+	if err != nil {
+		return nil, err
+	}
+
+	keys := make([]string, 0, len(trailer))
+	for k := range trailer {
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+	req.Header.Set(xhttp.AmzTrailer, strings.Join(keys, ","))
+	if signed {
+		req.Header.Set("x-amz-content-sha256", streamingContentSHA256Trailer)
+	} else {
+		req.Header.Set("x-amz-content-sha256", unsignedPayloadTrailer)
+	}
+
+	currTime := UTCNow()
+	signature, err := signStreamingRequest(req, accessKey, secretKey, currTime)
+	if err != nil {
+		return nil, err
+	}
+
+	cred := auth.Credentials{AccessKey: accessKey, SecretKey: secretKey}
+	var stream []byte
+	body.Seek(0, 0)
+	for {
+		buffer := make([]byte, chunkSize)
+		n, err := body.Read(buffer)
+		if err != nil && err != io.EOF {
+			return nil, err
+		}
+
+		if signed {
+			signature = getChunkSignature(cred, signature, globalServerRegion, currTime, getSHA256Hash(buffer[:n]))
+			stream = append(stream, []byte(fmt.Sprintf("%x", n)+";chunk-signature="+signature+"\r\n")...)
+		} else {
+			stream = append(stream, []byte(fmt.Sprintf("%x", n)+"\r\n")...)
+		}
+		if n <= 0 {
+			break
+		}
+		stream = append(stream, buffer[:n]...)
+		stream = append(stream, []byte("\r\n")...)
+	}
+
+	var canonical string
+	for _, k := range keys {
+		canonical += k + ":" + trailer[k] + "\n"
+		stream = append(stream, []byte(k+":"+trailer[k]+"\r\n")...)
+	}
+	if signed {
+		signature = getTrailerChunkSignature(cred, signature, globalServerRegion, currTime, getSHA256Hash([]byte(canonical)))
+		stream = append(stream, []byte(trailerSignatureKey+":"+signature+"\r\n")...)
+	}
+	stream = append(stream, []byte("\r\n")...)
+
+	req.Body = ioutil.NopCloser(bytes.NewReader(stream))
+	req.ContentLength = int64(len(stream))
+	return req, nil
+}
+
 // preSignV4 presign the request, in accordance with
 // http://docs.aws.amazon.com/AmazonS3/latest/API/sigv4-query-string-auth.html.
 func preSignV4(req *http.Request, accessKeyID, secretAccessKey string, expires int64) error {
@@ -1261,6 +1327,13 @@ func getHeadObjectURL(endPoint, bucketName, objectName string) string {
 	return makeTestTargetURL(endPoint, bucketName, objectName, url.Values{})
 }
 
+// return URL for getting the attributes of an object.
+func getGetObjectAttributesURL(endPoint, bucketName, objectName string) string {
+	queryValue := url.Values{}
+	queryValue.Set("attributes", "")
+	return makeTestTargetURL(endPoint, bucketName, objectName, queryValue)
+}
+
 // return url to be used while copying the object.
 func getCopyObjectURL(endPoint, bucketName, objectName string) string {
 	return makeTestTargetURL(endPoint, bucketName, objectName, url.Values{})
@@ -1329,6 +1402,30 @@ func getBucketLocationURL(endPoint, bucketName string) string {
 }
 
 // return URL For set/get lifecycle of the bucket.
+func getBucketLoggingURL(endPoint, bucketName string) (ret string) {
+	queryValue := url.Values{}
+	queryValue.Set("logging", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+func getBucketObjectLambdaURL(endPoint, bucketName string) (ret string) {
+	queryValue := url.Values{}
+	queryValue.Set("object-lambda", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+func getBucketWebsiteURL(endPoint, bucketName string) (ret string) {
+	queryValue := url.Values{}
+	queryValue.Set("website", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
+func getBucketCorsURL(endPoint, bucketName string) (ret string) {
+	queryValue := url.Values{}
+	queryValue.Set("cors", "")
+	return makeTestTargetURL(endPoint, bucketName, "", queryValue)
+}
+
 func getBucketLifecycleURL(endPoint, bucketName string) (ret string) {
 	queryValue := url.Values{}
 	queryValue.Set("lifecycle", "")
@@ -1920,6 +2017,9 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "HeadObject":
 			// Register HeadObject handler.
 			bucket.Methods("Head").Path("/{object:.+}").HandlerFunc(api.HeadObjectHandler)
+		case "GetObjectAttributes":
+			// Register GetObjectAttributes handler.
+			bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(api.GetObjectAttributesHandler).Queries("attributes", "")
 		case "GetObject":
 			// Register GetObject handler.
 			bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(api.GetObjectHandler)
@@ -1929,6 +2029,12 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "DeleteObject":
 			// Register Delete Object handler.
 			bucket.Methods(http.MethodDelete).Path("/{object:.+}").HandlerFunc(api.DeleteObjectHandler)
+		case "PutObjectExtract":
+			// Register PutObject with auto-extract handler.
+			bucket.Methods(http.MethodPut).Path("/{object:.+}").HeadersRegexp(xhttp.AmzSnowballExtract, "true").HandlerFunc(api.PutObjectExtractHandler)
+		case "RenameObject":
+			// Register Rename Object handler.
+			bucket.Methods(http.MethodPut).Path("/{object:.+}").HeadersRegexp(xhttp.MinIORenameSource, ".+").HandlerFunc(api.RenameObjectHandler)
 		case "CopyObject":
 			// Register Copy Object  handler.
 			bucket.Methods(http.MethodPut).Path("/{object:.+}").HeadersRegexp("X-Amz-Copy-Source", ".*?(\\/|%2F).*?").HandlerFunc(api.CopyObjectHandler)
@@ -1941,6 +2047,30 @@ func registerBucketLevelFunc(bucket *mux.Router, api objectAPIHandlers, apiFunct
 		case "GetBucketPolicy":
 			// Register Get Bucket policy HTTP Handler.
 			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketPolicyHandler).Queries("policy", "")
+		case "GetBucketObjectLambda":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketObjectLambdaHandler).Queries("object-lambda", "")
+		case "PutBucketObjectLambda":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketObjectLambdaHandler).Queries("object-lambda", "")
+		case "DeleteBucketObjectLambda":
+			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeleteBucketObjectLambdaHandler).Queries("object-lambda", "")
+		case "GetObjectLambda":
+			bucket.Methods(http.MethodGet).Path("/{object:.+}").HandlerFunc(api.GetObjectLambdaHandler).Queries("lambdaArn", "{lambdaArn:.+}")
+		case "GetBucketLogging":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketLoggingHandler).Queries("logging", "")
+		case "PutBucketLogging":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketLoggingHandler).Queries("logging", "")
+		case "GetBucketWebsite":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketWebsiteHandler).Queries("website", "")
+		case "PutBucketWebsite":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketWebsiteHandler).Queries("website", "")
+		case "DeleteBucketWebsite":
+			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeleteBucketWebsiteHandler).Queries("website", "")
+		case "GetBucketCors":
+			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketCorsHandler).Queries("cors", "")
+		case "PutBucketCors":
+			bucket.Methods(http.MethodPut).HandlerFunc(api.PutBucketCorsHandler).Queries("cors", "")
+		case "DeleteBucketCors":
+			bucket.Methods(http.MethodDelete).HandlerFunc(api.DeleteBucketCorsHandler).Queries("cors", "")
 		case "GetBucketLifecycle":
 			bucket.Methods(http.MethodGet).HandlerFunc(api.GetBucketLifecycleHandler).Queries("lifecycle", "")
 		case "PutBucketLifecycle":
@@ -2288,7 +2418,7 @@ func uploadTestObject(t *testing.T, apiRouter http.Handler, creds auth.Credentia
 				if etag == "" {
 					t.Fatalf("Unexpected empty etag")
 				}
-				cp = append(cp, CompletePart{partID, etag[1 : len(etag)-1]})
+				cp = append(cp, CompletePart{PartNumber: partID, ETag: etag[1 : len(etag)-1]})
 			} else {
 				t.Fatalf("Missing etag header")
 			}
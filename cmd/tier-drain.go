@@ -0,0 +1,114 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"fmt"
+	"sync/atomic"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+	"github.com/minio/minio/internal/logger"
+)
+
+// tierDrainStatus reports the progress of moving all content off a remote
+// tier before removing it.
+type tierDrainStatus struct {
+	Tier     string `json:"tier"`
+	Target   string `json:"target,omitempty"` // empty if content is recalled into this cluster
+	Objects  uint64 `json:"objects"`
+	Bytes    uint64 `json:"bytes"`
+	Failed   uint64 `json:"failed"`
+	Removed  bool   `json:"removed"`
+	Complete bool   `json:"complete"`
+	Error    string `json:"error,omitempty"`
+}
+
+// snapshot returns a copy of s safe to use while s is being updated by drainTier.
+func (s *tierDrainStatus) snapshot() tierDrainStatus {
+	return tierDrainStatus{
+		Tier:    s.Tier,
+		Target:  s.Target,
+		Objects: atomic.LoadUint64(&s.Objects),
+		Bytes:   atomic.LoadUint64(&s.Bytes),
+		Failed:  atomic.LoadUint64(&s.Failed),
+	}
+}
+
+// checkTierNotReferenced returns an error if the lifecycle configuration of
+// any bucket transitions objects to tierName.
+func checkTierNotReferenced(ctx context.Context, objAPI ObjectLayer, tierName string) error {
+	buckets, err := objAPI.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, bucket := range buckets {
+		lc, err := globalLifecycleSys.Get(bucket.Name)
+		if err != nil {
+			continue
+		}
+		for _, rule := range lc.Rules {
+			if rule.Transition.StorageClass == tierName || rule.NoncurrentVersionTransition.StorageClass == tierName {
+				err := errTierReferenced
+				err.Message = fmt.Sprintf("%s (bucket %s)", err.Message, bucket.Name)
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+// drainTier moves the content of every object version transitioned to
+// fromTier to toTier, or back into this cluster if toTier is empty. Progress
+// is recorded in status, versions that could not be moved are counted as
+// failed and logged.
+func drainTier(ctx context.Context, z *erasureServerPools, fromTier, toTier string, status *tierDrainStatus) error {
+	buckets, err := z.ListBuckets(ctx)
+	if err != nil {
+		return err
+	}
+	for _, bucket := range buckets {
+		objInfoCh := make(chan ObjectInfo)
+		if err := z.Walk(ctx, bucket.Name, "", objInfoCh, ObjectOptions{WalkVersions: true}); err != nil {
+			return err
+		}
+		for oi := range objInfoCh {
+			if oi.TransitionedObject.Status != lifecycle.TransitionComplete || oi.TransitionedObject.Tier != fromTier {
+				continue
+			}
+			versionID := oi.VersionID
+			if versionID == "" {
+				versionID = nullVersionID
+			}
+			err := z.MoveTransitionedObject(ctx, bucket.Name, oi.Name, fromTier, toTier, ObjectOptions{
+				VersionID: versionID,
+			})
+			if err != nil {
+				logger.LogIf(ctx, fmt.Errorf("Unable to move %s/%s(%s) off %s tier: %w", bucket.Name, oi.Name, versionID, fromTier, err))
+				atomic.AddUint64(&status.Failed, 1)
+				continue
+			}
+			atomic.AddUint64(&status.Objects, 1)
+			atomic.AddUint64(&status.Bytes, uint64(oi.Size))
+		}
+		if err := ctx.Err(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
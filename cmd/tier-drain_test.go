@@ -0,0 +1,191 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"net/http"
+	"os"
+	"testing"
+
+	"github.com/minio/minio/internal/bucket/lifecycle"
+)
+
+func TestDrainTier(t *testing.T) {
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	obj, fsDirs, err := prepareErasure16(ctx)
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer obj.Shutdown(context.Background())
+	defer removeRoots(fsDirs)
+	newAllSubsystems()
+	setObjectLayer(obj)
+	defer resetGlobalObjectAPI()
+
+	oldTierConfigMgr := globalTierConfigMgr
+	globalTierConfigMgr = NewTierConfigMgr()
+	defer func() { globalTierConfigMgr = oldTierConfigMgr }()
+	oldTierMetrics := globalTierMetrics
+	globalTierMetrics = newTierMetrics()
+	defer func() { globalTierMetrics = oldTierMetrics }()
+
+	tiers := make(map[string]*warmBackendFS)
+	for _, tier := range []string{"WARM-A", "WARM-B"} {
+		dir, err := ioutil.TempDir("", "minio-tier-drain-")
+		if err != nil {
+			t.Fatal(err)
+		}
+		defer os.RemoveAll(dir)
+		conf := tierFS{Name: tier, Path: dir}
+		w, err := newWarmBackendFS(conf)
+		if err != nil {
+			t.Fatal(err)
+		}
+		tiers[tier] = w
+		globalTierConfigMgr.FS[tier] = conf
+		globalTierConfigMgr.drivercache[tier] = w
+	}
+
+	bucket := "bucket"
+	if err = obj.MakeBucketWithLocation(ctx, bucket, BucketOptions{VersioningEnabled: true}); err != nil {
+		t.Fatal(err)
+	}
+
+	// A single part object and a multipart object.
+	contents := map[string][]byte{
+		"single":    bytes.Repeat([]byte("a"), 1024),
+		"multipart": bytes.Repeat([]byte("b"), 5*1024*1024+1024),
+	}
+	objInfos := make(map[string]ObjectInfo)
+	opts := ObjectOptions{Versioned: true}
+	objInfos["single"], err = obj.PutObject(ctx, bucket, "single", mustGetPutObjReader(t, bytes.NewReader(contents["single"]), 1024, "", ""), opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	uploadID, err := obj.NewMultipartUpload(ctx, bucket, "multipart", opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var parts []CompletePart
+	for i, part := range [][]byte{contents["multipart"][:5*1024*1024], contents["multipart"][5*1024*1024:]} {
+		pi, err := obj.PutObjectPart(ctx, bucket, "multipart", uploadID, i+1, mustGetPutObjReader(t, bytes.NewReader(part), int64(len(part)), "", ""), opts)
+		if err != nil {
+			t.Fatal(err)
+		}
+		parts = append(parts, CompletePart{PartNumber: pi.PartNumber, ETag: pi.ETag})
+	}
+	objInfos["multipart"], err = obj.CompleteMultipartUpload(ctx, bucket, "multipart", uploadID, parts, opts)
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	for object, oi := range objInfos {
+		err = obj.TransitionObject(ctx, bucket, object, ObjectOptions{
+			Transition: TransitionOptions{Tier: "WARM-A", ETag: oi.ETag},
+			VersionID:  oi.VersionID,
+			Versioned:  true,
+			MTime:      oi.ModTime,
+		})
+		if err != nil {
+			t.Fatal(err)
+		}
+	}
+
+	checkObjects := func(tier string) {
+		t.Helper()
+		for object, oi := range objInfos {
+			goi, err := obj.GetObjectInfo(ctx, bucket, object, ObjectOptions{VersionID: oi.VersionID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			if tier != "" && (goi.TransitionedObject.Status != lifecycle.TransitionComplete || goi.TransitionedObject.Tier != tier) {
+				t.Fatalf("Expected %s to be transitioned to %s, got %#v", object, tier, goi.TransitionedObject)
+			}
+			if tier == "" && goi.TransitionedObject.Status != "" {
+				t.Fatalf("Expected %s to be recalled, got %#v", object, goi.TransitionedObject)
+			}
+			if goi.ETag != oi.ETag || !goi.ModTime.Equal(oi.ModTime) || goi.VersionID != oi.VersionID {
+				t.Fatalf("Expected %s to retain its version, got %s %s %s", object, goi.ETag, goi.ModTime, goi.VersionID)
+			}
+			gr, err := obj.GetObjectNInfo(ctx, bucket, object, nil, http.Header{}, readLock, ObjectOptions{VersionID: oi.VersionID})
+			if err != nil {
+				t.Fatal(err)
+			}
+			data, err := ioutil.ReadAll(gr)
+			gr.Close()
+			if err != nil {
+				t.Fatal(err)
+			}
+			if !bytes.Equal(data, contents[object]) {
+				t.Fatalf("Unexpected content of %s", object)
+			}
+		}
+	}
+	checkObjects("WARM-A")
+	totalBytes := uint64(len(contents["single"]) + len(contents["multipart"]))
+	checkStats := func(tier string, want tierStats) {
+		t.Helper()
+		if got := globalTierMetrics.report()[tier]; got != want {
+			t.Fatalf("Expected %s stats %#v, got %#v", tier, want, got)
+		}
+	}
+	checkStats("WARM-A", tierStats{})
+
+	z := obj.(*erasureServerPools)
+	status := &tierDrainStatus{Tier: "WARM-A", Target: "WARM-B"}
+	if err = drainTier(ctx, z, "WARM-A", "WARM-B", status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Objects != 2 || status.Failed != 0 || status.Bytes != totalBytes {
+		t.Fatalf("Unexpected drain status %#v", status)
+	}
+	checkObjects("WARM-B")
+	checkStats("WARM-A", tierStats{})
+	checkStats("WARM-B", tierStats{})
+	if inUse, err := tiers["WARM-A"].InUse(ctx); err != nil || inUse {
+		t.Fatalf("Expected WARM-A to be drained, got %v, %v", inUse, err)
+	}
+	if err = globalTierConfigMgr.Remove(ctx, "WARM-A"); err != nil {
+		t.Fatal(err)
+	}
+	if err = globalTierConfigMgr.Remove(ctx, "WARM-B"); err != errTierBackendInUse {
+		t.Fatalf("Expected %v, got %v", errTierBackendInUse, err)
+	}
+
+	// Recall the content back into the cluster.
+	status = &tierDrainStatus{Tier: "WARM-B"}
+	if err = drainTier(ctx, z, "WARM-B", "", status); err != nil {
+		t.Fatal(err)
+	}
+	if status.Objects != 2 || status.Failed != 0 {
+		t.Fatalf("Unexpected drain status %#v", status)
+	}
+	checkObjects("")
+	checkStats("WARM-B", tierStats{})
+	if inUse, err := tiers["WARM-B"].InUse(ctx); err != nil || inUse {
+		t.Fatalf("Expected WARM-B to be drained, got %v, %v", inUse, err)
+	}
+	if err = globalTierConfigMgr.Remove(ctx, "WARM-B"); err != nil {
+		t.Fatal(err)
+	}
+}
@@ -19,8 +19,10 @@ package cmd
 
 import (
 	"encoding/json"
+	"fmt"
 	"io"
 	"net/http"
+	"time"
 
 	"github.com/gorilla/mux"
 	jsoniter "github.com/json-iterator/go"
@@ -60,6 +62,24 @@ var (
 		Message:    "Invalid remote tier credentials",
 		StatusCode: http.StatusBadRequest,
 	}
+	// error returned when remote tier is used by a bucket lifecycle configuration
+	errTierReferenced = AdminError{
+		Code:       "XMinioAdminTierReferenced",
+		Message:    "Specified remote tier is used by a bucket lifecycle configuration",
+		StatusCode: http.StatusConflict,
+	}
+	// error returned when credentials are edited of a tier without credentials
+	errTierNoCredentials = AdminError{
+		Code:       "XMinioAdminTierNoCredentials",
+		Message:    "Specified remote tier has no credentials",
+		StatusCode: http.StatusBadRequest,
+	}
+	// error returned when the target of a remote tier removal is invalid
+	errTierInvalidTarget = AdminError{
+		Code:       "XMinioAdminTierInvalidTarget",
+		Message:    "Target remote tier must be another existing remote tier",
+		StatusCode: http.StatusBadRequest,
+	}
 )
 
 func (api adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Request) {
@@ -85,9 +105,19 @@ func (api adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	var cfg madmin.TierConfig
+	var (
+		cfg   madmin.TierConfig
+		fsCfg tierConfigFS
+	)
 	var json = jsoniter.ConfigCompatibleWithStandardLibrary
-	if err := json.Unmarshal(reqBytes, &cfg); err != nil {
+	// Filesystem tiers are not known to madmin.TierConfig.
+	isFS := json.Unmarshal(reqBytes, &fsCfg) == nil && fsCfg.Type == tierTypeFS
+	if isFS {
+		err = fsCfg.Validate()
+	} else {
+		err = json.Unmarshal(reqBytes, &cfg)
+	}
+	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
 	}
@@ -98,7 +128,11 @@ func (api adminAPIHandlers) AddTierHandler(w http.ResponseWriter, r *http.Reques
 		return
 	}
 
-	err = globalTierConfigMgr.Add(ctx, cfg)
+	if isFS {
+		err = globalTierConfigMgr.AddFS(ctx, *fsCfg.FS)
+	} else {
+		err = globalTierConfigMgr.Add(ctx, cfg)
+	}
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
 		return
@@ -130,7 +164,57 @@ func (api adminAPIHandlers) ListTierHandler(w http.ResponseWriter, r *http.Reque
 		return
 	}
 
-	tiers := globalTierConfigMgr.ListTiers()
+	var tiers []interface{}
+	for _, tier := range globalTierConfigMgr.ListTiers() {
+		tiers = append(tiers, tier)
+	}
+	for _, tier := range globalTierConfigMgr.ListFSTiers() {
+		tiers = append(tiers, tier)
+	}
+	data, err := json.Marshal(tiers)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	writeSuccessResponseJSON(w, data)
+}
+
+// TierStatsHandler - GET /minio/admin/v3/tier-stats
+// ----------
+// Returns the usage of each remote tier as of the last scan, with its
+// transition and restore statistics summed over all nodes of the cluster.
+func (api adminAPIHandlers) TierStatsHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "TierStats")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.ListTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+
+	usage, err := loadTierUsageFromBackend(ctx, objAPI)
+	if err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	tiers := make(map[string]tierInfo, len(usage))
+	for tier, u := range usage {
+		tiers[tier] = tierInfo{tierUsage: u}
+	}
+	for tier, ts := range globalNotificationSys.GetClusterTierStats(ctx) {
+		ti := tiers[tier]
+		ti.tierStats = ts
+		tiers[tier] = ti
+	}
+
 	data, err := json.Marshal(tiers)
 	if err != nil {
 		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
@@ -191,3 +275,95 @@ func (api adminAPIHandlers) EditTierHandler(w http.ResponseWriter, r *http.Reque
 
 	writeSuccessNoContent(w)
 }
+
+// RemoveTierHandler - DELETE /minio/admin/v3/tier/{tier}?target={target}
+// ----------
+// Moves the content of every object version transitioned to tier to the
+// remote tier target, or back into this cluster if target is empty, then
+// removes the tier. Streams back the progress of the operation as JSON.
+func (api adminAPIHandlers) RemoveTierHandler(w http.ResponseWriter, r *http.Request) {
+	ctx := newContext(r, w, "RemoveTier")
+
+	defer logger.AuditLog(ctx, w, r, mustGetClaimsFromToken(r))
+
+	if !globalIsErasure {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	objAPI, _ := validateAdminReq(ctx, w, r, iampolicy.SetTierAction)
+	if objAPI == nil || globalNotificationSys == nil || globalTierConfigMgr == nil {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrServerNotInitialized), r.URL)
+		return
+	}
+	z, ok := objAPI.(*erasureServerPools)
+	if !ok {
+		writeErrorResponseJSON(ctx, w, errorCodes.ToAPIErr(ErrNotImplemented), r.URL)
+		return
+	}
+
+	vars := mux.Vars(r)
+	tierName := vars["tier"]
+	target := r.Form.Get("target")
+
+	// Refresh from the disk in case we had missed notifications about edits from peers.
+	if err := globalTierConfigMgr.Reload(ctx, objAPI); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+	if !globalTierConfigMgr.IsTierValid(tierName) {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errTierNotFound), r.URL)
+		return
+	}
+	if target != "" && (target == tierName || !globalTierConfigMgr.IsTierValid(target)) {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, errTierInvalidTarget), r.URL)
+		return
+	}
+	// Objects must not be transitioned to the tier while it is drained.
+	if err := checkTierNotReferenced(ctx, objAPI, tierName); err != nil {
+		writeErrorResponseJSON(ctx, w, toAdminAPIErr(ctx, err), r.URL)
+		return
+	}
+
+	status := &tierDrainStatus{Tier: tierName, Target: target}
+	doneCh := make(chan error, 1)
+	go func() {
+		doneCh <- drainTier(ctx, z, tierName, target, status)
+	}()
+
+	ticker := time.NewTicker(time.Second)
+	defer ticker.Stop()
+
+	enc := json.NewEncoder(w)
+	for {
+		select {
+		case <-ticker.C:
+			// Report progress, this also keeps the client connected.
+			if err := enc.Encode(status.snapshot()); err != nil {
+				return
+			}
+			w.(http.Flusher).Flush()
+		case err := <-doneCh:
+			result := status.snapshot()
+			if err == nil && result.Failed > 0 {
+				err = fmt.Errorf("unable to move %d object versions off tier %s", result.Failed, tierName)
+			}
+			if err == nil {
+				err = globalTierConfigMgr.Remove(ctx, tierName)
+			}
+			if err == nil {
+				err = globalTierConfigMgr.Save(ctx, objAPI)
+			}
+			if err == nil {
+				globalNotificationSys.LoadTransitionTierConfig(ctx)
+				result.Removed = true
+			} else {
+				result.Error = err.Error()
+			}
+			result.Complete = true
+			enc.Encode(result)
+			w.(http.Flusher).Flush()
+			return
+		}
+	}
+}
@@ -166,11 +166,7 @@ func deleteObjectFromRemoteTier(ctx context.Context, objName, rvID, tierName str
 	if err != nil {
 		return err
 	}
-	err = w.Remove(ctx, objName, remoteVersionID(rvID))
-	if err != nil {
-		return err
-	}
-	return nil
+	return w.Remove(ctx, objName, remoteVersionID(rvID))
 }
 
 func (jd *tierDiskJournal) deletePending(ctx context.Context) {
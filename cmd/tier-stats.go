@@ -0,0 +1,130 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"sync"
+	"sync/atomic"
+)
+
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore tierMetrics tierInfo
+
+// tierStats holds the transition and restore statistics of a remote tier
+// on this node since it started. The content of a tier is accounted by
+// the scanner, see tierUsage.
+type tierStats struct {
+	InFlight           int64  `json:"transitionsInFlight"`
+	TransitionFailures uint64 `json:"transitionFailures"`
+	RestoreRequests    uint64 `json:"restoreRequests"`
+	RestoreFailures    uint64 `json:"restoreFailures"`
+}
+
+func (ts *tierStats) add(o tierStats) {
+	ts.InFlight += o.InFlight
+	ts.TransitionFailures += o.TransitionFailures
+	ts.RestoreRequests += o.RestoreRequests
+	ts.RestoreFailures += o.RestoreFailures
+}
+
+// tierInfo reports the usage of a remote tier, as of the last scan,
+// along with its statistics summed over all nodes.
+type tierInfo struct {
+	tierUsage
+	tierStats
+}
+
+// tierStatsMap holds tierStats by tier name.
+type tierStatsMap map[string]tierStats
+
+func (m tierStatsMap) merge(o tierStatsMap) {
+	for tier, ts := range o {
+		st := m[tier]
+		st.add(ts)
+		m[tier] = st
+	}
+}
+
+// tierMetrics tracks tierStats of all remote tiers on this node.
+type tierMetrics struct {
+	sync.RWMutex
+	tiers map[string]*tierStats
+}
+
+func newTierMetrics() *tierMetrics {
+	return &tierMetrics{
+		tiers: make(map[string]*tierStats),
+	}
+}
+
+func (t *tierMetrics) get(tier string) *tierStats {
+	t.RLock()
+	ts, ok := t.tiers[tier]
+	t.RUnlock()
+	if ok {
+		return ts
+	}
+
+	t.Lock()
+	defer t.Unlock()
+	if ts, ok = t.tiers[tier]; !ok {
+		ts = &tierStats{}
+		t.tiers[tier] = ts
+	}
+	return ts
+}
+
+// transitionStarted records a transition of an object to tier in flight.
+func (t *tierMetrics) transitionStarted(tier string) {
+	atomic.AddInt64(&t.get(tier).InFlight, 1)
+}
+
+// transitionDone records the outcome of a transition to tier started by
+// transitionStarted.
+func (t *tierMetrics) transitionDone(tier string, err error) {
+	ts := t.get(tier)
+	atomic.AddInt64(&ts.InFlight, -1)
+	if err != nil {
+		atomic.AddUint64(&ts.TransitionFailures, 1)
+	}
+}
+
+// restoreDone records a restore request of an object from tier.
+func (t *tierMetrics) restoreDone(tier string, err error) {
+	ts := t.get(tier)
+	atomic.AddUint64(&ts.RestoreRequests, 1)
+	if err != nil {
+		atomic.AddUint64(&ts.RestoreFailures, 1)
+	}
+}
+
+// report returns a snapshot of the statistics of all tiers.
+func (t *tierMetrics) report() tierStatsMap {
+	t.RLock()
+	defer t.RUnlock()
+	m := make(tierStatsMap, len(t.tiers))
+	for tier, ts := range t.tiers {
+		m[tier] = tierStats{
+			InFlight:           atomic.LoadInt64(&ts.InFlight),
+			TransitionFailures: atomic.LoadUint64(&ts.TransitionFailures),
+			RestoreRequests:    atomic.LoadUint64(&ts.RestoreRequests),
+			RestoreFailures:    atomic.LoadUint64(&ts.RestoreFailures),
+		}
+	}
+	return m
+}
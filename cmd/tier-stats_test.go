@@ -0,0 +1,60 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"errors"
+	"testing"
+)
+
+func TestTierMetrics(t *testing.T) {
+	m := newTierMetrics()
+	m.transitionStarted("WARM")
+	m.transitionStarted("WARM")
+	m.transitionStarted("COLD")
+	m.transitionDone("WARM", nil)
+	m.transitionDone("COLD", errors.New("failed"))
+	m.restoreDone("WARM", nil)
+	m.restoreDone("WARM", errors.New("failed"))
+
+	want := tierStatsMap{
+		"WARM": {InFlight: 1, RestoreRequests: 2, RestoreFailures: 1},
+		"COLD": {TransitionFailures: 1},
+	}
+	got := m.report()
+	if len(got) != len(want) {
+		t.Fatalf("Expected %d tiers, got %d", len(want), len(got))
+	}
+	for tier, ts := range want {
+		if got[tier] != ts {
+			t.Fatalf("Expected %s stats %#v, got %#v", tier, ts, got[tier])
+		}
+	}
+
+	// Statistics of peers are summed per tier.
+	got.merge(tierStatsMap{
+		"WARM": {InFlight: 2, TransitionFailures: 1},
+		"HOT":  {RestoreRequests: 1},
+	})
+	if ts := got["WARM"]; ts.InFlight != 3 || ts.TransitionFailures != 1 || ts.RestoreRequests != 2 {
+		t.Fatalf("Unexpected merged WARM stats %#v", ts)
+	}
+	if ts := got["HOT"]; ts.RestoreRequests != 1 {
+		t.Fatalf("Unexpected merged HOT stats %#v", ts)
+	}
+}
@@ -34,7 +34,8 @@ import (
 	"github.com/minio/minio/internal/kms"
 )
 
-//go:generate msgp -file $GOFILE
+//go:generate msgp -file $GOFILE -unexported
+//msgp:ignore tierConfigFS
 
 var (
 	errTierInsufficientCreds = errors.New("insufficient tier credentials supplied")
@@ -51,12 +52,50 @@ const (
 // tierConfigPath refers to remote tier config object name
 var tierConfigPath = path.Join(minioConfigPrefix, tierConfigFile)
 
+// tierTypeFS is the tier type of filesystem tiers, madmin.TierType has no
+// such type so these tiers are kept apart from madmin.TierConfig.
+const tierTypeFS = "fs"
+
+// tierFS is the configuration of a warm tier backed by a directory on a
+// local or NFS mounted filesystem.
+type tierFS struct {
+	Name   string `json:",omitempty"`
+	Path   string `json:",omitempty"`
+	Prefix string `json:",omitempty"`
+}
+
+// tierConfigFS is the admin API representation of a filesystem tier,
+// laid out like madmin.TierConfig.
+type tierConfigFS struct {
+	Version string
+	Type    string
+	Name    string
+	FS      *tierFS `json:",omitempty"`
+}
+
+// Validate validates the filesystem tier config, like
+// madmin.TierConfig.UnmarshalJSON does for the other tier types.
+func (cfg *tierConfigFS) Validate() error {
+	if cfg.Version != madmin.TierConfigV1 {
+		return madmin.ErrTierInvalidConfigVersion
+	}
+	if cfg.FS == nil {
+		return madmin.ErrTierInvalidConfig
+	}
+	if cfg.Name == "" {
+		return madmin.ErrTierNameEmpty
+	}
+	cfg.FS.Name = cfg.Name
+	return nil
+}
+
 // TierConfigMgr holds the collection of remote tiers configured in this deployment.
 type TierConfigMgr struct {
 	sync.RWMutex `msg:"-"`
 	drivercache  map[string]WarmBackend `msg:"-"`
 
 	Tiers map[string]madmin.TierConfig `json:"tiers"`
+	FS    map[string]tierFS            `json:"fs"`
 }
 
 // IsTierValid returns true if there exists a remote tier by name tierName,
@@ -76,6 +115,9 @@ func (config *TierConfigMgr) isTierNameInUse(tierName string) (madmin.TierType,
 	if t, ok := config.Tiers[tierName]; ok {
 		return t.Type, true
 	}
+	if _, ok := config.FS[tierName]; ok {
+		return madmin.Unsupported, true
+	}
 	return madmin.Unsupported, false
 }
 
@@ -84,40 +126,81 @@ func (config *TierConfigMgr) Add(ctx context.Context, tier madmin.TierConfig) er
 	config.Lock()
 	defer config.Unlock()
 
-	// check if tier name is in all caps
+	d, err := config.newTier(ctx, tier.Name, func() (WarmBackend, error) {
+		return newWarmBackend(ctx, tier)
+	})
+	if err != nil {
+		return err
+	}
+
+	config.Tiers[tier.Name] = tier
+	config.drivercache[tier.Name] = d
+
+	return nil
+}
 
-	tierName := tier.Name
+// AddFS adds the filesystem tier to config if it passes all validations.
+func (config *TierConfigMgr) AddFS(ctx context.Context, tier tierFS) error {
+	config.Lock()
+	defer config.Unlock()
+
+	d, err := config.newTier(ctx, tier.Name, func() (WarmBackend, error) {
+		return newFSWarmBackend(ctx, tier)
+	})
+	if err != nil {
+		return err
+	}
+
+	config.FS[tier.Name] = tier
+	config.drivercache[tier.Name] = d
+
+	return nil
+}
+
+// newTier validates the name of a new tier and returns its WarmBackend
+// created by newBackend, provided the backend is not in use.
+func (config *TierConfigMgr) newTier(ctx context.Context, tierName string, newBackend func() (WarmBackend, error)) (WarmBackend, error) {
+	// check if tier name is in all caps
 	if tierName != strings.ToUpper(tierName) {
-		return errTierNameNotUppercase
+		return nil, errTierNameNotUppercase
 	}
 
 	// check if tier name already in use
 	if _, exists := config.isTierNameInUse(tierName); exists {
-		return errTierAlreadyExists
+		return nil, errTierAlreadyExists
 	}
 
-	d, err := newWarmBackend(ctx, tier)
+	d, err := newBackend()
 	if err != nil {
-		return err
+		return nil, err
 	}
 	// Check if warmbackend is in use by other MinIO tenants
 	inUse, err := d.InUse(ctx)
 	if err != nil {
-		return err
+		return nil, err
 	}
 	if inUse {
-		return errTierBackendInUse
+		return nil, errTierBackendInUse
 	}
+	return d, nil
+}
 
-	config.Tiers[tierName] = tier
-	config.drivercache[tierName] = d
-
-	return nil
+// newDriver instantiates the WarmBackend of the tier by tierName. N B this
+// function is meant for internal use, where the caller is expected to take
+// appropriate locks.
+func (config *TierConfigMgr) newDriver(ctx context.Context, tierName string) (WarmBackend, error) {
+	if t, ok := config.Tiers[tierName]; ok {
+		return newWarmBackend(ctx, t)
+	}
+	if t, ok := config.FS[tierName]; ok {
+		return newFSWarmBackend(ctx, t)
+	}
+	return nil, errTierNotFound
 }
 
 // Empty returns if tier targets are empty
 func (config *TierConfigMgr) Empty() bool {
-	return len(config.ListTiers()) == 0
+	return len(config.ListTiers()) == 0 && len(config.ListFSTiers()) == 0
 }
 
 // ListTiers lists remote tiers configured in this deployment.
@@ -135,6 +218,24 @@ func (config *TierConfigMgr) ListTiers() []madmin.TierConfig {
 	return tierCfgs
 }
 
+// ListFSTiers lists the filesystem tiers configured in this deployment.
+func (config *TierConfigMgr) ListFSTiers() []tierConfigFS {
+	config.RLock()
+	defer config.RUnlock()
+
+	var tierCfgs []tierConfigFS
+	for _, tier := range config.FS {
+		tier := tier
+		tierCfgs = append(tierCfgs, tierConfigFS{
+			Version: madmin.TierConfigV1,
+			Type:    tierTypeFS,
+			Name:    tier.Name,
+			FS:      &tier,
+		})
+	}
+	return tierCfgs
+}
+
 // Edit replaces the credentials of the remote tier specified by tierName with creds.
 func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds madmin.TierCreds) error {
 	config.Lock()
@@ -145,6 +246,9 @@ func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds ma
 	if !exists {
 		return errTierNotFound
 	}
+	if _, ok := config.FS[tierName]; ok {
+		return errTierNoCredentials
+	}
 
 	newCfg := config.Tiers[tierName]
 	switch tierType {
@@ -182,6 +286,38 @@ func (config *TierConfigMgr) Edit(ctx context.Context, tierName string, creds ma
 	return nil
 }
 
+// Remove removes the remote tier specified by tierName, provided no content
+// remains on the tier, see drainTier.
+func (config *TierConfigMgr) Remove(ctx context.Context, tierName string) error {
+	config.Lock()
+	defer config.Unlock()
+
+	if _, exists := config.isTierNameInUse(tierName); !exists {
+		return errTierNotFound
+	}
+
+	d, ok := config.drivercache[tierName]
+	if !ok {
+		var err error
+		d, err = config.newDriver(ctx, tierName)
+		if err != nil {
+			return err
+		}
+	}
+	inUse, err := d.InUse(ctx)
+	if err != nil {
+		return err
+	}
+	if inUse {
+		return errTierBackendInUse
+	}
+
+	delete(config.Tiers, tierName)
+	delete(config.FS, tierName)
+	delete(config.drivercache, tierName)
+	return nil
+}
+
 // Bytes returns msgpack encoded config with format and version headers.
 func (config *TierConfigMgr) Bytes() ([]byte, error) {
 	config.RLock()
@@ -209,11 +345,7 @@ func (config *TierConfigMgr) getDriver(tierName string) (d WarmBackend, err erro
 	}
 
 	// Initialize driver from tier config matching tierName
-	t, ok := config.Tiers[tierName]
-	if !ok {
-		return nil, errTierNotFound
-	}
-	d, err = newWarmBackend(context.TODO(), t)
+	d, err = config.newDriver(context.TODO(), tierName)
 	if err != nil {
 		return nil, err
 	}
@@ -295,10 +427,16 @@ func (config *TierConfigMgr) Reload(ctx context.Context, objAPI ObjectLayer) err
 	for k := range config.Tiers {
 		delete(config.Tiers, k)
 	}
+	for k := range config.FS {
+		delete(config.FS, k)
+	}
 	// Copy over the new tier configs
 	for tier, cfg := range newConfig.Tiers {
 		config.Tiers[tier] = cfg
 	}
+	for tier, cfg := range newConfig.FS {
+		config.FS[tier] = cfg
+	}
 
 	return nil
 }
@@ -323,6 +461,7 @@ func NewTierConfigMgr() *TierConfigMgr {
 	return &TierConfigMgr{
 		drivercache: make(map[string]WarmBackend),
 		Tiers:       make(map[string]madmin.TierConfig),
+		FS:          make(map[string]tierFS),
 	}
 }
 
@@ -371,6 +510,9 @@ func (config *TierConfigMgr) Reset() {
 	for k := range config.Tiers {
 		delete(config.Tiers, k)
 	}
+	for k := range config.FS {
+		delete(config.FS, k)
+	}
 	config.Unlock()
 
 }
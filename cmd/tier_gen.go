@@ -55,6 +55,71 @@ func (z *TierConfigMgr) DecodeMsg(dc *msgp.Reader) (err error) {
 				}
 				z.Tiers[za0001] = za0002
 			}
+		case "FS":
+			var zb0003 uint32
+			zb0003, err = dc.ReadMapHeader()
+			if err != nil {
+				err = msgp.WrapError(err, "FS")
+				return
+			}
+			if z.FS == nil {
+				z.FS = make(map[string]tierFS, zb0003)
+			} else if len(z.FS) > 0 {
+				for key := range z.FS {
+					delete(z.FS, key)
+				}
+			}
+			for zb0003 > 0 {
+				zb0003--
+				var za0003 string
+				var za0004 tierFS
+				za0003, err = dc.ReadString()
+				if err != nil {
+					err = msgp.WrapError(err, "FS")
+					return
+				}
+				var zb0004 uint32
+				zb0004, err = dc.ReadMapHeader()
+				if err != nil {
+					err = msgp.WrapError(err, "FS", za0003)
+					return
+				}
+				for zb0004 > 0 {
+					zb0004--
+					field, err = dc.ReadMapKeyPtr()
+					if err != nil {
+						err = msgp.WrapError(err, "FS", za0003)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Name":
+						za0004.Name, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "FS", za0003, "Name")
+							return
+						}
+					case "Path":
+						za0004.Path, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "FS", za0003, "Path")
+							return
+						}
+					case "Prefix":
+						za0004.Prefix, err = dc.ReadString()
+						if err != nil {
+							err = msgp.WrapError(err, "FS", za0003, "Prefix")
+							return
+						}
+					default:
+						err = dc.Skip()
+						if err != nil {
+							err = msgp.WrapError(err, "FS", za0003)
+							return
+						}
+					}
+				}
+				z.FS[za0003] = za0004
+			}
 		default:
 			err = dc.Skip()
 			if err != nil {
@@ -68,9 +133,9 @@ func (z *TierConfigMgr) DecodeMsg(dc *msgp.Reader) (err error) {
 
 // EncodeMsg implements msgp.Encodable
 func (z *TierConfigMgr) EncodeMsg(en *msgp.Writer) (err error) {
-	// map header, size 1
+	// map header, size 2
 	// write "Tiers"
-	err = en.Append(0x81, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
+	err = en.Append(0x82, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
 	if err != nil {
 		return
 	}
@@ -91,15 +156,63 @@ func (z *TierConfigMgr) EncodeMsg(en *msgp.Writer) (err error) {
 			return
 		}
 	}
+	// write "FS"
+	err = en.Append(0xa2, 0x46, 0x53)
+	if err != nil {
+		return
+	}
+	err = en.WriteMapHeader(uint32(len(z.FS)))
+	if err != nil {
+		err = msgp.WrapError(err, "FS")
+		return
+	}
+	for za0003, za0004 := range z.FS {
+		err = en.WriteString(za0003)
+		if err != nil {
+			err = msgp.WrapError(err, "FS")
+			return
+		}
+		// map header, size 3
+		// write "Name"
+		err = en.Append(0x83, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(za0004.Name)
+		if err != nil {
+			err = msgp.WrapError(err, "FS", za0003, "Name")
+			return
+		}
+		// write "Path"
+		err = en.Append(0xa4, 0x50, 0x61, 0x74, 0x68)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(za0004.Path)
+		if err != nil {
+			err = msgp.WrapError(err, "FS", za0003, "Path")
+			return
+		}
+		// write "Prefix"
+		err = en.Append(0xa6, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78)
+		if err != nil {
+			return
+		}
+		err = en.WriteString(za0004.Prefix)
+		if err != nil {
+			err = msgp.WrapError(err, "FS", za0003, "Prefix")
+			return
+		}
+	}
 	return
 }
 
 // MarshalMsg implements msgp.Marshaler
 func (z *TierConfigMgr) MarshalMsg(b []byte) (o []byte, err error) {
 	o = msgp.Require(b, z.Msgsize())
-	// map header, size 1
+	// map header, size 2
 	// string "Tiers"
-	o = append(o, 0x81, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
+	o = append(o, 0x82, 0xa5, 0x54, 0x69, 0x65, 0x72, 0x73)
 	o = msgp.AppendMapHeader(o, uint32(len(z.Tiers)))
 	for za0001, za0002 := range z.Tiers {
 		o = msgp.AppendString(o, za0001)
@@ -109,6 +222,22 @@ func (z *TierConfigMgr) MarshalMsg(b []byte) (o []byte, err error) {
 			return
 		}
 	}
+	// string "FS"
+	o = append(o, 0xa2, 0x46, 0x53)
+	o = msgp.AppendMapHeader(o, uint32(len(z.FS)))
+	for za0003, za0004 := range z.FS {
+		o = msgp.AppendString(o, za0003)
+		// map header, size 3
+		// string "Name"
+		o = append(o, 0x83, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+		o = msgp.AppendString(o, za0004.Name)
+		// string "Path"
+		o = append(o, 0xa4, 0x50, 0x61, 0x74, 0x68)
+		o = msgp.AppendString(o, za0004.Path)
+		// string "Prefix"
+		o = append(o, 0xa6, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78)
+		o = msgp.AppendString(o, za0004.Prefix)
+	}
 	return
 }
 
@@ -160,6 +289,71 @@ func (z *TierConfigMgr) UnmarshalMsg(bts []byte) (o []byte, err error) {
 				}
 				z.Tiers[za0001] = za0002
 			}
+		case "FS":
+			var zb0003 uint32
+			zb0003, bts, err = msgp.ReadMapHeaderBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "FS")
+				return
+			}
+			if z.FS == nil {
+				z.FS = make(map[string]tierFS, zb0003)
+			} else if len(z.FS) > 0 {
+				for key := range z.FS {
+					delete(z.FS, key)
+				}
+			}
+			for zb0003 > 0 {
+				var za0003 string
+				var za0004 tierFS
+				zb0003--
+				za0003, bts, err = msgp.ReadStringBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "FS")
+					return
+				}
+				var zb0004 uint32
+				zb0004, bts, err = msgp.ReadMapHeaderBytes(bts)
+				if err != nil {
+					err = msgp.WrapError(err, "FS", za0003)
+					return
+				}
+				for zb0004 > 0 {
+					zb0004--
+					field, bts, err = msgp.ReadMapKeyZC(bts)
+					if err != nil {
+						err = msgp.WrapError(err, "FS", za0003)
+						return
+					}
+					switch msgp.UnsafeString(field) {
+					case "Name":
+						za0004.Name, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "FS", za0003, "Name")
+							return
+						}
+					case "Path":
+						za0004.Path, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "FS", za0003, "Path")
+							return
+						}
+					case "Prefix":
+						za0004.Prefix, bts, err = msgp.ReadStringBytes(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "FS", za0003, "Prefix")
+							return
+						}
+					default:
+						bts, err = msgp.Skip(bts)
+						if err != nil {
+							err = msgp.WrapError(err, "FS", za0003)
+							return
+						}
+					}
+				}
+				z.FS[za0003] = za0004
+			}
 		default:
 			bts, err = msgp.Skip(bts)
 			if err != nil {
@@ -181,5 +375,165 @@ func (z *TierConfigMgr) Msgsize() (s int) {
 			s += msgp.StringPrefixSize + len(za0001) + za0002.Msgsize()
 		}
 	}
+	s += 3 + msgp.MapHeaderSize
+	if z.FS != nil {
+		for za0003, za0004 := range z.FS {
+			_ = za0004
+			s += msgp.StringPrefixSize + len(za0003) + 1 + 5 + msgp.StringPrefixSize + len(za0004.Name) + 5 + msgp.StringPrefixSize + len(za0004.Path) + 7 + msgp.StringPrefixSize + len(za0004.Prefix)
+		}
+	}
+	return
+}
+
+// DecodeMsg implements msgp.Decodable
+func (z *tierFS) DecodeMsg(dc *msgp.Reader) (err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, err = dc.ReadMapHeader()
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, err = dc.ReadMapKeyPtr()
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Name":
+			z.Name, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Name")
+				return
+			}
+		case "Path":
+			z.Path, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Path")
+				return
+			}
+		case "Prefix":
+			z.Prefix, err = dc.ReadString()
+			if err != nil {
+				err = msgp.WrapError(err, "Prefix")
+				return
+			}
+		default:
+			err = dc.Skip()
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	return
+}
+
+// EncodeMsg implements msgp.Encodable
+func (z tierFS) EncodeMsg(en *msgp.Writer) (err error) {
+	// map header, size 3
+	// write "Name"
+	err = en.Append(0x83, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Name)
+	if err != nil {
+		err = msgp.WrapError(err, "Name")
+		return
+	}
+	// write "Path"
+	err = en.Append(0xa4, 0x50, 0x61, 0x74, 0x68)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Path)
+	if err != nil {
+		err = msgp.WrapError(err, "Path")
+		return
+	}
+	// write "Prefix"
+	err = en.Append(0xa6, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78)
+	if err != nil {
+		return
+	}
+	err = en.WriteString(z.Prefix)
+	if err != nil {
+		err = msgp.WrapError(err, "Prefix")
+		return
+	}
+	return
+}
+
+// MarshalMsg implements msgp.Marshaler
+func (z tierFS) MarshalMsg(b []byte) (o []byte, err error) {
+	o = msgp.Require(b, z.Msgsize())
+	// map header, size 3
+	// string "Name"
+	o = append(o, 0x83, 0xa4, 0x4e, 0x61, 0x6d, 0x65)
+	o = msgp.AppendString(o, z.Name)
+	// string "Path"
+	o = append(o, 0xa4, 0x50, 0x61, 0x74, 0x68)
+	o = msgp.AppendString(o, z.Path)
+	// string "Prefix"
+	o = append(o, 0xa6, 0x50, 0x72, 0x65, 0x66, 0x69, 0x78)
+	o = msgp.AppendString(o, z.Prefix)
+	return
+}
+
+// UnmarshalMsg implements msgp.Unmarshaler
+func (z *tierFS) UnmarshalMsg(bts []byte) (o []byte, err error) {
+	var field []byte
+	_ = field
+	var zb0001 uint32
+	zb0001, bts, err = msgp.ReadMapHeaderBytes(bts)
+	if err != nil {
+		err = msgp.WrapError(err)
+		return
+	}
+	for zb0001 > 0 {
+		zb0001--
+		field, bts, err = msgp.ReadMapKeyZC(bts)
+		if err != nil {
+			err = msgp.WrapError(err)
+			return
+		}
+		switch msgp.UnsafeString(field) {
+		case "Name":
+			z.Name, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Name")
+				return
+			}
+		case "Path":
+			z.Path, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Path")
+				return
+			}
+		case "Prefix":
+			z.Prefix, bts, err = msgp.ReadStringBytes(bts)
+			if err != nil {
+				err = msgp.WrapError(err, "Prefix")
+				return
+			}
+		default:
+			bts, err = msgp.Skip(bts)
+			if err != nil {
+				err = msgp.WrapError(err)
+				return
+			}
+		}
+	}
+	o = bts
+	return
+}
+
+// Msgsize returns an upper bound estimate of the number of bytes occupied by the serialized message
+func (z tierFS) Msgsize() (s int) {
+	s = 1 + 5 + msgp.StringPrefixSize + len(z.Name) + 5 + msgp.StringPrefixSize + len(z.Path) + 7 + msgp.StringPrefixSize + len(z.Prefix)
 	return
 }
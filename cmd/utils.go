@@ -954,6 +954,7 @@ type AuditLogOptions struct {
 	APIName   string
 	Status    string
 	VersionID string
+	UploadID  string
 }
 
 // sends audit logs for internal subsystem activity
@@ -967,6 +968,12 @@ func auditLogInternal(ctx context.Context, bucket, object string, opts AuditLogO
 		entry.ReqQuery = make(map[string]string)
 		entry.ReqQuery[xhttp.VersionID] = opts.VersionID
 	}
+	if opts.UploadID != "" {
+		if entry.ReqQuery == nil {
+			entry.ReqQuery = make(map[string]string)
+		}
+		entry.ReqQuery[xhttp.UploadID] = opts.UploadID
+	}
 	entry.API.Status = opts.Status
 	ctx = logger.SetAuditEntry(ctx, &entry)
 	logger.AuditLog(ctx, nil, nil, nil)
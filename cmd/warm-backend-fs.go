@@ -0,0 +1,197 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"context"
+	"crypto/sha256"
+	"encoding/hex"
+	"errors"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+// warmBackendFS stores transitioned objects as files under a directory.
+// Every object version is stored as <Path>/<Prefix>/<object>/<sha256 of
+// its content>, the hex encoded content hash is its remote version ID.
+type warmBackendFS struct {
+	Path   string
+	Prefix string
+}
+
+func (fs *warmBackendFS) ToObjectError(err error, params ...string) error {
+	object := ""
+	if len(params) >= 1 {
+		object = params[0]
+	}
+	return toObjectErr(osErrToFileErr(err), fs.Path, fs.getDest(object))
+}
+
+func (fs *warmBackendFS) getDest(object string) string {
+	destObj := object
+	if fs.Prefix != "" {
+		destObj = pathJoin(fs.Prefix, object)
+	}
+	return destObj
+}
+
+// objectDir returns the directory holding the versions of object.
+func (fs *warmBackendFS) objectDir(object string) string {
+	return filepath.Join(fs.Path, filepath.FromSlash(fs.getDest(object)))
+}
+
+func (fs *warmBackendFS) Put(ctx context.Context, object string, r io.Reader, length int64) (remoteVersionID, error) {
+	dir := fs.objectDir(object)
+	if err := mkdirAll(dir, 0777); err != nil {
+		return "", fs.ToObjectError(err, object)
+	}
+
+	tmp, err := os.CreateTemp(dir, ".tmp-")
+	if err != nil {
+		return "", fs.ToObjectError(err, object)
+	}
+	tmpPath := tmp.Name()
+	defer os.Remove(tmpPath)
+
+	h := sha256.New()
+	n, err := io.Copy(io.MultiWriter(tmp, h), r)
+	if err == nil && length >= 0 && n != length {
+		err = io.ErrUnexpectedEOF
+	}
+	if err == nil {
+		err = tmp.Sync()
+	}
+	if cerr := tmp.Close(); err == nil {
+		err = cerr
+	}
+	if err != nil {
+		return "", fs.ToObjectError(err, object)
+	}
+
+	rv := remoteVersionID(hex.EncodeToString(h.Sum(nil)))
+	if err = os.Rename(tmpPath, filepath.Join(dir, string(rv))); err != nil {
+		return "", fs.ToObjectError(err, object)
+	}
+	if err = fsyncDir(dir); err != nil {
+		return "", fs.ToObjectError(err, object)
+	}
+	return rv, nil
+}
+
+func (fs *warmBackendFS) Get(ctx context.Context, object string, rv remoteVersionID, opts WarmBackendGetOpts) (io.ReadCloser, error) {
+	if !isValidFSRemoteVersionID(rv) {
+		return nil, ObjectNotFound{Bucket: fs.Path, Object: fs.getDest(object)}
+	}
+	f, err := os.Open(filepath.Join(fs.objectDir(object), string(rv)))
+	if err != nil {
+		return nil, fs.ToObjectError(err, object)
+	}
+	if opts.startOffset > 0 {
+		if _, err = f.Seek(opts.startOffset, io.SeekStart); err != nil {
+			f.Close()
+			return nil, fs.ToObjectError(err, object)
+		}
+	}
+	if opts.length > 0 {
+		return struct {
+			io.Reader
+			io.Closer
+		}{io.LimitReader(f, opts.length), f}, nil
+	}
+	return f, nil
+}
+
+func (fs *warmBackendFS) Remove(ctx context.Context, object string, rv remoteVersionID) error {
+	if !isValidFSRemoteVersionID(rv) {
+		return nil
+	}
+	dir := fs.objectDir(object)
+	if err := os.Remove(filepath.Join(dir, string(rv))); err != nil && !osIsNotExist(err) {
+		return fs.ToObjectError(err, object)
+	}
+	// Remove the now empty parent directories, up to the tier root.
+	root := filepath.Clean(fs.Path)
+	for dir != root && strings.HasPrefix(dir, root) {
+		if err := os.Remove(dir); err != nil {
+			break
+		}
+		dir = filepath.Dir(dir)
+	}
+	return nil
+}
+
+var errFSTierInUse = errors.New("fs tier in use")
+
+func (fs *warmBackendFS) InUse(ctx context.Context) (bool, error) {
+	root := fs.objectDir("")
+	err := filepath.Walk(root, func(path string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if !info.IsDir() {
+			return errFSTierInUse
+		}
+		return nil
+	})
+	switch {
+	case err == errFSTierInUse:
+		return true, nil
+	case err != nil && !osIsNotExist(err):
+		return false, fs.ToObjectError(err)
+	}
+	return false, nil
+}
+
+// isValidFSRemoteVersionID returns true if rv is a hex encoded sha256 sum,
+// this also guards against version IDs escaping the object directory.
+func isValidFSRemoteVersionID(rv remoteVersionID) bool {
+	if len(rv) != hex.EncodedLen(sha256.Size) {
+		return false
+	}
+	_, err := hex.DecodeString(string(rv))
+	return err == nil
+}
+
+// fsyncDir commits the directory entries of dir to stable storage.
+func fsyncDir(dir string) error {
+	d, err := os.Open(dir)
+	if err != nil {
+		return err
+	}
+	defer d.Close()
+	return d.Sync()
+}
+
+func newWarmBackendFS(conf tierFS) (*warmBackendFS, error) {
+	if conf.Path == "" || !filepath.IsAbs(conf.Path) {
+		return nil, errInvalidArgument
+	}
+	fi, err := os.Stat(conf.Path)
+	if err != nil {
+		return nil, osErrToFileErr(err)
+	}
+	if !fi.IsDir() {
+		return nil, errFileNotFound
+	}
+	return &warmBackendFS{
+		Path:   filepath.Clean(conf.Path),
+		Prefix: strings.Trim(conf.Prefix, slashSeparator),
+	}, nil
+}
@@ -0,0 +1,185 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cmd
+
+import (
+	"bytes"
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+
+	"github.com/minio/madmin-go"
+)
+
+func TestWarmBackendFS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minio-tier-fs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	if _, err = newWarmBackendFS(tierFS{Path: "relative/path"}); err == nil {
+		t.Fatal("Expected relative tier paths to be rejected")
+	}
+
+	w, err := newWarmBackendFS(tierFS{Path: dir, Prefix: "/tier/"})
+	if err != nil {
+		t.Fatal(err)
+	}
+	ctx := context.Background()
+	if err = checkWarmBackend(ctx, w); err != nil {
+		t.Fatal(err)
+	}
+	if inUse, err := w.InUse(ctx); err != nil || inUse {
+		t.Fatalf("Expected tier to be unused after probe, got %v, %v", inUse, err)
+	}
+
+	data := []byte("hello, warm tier")
+	rv, err := w.Put(ctx, "ab/cd/object", bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !isValidFSRemoteVersionID(rv) {
+		t.Fatalf("Expected a content addressed remote version ID, got %s", rv)
+	}
+	rv2, err := w.Put(ctx, "ab/cd/object", bytes.NewReader(data), int64(len(data)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if rv != rv2 {
+		t.Fatalf("Expected identical content to have the same version ID, got %s and %s", rv, rv2)
+	}
+	if _, err = w.Put(ctx, "short", bytes.NewReader(data), int64(len(data)+1)); err == nil {
+		t.Fatal("Expected short writes to fail")
+	}
+
+	testCases := []struct {
+		opts     WarmBackendGetOpts
+		expected []byte
+	}{
+		{WarmBackendGetOpts{}, data},
+		{WarmBackendGetOpts{startOffset: 7}, data[7:]},
+		{WarmBackendGetOpts{startOffset: 7, length: 4}, data[7:11]},
+	}
+	for i, tc := range testCases {
+		r, err := w.Get(ctx, "ab/cd/object", rv, tc.opts)
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		got, err := ioutil.ReadAll(r)
+		r.Close()
+		if err != nil {
+			t.Fatalf("Test %d: %v", i+1, err)
+		}
+		if !bytes.Equal(got, tc.expected) {
+			t.Fatalf("Test %d: expected %q but got %q", i+1, tc.expected, got)
+		}
+	}
+
+	if _, err = w.Get(ctx, "ab/cd/object", "../../../etc/passwd", WarmBackendGetOpts{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected ObjectNotFound for invalid version IDs, got %v", err)
+	}
+
+	if inUse, err := w.InUse(ctx); err != nil || !inUse {
+		t.Fatalf("Expected tier to be in use, got %v, %v", inUse, err)
+	}
+	if err = w.Remove(ctx, "ab/cd/object", rv); err != nil {
+		t.Fatal(err)
+	}
+	if _, err = w.Get(ctx, "ab/cd/object", rv, WarmBackendGetOpts{}); !isErrObjectNotFound(err) {
+		t.Fatalf("Expected ObjectNotFound after removal, got %v", err)
+	}
+	// Removing a missing version is not an error.
+	if err = w.Remove(ctx, "ab/cd/object", rv); err != nil {
+		t.Fatal(err)
+	}
+	if inUse, err := w.InUse(ctx); err != nil || inUse {
+		t.Fatalf("Expected tier to be unused, got %v, %v", inUse, err)
+	}
+	if _, err = os.Stat(dir); err != nil {
+		t.Fatalf("Expected tier root to be retained, got %v", err)
+	}
+}
+
+func TestTierConfigMgrFS(t *testing.T) {
+	dir, err := ioutil.TempDir("", "minio-tier-fs-")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer os.RemoveAll(dir)
+
+	ctx := context.Background()
+	config := NewTierConfigMgr()
+	if err = config.AddFS(ctx, tierFS{Name: "nfs", Path: dir}); err != errTierNameNotUppercase {
+		t.Fatalf("Expected %v, got %v", errTierNameNotUppercase, err)
+	}
+	if err = config.AddFS(ctx, tierFS{Name: "NFS", Path: "relative/path"}); err == nil {
+		t.Fatal("Expected relative tier paths to be rejected")
+	}
+	if err = config.AddFS(ctx, tierFS{Name: "NFS", Path: dir}); err != nil {
+		t.Fatal(err)
+	}
+	if err = config.AddFS(ctx, tierFS{Name: "NFS", Path: dir}); err != errTierAlreadyExists {
+		t.Fatalf("Expected %v, got %v", errTierAlreadyExists, err)
+	}
+	if !config.IsTierValid("NFS") {
+		t.Fatal("Expected NFS to be a valid tier")
+	}
+	if tiers := config.ListFSTiers(); len(tiers) != 1 || tiers[0].Type != tierTypeFS || tiers[0].FS.Path != dir {
+		t.Fatalf("Unexpected filesystem tiers %v", tiers)
+	}
+	if err = config.Edit(ctx, "NFS", madmin.TierCreds{AccessKey: "a", SecretKey: "b"}); err != errTierNoCredentials {
+		t.Fatalf("Expected %v, got %v", errTierNoCredentials, err)
+	}
+
+	// The tier config survives a save and a reload.
+	b, err := config.Bytes()
+	if err != nil {
+		t.Fatal(err)
+	}
+	loaded := NewTierConfigMgr()
+	if _, err = loaded.UnmarshalMsg(b[4:]); err != nil {
+		t.Fatal(err)
+	}
+	if !reflect.DeepEqual(loaded.FS, config.FS) {
+		t.Fatalf("Expected %v, got %v", config.FS, loaded.FS)
+	}
+	d, err := loaded.getDriver("NFS")
+	if err != nil {
+		t.Fatal(err)
+	}
+	data := []byte("transitioned")
+	if _, err = d.Put(ctx, "object", bytes.NewReader(data), int64(len(data))); err != nil {
+		t.Fatal(err)
+	}
+	if err = loaded.Remove(ctx, "NFS"); err != errTierBackendInUse {
+		t.Fatalf("Expected %v, got %v", errTierBackendInUse, err)
+	}
+	if err = os.RemoveAll(filepath.Join(dir, "object")); err != nil {
+		t.Fatal(err)
+	}
+	if err = loaded.Remove(ctx, "NFS"); err != nil {
+		t.Fatal(err)
+	}
+	if !loaded.Empty() {
+		t.Fatal("Expected no tiers after removal")
+	}
+}
@@ -142,3 +142,16 @@ func newWarmBackend(ctx context.Context, tier madmin.TierConfig) (d WarmBackend,
 	}
 	return d, nil
 }
+
+// newFSWarmBackend instantiates a filesystem WarmBackend, runs
+// checkWarmBackend on it.
+func newFSWarmBackend(ctx context.Context, tier tierFS) (WarmBackend, error) {
+	d, err := newWarmBackendFS(tier)
+	if err != nil {
+		return nil, err
+	}
+	if err = checkWarmBackend(ctx, d); err != nil {
+		return nil, err
+	}
+	return d, nil
+}
@@ -19,6 +19,7 @@ package cmd
 
 import (
 	"sort"
+	"time"
 
 	jsoniter "github.com/json-iterator/go"
 )
@@ -34,10 +35,26 @@ func (v versionsSorter) sort() {
 		if v[j].IsLatest {
 			return false
 		}
-		return v[i].ModTime.After(v[j].ModTime)
+		return isNewerVersion(v[i].ModTime, v[i].VersionID, v[j].ModTime, v[j].VersionID)
 	})
 }
 
+// isNewerVersion returns true if the first version supersedes the second,
+// the newer modification time wins and ties go to the higher version ID.
+// Sites replicating into each other thus agree on the latest version.
+func isNewerVersion(mtime1 time.Time, versionID1 string, mtime2 time.Time, versionID2 string) bool {
+	if !mtime1.Equal(mtime2) {
+		return mtime1.After(mtime2)
+	}
+	if versionID1 == nullVersionID {
+		versionID1 = ""
+	}
+	if versionID2 == nullVersionID {
+		versionID2 = ""
+	}
+	return versionID1 > versionID2
+}
+
 func getFileInfoVersions(xlMetaBuf []byte, volume, path string) (FileInfoVersions, error) {
 	fivs, err := getAllFileInfoVersions(xlMetaBuf, volume, path)
 	if err != nil {
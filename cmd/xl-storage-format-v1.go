@@ -113,6 +113,8 @@ const (
 	HighwayHash256S
 	// BLAKE2b512 represents the BLAKE2b-512 hash function
 	BLAKE2b512
+	// BLAKE3256S represents the Streaming BLAKE3-256 hash function
+	BLAKE3256S
 )
 
 // DefaultBitrotAlgorithm is the default algorithm used for bitrot protection.
@@ -127,6 +129,7 @@ type ObjectPartInfo struct {
 	Number     int    `json:"number"`
 	Size       int64  `json:"size"`
 	ActualSize int64  `json:"actualSize"`
+	Checksum   string `json:"checksum,omitempty" msg:"checksum,omitempty"`
 }
 
 // ChecksumInfo - carries checksums of individual scattered parts per disk.
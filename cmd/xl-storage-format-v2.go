@@ -169,13 +169,35 @@ type ChecksumAlgo uint8
 const (
 	invalidChecksumAlgo ChecksumAlgo = 0
 	HighwayHash         ChecksumAlgo = 1
-	lastChecksumAlgo    ChecksumAlgo = 2
+	BLAKE3              ChecksumAlgo = 2
+	lastChecksumAlgo    ChecksumAlgo = 3
 )
 
 func (e ChecksumAlgo) valid() bool {
 	return e > invalidChecksumAlgo && e < lastChecksumAlgo
 }
 
+// bitrotAlgorithm returns the streaming bitrot algorithm of the checksum algorithm.
+func (e ChecksumAlgo) bitrotAlgorithm() BitrotAlgorithm {
+	switch e {
+	case HighwayHash:
+		return HighwayHash256S
+	case BLAKE3:
+		return BLAKE3256S
+	}
+	return 0
+}
+
+// checksumAlgoFromBitrot returns the checksum algorithm recorded in xl.meta for the
+// bitrot algorithm, objects without parts are recorded with HighwayHash.
+func checksumAlgoFromBitrot(algo BitrotAlgorithm) ChecksumAlgo {
+	switch algo {
+	case BLAKE3256S:
+		return BLAKE3
+	}
+	return HighwayHash
+}
+
 // xlMetaV2DeleteMarker defines the data struct for the delete marker journal type
 type xlMetaV2DeleteMarker struct {
 	VersionID [16]byte          `json:"ID" msg:"ID"`                               // Version ID for delete marker
@@ -198,6 +220,7 @@ type xlMetaV2Object struct {
 	PartETags          []string          `json:"PartETags" msg:"PartETags"`                       // Part ETags
 	PartSizes          []int64           `json:"PartSizes" msg:"PartSizes"`                       // Part Sizes
 	PartActualSizes    []int64           `json:"PartASizes,omitempty" msg:"PartASizes,omitempty"` // Part ActualSizes (compression)
+	PartChecksums      []string          `json:"PartCksums,omitempty" msg:"PartCksums,omitempty"` // Part content checksums
 	Size               int64             `json:"Size" msg:"Size"`                                 // Object version size
 	ModTime            int64             `json:"MTime" msg:"MTime"`                               // Object version modified time
 	MetaSys            map[string][]byte `json:"MetaSys,omitempty" msg:"MetaSys,omitempty"`       // Object version internal metadata
@@ -250,6 +273,24 @@ func (j xlMetaV2Version) getModTime() time.Time {
 	return time.Time{}
 }
 
+// getVersionID will return the version ID of the underlying version,
+// empty for the null version.
+func (j xlMetaV2Version) getVersionID() string {
+	var versionID [16]byte
+	switch j.Type {
+	case ObjectType:
+		versionID = j.ObjectV2.VersionID
+	case DeleteType:
+		versionID = j.DeleteMarker.VersionID
+	case LegacyType:
+		return j.ObjectV1.VersionID
+	}
+	if versionID == [16]byte{} {
+		return ""
+	}
+	return uuid.UUID(versionID).String()
+}
+
 // xlMetaV2 - object meta structure defines the format and list of
 // the journals for the object.
 type xlMetaV2 struct {
@@ -843,6 +884,10 @@ func (z *xlMetaV2) AddVersion(fi FileInfo) error {
 			MetaSys:   make(map[string][]byte),
 		}
 	} else {
+		var bitrotAlgo BitrotAlgorithm
+		if len(fi.Erasure.Checksums) > 0 {
+			bitrotAlgo = fi.Erasure.Checksums[0].Algorithm
+		}
 		ventry.Type = ObjectType
 		ventry.ObjectV2 = &xlMetaV2Object{
 			VersionID:          uv,
@@ -854,7 +899,7 @@ func (z *xlMetaV2) AddVersion(fi FileInfo) error {
 			ErasureN:           fi.Erasure.ParityBlocks,
 			ErasureBlockSize:   fi.Erasure.BlockSize,
 			ErasureIndex:       fi.Erasure.Index,
-			BitrotChecksumAlgo: HighwayHash,
+			BitrotChecksumAlgo: checksumAlgoFromBitrot(bitrotAlgo),
 			ErasureDist:        make([]uint8, len(fi.Erasure.Distribution)),
 			PartNumbers:        make([]int, len(fi.Parts)),
 			PartETags:          make([]string, len(fi.Parts)),
@@ -875,16 +920,23 @@ func (z *xlMetaV2) AddVersion(fi FileInfo) error {
 			}
 			ventry.ObjectV2.PartNumbers[i] = fi.Parts[i].Number
 			ventry.ObjectV2.PartActualSizes[i] = fi.Parts[i].ActualSize
+			if fi.Parts[i].Checksum != "" {
+				if ventry.ObjectV2.PartChecksums == nil {
+					ventry.ObjectV2.PartChecksums = make([]string, len(fi.Parts))
+				}
+				ventry.ObjectV2.PartChecksums[i] = fi.Parts[i].Checksum
+			}
 		}
 
 		tierFVIDKey := ReservedMetadataPrefixLower + tierFVID
 		tierFVMarkerKey := ReservedMetadataPrefixLower + tierFVMarker
+		tierSkipFVIDKey := ReservedMetadataPrefixLower + tierSkipFVID
 		for k, v := range fi.Metadata {
 			if strings.HasPrefix(strings.ToLower(k), ReservedMetadataPrefixLower) {
-				// Skip tierFVID, tierFVMarker keys; it's used
-				// only for creating free-version.
+				// Skip tierFVID, tierFVMarker, tierSkipFVID keys; it's
+				// used only for creating free-version.
 				switch k {
-				case tierFVIDKey, tierFVMarkerKey:
+				case tierFVIDKey, tierFVMarkerKey, tierSkipFVIDKey:
 					continue
 				}
 
@@ -1027,17 +1079,19 @@ func (j xlMetaV2Object) ToFileInfo(volume, path string) (FileInfo, error) {
 		fi.Parts[i].Size = j.PartSizes[i]
 		fi.Parts[i].ETag = j.PartETags[i]
 		fi.Parts[i].ActualSize = j.PartActualSizes[i]
+		if len(j.PartChecksums) == len(fi.Parts) {
+			fi.Parts[i].Checksum = j.PartChecksums[i]
+		}
 	}
 	fi.Erasure.Checksums = make([]ChecksumInfo, len(j.PartSizes))
 	for i := range fi.Parts {
 		fi.Erasure.Checksums[i].PartNumber = fi.Parts[i].Number
-		switch j.BitrotChecksumAlgo {
-		case HighwayHash:
-			fi.Erasure.Checksums[i].Algorithm = HighwayHash256S
-			fi.Erasure.Checksums[i].Hash = []byte{}
-		default:
+		algo := j.BitrotChecksumAlgo.bitrotAlgorithm()
+		if algo == 0 {
 			return FileInfo{}, fmt.Errorf("unknown BitrotChecksumAlgo: %v", j.BitrotChecksumAlgo)
 		}
+		fi.Erasure.Checksums[i].Algorithm = algo
+		fi.Erasure.Checksums[i].Hash = []byte{}
 	}
 	fi.Metadata = make(map[string]string, len(j.MetaUser)+len(j.MetaSys))
 	for k, v := range j.MetaUser {
@@ -1248,8 +1302,10 @@ func (z *xlMetaV2) DeleteVersion(fi FileInfo) (string, bool, error) {
 					// if uv has tiered content we add a
 					// free-version to track it for
 					// asynchronous deletion via scanner.
-					if freeVersion, toFree := version.ObjectV2.InitFreeVersion(fi); toFree {
-						z.Versions = append(z.Versions, freeVersion)
+					if !fi.SkipTierFreeVersion() {
+						if freeVersion, toFree := version.ObjectV2.InitFreeVersion(fi); toFree {
+							z.Versions = append(z.Versions, freeVersion)
+						}
 					}
 				}
 
@@ -1358,7 +1414,8 @@ func (z xlMetaV2) ToFileInfo(volume, path, versionID string) (fi FileInfo, err e
 
 	if len(orderedVersions) > 1 {
 		sort.Slice(orderedVersions, func(i, j int) bool {
-			return orderedVersions[i].getModTime().After(orderedVersions[j].getModTime())
+			return isNewerVersion(orderedVersions[i].getModTime(), orderedVersions[i].getVersionID(),
+				orderedVersions[j].getModTime(), orderedVersions[j].getVersionID())
 		})
 	}
 
@@ -155,6 +155,55 @@ func TestXLV2FormatData(t *testing.T) {
 	}
 }
 
+// TestXLV2BitrotAlgorithm tests that versions protected with different
+// bitrot algorithms can be stored side by side.
+func TestXLV2BitrotAlgorithm(t *testing.T) {
+	xl := xlMetaV2{}
+	versions := make(map[string]BitrotAlgorithm)
+	for _, algo := range []BitrotAlgorithm{HighwayHash256S, BLAKE3256S} {
+		fi := FileInfo{
+			Volume:    "volume",
+			Name:      "object-name",
+			VersionID: mustGetUUID(),
+			DataDir:   mustGetUUID(),
+			ModTime:   time.Now(),
+			Size:      1024,
+			Parts:     []ObjectPartInfo{{Number: 1, Size: 1024, ActualSize: 1024}},
+			Erasure: ErasureInfo{
+				Algorithm:    ReedSolomon.String(),
+				DataBlocks:   4,
+				ParityBlocks: 2,
+				BlockSize:    blockSizeV2,
+				Index:        1,
+				Distribution: []int{1, 2, 3, 4, 5, 6},
+				Checksums:    []ChecksumInfo{{PartNumber: 1, Algorithm: algo}},
+			},
+		}
+		if err := xl.AddVersion(fi); err != nil {
+			t.Fatal(err)
+		}
+		versions[fi.VersionID] = algo
+	}
+
+	serialized, err := xl.AppendTo(nil)
+	if err != nil {
+		t.Fatal(err)
+	}
+	var xl2 xlMetaV2
+	if err = xl2.Load(serialized); err != nil {
+		t.Fatal(err)
+	}
+	for versionID, algo := range versions {
+		fi, err := xl2.ToFileInfo("volume", "object-name", versionID)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if got := fi.Erasure.GetChecksumInfo(1).Algorithm; got != algo {
+			t.Errorf("Expected version %s to use %s, got %s", versionID, algo, got)
+		}
+	}
+}
+
 // TestUsesDataDir tests xlMetaV2.UsesDataDir
 func TestUsesDataDir(t *testing.T) {
 	vID := uuid.New()
@@ -492,11 +492,13 @@ func (s *xlStorage) NSScanner(ctx context.Context, cache dataUsageCache, updates
 			return sizeSummary{}, errSkipFile
 		}
 		sizeS := sizeSummary{}
+		fivs.Versions = item.applyNewerNoncurrentVersionLimit(ctx, fivs.Versions)
 		for _, version := range fivs.Versions {
 			oi := version.ToObjectInfo(item.bucket, item.objectPath())
 			sz := item.applyActions(ctx, objAPI, oi, &sizeS)
 			if !oi.DeleteMarker && sz == oi.Size {
 				sizeS.versions++
+				sizeS.addTransitioned(oi)
 			}
 			sizeS.totalSize += sz
 		}
@@ -2086,7 +2088,9 @@ func (s *xlStorage) RenameData(ctx context.Context, srcVolume, srcPath string, f
 		// suspended or disabled on this bucket. RenameData will replace
 		// the 'null' version. We add a free-version to track its tiered
 		// content for asynchronous deletion.
-		xlMeta.AddFreeVersion(fi)
+		if !fi.SkipTierFreeVersion() {
+			xlMeta.AddFreeVersion(fi)
+		}
 	}
 
 	if err = xlMeta.AddVersion(fi); err != nil {
@@ -0,0 +1,180 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cors
+
+import (
+	"encoding/xml"
+	"io"
+	"net/http"
+	"strings"
+
+	"github.com/minio/pkg/wildcard"
+)
+
+const (
+	// Maximum number of rules in a CORS configuration, same as AWS S3.
+	maxRules = 100
+
+	// Maximum length of a rule ID.
+	maxRuleIDLength = 255
+
+	xmlNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+)
+
+var (
+	errNoRules               = Errorf("CORS configuration must have at least one CORSRule")
+	errTooManyRules          = Errorf("CORS configuration allows at most %d rules", maxRules)
+	errRuleIDTooLong         = Errorf("ID length is limited to %d characters", maxRuleIDLength)
+	errMissingOriginOrMethod = Errorf("Each CORSRule must identify at least one origin and one method")
+)
+
+// Rule - a single CORS rule of a bucket CORS configuration.
+type Rule struct {
+	XMLName        xml.Name `xml:"CORSRule"`
+	ID             string   `xml:"ID,omitempty"`
+	AllowedHeaders []string `xml:"AllowedHeader,omitempty"`
+	AllowedMethods []string `xml:"AllowedMethod"`
+	AllowedOrigins []string `xml:"AllowedOrigin"`
+	ExposeHeaders  []string `xml:"ExposeHeader,omitempty"`
+	MaxAgeSeconds  *int     `xml:"MaxAgeSeconds,omitempty"`
+}
+
+// Config - bucket CORS configuration, as specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketCors.html
+type Config struct {
+	XMLNS     string   `xml:"xmlns,attr,omitempty"`
+	XMLName   xml.Name `xml:"CORSConfiguration"`
+	CORSRules []Rule   `xml:"CORSRule"`
+}
+
+// ParseBucketCorsConfig - parses and validates a bucket CORS configuration.
+func ParseBucketCorsConfig(r io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(r).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.XMLNS == "" {
+		config.XMLNS = xmlNS
+	}
+	return &config, nil
+}
+
+// Validate - validates the CORS configuration.
+func (c Config) Validate() error {
+	if len(c.CORSRules) == 0 {
+		return errNoRules
+	}
+	if len(c.CORSRules) > maxRules {
+		return errTooManyRules
+	}
+	for _, rule := range c.CORSRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate - validates a single CORS rule.
+func (r Rule) Validate() error {
+	if len(r.ID) > maxRuleIDLength {
+		return errRuleIDTooLong
+	}
+	if len(r.AllowedMethods) == 0 || len(r.AllowedOrigins) == 0 {
+		return errMissingOriginOrMethod
+	}
+	for _, method := range r.AllowedMethods {
+		switch method {
+		case http.MethodGet, http.MethodPut, http.MethodHead, http.MethodPost, http.MethodDelete:
+		default:
+			return Errorf("Found unsupported HTTP method in CORS config. Unsupported method is %s", method)
+		}
+	}
+	for _, origin := range r.AllowedOrigins {
+		if strings.Count(origin, "*") > 1 {
+			return Errorf(`AllowedOrigin "%s" can not have more than one wildcard.`, origin)
+		}
+	}
+	for _, header := range r.AllowedHeaders {
+		if strings.Count(header, "*") > 1 {
+			return Errorf(`AllowedHeader "%s" can not have more than one wildcard.`, header)
+		}
+	}
+	return nil
+}
+
+// Match returns the first rule allowing a request from origin with the
+// given method and request headers, the headers are those requested by
+// a preflight request and are empty for actual requests.
+func (c *Config) Match(origin, method string, headers []string) (Rule, bool) {
+	for _, rule := range c.CORSRules {
+		if rule.matchOrigin(origin) && rule.matchMethod(method) && rule.matchHeaders(headers) {
+			return rule, true
+		}
+	}
+	return Rule{}, false
+}
+
+func (r Rule) matchOrigin(origin string) bool {
+	for _, allowed := range r.AllowedOrigins {
+		if wildcard.MatchSimple(allowed, origin) {
+			return true
+		}
+	}
+	return false
+}
+
+func (r Rule) matchMethod(method string) bool {
+	for _, allowed := range r.AllowedMethods {
+		if allowed == method {
+			return true
+		}
+	}
+	return false
+}
+
+// Header names are case-insensitive, every requested header
+// must be allowed by the rule.
+func (r Rule) matchHeaders(headers []string) bool {
+	for _, header := range headers {
+		allowed := false
+		for _, pattern := range r.AllowedHeaders {
+			if wildcard.MatchSimple(strings.ToLower(pattern), strings.ToLower(header)) {
+				allowed = true
+				break
+			}
+		}
+		if !allowed {
+			return false
+		}
+	}
+	return true
+}
+
+// AllowsAnyOrigin returns true if the rule allows requests from all origins.
+func (r Rule) AllowsAnyOrigin() bool {
+	for _, origin := range r.AllowedOrigins {
+		if origin == "*" {
+			return true
+		}
+	}
+	return false
+}
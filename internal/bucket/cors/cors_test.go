@@ -0,0 +1,139 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package cors
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestParseBucketCorsConfig(t *testing.T) {
+	testCases := []struct {
+		input     string
+		expectErr bool
+	}{
+		// Test 1: valid configuration.
+		{
+			input: `<CORSConfiguration><CORSRule><ID>rule1</ID><AllowedHeader>*</AllowedHeader><AllowedMethod>GET</AllowedMethod><AllowedMethod>PUT</AllowedMethod><AllowedOrigin>https://*.example.com</AllowedOrigin><ExposeHeader>ETag</ExposeHeader><MaxAgeSeconds>3000</MaxAgeSeconds></CORSRule></CORSConfiguration>`,
+		},
+		// Test 2: no rules.
+		{
+			input:     `<CORSConfiguration></CORSConfiguration>`,
+			expectErr: true,
+		},
+		// Test 3: missing origin.
+		{
+			input:     `<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod></CORSRule></CORSConfiguration>`,
+			expectErr: true,
+		},
+		// Test 4: missing method.
+		{
+			input:     `<CORSConfiguration><CORSRule><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`,
+			expectErr: true,
+		},
+		// Test 5: unsupported method.
+		{
+			input:     `<CORSConfiguration><CORSRule><AllowedMethod>PATCH</AllowedMethod><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`,
+			expectErr: true,
+		},
+		// Test 6: more than one wildcard in origin.
+		{
+			input:     `<CORSConfiguration><CORSRule><AllowedMethod>GET</AllowedMethod><AllowedOrigin>https://*.*.com</AllowedOrigin></CORSRule></CORSConfiguration>`,
+			expectErr: true,
+		},
+		// Test 7: rule ID too long.
+		{
+			input:     `<CORSConfiguration><CORSRule><ID>` + strings.Repeat("a", 256) + `</ID><AllowedMethod>GET</AllowedMethod><AllowedOrigin>*</AllowedOrigin></CORSRule></CORSConfiguration>`,
+			expectErr: true,
+		},
+		// Test 8: malformed XML.
+		{
+			input:     `<CORSConfiguration><CORSRule>`,
+			expectErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseBucketCorsConfig(strings.NewReader(tc.input))
+		if tc.expectErr && err == nil {
+			t.Errorf("Test %d: expected error, got nil", i+1)
+		}
+		if !tc.expectErr && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
+
+func TestCorsConfigRoundTrip(t *testing.T) {
+	input := `<CORSConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><CORSRule><AllowedMethod>GET</AllowedMethod><AllowedOrigin>*</AllowedOrigin><MaxAgeSeconds>0</MaxAgeSeconds></CORSRule></CORSConfiguration>`
+	config, err := ParseBucketCorsConfig(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte(input)) {
+		t.Fatalf("expected %s, got %s", input, data)
+	}
+}
+
+func TestCorsMatch(t *testing.T) {
+	config, err := ParseBucketCorsConfig(strings.NewReader(`<CORSConfiguration>` +
+		`<CORSRule><ID>write</ID><AllowedHeader>x-amz-*</AllowedHeader><AllowedHeader>Content-Type</AllowedHeader><AllowedMethod>PUT</AllowedMethod><AllowedMethod>DELETE</AllowedMethod><AllowedOrigin>https://*.example.com</AllowedOrigin></CORSRule>` +
+		`<CORSRule><ID>read</ID><AllowedMethod>GET</AllowedMethod><AllowedOrigin>*</AllowedOrigin></CORSRule>` +
+		`</CORSConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		origin  string
+		method  string
+		headers []string
+		ruleID  string
+		match   bool
+	}{
+		// Test 1: wildcard origin and allowed headers.
+		{origin: "https://www.example.com", method: "PUT", headers: []string{"X-Amz-Date", "content-type"}, ruleID: "write", match: true},
+		// Test 2: header not allowed.
+		{origin: "https://www.example.com", method: "PUT", headers: []string{"Authorization"}},
+		// Test 3: origin not allowed.
+		{origin: "http://www.example.com", method: "DELETE"},
+		// Test 4: any origin for GET.
+		{origin: "http://localhost:3000", method: "GET", ruleID: "read", match: true},
+		// Test 5: GET with headers not allowed by any rule.
+		{origin: "http://localhost:3000", method: "GET", headers: []string{"X-Amz-Date"}},
+		// Test 6: method not allowed.
+		{origin: "https://www.example.com", method: "POST"},
+	}
+
+	for i, tc := range testCases {
+		rule, ok := config.Match(tc.origin, tc.method, tc.headers)
+		if ok != tc.match {
+			t.Errorf("Test %d: expected match %v, got %v", i+1, tc.match, ok)
+			continue
+		}
+		if ok && rule.ID != tc.ruleID {
+			t.Errorf("Test %d: expected rule %s, got %s", i+1, tc.ruleID, rule.ID)
+		}
+	}
+}
@@ -0,0 +1,71 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lambda
+
+// Version of the event sent to transformation endpoints.
+const protocolVersion = "1.00"
+
+// GetObjectContext - where the transformation endpoint reads the
+// original object from and the route and token it must echo back
+// with its response.
+type GetObjectContext struct {
+	InputS3URL  string `json:"inputS3Url"`
+	OutputRoute string `json:"outputRoute"`
+	OutputToken string `json:"outputToken"`
+}
+
+// Configuration - the transformation being invoked.
+type Configuration struct {
+	AccessPointARN string `json:"accessPointArn"`
+}
+
+// UserRequest - the original GET request of the client.
+type UserRequest struct {
+	URL     string              `json:"url"`
+	Headers map[string][]string `json:"headers"`
+}
+
+// UserIdentity - the identity which made the original request.
+type UserIdentity struct {
+	Type        string `json:"type"`
+	PrincipalID string `json:"principalId"`
+	AccessKeyID string `json:"accessKeyId,omitempty"`
+}
+
+// Event - request sent to a transformation endpoint on GET, modeled
+// after the AWS S3 Object Lambda event.
+type Event struct {
+	XAmzRequestID    string           `json:"xAmzRequestId"`
+	GetObjectContext GetObjectContext `json:"getObjectContext"`
+	Configuration    Configuration    `json:"configuration"`
+	UserRequest      UserRequest      `json:"userRequest"`
+	UserIdentity     UserIdentity     `json:"userIdentity"`
+	ProtocolVersion  string           `json:"protocolVersion"`
+}
+
+// NewEvent - returns a new event for the transformation arn.
+func NewEvent(requestID, arn string, ctx GetObjectContext, req UserRequest, identity UserIdentity) Event {
+	return Event{
+		XAmzRequestID:    requestID,
+		GetObjectContext: ctx,
+		Configuration:    Configuration{AccessPointARN: arn},
+		UserRequest:      req,
+		UserIdentity:     identity,
+		ProtocolVersion:  protocolVersion,
+	}
+}
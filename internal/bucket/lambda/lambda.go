@@ -0,0 +1,124 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lambda
+
+import (
+	"encoding/xml"
+	"io"
+	"strings"
+
+	"github.com/minio/minio/internal/event"
+)
+
+const (
+	xmlNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+	// Maximum number of transformations per bucket.
+	maxTransformations = 10
+
+	arnPrefix = "arn:minio:s3-object-lambda:"
+)
+
+var (
+	errNoTransformations       = Errorf("At least one Transformation must be specified")
+	errTooManyTransformations  = Errorf("At most %d Transformations may be specified", maxTransformations)
+	errDuplicateTransformation = Errorf("Transformations must have unique Arns")
+)
+
+// Transformation - a transformation endpoint which may be
+// invoked on GET requests of objects in the bucket.
+type Transformation struct {
+	ARN string `xml:"Arn"`
+}
+
+// Config - bucket object lambda configuration, lists the
+// transformation endpoints enabled for a bucket.
+type Config struct {
+	XMLNS           string           `xml:"xmlns,attr,omitempty"`
+	XMLName         xml.Name         `xml:"ObjectLambdaConfiguration"`
+	Transformations []Transformation `xml:"Transformation"`
+}
+
+// ParseConfig - parses a bucket object lambda configuration.
+func ParseConfig(r io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(r).Decode(&config); err != nil {
+		return nil, err
+	}
+	if config.XMLNS == "" {
+		config.XMLNS = xmlNS
+	}
+	return &config, nil
+}
+
+// Validate - validates the configuration, every transformation
+// must refer to a webhook target configured on this server.
+func (c Config) Validate(region string, targetList *event.TargetList) error {
+	if len(c.Transformations) == 0 {
+		return errNoTransformations
+	}
+	if len(c.Transformations) > maxTransformations {
+		return errTooManyTransformations
+	}
+	seen := make(map[event.TargetID]struct{}, len(c.Transformations))
+	for _, t := range c.Transformations {
+		arnRegion, id, err := ParseARN(t.ARN)
+		if err != nil {
+			return err
+		}
+		if arnRegion != "" && region != "" && arnRegion != region {
+			return Errorf("Arn '%s' is not in region '%s'", t.ARN, region)
+		}
+		if !targetList.Exists(id) {
+			return Errorf("Arn '%s' does not refer to a configured webhook target", t.ARN)
+		}
+		if _, ok := seen[id]; ok {
+			return errDuplicateTransformation
+		}
+		seen[id] = struct{}{}
+	}
+	return nil
+}
+
+// Lookup - returns the target ID of the transformation with
+// the given ARN, false if the ARN is not configured.
+func (c *Config) Lookup(arn string) (event.TargetID, bool) {
+	if c == nil {
+		return event.TargetID{}, false
+	}
+	for _, t := range c.Transformations {
+		if t.ARN == arn {
+			_, id, err := ParseARN(arn)
+			return id, err == nil
+		}
+	}
+	return event.TargetID{}, false
+}
+
+// ParseARN - parses an object lambda ARN of the format
+// arn:minio:s3-object-lambda:<REGION>:<ID>:webhook
+func ParseARN(s string) (region string, id event.TargetID, err error) {
+	if !strings.HasPrefix(s, arnPrefix) {
+		return "", id, Errorf("Invalid object lambda Arn '%s'", s)
+	}
+	tokens := strings.Split(s, ":")
+	if len(tokens) != 6 || tokens[4] == "" || tokens[5] != "webhook" {
+		return "", id, Errorf("Invalid object lambda Arn '%s'", s)
+	}
+	return tokens[3], event.TargetID{ID: tokens[4], Name: tokens[5]}, nil
+}
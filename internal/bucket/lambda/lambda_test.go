@@ -0,0 +1,145 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lambda
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/event"
+)
+
+type testTarget struct {
+	id event.TargetID
+}
+
+func (t testTarget) ID() event.TargetID      { return t.id }
+func (t testTarget) IsActive() (bool, error) { return true, nil }
+func (t testTarget) Save(event.Event) error  { return nil }
+func (t testTarget) Send(string) error       { return nil }
+func (t testTarget) Close() error            { return nil }
+func (t testTarget) HasQueueStore() bool     { return false }
+
+func TestParseARN(t *testing.T) {
+	testCases := []struct {
+		arn          string
+		expectErr    bool
+		expectRegion string
+		expectID     event.TargetID
+	}{
+		{arn: "arn:minio:s3-object-lambda:us-east-1:1:webhook", expectRegion: "us-east-1", expectID: event.TargetID{ID: "1", Name: "webhook"}},
+		{arn: "arn:minio:s3-object-lambda::redact:webhook", expectID: event.TargetID{ID: "redact", Name: "webhook"}},
+		// Notification ARNs are not lambda ARNs.
+		{arn: "arn:minio:sqs:us-east-1:1:webhook", expectErr: true},
+		// Only webhook targets can transform objects.
+		{arn: "arn:minio:s3-object-lambda:us-east-1:1:amqp", expectErr: true},
+		{arn: "arn:minio:s3-object-lambda:us-east-1::webhook", expectErr: true},
+		{arn: "arn:minio:s3-object-lambda:us-east-1:1", expectErr: true},
+	}
+
+	for i, tc := range testCases {
+		region, id, err := ParseARN(tc.arn)
+		if tc.expectErr {
+			if err == nil {
+				t.Errorf("Test %d: expected error, got nil", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error: %v", i+1, err)
+		}
+		if region != tc.expectRegion || id != tc.expectID {
+			t.Errorf("Test %d: expected %s %v, got %s %v", i+1, tc.expectRegion, tc.expectID, region, id)
+		}
+	}
+}
+
+func TestParseConfig(t *testing.T) {
+	targetList := event.NewTargetList()
+	if err := targetList.Add(testTarget{id: event.TargetID{ID: "1", Name: "webhook"}}); err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		input     string
+		expectErr bool
+	}{
+		// Test 1: single transformation.
+		{
+			input: `<ObjectLambdaConfiguration><Transformation><Arn>arn:minio:s3-object-lambda:us-east-1:1:webhook</Arn></Transformation></ObjectLambdaConfiguration>`,
+		},
+		// Test 2: the region may be omitted.
+		{
+			input: `<ObjectLambdaConfiguration><Transformation><Arn>arn:minio:s3-object-lambda::1:webhook</Arn></Transformation></ObjectLambdaConfiguration>`,
+		},
+		// Test 3: no transformations.
+		{
+			input:     `<ObjectLambdaConfiguration></ObjectLambdaConfiguration>`,
+			expectErr: true,
+		},
+		// Test 4: unknown target.
+		{
+			input:     `<ObjectLambdaConfiguration><Transformation><Arn>arn:minio:s3-object-lambda:us-east-1:2:webhook</Arn></Transformation></ObjectLambdaConfiguration>`,
+			expectErr: true,
+		},
+		// Test 5: wrong region.
+		{
+			input:     `<ObjectLambdaConfiguration><Transformation><Arn>arn:minio:s3-object-lambda:eu-west-1:1:webhook</Arn></Transformation></ObjectLambdaConfiguration>`,
+			expectErr: true,
+		},
+		// Test 6: duplicate transformations.
+		{
+			input:     `<ObjectLambdaConfiguration><Transformation><Arn>arn:minio:s3-object-lambda:us-east-1:1:webhook</Arn></Transformation><Transformation><Arn>arn:minio:s3-object-lambda::1:webhook</Arn></Transformation></ObjectLambdaConfiguration>`,
+			expectErr: true,
+		},
+		// Test 7: malformed XML.
+		{
+			input:     `<ObjectLambdaConfiguration><Transformation>`,
+			expectErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseConfig(strings.NewReader(tc.input))
+		if err == nil {
+			err = config.Validate("us-east-1", targetList)
+		}
+		if tc.expectErr && err == nil {
+			t.Errorf("Test %d: expected error, got nil", i+1)
+		}
+		if !tc.expectErr && err != nil {
+			t.Errorf("Test %d: unexpected error: %v", i+1, err)
+		}
+	}
+}
+
+func TestConfigLookup(t *testing.T) {
+	config := &Config{Transformations: []Transformation{{ARN: "arn:minio:s3-object-lambda:us-east-1:1:webhook"}}}
+
+	id, ok := config.Lookup("arn:minio:s3-object-lambda:us-east-1:1:webhook")
+	if !ok || id != (event.TargetID{ID: "1", Name: "webhook"}) {
+		t.Errorf("expected target 1:webhook, got %v %v", id, ok)
+	}
+	if _, ok = config.Lookup("arn:minio:s3-object-lambda:us-east-1:2:webhook"); ok {
+		t.Error("expected unconfigured ARN not to be found")
+	}
+	var nilConfig *Config
+	if _, ok = nilConfig.Lookup("arn:minio:s3-object-lambda:us-east-1:1:webhook"); ok {
+		t.Error("expected no transformations in a nil configuration")
+	}
+}
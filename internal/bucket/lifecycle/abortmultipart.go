@@ -0,0 +1,83 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package lifecycle
+
+import (
+	"encoding/xml"
+	"time"
+)
+
+var (
+	errAbortMultipartInvalidDays = Errorf("DaysAfterInitiation must be positive integer when used with AbortIncompleteMultipartUpload")
+	errAbortMultipartWithTags    = Errorf("AbortIncompleteMultipartUpload cannot be specified with Tags")
+	errAbortMultipartWithSize    = Errorf("AbortIncompleteMultipartUpload cannot be specified with an object size filter")
+)
+
+// AbortIncompleteMultipartUpload - an action for lifecycle configuration rule.
+type AbortIncompleteMultipartUpload struct {
+	XMLName             xml.Name `xml:"AbortIncompleteMultipartUpload"`
+	DaysAfterInitiation int      `xml:"DaysAfterInitiation"`
+	set                 bool
+}
+
+// MarshalXML if days after initiation not set to non zero value
+func (a AbortIncompleteMultipartUpload) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
+	if a.IsDaysNull() {
+		return nil
+	}
+	type abortIncompleteMultipartUploadWrapper AbortIncompleteMultipartUpload
+	return e.EncodeElement(abortIncompleteMultipartUploadWrapper(a), start)
+}
+
+// UnmarshalXML decodes AbortIncompleteMultipartUpload
+func (a *AbortIncompleteMultipartUpload) UnmarshalXML(d *xml.Decoder, startElement xml.StartElement) error {
+	type abortIncompleteMultipartUploadWrapper AbortIncompleteMultipartUpload
+	var val abortIncompleteMultipartUploadWrapper
+	err := d.DecodeElement(&val, &startElement)
+	if err != nil {
+		return err
+	}
+	*a = AbortIncompleteMultipartUpload(val)
+	a.set = true
+	return nil
+}
+
+// IsDaysNull returns true if days field is null
+func (a AbortIncompleteMultipartUpload) IsDaysNull() bool {
+	return a.DaysAfterInitiation == 0
+}
+
+// Validate returns an error with wrong value
+func (a AbortIncompleteMultipartUpload) Validate() error {
+	if !a.set {
+		return nil
+	}
+	if a.DaysAfterInitiation <= 0 {
+		return errAbortMultipartInvalidDays
+	}
+	return nil
+}
+
+// Due returns the time after which an incomplete multipart upload
+// initiated at the given time is aborted, returns false if not applicable.
+func (a AbortIncompleteMultipartUpload) Due(initiated time.Time) (time.Time, bool) {
+	if a.IsDaysNull() {
+		return time.Time{}, false
+	}
+	return ExpectedExpiryTime(initiated, a.DaysAfterInitiation), true
+}
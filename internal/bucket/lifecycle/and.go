@@ -23,16 +23,24 @@ import (
 
 var errDuplicateTagKey = Errorf("Duplicate Tag Keys are not allowed")
 
-// And - a tag to combine a prefix and multiple tags for lifecycle configuration rule.
+// And - a tag to combine a prefix, multiple tags and object size
+// filters for lifecycle configuration rule.
 type And struct {
-	XMLName xml.Name `xml:"And"`
-	Prefix  Prefix   `xml:"Prefix,omitempty"`
-	Tags    []Tag    `xml:"Tag,omitempty"`
+	XMLName               xml.Name `xml:"And"`
+	Prefix                Prefix   `xml:"Prefix,omitempty"`
+	Tags                  []Tag    `xml:"Tag,omitempty"`
+	ObjectSizeGreaterThan int64    `xml:"ObjectSizeGreaterThan,omitempty"`
+	ObjectSizeLessThan    int64    `xml:"ObjectSizeLessThan,omitempty"`
 }
 
 // isEmpty returns true if Tags field is null
 func (a And) isEmpty() bool {
-	return len(a.Tags) == 0 && !a.Prefix.set
+	return len(a.Tags) == 0 && !a.Prefix.set && !a.hasObjectSize()
+}
+
+// hasObjectSize returns true if any object size filter is specified
+func (a And) hasObjectSize() bool {
+	return a.ObjectSizeGreaterThan != 0 || a.ObjectSizeLessThan != 0
 }
 
 // Validate - validates the And field
@@ -40,11 +48,29 @@ func (a And) Validate() error {
 	emptyPrefix := !a.Prefix.set
 	emptyTags := len(a.Tags) == 0
 
-	if emptyPrefix && emptyTags {
+	if emptyPrefix && emptyTags && !a.hasObjectSize() {
 		return nil
 	}
 
-	if emptyPrefix && !emptyTags || !emptyPrefix && emptyTags {
+	if a.hasObjectSize() {
+		// And combines at least two conditions.
+		var conditions int
+		if !emptyPrefix {
+			conditions++
+		}
+		if a.ObjectSizeGreaterThan != 0 {
+			conditions++
+		}
+		if a.ObjectSizeLessThan != 0 {
+			conditions++
+		}
+		if conditions+len(a.Tags) < 2 {
+			return errXMLNotWellFormed
+		}
+		if err := validateObjectSize(a.ObjectSizeGreaterThan, a.ObjectSizeLessThan); err != nil {
+			return err
+		}
+	} else if emptyPrefix && !emptyTags || !emptyPrefix && emptyTags {
 		return errXMLNotWellFormed
 	}
 
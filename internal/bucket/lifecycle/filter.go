@@ -23,7 +23,9 @@ import (
 )
 
 var (
-	errInvalidFilter = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
+	errInvalidFilter       = Errorf("Filter must have exactly one of Prefix, Tag, or And specified")
+	errInvalidObjectSize   = Errorf("ObjectSizeGreaterThan and ObjectSizeLessThan must be non-negative integers")
+	errInvalidObjectSizeLT = Errorf("ObjectSizeLessThan must be greater than ObjectSizeGreaterThan")
 )
 
 // Filter - a filter for a lifecycle configuration Rule.
@@ -38,6 +40,10 @@ type Filter struct {
 
 	Tag    Tag
 	tagSet bool
+
+	ObjectSizeGreaterThan int64
+	ObjectSizeLessThan    int64
+
 	// Caching tags, only once
 	cachedTags []string
 }
@@ -58,8 +64,16 @@ func (f Filter) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
 		if err := e.EncodeElement(f.Tag, xml.StartElement{Name: xml.Name{Local: "Tag"}}); err != nil {
 			return err
 		}
+	case f.ObjectSizeGreaterThan != 0:
+		if err := e.EncodeElement(f.ObjectSizeGreaterThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeGreaterThan"}}); err != nil {
+			return err
+		}
+	case f.ObjectSizeLessThan != 0:
+		if err := e.EncodeElement(f.ObjectSizeLessThan, xml.StartElement{Name: xml.Name{Local: "ObjectSizeLessThan"}}); err != nil {
+			return err
+		}
 	default:
-		// Always print Prefix field when both And & Tag are empty
+		// Always print Prefix field when And, Tag and object size filters are empty
 		if err := e.EncodeElement(f.Prefix, xml.StartElement{Name: xml.Name{Local: "Prefix"}}); err != nil {
 			return err
 		}
@@ -104,6 +118,14 @@ func (f *Filter) UnmarshalXML(d *xml.Decoder, start xml.StartElement) (err error
 				}
 				f.Tag = tag
 				f.tagSet = true
+			case "ObjectSizeGreaterThan":
+				if err = d.DecodeElement(&f.ObjectSizeGreaterThan, &se); err != nil {
+					return err
+				}
+			case "ObjectSizeLessThan":
+				if err = d.DecodeElement(&f.ObjectSizeLessThan, &se); err != nil {
+					return err
+				}
 			default:
 				return errUnknownXMLTag
 			}
@@ -147,9 +169,52 @@ func (f Filter) Validate() error {
 			return err
 		}
 	}
+	if f.ObjectSizeGreaterThan != 0 || f.ObjectSizeLessThan != 0 {
+		// Both object size filters must be combined with And.
+		if f.Prefix.set || !f.Tag.IsEmpty() || !f.And.isEmpty() ||
+			f.ObjectSizeGreaterThan != 0 && f.ObjectSizeLessThan != 0 {
+			return errInvalidFilter
+		}
+		if err := validateObjectSize(f.ObjectSizeGreaterThan, f.ObjectSizeLessThan); err != nil {
+			return err
+		}
+	}
 	return nil
 }
 
+// validateObjectSize validates object size filters, a zero value
+// means the filter is not specified.
+func validateObjectSize(greaterThan, lessThan int64) error {
+	if greaterThan < 0 || lessThan < 0 {
+		return errInvalidObjectSize
+	}
+	if greaterThan > 0 && lessThan > 0 && lessThan <= greaterThan {
+		return errInvalidObjectSizeLT
+	}
+	return nil
+}
+
+// BySize returns true if the object size satisfies the object size
+// filters, it returns true if there are no object size filters.
+func (f Filter) BySize(sz int64) bool {
+	for _, gt := range []int64{f.ObjectSizeGreaterThan, f.And.ObjectSizeGreaterThan} {
+		if gt > 0 && sz <= gt {
+			return false
+		}
+	}
+	for _, lt := range []int64{f.ObjectSizeLessThan, f.And.ObjectSizeLessThan} {
+		if lt > 0 && sz >= lt {
+			return false
+		}
+	}
+	return true
+}
+
+// HasObjectSize returns true if the Filter has object size filters.
+func (f Filter) HasObjectSize() bool {
+	return f.ObjectSizeGreaterThan != 0 || f.ObjectSizeLessThan != 0 || f.And.hasObjectSize()
+}
+
 // TestTags tests if the object tags satisfy the Filter tags requirement,
 // it returns true if there is no tags in the underlying Filter.
 func (f Filter) TestTags(tags []string) bool {
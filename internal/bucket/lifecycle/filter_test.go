@@ -109,6 +109,68 @@ func TestUnsupportedFilters(t *testing.T) {
 						</Filter>`,
 			expectedErr: errInvalidFilter,
 		},
+		{ // Filter with ObjectSizeGreaterThan
+			inputXML: ` <Filter>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+						</Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with ObjectSizeGreaterThan and ObjectSizeLessThan without And
+			inputXML: ` <Filter>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+							<ObjectSizeLessThan>4096</ObjectSizeLessThan>
+						</Filter>`,
+			expectedErr: errInvalidFilter,
+		},
+		{ // Filter with Prefix and ObjectSizeLessThan without And
+			inputXML: ` <Filter>
+							<Prefix>key-prefix</Prefix>
+							<ObjectSizeLessThan>4096</ObjectSizeLessThan>
+						</Filter>`,
+			expectedErr: errInvalidFilter,
+		},
+		{ // Filter with negative ObjectSizeLessThan
+			inputXML: ` <Filter>
+							<ObjectSizeLessThan>-1</ObjectSizeLessThan>
+						</Filter>`,
+			expectedErr: errInvalidObjectSize,
+		},
+		{ // Filter with And, Prefix and object size range
+			inputXML: ` <Filter>
+							<And>
+							<Prefix>key-prefix</Prefix>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+							<ObjectSizeLessThan>4096</ObjectSizeLessThan>
+							</And>
+						</Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with And and object size range
+			inputXML: ` <Filter>
+							<And>
+							<ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan>
+							<ObjectSizeLessThan>4096</ObjectSizeLessThan>
+							</And>
+						</Filter>`,
+			expectedErr: nil,
+		},
+		{ // Filter with And and empty object size range
+			inputXML: ` <Filter>
+							<And>
+							<ObjectSizeGreaterThan>4096</ObjectSizeGreaterThan>
+							<ObjectSizeLessThan>1024</ObjectSizeLessThan>
+							</And>
+						</Filter>`,
+			expectedErr: errInvalidObjectSizeLT,
+		},
+		{ // Filter with And and a single object size filter
+			inputXML: ` <Filter>
+							<And>
+							<ObjectSizeGreaterThan>4096</ObjectSizeGreaterThan>
+							</And>
+						</Filter>`,
+			expectedErr: errXMLNotWellFormed,
+		},
 	}
 	for i, tc := range testCases {
 		t.Run(fmt.Sprintf("Test %d", i+1), func(t *testing.T) {
@@ -124,3 +186,28 @@ func TestUnsupportedFilters(t *testing.T) {
 		})
 	}
 }
+
+func TestFilterBySize(t *testing.T) {
+	testCases := []struct {
+		inputXML string
+		size     int64
+		expected bool
+	}{
+		{`<Filter><Prefix>key-prefix</Prefix></Filter>`, 0, true},
+		{`<Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter>`, 1024, false},
+		{`<Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter>`, 1025, true},
+		{`<Filter><ObjectSizeLessThan>1024</ObjectSizeLessThan></Filter>`, 1023, true},
+		{`<Filter><ObjectSizeLessThan>1024</ObjectSizeLessThan></Filter>`, 1024, false},
+		{`<Filter><And><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan><ObjectSizeLessThan>4096</ObjectSizeLessThan></And></Filter>`, 2048, true},
+		{`<Filter><And><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan><ObjectSizeLessThan>4096</ObjectSizeLessThan></And></Filter>`, 512, false},
+	}
+	for i, tc := range testCases {
+		var filter Filter
+		if err := xml.Unmarshal([]byte(tc.inputXML), &filter); err != nil {
+			t.Fatalf("%d: Expected no error but got %v", i+1, err)
+		}
+		if got := filter.BySize(tc.size); got != tc.expected {
+			t.Fatalf("%d: Expected %v but got %v", i+1, tc.expected, got)
+		}
+	}
+}
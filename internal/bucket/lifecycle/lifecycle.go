@@ -134,7 +134,7 @@ func (lc Lifecycle) HasActiveRules(prefix string, recursive bool) bool {
 			}
 		}
 
-		if rule.NoncurrentVersionExpiration.NoncurrentDays > 0 {
+		if rule.NoncurrentVersionExpiration.NoncurrentDays > 0 || rule.NoncurrentVersionExpiration.NewerNoncurrentVersions > 0 {
 			return true
 		}
 		if rule.NoncurrentVersionTransition.NoncurrentDays > 0 {
@@ -210,6 +210,11 @@ func (lc Lifecycle) FilterActionableRules(obj ObjectOpts) []Rule {
 		if !strings.HasPrefix(obj.Name, rule.GetPrefix()) {
 			continue
 		}
+		// Delete markers have no size, object size filters
+		// apply to object versions only.
+		if !obj.DeleteMarker && !rule.Filter.BySize(obj.Size) {
+			continue
+		}
 		// Indicates whether MinIO will remove a delete marker with no
 		// noncurrent versions. If set to true, the delete marker will
 		// be expired; if set to false the policy takes no action. This
@@ -221,8 +226,8 @@ func (lc Lifecycle) FilterActionableRules(obj ObjectOpts) []Rule {
 		}
 		// The NoncurrentVersionExpiration action requests MinIO to expire
 		// noncurrent versions of objects x days after the objects become
-		// noncurrent.
-		if !rule.NoncurrentVersionExpiration.IsDaysNull() {
+		// noncurrent, optionally retaining the newest noncurrent versions.
+		if !rule.NoncurrentVersionExpiration.IsDaysNull() || rule.NoncurrentVersionExpiration.NewerNoncurrentVersions > 0 {
 			rules = append(rules, rule)
 			continue
 		}
@@ -249,6 +254,7 @@ func (lc Lifecycle) FilterActionableRules(obj ObjectOpts) []Rule {
 type ObjectOpts struct {
 	Name                   string
 	UserTags               string
+	Size                   int64
 	ModTime                time.Time
 	VersionID              string
 	IsLatest               bool
@@ -282,6 +288,12 @@ func (o ObjectOpts) ExpiredObjectDeleteMarker() bool {
 // ComputeAction returns the action to perform by evaluating all lifecycle rules
 // against the object name and its modification time.
 func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
+	return lc.ComputeActionAt(obj, time.Now())
+}
+
+// ComputeActionAt is like ComputeAction but evaluates the rules as of
+// the given time instead of the current time.
+func (lc Lifecycle) ComputeActionAt(obj ObjectOpts, now time.Time) Action {
 	var action = NoneAction
 	if obj.ModTime.IsZero() {
 		return action
@@ -300,17 +312,19 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 				// Specifying the Days tag will automatically perform ExpiredObjectDeleteMarker cleanup
 				// once delete markers are old enough to satisfy the age criteria.
 				// https://docs.aws.amazon.com/AmazonS3/latest/userguide/lifecycle-configuration-examples.html
-				if time.Now().After(ExpectedExpiryTime(obj.ModTime, int(rule.Expiration.Days))) {
+				if now.After(ExpectedExpiryTime(obj.ModTime, int(rule.Expiration.Days))) {
 					return DeleteVersionAction
 				}
 			}
 		}
 
-		if !rule.NoncurrentVersionExpiration.IsDaysNull() {
+		// Rules retaining newer noncurrent versions depend on all versions
+		// of the object, see NoncurrentVersionsExpirationLimit.
+		if !rule.NoncurrentVersionExpiration.IsDaysNull() && rule.NoncurrentVersionExpiration.NewerNoncurrentVersions == 0 {
 			if obj.VersionID != "" && !obj.IsLatest && !obj.SuccessorModTime.IsZero() {
 				// Non current versions should be deleted if their age exceeds non current days configuration
 				// https://docs.aws.amazon.com/AmazonS3/latest/dev/intro-lifecycle-rules.html#intro-lifecycle-rules-actions
-				if time.Now().After(ExpectedExpiryTime(obj.SuccessorModTime, int(rule.NoncurrentVersionExpiration.NoncurrentDays))) {
+				if now.After(ExpectedExpiryTime(obj.SuccessorModTime, int(rule.NoncurrentVersionExpiration.NoncurrentDays))) {
 					return DeleteVersionAction
 				}
 			}
@@ -320,7 +334,7 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 			if obj.VersionID != "" && !obj.IsLatest && !obj.SuccessorModTime.IsZero() && !obj.DeleteMarker && obj.TransitionStatus != TransitionComplete {
 				// Non current versions should be transitioned if their age exceeds non current days configuration
 				// https://docs.aws.amazon.com/AmazonS3/latest/dev/intro-lifecycle-rules.html#intro-lifecycle-rules-actions
-				if time.Now().After(ExpectedExpiryTime(obj.SuccessorModTime, int(rule.NoncurrentVersionTransition.NoncurrentDays))) {
+				if now.After(ExpectedExpiryTime(obj.SuccessorModTime, int(rule.NoncurrentVersionTransition.NoncurrentDays))) {
 					return TransitionVersionAction
 				}
 
@@ -336,11 +350,11 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 		if obj.VersionID == "" || obj.IsLatest && !obj.DeleteMarker {
 			switch {
 			case !rule.Expiration.IsDateNull():
-				if time.Now().UTC().After(rule.Expiration.Date.Time) {
+				if now.After(rule.Expiration.Date.Time) {
 					return DeleteAction
 				}
 			case !rule.Expiration.IsDaysNull():
-				if time.Now().UTC().After(ExpectedExpiryTime(obj.ModTime, int(rule.Expiration.Days))) {
+				if now.After(ExpectedExpiryTime(obj.ModTime, int(rule.Expiration.Days))) {
 					return DeleteAction
 				}
 			}
@@ -348,11 +362,11 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 			if obj.TransitionStatus != TransitionComplete {
 				switch {
 				case !rule.Transition.IsDateNull():
-					if time.Now().UTC().After(rule.Transition.Date.Time) {
+					if now.After(rule.Transition.Date.Time) {
 						action = TransitionAction
 					}
 				case !rule.Transition.IsDaysNull():
-					if time.Now().UTC().After(ExpectedExpiryTime(obj.ModTime, int(rule.Transition.Days))) {
+					if now.After(ExpectedExpiryTime(obj.ModTime, int(rule.Transition.Days))) {
 						action = TransitionAction
 					}
 
@@ -363,7 +377,7 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 					action = TransitionAction
 				}
 
-				if !obj.RestoreExpires.IsZero() && time.Now().After(obj.RestoreExpires) {
+				if !obj.RestoreExpires.IsZero() && now.After(obj.RestoreExpires) {
 					if obj.VersionID != "" {
 						action = DeleteRestoredVersionAction
 					} else {
@@ -371,7 +385,7 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 					}
 				}
 			}
-			if !obj.RestoreExpires.IsZero() && time.Now().After(obj.RestoreExpires) {
+			if !obj.RestoreExpires.IsZero() && now.After(obj.RestoreExpires) {
 				if obj.VersionID != "" {
 					action = DeleteRestoredVersionAction
 				} else {
@@ -384,6 +398,60 @@ func (lc Lifecycle) ComputeAction(obj ObjectOpts) Action {
 	return action
 }
 
+// NoncurrentVersionsExpirationLimit returns the number of newest noncurrent
+// versions to retain and the number of days after becoming noncurrent the
+// remaining noncurrent versions expire, from the first applicable rule
+// retaining newer noncurrent versions. obj refers to the latest version of
+// the object. It returns 0, 0 if no such rule applies.
+func (lc Lifecycle) NoncurrentVersionsExpirationLimit(obj ObjectOpts) (newerNoncurrentVersions, noncurrentDays int) {
+	for _, rule := range lc.FilterActionableRules(obj) {
+		if rule.NoncurrentVersionExpiration.NewerNoncurrentVersions == 0 {
+			continue
+		}
+		return rule.NoncurrentVersionExpiration.NewerNoncurrentVersions, int(rule.NoncurrentVersionExpiration.NoncurrentDays)
+	}
+	return 0, 0
+}
+
+// AbortMultipartUploadDue returns true if an incomplete multipart upload
+// of the object name initiated at the given time must be aborted.
+func (lc Lifecycle) AbortMultipartUploadDue(name string, initiated time.Time) bool {
+	due, ok := lc.AbortMultipartUploadTime(name, initiated)
+	return ok && time.Now().UTC().After(due)
+}
+
+// AbortMultipartUploadTime returns the time an incomplete multipart upload
+// of the object name initiated at the given time is to be aborted, the
+// earliest of all matching rules. ok is false if no rule matches.
+func (lc Lifecycle) AbortMultipartUploadTime(name string, initiated time.Time) (due time.Time, ok bool) {
+	if name == "" || initiated.IsZero() {
+		return due, false
+	}
+	for _, rule := range lc.Rules {
+		if rule.Status == Disabled {
+			continue
+		}
+		if !strings.HasPrefix(name, rule.GetPrefix()) {
+			continue
+		}
+		if t, tok := rule.AbortIncompleteMultipartUpload.Due(initiated); tok && (!ok || t.Before(due)) {
+			due, ok = t, true
+		}
+	}
+	return due, ok
+}
+
+// HasAbortIncompleteMultipartUpload returns true if lifecycle configuration
+// has an enabled AbortIncompleteMultipartUpload rule.
+func (lc Lifecycle) HasAbortIncompleteMultipartUpload() bool {
+	for _, rule := range lc.Rules {
+		if rule.Status == Enabled && !rule.AbortIncompleteMultipartUpload.IsDaysNull() {
+			return true
+		}
+	}
+	return false
+}
+
 // ExpectedExpiryTime calculates the expiry, transition or restore date/time based on a object modtime.
 // The expected transition or restore time is always a midnight time following the the object
 // modification time plus the number of transition/restore days.
@@ -408,7 +476,7 @@ func (lc Lifecycle) PredictExpiryTime(obj ObjectOpts) (string, time.Time) {
 	// Iterate over all actionable rules and find the earliest
 	// expiration date and its associated rule ID.
 	for _, rule := range lc.FilterActionableRules(obj) {
-		if !rule.NoncurrentVersionExpiration.IsDaysNull() && !obj.IsLatest && obj.VersionID != "" {
+		if !rule.NoncurrentVersionExpiration.IsDaysNull() && rule.NoncurrentVersionExpiration.NewerNoncurrentVersions == 0 && !obj.IsLatest && obj.VersionID != "" {
 			return rule.ID, ExpectedExpiryTime(obj.SuccessorModTime, int(rule.NoncurrentVersionExpiration.NoncurrentDays))
 		}
 
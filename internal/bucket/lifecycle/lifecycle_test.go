@@ -213,6 +213,7 @@ func TestComputeActions(t *testing.T) {
 		inputConfig        string
 		objectName         string
 		objectTags         string
+		objectSize         int64
 		objectModTime      time.Time
 		isExpiredDelMarker bool
 		expectedAction     Action
@@ -380,6 +381,38 @@ func TestComputeActions(t *testing.T) {
 			isExpiredDelMarker: true,
 			expectedAction:     DeleteVersionAction,
 		},
+		// Should not transition objects smaller than ObjectSizeGreaterThan
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><ObjectSizeGreaterThan>131072</ObjectSizeGreaterThan></Filter><Status>Enabled</Status><Transition><Days>1</Days><StorageClass>WARM-1</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectSize:     1024,
+			objectModTime:  time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+			expectedAction: NoneAction,
+		},
+		// Should transition objects larger than ObjectSizeGreaterThan
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><ObjectSizeGreaterThan>131072</ObjectSizeGreaterThan></Filter><Status>Enabled</Status><Transition><Days>1</Days><StorageClass>WARM-1</StorageClass></Transition></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectSize:     1 << 20,
+			objectModTime:  time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+			expectedAction: TransitionAction,
+		},
+		// Should remove objects matching prefix and object size range
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><And><Prefix>foodir/</Prefix><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan><ObjectSizeLessThan>4096</ObjectSizeLessThan></And></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectSize:     2048,
+			objectModTime:  time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+			expectedAction: DeleteAction,
+		},
+		// Should not remove objects outside object size range
+		{
+			inputConfig:    `<LifecycleConfiguration><Rule><Filter><And><Prefix>foodir/</Prefix><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan><ObjectSizeLessThan>4096</ObjectSizeLessThan></And></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration></Rule></LifecycleConfiguration>`,
+			objectName:     "foodir/fooobject",
+			objectSize:     4096,
+			objectModTime:  time.Now().UTC().Add(-10 * 24 * time.Hour), // Created 10 days ago
+			expectedAction: NoneAction,
+		},
 	}
 
 	for _, tc := range testCases {
@@ -392,6 +425,7 @@ func TestComputeActions(t *testing.T) {
 			if resultAction := lc.ComputeAction(ObjectOpts{
 				Name:         tc.objectName,
 				UserTags:     tc.objectTags,
+				Size:         tc.objectSize,
 				ModTime:      tc.objectModTime,
 				DeleteMarker: tc.isExpiredDelMarker,
 				NumVersions:  1,
@@ -580,3 +614,122 @@ func TestTransitionTier(t *testing.T) {
 		t.Fatalf("Expected TIER-2 but got %s", got)
 	}
 }
+
+func TestAbortMultipartUploadDue(t *testing.T) {
+	lc := Lifecycle{
+		Rules: []Rule{
+			{
+				ID:     "rule-1",
+				Status: Enabled,
+				Filter: Filter{Prefix: Prefix{string: "uploads/", set: true}, set: true},
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{
+					DaysAfterInitiation: 3,
+				},
+			},
+			{
+				ID:     "rule-2",
+				Status: Disabled,
+				AbortIncompleteMultipartUpload: AbortIncompleteMultipartUpload{
+					DaysAfterInitiation: 1,
+				},
+			},
+		},
+	}
+	if !lc.HasAbortIncompleteMultipartUpload() {
+		t.Fatal("Expected lifecycle to have an AbortIncompleteMultipartUpload rule")
+	}
+
+	testCases := []struct {
+		name      string
+		initiated time.Time
+		expected  bool
+	}{
+		{"uploads/obj", time.Now().Add(-5 * 24 * time.Hour), true},
+		{"uploads/obj", time.Now().Add(-1 * 24 * time.Hour), false},
+		{"other/obj", time.Now().Add(-5 * 24 * time.Hour), false},
+		{"uploads/obj", time.Time{}, false},
+	}
+	for i, tc := range testCases {
+		if got := lc.AbortMultipartUploadDue(tc.name, tc.initiated); got != tc.expected {
+			t.Fatalf("Test %d: expected %v but got %v", i+1, tc.expected, got)
+		}
+	}
+}
+
+func TestAbortMultipartUploadTime(t *testing.T) {
+	lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><ID>rule-1</ID><Filter><Prefix>uploads/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>7</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule><Rule><ID>rule-2</ID><Filter><Prefix>uploads/tmp/</Prefix></Filter><Status>Enabled</Status><AbortIncompleteMultipartUpload><DaysAfterInitiation>2</DaysAfterInitiation></AbortIncompleteMultipartUpload></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	initiated := time.Date(2021, time.September, 1, 10, 0, 0, 0, time.UTC)
+	testCases := []struct {
+		name     string
+		expected time.Time
+		ok       bool
+	}{
+		{"uploads/obj", time.Date(2021, time.September, 9, 0, 0, 0, 0, time.UTC), true},
+		// The earliest of all matching rules applies.
+		{"uploads/tmp/obj", time.Date(2021, time.September, 4, 0, 0, 0, 0, time.UTC), true},
+		{"other/obj", time.Time{}, false},
+	}
+	for i, tc := range testCases {
+		got, ok := lc.AbortMultipartUploadTime(tc.name, initiated)
+		if ok != tc.ok || !got.Equal(tc.expected) {
+			t.Fatalf("Test %d: expected %v, %v but got %v, %v", i+1, tc.expected, tc.ok, got, ok)
+		}
+	}
+}
+
+func TestNoncurrentVersionsExpirationLimit(t *testing.T) {
+	lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><ID>rule-1</ID><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><NoncurrentVersionExpiration><NoncurrentDays>5</NoncurrentDays><NewerNoncurrentVersions>3</NewerNoncurrentVersions></NoncurrentVersionExpiration></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+	if err = lc.Validate(); err != nil {
+		t.Fatal(err)
+	}
+	if !lc.HasActiveRules("foodir/", true) {
+		t.Fatal("Expected active rules")
+	}
+
+	if newer, days := lc.NoncurrentVersionsExpirationLimit(ObjectOpts{Name: "foodir/fooobject", IsLatest: true}); newer != 3 || days != 5 {
+		t.Fatalf("Expected 3 newer noncurrent versions and 5 days, got %d and %d", newer, days)
+	}
+	if newer, days := lc.NoncurrentVersionsExpirationLimit(ObjectOpts{Name: "foxdir/fooobject", IsLatest: true}); newer != 0 || days != 0 {
+		t.Fatalf("Expected no limit, got %d and %d", newer, days)
+	}
+
+	// Noncurrent versions are not expired individually by such rules.
+	if action := lc.ComputeAction(ObjectOpts{
+		Name:             "foodir/fooobject",
+		ModTime:          time.Now().UTC().Add(-20 * 24 * time.Hour),
+		VersionID:        "v1",
+		SuccessorModTime: time.Now().UTC().Add(-10 * 24 * time.Hour),
+	}); action != NoneAction {
+		t.Fatalf("Expected NoneAction, got %v", action)
+	}
+}
+
+func TestComputeActionAt(t *testing.T) {
+	lc, err := ParseLifecycleConfig(bytes.NewReader([]byte(`<LifecycleConfiguration><Rule><Filter><Prefix>foodir/</Prefix></Filter><Status>Enabled</Status><Expiration><Days>5</Days></Expiration><Transition><Days>2</Days><StorageClass>WARM-1</StorageClass></Transition></Rule></LifecycleConfiguration>`)))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	modTime := time.Date(2021, time.September, 1, 10, 0, 0, 0, time.UTC)
+	obj := ObjectOpts{Name: "foodir/fooobject", ModTime: modTime, IsLatest: true}
+	testCases := []struct {
+		now      time.Time
+		expected Action
+	}{
+		{modTime, NoneAction},
+		{modTime.Add(3 * 24 * time.Hour), TransitionAction},
+		{modTime.Add(10 * 24 * time.Hour), DeleteAction},
+	}
+	for i, tc := range testCases {
+		if got := lc.ComputeActionAt(obj, tc.now); got != tc.expected {
+			t.Fatalf("Test %d: expected %v but got %v", i+1, tc.expected, got)
+		}
+	}
+}
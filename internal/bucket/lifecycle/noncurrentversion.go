@@ -22,16 +22,19 @@ import (
 	"time"
 )
 
+var errNewerNoncurrentVersions = Errorf("NewerNoncurrentVersions must be a positive integer not greater than 100")
+
 // NoncurrentVersionExpiration - an action for lifecycle configuration rule.
 type NoncurrentVersionExpiration struct {
-	XMLName        xml.Name       `xml:"NoncurrentVersionExpiration"`
-	NoncurrentDays ExpirationDays `xml:"NoncurrentDays,omitempty"`
-	set            bool
+	XMLName                 xml.Name       `xml:"NoncurrentVersionExpiration"`
+	NoncurrentDays          ExpirationDays `xml:"NoncurrentDays,omitempty"`
+	NewerNoncurrentVersions int            `xml:"NewerNoncurrentVersions,omitempty"`
+	set                     bool
 }
 
 // MarshalXML if non-current days not set to non zero value
 func (n NoncurrentVersionExpiration) MarshalXML(e *xml.Encoder, start xml.StartElement) error {
-	if n.IsDaysNull() {
+	if n.IsDaysNull() && n.NewerNoncurrentVersions == 0 {
 		return nil
 	}
 	type noncurrentVersionExpirationWrapper NoncurrentVersionExpiration
@@ -61,8 +64,13 @@ func (n NoncurrentVersionExpiration) Validate() error {
 	if !n.set {
 		return nil
 	}
+	if n.NewerNoncurrentVersions < 0 || n.NewerNoncurrentVersions > 100 {
+		return errNewerNoncurrentVersions
+	}
+	// NoncurrentDays may be left out to retain only the
+	// NewerNoncurrentVersions most recent noncurrent versions.
 	val := int(n.NoncurrentDays)
-	if val <= 0 {
+	if val <= 0 && n.NewerNoncurrentVersions == 0 {
 		return errXMLNotWellFormed
 	}
 	return nil
@@ -61,6 +61,73 @@ func TestInvalidRules(t *testing.T) {
 	                    </Rule>`,
 			expectedErr: errInvalidRuleStatus,
 		},
+		{ // Rule with only AbortIncompleteMultipartUpload
+			inputXML: ` <Rule>
+			                  <ID>rule with abort incomplete multipart upload</ID>
+			                  <Filter><Prefix>uploads/</Prefix></Filter>
+			                  <AbortIncompleteMultipartUpload>
+			                      <DaysAfterInitiation>7</DaysAfterInitiation>
+			                  </AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
+		{ // Rule with invalid DaysAfterInitiation
+			inputXML: ` <Rule>
+			                  <ID>rule with invalid days after initiation</ID>
+			                  <Filter><Prefix></Prefix></Filter>
+			                  <AbortIncompleteMultipartUpload>
+			                      <DaysAfterInitiation>0</DaysAfterInitiation>
+			                  </AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errAbortMultipartInvalidDays,
+		},
+		{ // Rule with AbortIncompleteMultipartUpload and tag filter
+			inputXML: ` <Rule>
+			                  <ID>rule with abort incomplete multipart upload and tags</ID>
+			                  <Filter><Tag><Key>key1</Key><Value>val1</Value></Tag></Filter>
+			                  <AbortIncompleteMultipartUpload>
+			                      <DaysAfterInitiation>7</DaysAfterInitiation>
+			                  </AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errAbortMultipartWithTags,
+		},
+		{ // Rule with AbortIncompleteMultipartUpload and object size filter
+			inputXML: ` <Rule>
+			                  <ID>rule with abort incomplete multipart upload and object size</ID>
+			                  <Filter><ObjectSizeGreaterThan>1024</ObjectSizeGreaterThan></Filter>
+			                  <AbortIncompleteMultipartUpload>
+			                      <DaysAfterInitiation>7</DaysAfterInitiation>
+			                  </AbortIncompleteMultipartUpload>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errAbortMultipartWithSize,
+		},
+		{ // Rule with only NewerNoncurrentVersions
+			inputXML: ` <Rule>
+			                  <ID>rule with newer noncurrent versions</ID>
+			                  <Filter><Prefix></Prefix></Filter>
+			                  <NoncurrentVersionExpiration>
+			                      <NewerNoncurrentVersions>5</NewerNoncurrentVersions>
+			                  </NoncurrentVersionExpiration>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: nil,
+		},
+		{ // Rule with invalid NewerNoncurrentVersions
+			inputXML: ` <Rule>
+			                  <ID>rule with invalid newer noncurrent versions</ID>
+			                  <Filter><Prefix></Prefix></Filter>
+			                  <NoncurrentVersionExpiration>
+			                      <NoncurrentDays>5</NoncurrentDays>
+			                      <NewerNoncurrentVersions>101</NewerNoncurrentVersions>
+			                  </NoncurrentVersionExpiration>
+                              <Status>Enabled</Status>
+	                    </Rule>`,
+			expectedErr: errNewerNoncurrentVersions,
+		},
 	}
 
 	for i, tc := range invalidTestCases {
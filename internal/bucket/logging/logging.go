@@ -0,0 +1,88 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"encoding/xml"
+	"io"
+)
+
+const xmlNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+
+var (
+	errMissingTargetBucket = Errorf("TargetBucket must be specified to enable logging")
+	errTargetGrants        = Errorf("TargetGrants are not supported, access to the log objects is managed with bucket policies")
+)
+
+// LoggingEnabled - where the server access logs of a bucket are delivered.
+type LoggingEnabled struct {
+	TargetBucket string    `xml:"TargetBucket"`
+	TargetPrefix string    `xml:"TargetPrefix"`
+	TargetGrants *struct{} `xml:"TargetGrants,omitempty"`
+}
+
+// Config - bucket logging status, as specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketLogging.html
+type Config struct {
+	XMLNS          string          `xml:"xmlns,attr,omitempty"`
+	XMLName        xml.Name        `xml:"BucketLoggingStatus"`
+	LoggingEnabled *LoggingEnabled `xml:"LoggingEnabled,omitempty"`
+}
+
+// ParseConfig - parses and validates a bucket logging status.
+func ParseConfig(r io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(r).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.XMLNS == "" {
+		config.XMLNS = xmlNS
+	}
+	return &config, nil
+}
+
+// Validate - validates the logging status.
+func (c Config) Validate() error {
+	if c.LoggingEnabled == nil {
+		return nil
+	}
+	if c.LoggingEnabled.TargetBucket == "" {
+		return errMissingTargetBucket
+	}
+	if c.LoggingEnabled.TargetGrants != nil {
+		return errTargetGrants
+	}
+	return nil
+}
+
+// Enabled - returns true if server access logging is enabled.
+func (c *Config) Enabled() bool {
+	return c != nil && c.LoggingEnabled != nil
+}
+
+// Target - returns the bucket and the object name prefix the
+// server access logs are delivered to.
+func (c *Config) Target() (bucket, prefix string) {
+	if !c.Enabled() {
+		return "", ""
+	}
+	return c.LoggingEnabled.TargetBucket, c.LoggingEnabled.TargetPrefix
+}
@@ -0,0 +1,153 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input         string
+		expectErr     bool
+		expectEnabled bool
+	}{
+		// Test 1: logging enabled.
+		{
+			input:         `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			expectEnabled: true,
+		},
+		// Test 2: empty logging status disables logging.
+		{
+			input: `<BucketLoggingStatus xmlns="http://s3.amazonaws.com/doc/2006-03-01/"></BucketLoggingStatus>`,
+		},
+		// Test 3: missing target bucket.
+		{
+			input:     `<BucketLoggingStatus><LoggingEnabled><TargetPrefix>access/</TargetPrefix></LoggingEnabled></BucketLoggingStatus>`,
+			expectErr: true,
+		},
+		// Test 4: target grants are not supported.
+		{
+			input:     `<BucketLoggingStatus><LoggingEnabled><TargetBucket>logs</TargetBucket><TargetPrefix></TargetPrefix><TargetGrants><Grant><Permission>READ</Permission></Grant></TargetGrants></LoggingEnabled></BucketLoggingStatus>`,
+			expectErr: true,
+		},
+		// Test 5: malformed XML.
+		{
+			input:     `<BucketLoggingStatus><LoggingEnabled>`,
+			expectErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		config, err := ParseConfig(strings.NewReader(tc.input))
+		if tc.expectErr {
+			if err == nil {
+				t.Fatalf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if config.Enabled() != tc.expectEnabled {
+			t.Fatalf("Test %d: expected enabled %v, got %v", i+1, tc.expectEnabled, config.Enabled())
+		}
+	}
+}
+
+func TestFormatEntry(t *testing.T) {
+	getObject := audit.Entry{
+		Time:       "2021-09-20T10:11:12.000000013Z",
+		RemoteHost: "192.168.1.10",
+		RequestID:  "16A6B3C2D1E0F9A8",
+		UserAgent:  "aws-cli/2.2.0",
+		ReqMethod:  "GET",
+		ReqPath:    "/bucket/dir/my%20object",
+		ReqHost:    "localhost:9000",
+		AccessKey:  "minio",
+		ReqQuery:   map[string]string{"versionId": "v1"},
+		ReqHeader: map[string]string{
+			"Authorization": "AWS4-HMAC-SHA256 Credential=minio/20210920/us-east-1/s3/aws4_request",
+			"Referer":       "http://example.com/",
+		},
+		RespHeader: map[string]string{
+			"Content-Length":   "10",
+			"Content-Range":    "bytes 0-9/100",
+			"X-Amz-Version-Id": "v1",
+		},
+	}
+	getObject.API.Bucket = "bucket"
+	getObject.API.Object = "dir/my object"
+	getObject.API.StatusCode = 206
+	getObject.API.TimeToResponse = "25000000ns"
+	getObject.API.TimeToFirstByte = "5000000ns"
+
+	anonymousPut := audit.Entry{
+		Time:       "2021-09-20T10:11:12Z",
+		RemoteHost: "192.168.1.11",
+		ReqMethod:  "PUT",
+		ReqPath:    "/bucket/obj",
+		ReqHost:    "localhost:9000",
+		ReqQuery:   map[string]string{"uploadId": "abc", "partNumber": "1"},
+		ReqHeader:  map[string]string{"Content-Length": "5"},
+		RespHeader: map[string]string{},
+	}
+	anonymousPut.API.Bucket = "bucket"
+	anonymousPut.API.Object = "obj"
+	anonymousPut.API.StatusCode = 403
+
+	presignedList := audit.Entry{
+		Time:      "2021-09-20T10:11:12Z",
+		ReqMethod: "GET",
+		ReqPath:   "/bucket/",
+		ReqQuery: map[string]string{
+			"X-Amz-Algorithm": "AWS4-HMAC-SHA256",
+			"X-Amz-Signature": "secret",
+			"versioning":      "",
+		},
+	}
+	presignedList.API.Bucket = "bucket"
+	presignedList.API.StatusCode = 200
+
+	testCases := []struct {
+		entry    audit.Entry
+		expected string
+	}{
+		{
+			entry:    getObject,
+			expected: `- bucket [20/Sep/2021:10:11:12 +0000] 192.168.1.10 minio 16A6B3C2D1E0F9A8 REST.GET.OBJECT dir%2Fmy+object "GET /bucket/dir/my%20object?versionId=v1 HTTP/1.1" 206 - 10 100 25 5 "http://example.com/" "aws-cli/2.2.0" v1 - SigV4 - AuthHeader localhost:9000 -`,
+		},
+		{
+			entry:    anonymousPut,
+			expected: `- bucket [20/Sep/2021:10:11:12 +0000] 192.168.1.11 - - REST.PUT.PART obj "PUT /bucket/obj?partNumber=1&uploadId=abc HTTP/1.1" 403 - - 5 - - - - - - - - - localhost:9000 -`,
+		},
+		{
+			entry:    presignedList,
+			expected: `- bucket [20/Sep/2021:10:11:12 +0000] - - - REST.GET.VERSIONING - "GET /bucket/?X-Amz-Algorithm=AWS4-HMAC-SHA256&versioning HTTP/1.1" 200 - - - - - - - - - SigV4 - QueryString - -`,
+		},
+	}
+
+	for i, tc := range testCases {
+		if got := FormatEntry(tc.entry); got != tc.expected {
+			t.Errorf("Test %d: expected\n%s\ngot\n%s", i+1, tc.expected, got)
+		}
+	}
+}
@@ -0,0 +1,233 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package logging
+
+import (
+	"net/http"
+	"net/url"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	xhttp "github.com/minio/minio/internal/http"
+	"github.com/minio/minio/internal/logger/message/audit"
+)
+
+// recordTimeFormat - time format of the server access log records.
+const recordTimeFormat = "02/Jan/2006:15:04:05 -0700"
+
+// Sub-resources reported in the operation of a log record, any other
+// request is reported as an operation on the bucket or the object.
+var operationSubResources = []string{
+	"acl", "cors", "delete", "encryption", "legal-hold", "lifecycle",
+	"location", "logging", "notification", "object-lock", "policy",
+	"replication", "restore", "retention", "select", "tagging",
+	"versioning", "versions", "website",
+}
+
+// Query parameters never written to a log record.
+var redactedQueryParams = map[string]struct{}{
+	xhttp.AmzSignature: {},
+	"Signature":        {},
+}
+
+func orDash(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return s
+}
+
+func quote(s string) string {
+	if s == "" {
+		return "-"
+	}
+	return `"` + strings.ReplaceAll(s, `"`, `\"`) + `"`
+}
+
+// operation - returns the S3 operation of the request, in the
+// REST.<METHOD>.<RESOURCE> form used by the server access logs.
+func operation(e audit.Entry) string {
+	method := e.ReqMethod
+	resource := "SERVICE"
+	switch {
+	case e.API.Object != "":
+		resource = "OBJECT"
+	case e.API.Bucket != "":
+		resource = "BUCKET"
+	}
+
+	_, uploadID := e.ReqQuery["uploadId"]
+	_, partNumber := e.ReqQuery["partNumber"]
+	_, uploads := e.ReqQuery["uploads"]
+	switch {
+	case uploadID && partNumber:
+		resource = "PART"
+	case uploads:
+		resource = "UPLOADS"
+	case uploadID:
+		resource = "UPLOAD"
+	default:
+		for _, sub := range operationSubResources {
+			if _, ok := e.ReqQuery[sub]; ok {
+				resource = strings.ToUpper(strings.ReplaceAll(sub, "-", "_"))
+				break
+			}
+		}
+	}
+
+	if _, ok := e.ReqHeader[xhttp.AmzCopySource]; ok && method == "PUT" {
+		method = "COPY"
+	}
+	return "REST." + orDash(method) + "." + resource
+}
+
+// requestURI - returns the request line of the request.
+func requestURI(e audit.Entry) string {
+	if e.ReqMethod == "" {
+		return ""
+	}
+	keys := make([]string, 0, len(e.ReqQuery))
+	for k := range e.ReqQuery {
+		if _, ok := redactedQueryParams[k]; ok {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	sort.Strings(keys)
+
+	var query []string
+	for _, k := range keys {
+		if v := e.ReqQuery[k]; v != "" {
+			query = append(query, url.QueryEscape(k)+"="+url.QueryEscape(v))
+		} else {
+			query = append(query, url.QueryEscape(k))
+		}
+	}
+
+	uri := e.ReqPath
+	if len(query) > 0 {
+		uri += "?" + strings.Join(query, "&")
+	}
+	return e.ReqMethod + " " + uri + " HTTP/1.1"
+}
+
+// objectSize - returns the total size of the object read or written.
+func objectSize(e audit.Entry) string {
+	if e.API.Object == "" {
+		return ""
+	}
+	switch e.ReqMethod {
+	case "GET", "HEAD":
+		if cr := e.RespHeader[xhttp.ContentRange]; cr != "" {
+			if i := strings.LastIndex(cr, "/"); i >= 0 && cr[i+1:] != "*" {
+				return cr[i+1:]
+			}
+		}
+		return e.RespHeader[xhttp.ContentLength]
+	case "PUT":
+		if size := e.ReqHeader[xhttp.AmzDecodedContentLength]; size != "" {
+			return size
+		}
+		return e.ReqHeader[xhttp.ContentLength]
+	}
+	return ""
+}
+
+// signature - returns the signature version and the authentication
+// type used by the request.
+func signature(e audit.Entry) (version, authType string) {
+	if auth := e.ReqHeader[xhttp.Authorization]; auth != "" {
+		if strings.HasPrefix(auth, "AWS4-HMAC-SHA256") {
+			return "SigV4", "AuthHeader"
+		}
+		return "SigV2", "AuthHeader"
+	}
+	if _, ok := e.ReqQuery[xhttp.AmzAlgorithm]; ok {
+		return "SigV4", "QueryString"
+	}
+	if _, ok := e.ReqQuery["Signature"]; ok {
+		return "SigV2", "QueryString"
+	}
+	return "", ""
+}
+
+// milliseconds - converts a duration of the audit entry to milliseconds.
+func milliseconds(d string) string {
+	if d == "" {
+		return ""
+	}
+	duration, err := time.ParseDuration(d)
+	if err != nil {
+		return ""
+	}
+	return strconv.FormatInt(duration.Milliseconds(), 10)
+}
+
+// FormatEntry - converts an audit entry into a single server access
+// log record, as documented in
+// https://docs.aws.amazon.com/AmazonS3/latest/userguide/LogFormat.html
+//
+// Fields which are unknown to the server are logged as "-".
+func FormatEntry(e audit.Entry) string {
+	t, err := time.Parse(time.RFC3339Nano, e.Time)
+	if err != nil {
+		t = time.Now().UTC()
+	}
+
+	key := e.API.Object
+	if key != "" {
+		key = url.QueryEscape(key)
+	}
+
+	var status string
+	if e.API.StatusCode != 0 {
+		status = strconv.Itoa(e.API.StatusCode)
+	}
+
+	sigVersion, authType := signature(e)
+
+	fields := []string{
+		"-", // bucket owner
+		orDash(e.API.Bucket),
+		"[" + t.Format(recordTimeFormat) + "]",
+		orDash(e.RemoteHost),
+		orDash(e.AccessKey),
+		orDash(e.RequestID),
+		operation(e),
+		orDash(key),
+		quote(requestURI(e)),
+		orDash(status),
+		"-", // error code
+		orDash(e.RespHeader[xhttp.ContentLength]),
+		orDash(objectSize(e)),
+		orDash(milliseconds(e.API.TimeToResponse)),
+		orDash(milliseconds(e.API.TimeToFirstByte)),
+		quote(e.ReqHeader["Referer"]),
+		quote(e.UserAgent),
+		orDash(e.RespHeader[http.CanonicalHeaderKey(xhttp.AmzVersionID)]),
+		"-", // host id
+		orDash(sigVersion),
+		"-", // cipher suite
+		orDash(authType),
+		orDash(e.ReqHost),
+		"-", // TLS version
+	}
+	return strings.Join(fields, " ")
+}
@@ -0,0 +1,254 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package website
+
+import (
+	"encoding/xml"
+	"io"
+	"net/url"
+	"strings"
+)
+
+const (
+	// Maximum number of routing rules, same as AWS S3.
+	maxRoutingRules = 50
+
+	xmlNS = "http://s3.amazonaws.com/doc/2006-03-01/"
+)
+
+var (
+	errMissingIndexDocument = Errorf("A value for IndexDocument Suffix must be provided if RedirectAllRequestsTo is empty")
+	errRedirectAllExclusive = Errorf("RedirectAllRequestsTo cannot be provided in conjunction with other Routing/Redirect configurations.")
+	errInvalidSuffix        = Errorf("The IndexDocument Suffix is not well formed")
+	errMissingHostName      = Errorf("RedirectAllRequestsTo must contain a HostName")
+	errInvalidProtocol      = Errorf("Invalid protocol, protocol can be http or https. If not defined the protocol will be selected automatically.")
+	errTooManyRoutingRules  = Errorf("The number of routing rules must not exceed %d", maxRoutingRules)
+	errMissingRedirect      = Errorf("RoutingRule must contain a Redirect")
+	errEmptyCondition       = Errorf("Condition cannot be empty. To redirect all requests without a condition, the condition element shouldn't be present.")
+	errReplaceKeyExclusive  = Errorf("You can only define ReplaceKeyPrefix or ReplaceKey but not both.")
+	errInvalidRedirectCode  = Errorf("The provided HTTP redirect code is not valid. It should be a string containing a number in the 3XX range.")
+	errInvalidErrorCode     = Errorf("The provided HTTP error code is not valid. Valid codes are 4XX or 5XX.")
+	errMissingErrorKey      = Errorf("The ErrorDocument Key must be provided")
+)
+
+// IndexDocument - the document returned for requests on a directory.
+type IndexDocument struct {
+	Suffix string `xml:"Suffix"`
+}
+
+// ErrorDocument - the object returned when an error occurs.
+type ErrorDocument struct {
+	Key string `xml:"Key"`
+}
+
+// RedirectAllRequestsTo - redirects every request to another host.
+type RedirectAllRequestsTo struct {
+	HostName string `xml:"HostName"`
+	Protocol string `xml:"Protocol,omitempty"`
+}
+
+// Condition - the condition which must be met for a redirect to apply.
+type Condition struct {
+	HTTPErrorCodeReturnedEquals int    `xml:"HttpErrorCodeReturnedEquals,omitempty"`
+	KeyPrefixEquals             string `xml:"KeyPrefixEquals,omitempty"`
+}
+
+// Redirect - where and how a matching request is redirected.
+type Redirect struct {
+	HostName             string  `xml:"HostName,omitempty"`
+	HTTPRedirectCode     int     `xml:"HttpRedirectCode,omitempty"`
+	Protocol             string  `xml:"Protocol,omitempty"`
+	ReplaceKeyPrefixWith *string `xml:"ReplaceKeyPrefixWith,omitempty"`
+	ReplaceKeyWith       string  `xml:"ReplaceKeyWith,omitempty"`
+}
+
+// RoutingRule - redirects requests matching a condition.
+type RoutingRule struct {
+	Condition *Condition `xml:"Condition,omitempty"`
+	Redirect  *Redirect  `xml:"Redirect"`
+}
+
+// Config - bucket website configuration, as specified in
+// https://docs.aws.amazon.com/AmazonS3/latest/API/API_PutBucketWebsite.html
+type Config struct {
+	XMLNS                 string                 `xml:"xmlns,attr,omitempty"`
+	XMLName               xml.Name               `xml:"WebsiteConfiguration"`
+	ErrorDocument         *ErrorDocument         `xml:"ErrorDocument,omitempty"`
+	IndexDocument         *IndexDocument         `xml:"IndexDocument,omitempty"`
+	RedirectAllRequestsTo *RedirectAllRequestsTo `xml:"RedirectAllRequestsTo,omitempty"`
+	RoutingRules          []RoutingRule          `xml:"RoutingRules>RoutingRule,omitempty"`
+}
+
+// ParseConfig - parses and validates a bucket website configuration.
+func ParseConfig(r io.Reader) (*Config, error) {
+	var config Config
+	if err := xml.NewDecoder(r).Decode(&config); err != nil {
+		return nil, err
+	}
+	if err := config.Validate(); err != nil {
+		return nil, err
+	}
+	if config.XMLNS == "" {
+		config.XMLNS = xmlNS
+	}
+	return &config, nil
+}
+
+func validProtocol(protocol string) bool {
+	return protocol == "" || protocol == "http" || protocol == "https"
+}
+
+// Validate - validates the website configuration.
+func (c Config) Validate() error {
+	if c.RedirectAllRequestsTo != nil {
+		if c.IndexDocument != nil || c.ErrorDocument != nil || len(c.RoutingRules) > 0 {
+			return errRedirectAllExclusive
+		}
+		if c.RedirectAllRequestsTo.HostName == "" {
+			return errMissingHostName
+		}
+		if !validProtocol(c.RedirectAllRequestsTo.Protocol) {
+			return errInvalidProtocol
+		}
+		return nil
+	}
+
+	if c.IndexDocument == nil || c.IndexDocument.Suffix == "" {
+		return errMissingIndexDocument
+	}
+	if strings.Contains(c.IndexDocument.Suffix, "/") {
+		return errInvalidSuffix
+	}
+	if c.ErrorDocument != nil && c.ErrorDocument.Key == "" {
+		return errMissingErrorKey
+	}
+	if len(c.RoutingRules) > maxRoutingRules {
+		return errTooManyRoutingRules
+	}
+	for _, rule := range c.RoutingRules {
+		if err := rule.Validate(); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+// Validate - validates a single routing rule.
+func (r RoutingRule) Validate() error {
+	if r.Redirect == nil {
+		return errMissingRedirect
+	}
+	if r.Condition != nil {
+		if r.Condition.HTTPErrorCodeReturnedEquals == 0 && r.Condition.KeyPrefixEquals == "" {
+			return errEmptyCondition
+		}
+		if code := r.Condition.HTTPErrorCodeReturnedEquals; code != 0 && (code < 400 || code > 599) {
+			return errInvalidErrorCode
+		}
+	}
+	if r.Redirect.ReplaceKeyPrefixWith != nil && r.Redirect.ReplaceKeyWith != "" {
+		return errReplaceKeyExclusive
+	}
+	if code := r.Redirect.HTTPRedirectCode; code != 0 && (code < 300 || code > 399) {
+		return errInvalidRedirectCode
+	}
+	if !validProtocol(r.Redirect.Protocol) {
+		return errInvalidProtocol
+	}
+	return nil
+}
+
+// IndexKey returns the object key serving the request for key, requests
+// for the root or a directory are served by the index document.
+func (c *Config) IndexKey(key string) string {
+	if c.IndexDocument == nil {
+		return key
+	}
+	if key == "" || strings.HasSuffix(key, "/") {
+		return key + c.IndexDocument.Suffix
+	}
+	return key
+}
+
+// ErrorKey returns the object key of the error document, if any.
+func (c *Config) ErrorKey() (string, bool) {
+	if c.ErrorDocument == nil {
+		return "", false
+	}
+	return c.ErrorDocument.Key, true
+}
+
+// RedirectAll returns the location all requests for key are
+// redirected to, scheme is used if no protocol is configured.
+func (c *Config) RedirectAll(scheme, key string) (string, bool) {
+	if c.RedirectAllRequestsTo == nil {
+		return "", false
+	}
+	if c.RedirectAllRequestsTo.Protocol != "" {
+		scheme = c.RedirectAllRequestsTo.Protocol
+	}
+	return location(scheme, c.RedirectAllRequestsTo.HostName, key), true
+}
+
+// Route returns the location and redirect code of the first routing rule
+// matching key and the HTTP error code of the request, errCode is zero for
+// requests which did not fail. Rules with an error code condition only
+// match failed requests.
+func (c *Config) Route(scheme, host, key string, errCode int) (string, int, bool) {
+	for _, rule := range c.RoutingRules {
+		var prefix string
+		if cond := rule.Condition; cond != nil {
+			if cond.HTTPErrorCodeReturnedEquals != 0 && cond.HTTPErrorCodeReturnedEquals != errCode {
+				continue
+			}
+			if !strings.HasPrefix(key, cond.KeyPrefixEquals) {
+				continue
+			}
+			prefix = cond.KeyPrefixEquals
+		}
+
+		redirect := rule.Redirect
+		switch {
+		case redirect.ReplaceKeyWith != "":
+			key = redirect.ReplaceKeyWith
+		case redirect.ReplaceKeyPrefixWith != nil:
+			key = *redirect.ReplaceKeyPrefixWith + strings.TrimPrefix(key, prefix)
+		}
+		if redirect.Protocol != "" {
+			scheme = redirect.Protocol
+		}
+		if redirect.HostName != "" {
+			host = redirect.HostName
+		}
+		code := redirect.HTTPRedirectCode
+		if code == 0 {
+			code = 301
+		}
+		return location(scheme, host, key), code, true
+	}
+	return "", 0, false
+}
+
+func location(scheme, host, key string) string {
+	u := url.URL{
+		Scheme: scheme,
+		Host:   host,
+		Path:   "/" + key,
+	}
+	return u.String()
+}
@@ -0,0 +1,155 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package website
+
+import (
+	"bytes"
+	"encoding/xml"
+	"strings"
+	"testing"
+)
+
+func TestParseConfig(t *testing.T) {
+	testCases := []struct {
+		input     string
+		expectErr bool
+	}{
+		// Test 1: index and error documents.
+		{
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><ErrorDocument><Key>error.html</Key></ErrorDocument></WebsiteConfiguration>`,
+		},
+		// Test 2: redirect all requests.
+		{
+			input: `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>https</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+		},
+		// Test 3: routing rules.
+		{
+			input: `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+		},
+		// Test 4: missing index document.
+		{
+			input:     `<WebsiteConfiguration><ErrorDocument><Key>error.html</Key></ErrorDocument></WebsiteConfiguration>`,
+			expectErr: true,
+		},
+		// Test 5: redirect all with other settings.
+		{
+			input:     `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RedirectAllRequestsTo><HostName>example.com</HostName></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectErr: true,
+		},
+		// Test 6: index document suffix with slash.
+		{
+			input:     `<WebsiteConfiguration><IndexDocument><Suffix>dir/index.html</Suffix></IndexDocument></WebsiteConfiguration>`,
+			expectErr: true,
+		},
+		// Test 7: both ReplaceKeyPrefixWith and ReplaceKeyWith.
+		{
+			input:     `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><ReplaceKeyPrefixWith>a/</ReplaceKeyPrefixWith><ReplaceKeyWith>b</ReplaceKeyWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectErr: true,
+		},
+		// Test 8: invalid redirect code.
+		{
+			input:     `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Redirect><HttpRedirectCode>200</HttpRedirectCode></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectErr: true,
+		},
+		// Test 9: invalid protocol.
+		{
+			input:     `<WebsiteConfiguration><RedirectAllRequestsTo><HostName>example.com</HostName><Protocol>ftp</Protocol></RedirectAllRequestsTo></WebsiteConfiguration>`,
+			expectErr: true,
+		},
+		// Test 10: empty condition.
+		{
+			input:     `<WebsiteConfiguration><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition></Condition><Redirect><HostName>example.com</HostName></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`,
+			expectErr: true,
+		},
+	}
+
+	for i, tc := range testCases {
+		_, err := ParseConfig(strings.NewReader(tc.input))
+		if tc.expectErr && err == nil {
+			t.Errorf("Test %d: expected error, got nil", i+1)
+		}
+		if !tc.expectErr && err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+	}
+}
+
+func TestConfigRoundTrip(t *testing.T) {
+	input := `<WebsiteConfiguration xmlns="http://s3.amazonaws.com/doc/2006-03-01/"><IndexDocument><Suffix>index.html</Suffix></IndexDocument><RoutingRules><RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith></ReplaceKeyPrefixWith></Redirect></RoutingRule></RoutingRules></WebsiteConfiguration>`
+	config, err := ParseConfig(strings.NewReader(input))
+	if err != nil {
+		t.Fatal(err)
+	}
+	data, err := xml.Marshal(config)
+	if err != nil {
+		t.Fatal(err)
+	}
+	if !bytes.Equal(data, []byte(input)) {
+		t.Fatalf("expected %s, got %s", input, data)
+	}
+}
+
+func TestConfigRoute(t *testing.T) {
+	config, err := ParseConfig(strings.NewReader(`<WebsiteConfiguration>` +
+		`<IndexDocument><Suffix>index.html</Suffix></IndexDocument>` +
+		`<RoutingRules>` +
+		`<RoutingRule><Condition><KeyPrefixEquals>docs/</KeyPrefixEquals></Condition><Redirect><ReplaceKeyPrefixWith>documents/</ReplaceKeyPrefixWith></Redirect></RoutingRule>` +
+		`<RoutingRule><Condition><HttpErrorCodeReturnedEquals>404</HttpErrorCodeReturnedEquals></Condition><Redirect><HostName>example.com</HostName><Protocol>https</Protocol><HttpRedirectCode>302</HttpRedirectCode><ReplaceKeyWith>404.html</ReplaceKeyWith></Redirect></RoutingRule>` +
+		`</RoutingRules></WebsiteConfiguration>`))
+	if err != nil {
+		t.Fatal(err)
+	}
+
+	testCases := []struct {
+		key      string
+		errCode  int
+		location string
+		code     int
+		match    bool
+	}{
+		// Test 1: prefix replaced, same host.
+		{key: "docs/a.html", location: "http://site.local/documents/a.html", code: 301, match: true},
+		// Test 2: no rule matches a successful request.
+		{key: "a.html"},
+		// Test 3: error code rule.
+		{key: "a.html", errCode: 404, location: "https://example.com/404.html", code: 302, match: true},
+		// Test 4: error code rule doesn't match other errors.
+		{key: "a.html", errCode: 403},
+	}
+
+	for i, tc := range testCases {
+		location, code, ok := config.Route("http", "site.local", tc.key, tc.errCode)
+		if ok != tc.match {
+			t.Errorf("Test %d: expected match %v, got %v", i+1, tc.match, ok)
+			continue
+		}
+		if location != tc.location || code != tc.code {
+			t.Errorf("Test %d: expected %s (%d), got %s (%d)", i+1, tc.location, tc.code, location, code)
+		}
+	}
+
+	for key, expected := range map[string]string{
+		"":          "index.html",
+		"dir/":      "dir/index.html",
+		"file.html": "file.html",
+	} {
+		if got := config.IndexKey(key); got != expected {
+			t.Errorf("IndexKey(%q): expected %q, got %q", key, expected, got)
+		}
+	}
+}
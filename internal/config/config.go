@@ -80,6 +80,8 @@ const (
 	HealSubSys           = "heal"
 	ScannerSubSys        = "scanner"
 	CrawlerSubSys        = "crawler"
+	RebalanceSubSys      = "rebalance"
+	ScrubSubSys          = "scrub"
 	SubnetSubSys         = "subnet"
 
 	// Add new constants here if you add new fields to config.
@@ -119,6 +121,8 @@ var SubSystems = set.CreateStringSet(
 	IdentityTLSSubSys,
 	ScannerSubSys,
 	HealSubSys,
+	RebalanceSubSys,
+	ScrubSubSys,
 	NotifyAMQPSubSys,
 	NotifyESSubSys,
 	NotifyKafkaSubSys,
@@ -138,6 +142,8 @@ var SubSystemsDynamic = set.CreateStringSet(
 	CompressionSubSys,
 	ScannerSubSys,
 	HealSubSys,
+	RebalanceSubSys,
+	ScrubSubSys,
 	SubnetSubSys,
 )
 
@@ -156,6 +162,8 @@ var SubSystemsSingleTargets = set.CreateStringSet([]string{
 	IdentityTLSSubSys,
 	HealSubSys,
 	ScannerSubSys,
+	RebalanceSubSys,
+	ScrubSubSys,
 }...)
 
 // Constant separators
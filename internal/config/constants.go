@@ -29,13 +29,14 @@ const (
 	EnvRootUser     = "MINIO_ROOT_USER"
 	EnvRootPassword = "MINIO_ROOT_PASSWORD"
 
-	EnvBrowser    = "MINIO_BROWSER"
-	EnvDomain     = "MINIO_DOMAIN"
-	EnvRegionName = "MINIO_REGION_NAME"
-	EnvPublicIPs  = "MINIO_PUBLIC_IPS"
-	EnvFSOSync    = "MINIO_FS_OSYNC"
-	EnvArgs       = "MINIO_ARGS"
-	EnvDNSWebhook = "MINIO_DNS_WEBHOOK_ENDPOINT"
+	EnvBrowser       = "MINIO_BROWSER"
+	EnvDomain        = "MINIO_DOMAIN"
+	EnvWebsiteDomain = "MINIO_WEBSITE_DOMAIN"
+	EnvRegionName    = "MINIO_REGION_NAME"
+	EnvPublicIPs     = "MINIO_PUBLIC_IPS"
+	EnvFSOSync       = "MINIO_FS_OSYNC"
+	EnvArgs          = "MINIO_ARGS"
+	EnvDNSWebhook    = "MINIO_DNS_WEBHOOK_ENDPOINT"
 
 	EnvMinIOSubnetLicense      = "MINIO_SUBNET_LICENSE"
 	EnvMinIOServerURL          = "MINIO_SERVER_URL"
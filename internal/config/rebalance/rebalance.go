@@ -0,0 +1,112 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package rebalance
+
+import (
+	"fmt"
+	"strconv"
+	"time"
+
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Rebalance environment variables
+const (
+	Threshold = "threshold"
+	Delay     = "delay"
+	MaxWait   = "max_wait"
+
+	EnvThreshold = "MINIO_REBALANCE_THRESHOLD"
+	EnvDelay     = "MINIO_REBALANCE_DELAY"
+	EnvMaxWait   = "MINIO_REBALANCE_MAX_WAIT"
+)
+
+// Config represents the rebalance settings.
+type Config struct {
+	// Threshold is the maximum difference in percentage points
+	// between the free space of a pool and the cluster average.
+	Threshold float64 `json:"threshold"`
+	// Delay is the sleep multiplier.
+	Delay float64 `json:"delay"`
+	// MaxWait is maximum wait time between operations
+	MaxWait time.Duration `json:"maxWait"`
+}
+
+var (
+	// DefaultKVS - default KV config for rebalance settings
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   Threshold,
+			Value: "5",
+		},
+		config.KV{
+			Key:   Delay,
+			Value: "1",
+		},
+		config.KV{
+			Key:   MaxWait,
+			Value: "1s",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         Threshold,
+			Description: `allowed difference in free space percentage between a pool and the cluster average, defaults to '5'`,
+			Optional:    true,
+			Type:        "float",
+		},
+		config.HelpKV{
+			Key:         Delay,
+			Description: `rebalance delay multiplier, defaults to '1.0'`,
+			Optional:    true,
+			Type:        "float",
+		},
+		config.HelpKV{
+			Key:         MaxWait,
+			Description: `maximum wait time between objects to slow down rebalance, defaults to '1s'`,
+			Optional:    true,
+			Type:        "duration",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.RebalanceSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Threshold, err = strconv.ParseFloat(env.Get(EnvThreshold, kvs.Get(Threshold)), 64)
+	if err != nil {
+		return cfg, fmt.Errorf("'rebalance:threshold' value invalid: %w", err)
+	}
+	if cfg.Threshold < 0 || cfg.Threshold > 100 {
+		return cfg, fmt.Errorf("'rebalance:threshold' value must be between 0 and 100, got %v", cfg.Threshold)
+	}
+	cfg.Delay, err = strconv.ParseFloat(env.Get(EnvDelay, kvs.Get(Delay)), 64)
+	if err != nil {
+		return cfg, fmt.Errorf("'rebalance:delay' value invalid: %w", err)
+	}
+	cfg.MaxWait, err = time.ParseDuration(env.Get(EnvMaxWait, kvs.Get(MaxWait)))
+	if err != nil {
+		return cfg, fmt.Errorf("'rebalance:max_wait' value invalid: %w", err)
+	}
+	return cfg, nil
+}
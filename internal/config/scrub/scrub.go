@@ -0,0 +1,112 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package scrub
+
+import (
+	"fmt"
+	"time"
+
+	"github.com/dustin/go-humanize"
+	"github.com/minio/minio/internal/config"
+	"github.com/minio/pkg/env"
+)
+
+// Scrub environment variables
+const (
+	Bandwidth = "bandwidth"
+	Interval  = "interval"
+
+	EnvEnable    = "MINIO_SCRUB_ENABLE"
+	EnvBandwidth = "MINIO_SCRUB_BANDWIDTH"
+	EnvInterval  = "MINIO_SCRUB_INTERVAL"
+)
+
+// Config represents the scrub settings.
+type Config struct {
+	// Enabled scrubs all erasure sets periodically.
+	Enabled bool `json:"enabled"`
+	// Bandwidth is the maximum number of bytes verified per second
+	// on each node.
+	Bandwidth uint64 `json:"bandwidth"`
+	// Interval is the time between the end of a scrub of an erasure
+	// set and the start of its next scrub.
+	Interval time.Duration `json:"interval"`
+}
+
+var (
+	// DefaultKVS - default KV config for scrub settings
+	DefaultKVS = config.KVS{
+		config.KV{
+			Key:   config.Enable,
+			Value: config.EnableOff,
+		},
+		config.KV{
+			Key:   Bandwidth,
+			Value: "10MiB",
+		},
+		config.KV{
+			Key:   Interval,
+			Value: "720h",
+		},
+	}
+
+	// Help provides help for config values
+	Help = config.HelpKVS{
+		config.HelpKV{
+			Key:         config.Enable,
+			Description: `set to 'on' to periodically verify the bitrot checksums of all objects, defaults to 'off'`,
+			Optional:    true,
+			Type:        "on|off",
+		},
+		config.HelpKV{
+			Key:         Bandwidth,
+			Description: `maximum bytes verified per second on each node e.g. "50MiB", defaults to '10MiB'`,
+			Optional:    true,
+			Type:        "string",
+		},
+		config.HelpKV{
+			Key:         Interval,
+			Description: `time between two scrubs of an erasure set, defaults to '720h'`,
+			Optional:    true,
+			Type:        "duration",
+		},
+	}
+)
+
+// LookupConfig - lookup config and override with valid environment settings if any.
+func LookupConfig(kvs config.KVS) (cfg Config, err error) {
+	if err = config.CheckValidKeys(config.ScrubSubSys, kvs, DefaultKVS); err != nil {
+		return cfg, err
+	}
+	cfg.Enabled, err = config.ParseBool(env.Get(EnvEnable, kvs.Get(config.Enable)))
+	if err != nil {
+		return cfg, fmt.Errorf("'scrub:enable' value invalid: %w", err)
+	}
+	cfg.Bandwidth, err = humanize.ParseBytes(env.Get(EnvBandwidth, kvs.Get(Bandwidth)))
+	if err != nil {
+		return cfg, fmt.Errorf("'scrub:bandwidth' value invalid: %w", err)
+	}
+	if cfg.Bandwidth == 0 {
+		return cfg, fmt.Errorf("'scrub:bandwidth' value must be greater than 0")
+	}
+	cfg.Interval, err = time.ParseDuration(env.Get(EnvInterval, kvs.Get(Interval)))
+	if err != nil {
+		return cfg, fmt.Errorf("'scrub:interval' value invalid: %w", err)
+	}
+	return cfg, nil
+}
@@ -34,6 +34,18 @@ var (
 			Optional:    true,
 			Type:        "string",
 		},
+		config.HelpKV{
+			Key:         ClassCustom,
+			Description: `comma separated list of custom storage classes with their parity count e.g. "ARCHIVE=EC:6,EC:2"`,
+			Optional:    true,
+			Type:        "csv",
+		},
+		config.HelpKV{
+			Key:         ClassBitrot,
+			Description: `set the bitrot algorithm for new objects, "highwayhash256S" or "blake3S"`,
+			Optional:    true,
+			Type:        "string",
+		},
 		config.HelpKV{
 			Key:         config.Comment,
 			Description: config.DefaultComment,
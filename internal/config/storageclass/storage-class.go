@@ -40,11 +40,21 @@ const (
 const (
 	ClassStandard = "standard"
 	ClassRRS      = "rrs"
+	ClassCustom   = "custom"
+	ClassBitrot   = "bitrot"
 
 	// Reduced redundancy storage class environment variable
 	RRSEnv = "MINIO_STORAGE_CLASS_RRS"
 	// Standard storage class environment variable
 	StandardEnv = "MINIO_STORAGE_CLASS_STANDARD"
+	// Custom storage classes environment variable
+	CustomEnv = "MINIO_STORAGE_CLASS_CUSTOM"
+	// Bitrot algorithm environment variable
+	BitrotEnv = "MINIO_STORAGE_CLASS_BITROT"
+
+	// Supported bitrot algorithms for new objects
+	BitrotHighwayHash256S = "highwayhash256S"
+	BitrotBLAKE3S         = "blake3S"
 
 	// Supported storage class scheme is EC
 	schemePrefix = "EC"
@@ -67,6 +77,14 @@ var (
 			Key:   ClassRRS,
 			Value: "EC:2",
 		},
+		config.KV{
+			Key:   ClassCustom,
+			Value: "",
+		},
+		config.KV{
+			Key:   ClassBitrot,
+			Value: "",
+		},
 	}
 )
 
@@ -82,6 +100,13 @@ var ConfigLock = sync.RWMutex{}
 type Config struct {
 	Standard StorageClass `json:"standard"`
 	RRS      StorageClass `json:"rrs"`
+
+	// Custom holds the operator defined storage classes by name.
+	Custom map[string]StorageClass `json:"custom,omitempty"`
+
+	// Bitrot holds the bitrot algorithm protecting new objects,
+	// empty for the default algorithm.
+	Bitrot string `json:"bitrot,omitempty"`
 }
 
 // UnmarshalJSON - Validate SS and RRS parity when unmarshalling JSON.
@@ -101,6 +126,18 @@ func IsValid(sc string) bool {
 	return sc == RRS || sc == STANDARD
 }
 
+// IsValid - returns true if input string is a standard storage
+// class kind or one of the configured custom storage classes.
+func (sCfg *Config) IsValid(sc string) bool {
+	if IsValid(sc) {
+		return true
+	}
+	ConfigLock.RLock()
+	defer ConfigLock.RUnlock()
+	_, ok := sCfg.Custom[sc]
+	return ok
+}
+
 // UnmarshalText unmarshals storage class from its textual form into
 // storageClass structure.
 func (sc *StorageClass) UnmarshalText(b []byte) error {
@@ -160,6 +197,65 @@ func parseStorageClass(storageClassEnv string) (sc StorageClass, err error) {
 	}, nil
 }
 
+// Returns true if name can be used as the name of a custom storage class,
+// names are made of upper case letters, digits and '_', '-', '.' or ':'.
+func isValidCustomName(name string) bool {
+	if name == "" || IsValid(name) {
+		return false
+	}
+	for _, c := range name {
+		switch {
+		case c >= 'A' && c <= 'Z', c >= '0' && c <= '9':
+		case c == '_', c == '-', c == '.', c == ':':
+		default:
+			return false
+		}
+	}
+	return true
+}
+
+// Parses the custom storage classes from a comma separated list of
+// "NAME=EC:parity" entries. An entry "EC:parity" without a name defines a
+// storage class named after its parity, e.g. "EC:6".
+func parseCustomStorageClasses(customEnv string) (map[string]StorageClass, error) {
+	custom := make(map[string]StorageClass)
+	for _, entry := range strings.Split(customEnv, ",") {
+		entry = strings.TrimSpace(entry)
+		if entry == "" {
+			continue
+		}
+		name, value := entry, entry
+		if i := strings.Index(entry, "="); i >= 0 {
+			name, value = strings.TrimSpace(entry[:i]), strings.TrimSpace(entry[i+1:])
+		}
+		if !isValidCustomName(name) {
+			return nil, config.ErrStorageClassValue(nil).Msg("Invalid custom storage class name " + name)
+		}
+		if _, ok := custom[name]; ok {
+			return nil, config.ErrStorageClassValue(nil).Msg("Duplicate custom storage class " + name)
+		}
+		sc, err := parseStorageClass(value)
+		if err != nil {
+			return nil, err
+		}
+		custom[name] = sc
+	}
+	return custom, nil
+}
+
+// Validates the parity disks of the custom storage classes.
+func validateCustomParity(custom map[string]StorageClass, setDriveCount int) error {
+	for name, sc := range custom {
+		if sc.Parity < minParityDisks {
+			return fmt.Errorf("Storage class %s parity %d should be greater than or equal to %d", name, sc.Parity, minParityDisks)
+		}
+		if sc.Parity > setDriveCount/2 {
+			return fmt.Errorf("Storage class %s parity %d should be less than or equal to %d", name, sc.Parity, setDriveCount/2)
+		}
+	}
+	return nil
+}
+
 // ValidateParity validate standard storage class parity.
 func ValidateParity(ssParity, setDriveCount int) error {
 	// SS parity disks should be greater than or equal to minParityDisks.
@@ -218,10 +314,13 @@ func validateParity(ssParity, rrsParity, setDriveCount int) (err error) {
 // -- if input is STANDARD but STANDARD is not configured '0' parity
 //    is returned, the caller is expected to choose the right parity
 //    at that point.
-func (sCfg Config) GetParityForSC(sc string) (parity int) {
+// -- if input is a configured custom storage class its parity is
+//    returned, unknown storage classes are treated as STANDARD.
+func (sCfg *Config) GetParityForSC(sc string) (parity int) {
 	ConfigLock.RLock()
 	defer ConfigLock.RUnlock()
-	switch strings.TrimSpace(sc) {
+	sc = strings.TrimSpace(sc)
+	switch sc {
 	case RRS:
 		// set the rrs parity if available
 		if sCfg.RRS.Parity == 0 {
@@ -229,23 +328,38 @@ func (sCfg Config) GetParityForSC(sc string) (parity int) {
 		}
 		return sCfg.RRS.Parity
 	default:
+		if custom, ok := sCfg.Custom[sc]; ok {
+			return custom.Parity
+		}
 		return sCfg.Standard.Parity
 	}
 }
 
+// GetBitrotAlgorithm - returns the configured bitrot algorithm,
+// empty if the default algorithm is used.
+func (sCfg *Config) GetBitrotAlgorithm() string {
+	ConfigLock.RLock()
+	defer ConfigLock.RUnlock()
+	return sCfg.Bitrot
+}
+
 // Update update storage-class with new config
-func (sCfg Config) Update(newCfg Config) {
+func (sCfg *Config) Update(newCfg Config) {
 	ConfigLock.Lock()
 	defer ConfigLock.Unlock()
 	sCfg.RRS = newCfg.RRS
 	sCfg.Standard = newCfg.Standard
+	sCfg.Custom = newCfg.Custom
+	sCfg.Bitrot = newCfg.Bitrot
 }
 
 // Enabled returns if etcd is enabled.
 func Enabled(kvs config.KVS) bool {
 	ssc := kvs.Get(ClassStandard)
 	rrsc := kvs.Get(ClassRRS)
-	return ssc != "" || rrsc != ""
+	customsc := kvs.Get(ClassCustom)
+	bitrot := kvs.Get(ClassBitrot)
+	return ssc != "" || rrsc != "" || customsc != "" || bitrot != ""
 }
 
 // LookupConfig - lookup storage class config and override with valid environment settings if any.
@@ -278,11 +392,29 @@ func LookupConfig(kvs config.KVS, setDriveCount int) (cfg Config, err error) {
 		cfg.RRS.Parity = defaultRRSParity
 	}
 
+	if customsc := env.Get(CustomEnv, kvs.Get(ClassCustom)); customsc != "" {
+		cfg.Custom, err = parseCustomStorageClasses(customsc)
+		if err != nil {
+			return Config{}, err
+		}
+	}
+
+	switch bitrot := env.Get(BitrotEnv, kvs.Get(ClassBitrot)); bitrot {
+	case "", BitrotHighwayHash256S, BitrotBLAKE3S:
+		cfg.Bitrot = bitrot
+	default:
+		return Config{}, config.ErrStorageClassValue(nil).Msg("Unsupported bitrot algorithm " + bitrot + ". Supported algorithms are " + BitrotHighwayHash256S + " and " + BitrotBLAKE3S)
+	}
+
 	// Validation is done after parsing both the storage classes. This is needed because we need one
 	// storage class value to deduce the correct value of the other storage class.
 	if err = validateParity(cfg.Standard.Parity, cfg.RRS.Parity, setDriveCount); err != nil {
 		return Config{}, err
 	}
 
+	if err = validateCustomParity(cfg.Custom, setDriveCount); err != nil {
+		return Config{}, err
+	}
+
 	return cfg, nil
 }
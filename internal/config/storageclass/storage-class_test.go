@@ -21,6 +21,8 @@ import (
 	"errors"
 	"reflect"
 	"testing"
+
+	"github.com/minio/minio/internal/config"
 )
 
 func TestParseStorageClass(t *testing.T) {
@@ -162,3 +164,91 @@ func TestIsValidStorageClassKind(t *testing.T) {
 		}
 	}
 }
+
+func TestParseCustomStorageClasses(t *testing.T) {
+	tests := []struct {
+		customEnv string
+		want      map[string]StorageClass
+		success   bool
+	}{
+		{"ARCHIVE=EC:6", map[string]StorageClass{"ARCHIVE": {Parity: 6}}, true},
+		{"EC:2, EC:6 ,COLD_1=EC:4", map[string]StorageClass{"EC:2": {Parity: 2}, "EC:6": {Parity: 6}, "COLD_1": {Parity: 4}}, true},
+		{"", map[string]StorageClass{}, true},
+		{"archive=EC:6", nil, false},
+		{"STANDARD=EC:6", nil, false},
+		{"ARCHIVE=EC:6,ARCHIVE=EC:4", nil, false},
+		{"ARCHIVE=AB:6", nil, false},
+		{"ARCHIVE", nil, false},
+	}
+	for i, tt := range tests {
+		got, err := parseCustomStorageClasses(tt.customEnv)
+		if (err == nil) != tt.success {
+			t.Errorf("Test %d, Expected success %t, got %v", i+1, tt.success, err)
+			continue
+		}
+		if tt.success && !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("Test %d, Expected %v, got %v", i+1, tt.want, got)
+		}
+	}
+}
+
+func TestCustomStorageClass(t *testing.T) {
+	scfg := &Config{
+		Standard: StorageClass{Parity: 4},
+		RRS:      StorageClass{Parity: 2},
+		Custom:   map[string]StorageClass{"ARCHIVE": {Parity: 6}, "EC:3": {Parity: 3}},
+	}
+	tests := []struct {
+		sc     string
+		valid  bool
+		parity int
+	}{
+		{STANDARD, true, 4},
+		{RRS, true, 2},
+		{"ARCHIVE", true, 6},
+		{"EC:3", true, 3},
+		{"EC:5", false, 4},
+		{"", false, 4},
+	}
+	for i, tt := range tests {
+		if got := scfg.IsValid(tt.sc); got != tt.valid {
+			t.Errorf("Test %d, Expected Storage Class to be %t, got %t", i+1, tt.valid, got)
+		}
+		if got := scfg.GetParityForSC(tt.sc); got != tt.parity {
+			t.Errorf("Test %d, Expected parity disks %d, got %d", i+1, tt.parity, got)
+		}
+	}
+
+	if err := validateCustomParity(scfg.Custom, 16); err != nil {
+		t.Errorf("Expected custom parity to be valid for 16 drives, got %v", err)
+	}
+	if err := validateCustomParity(scfg.Custom, 8); err == nil {
+		t.Errorf("Expected custom parity 6 to be invalid for 8 drives")
+	}
+}
+
+func TestLookupBitrotAlgorithm(t *testing.T) {
+	tests := []struct {
+		bitrot  string
+		success bool
+	}{
+		{"", true},
+		{BitrotHighwayHash256S, true},
+		{BitrotBLAKE3S, true},
+		{"sha256", false},
+		{"blake3", false},
+	}
+	for i, tt := range tests {
+		kvs := config.KVS{config.KV{Key: ClassBitrot, Value: tt.bitrot}}
+		cfg, err := LookupConfig(kvs, 16)
+		if tt.success && err != nil {
+			t.Errorf("Test %d, Expected success, got %v", i+1, err)
+		}
+		if !tt.success && err == nil {
+			t.Errorf("Test %d, Expected failure for %s", i+1, tt.bitrot)
+		}
+		if tt.success && cfg.Bitrot != tt.bitrot {
+			t.Errorf("Test %d, Expected bitrot algorithm %s, got %s", i+1, tt.bitrot, cfg.Bitrot)
+		}
+	}
+}
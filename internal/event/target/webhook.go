@@ -164,6 +164,27 @@ func (target *WebhookTarget) send(eventData event.Event) error {
 		return err
 	}
 
+	target.setAuthorization(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := target.httpClient.Do(req)
+	if err != nil {
+		target.Close()
+		return err
+	}
+	defer resp.Body.Close()
+	io.Copy(ioutil.Discard, resp.Body)
+
+	if resp.StatusCode < 200 || resp.StatusCode > 299 {
+		target.Close()
+		return fmt.Errorf("sending event failed with %v", resp.Status)
+	}
+
+	return nil
+}
+
+// setAuthorization - sets the configured auth token on the request.
+func (target *WebhookTarget) setAuthorization(req *http.Request) {
 	// Verify if the authToken already contains
 	// <Key> <Token> like format, if this is
 	// already present we can blindly use the
@@ -175,23 +196,26 @@ func (target *WebhookTarget) send(eventData event.Event) error {
 	case 1:
 		req.Header.Set("Authorization", "Bearer "+target.args.AuthToken)
 	}
+}
 
-	req.Header.Set("Content-Type", "application/json")
-
-	resp, err := target.httpClient.Do(req)
+// Transform - synchronously sends an object lambda event to the
+// webhook and returns its response, unlike events it is never
+// queued. The caller must close the response body.
+func (target *WebhookTarget) Transform(ctx context.Context, lambdaEvent interface{}) (*http.Response, error) {
+	data, err := json.Marshal(lambdaEvent)
 	if err != nil {
-		target.Close()
-		return err
+		return nil, err
 	}
-	defer resp.Body.Close()
-	io.Copy(ioutil.Discard, resp.Body)
 
-	if resp.StatusCode < 200 || resp.StatusCode > 299 {
-		target.Close()
-		return fmt.Errorf("sending event failed with %v", resp.Status)
+	req, err := http.NewRequestWithContext(ctx, http.MethodPost, target.args.Endpoint.String(), bytes.NewReader(data))
+	if err != nil {
+		return nil, err
 	}
 
-	return nil
+	target.setAuthorization(req)
+	req.Header.Set("Content-Type", "application/json")
+
+	return target.httpClient.Do(req)
 }
 
 // Send - reads an event from store and sends it to webhook.
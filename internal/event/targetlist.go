@@ -62,6 +62,15 @@ func (list *TargetList) Exists(id TargetID) bool {
 	return found
 }
 
+// Get - returns the target by target ID.
+func (list *TargetList) Get(id TargetID) (Target, bool) {
+	list.RLock()
+	defer list.RUnlock()
+
+	target, found := list.targets[id]
+	return target, found
+}
+
 // TargetIDResult returns result of Remove/Send operation, sets err if
 // any for the associated TargetID
 type TargetIDResult struct {
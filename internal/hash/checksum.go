@@ -0,0 +1,329 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hash
+
+import (
+	"crypto/sha1"
+	"encoding/base64"
+	"hash"
+	"hash/crc32"
+	"net/http"
+	"strconv"
+	"strings"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+// ChecksumType contains information about the checksum type.
+type ChecksumType uint32
+
+const (
+	// ChecksumTrailing indicates the checksum will be sent in the trailing header.
+	// Another checksum type will be set.
+	ChecksumTrailing ChecksumType = 1 << iota
+
+	// ChecksumSHA256 indicates a SHA256 checksum.
+	ChecksumSHA256
+	// ChecksumSHA1 indicates a SHA-1 checksum.
+	ChecksumSHA1
+	// ChecksumCRC32 indicates a CRC32 checksum with IEEE table.
+	ChecksumCRC32
+	// ChecksumCRC32C indicates a CRC32 checksum with Castagnoli table.
+	ChecksumCRC32C
+	// ChecksumInvalid indicates an invalid checksum.
+	ChecksumInvalid
+	// ChecksumMultipart indicates the checksum is computed from
+	// the checksums of the parts of a multipart object.
+	ChecksumMultipart
+
+	// ChecksumNone indicates no checksum.
+	ChecksumNone ChecksumType = 0
+)
+
+// Checksum is a type and base 64 encoded value.
+type Checksum struct {
+	Type    ChecksumType
+	Encoded string
+	Raw     []byte
+}
+
+// Is returns if c is all of t.
+func (c ChecksumType) Is(t ChecksumType) bool {
+	if t == ChecksumNone {
+		return c == ChecksumNone
+	}
+	return c&t == t
+}
+
+// Key returns the header key.
+// returns empty string if invalid or none.
+func (c ChecksumType) Key() string {
+	switch {
+	case c.Is(ChecksumCRC32):
+		return xhttp.AmzChecksumCRC32
+	case c.Is(ChecksumCRC32C):
+		return xhttp.AmzChecksumCRC32C
+	case c.Is(ChecksumSHA1):
+		return xhttp.AmzChecksumSHA1
+	case c.Is(ChecksumSHA256):
+		return xhttp.AmzChecksumSHA256
+	}
+	return ""
+}
+
+// RawByteLen returns the size of the un-encoded checksum.
+func (c ChecksumType) RawByteLen() int {
+	switch {
+	case c.Is(ChecksumCRC32), c.Is(ChecksumCRC32C):
+		return 4
+	case c.Is(ChecksumSHA1):
+		return sha1.Size
+	case c.Is(ChecksumSHA256):
+		return 32
+	}
+	return 0
+}
+
+// IsSet returns whether the type is valid and known.
+func (c ChecksumType) IsSet() bool {
+	return !c.Is(ChecksumInvalid) && c.Key() != ""
+}
+
+// Trailing returns whether the checksum is sent in the trailing header.
+func (c ChecksumType) Trailing() bool {
+	return c.Is(ChecksumTrailing)
+}
+
+// String returns the type as a string as used in the
+// x-amz-checksum-algorithm header.
+func (c ChecksumType) String() string {
+	switch {
+	case c.Is(ChecksumCRC32):
+		return "CRC32"
+	case c.Is(ChecksumCRC32C):
+		return "CRC32C"
+	case c.Is(ChecksumSHA1):
+		return "SHA1"
+	case c.Is(ChecksumSHA256):
+		return "SHA256"
+	case c.Is(ChecksumNone):
+		return ""
+	}
+	return "invalid"
+}
+
+// Hasher returns a hasher corresponding to the checksum type.
+// Returns nil if no checksum.
+func (c ChecksumType) Hasher() hash.Hash {
+	switch {
+	case c.Is(ChecksumCRC32):
+		return crc32.NewIEEE()
+	case c.Is(ChecksumCRC32C):
+		return crc32.New(crc32.MakeTable(crc32.Castagnoli))
+	case c.Is(ChecksumSHA1):
+		return sha1.New()
+	case c.Is(ChecksumSHA256):
+		return newSHA256()
+	}
+	return nil
+}
+
+// NewChecksumType returns a checksum type based on the algorithm string.
+func NewChecksumType(alg string) ChecksumType {
+	switch strings.ToUpper(alg) {
+	case "CRC32":
+		return ChecksumCRC32
+	case "CRC32C":
+		return ChecksumCRC32C
+	case "SHA1":
+		return ChecksumSHA1
+	case "SHA256":
+		return ChecksumSHA256
+	case "":
+		return ChecksumNone
+	}
+	return ChecksumInvalid
+}
+
+// NewChecksumFromData returns a new checksum from specified algorithm and data.
+func NewChecksumFromData(t ChecksumType, data []byte) *Checksum {
+	if !t.IsSet() {
+		return nil
+	}
+	h := t.Hasher()
+	h.Write(data)
+	raw := h.Sum(nil)
+	return &Checksum{Type: t, Encoded: base64.StdEncoding.EncodeToString(raw), Raw: raw}
+}
+
+// NewChecksumString returns a new checksum from specified algorithm
+// and base64 encoded value. For multipart checksums the value is
+// followed by the number of parts, separated by a dash.
+// Returns nil if the algorithm or the value is invalid.
+func NewChecksumString(alg, value string) *Checksum {
+	t := NewChecksumType(alg)
+	if !t.IsSet() {
+		return nil
+	}
+	encoded := value
+	if i := strings.LastIndexByte(value, '-'); i > 0 {
+		n, err := strconv.Atoi(value[i+1:])
+		if err != nil || n <= 0 {
+			return nil
+		}
+		t |= ChecksumMultipart
+		encoded = value[:i]
+	}
+	raw, err := base64.StdEncoding.DecodeString(encoded)
+	if err != nil || len(raw) != t.RawByteLen() {
+		return nil
+	}
+	return &Checksum{Type: t, Encoded: value, Raw: raw}
+}
+
+// NewCompositeChecksum returns the checksum of a multipart object,
+// computed from the checksums of its parts in part number order.
+// All part checksums must be of type t.
+func NewCompositeChecksum(t ChecksumType, parts []*Checksum) *Checksum {
+	if !t.IsSet() || len(parts) == 0 {
+		return nil
+	}
+	h := t.Hasher()
+	for _, part := range parts {
+		if part == nil || part.Type.String() != t.String() {
+			return nil
+		}
+		h.Write(part.Raw)
+	}
+	raw := h.Sum(nil)
+	return &Checksum{
+		Type:    t | ChecksumMultipart,
+		Encoded: base64.StdEncoding.EncodeToString(raw) + "-" + strconv.Itoa(len(parts)),
+		Raw:     raw,
+	}
+}
+
+// ParseChecksum parses a checksum in the format returned by String.
+// Returns nil if s is not a valid checksum.
+func ParseChecksum(s string) *Checksum {
+	i := strings.IndexByte(s, ':')
+	if i < 0 {
+		return nil
+	}
+	return NewChecksumString(s[:i], s[i+1:])
+}
+
+// String returns the checksum in the <ALGORITHM>:<value> format
+// used to persist it.
+func (c *Checksum) String() string {
+	if !c.Valid() {
+		return ""
+	}
+	return c.Type.String() + ":" + c.Encoded
+}
+
+// Valid returns whether the checksum is set and has a value.
+func (c *Checksum) Valid() bool {
+	if c == nil || !c.Type.IsSet() {
+		return false
+	}
+	return len(c.Raw) == c.Type.RawByteLen()
+}
+
+// Equal returns whether the checksums are of the same type and value.
+func (c *Checksum) Equal(s *Checksum) bool {
+	if !c.Valid() || !s.Valid() {
+		return false
+	}
+	return c.Type.String() == s.Type.String() && c.Encoded == s.Encoded
+}
+
+// Matches returns whether given content matches c.
+func (c *Checksum) Matches(content []byte) error {
+	if !c.Valid() || c.Type.Is(ChecksumMultipart) {
+		return ErrInvalidChecksum
+	}
+	got := NewChecksumFromData(c.Type, content)
+	if got.Encoded != c.Encoded {
+		return ChecksumMismatch{Want: c.Encoded, Got: got.Encoded}
+	}
+	return nil
+}
+
+// AsMap returns the checksum as a header map, nil if not valid.
+func (c *Checksum) AsMap() map[string]string {
+	if !c.Valid() {
+		return nil
+	}
+	return map[string]string{c.Type.Key(): c.Encoded}
+}
+
+// AddChecksumHeader adds the checksum headers to the response.
+func AddChecksumHeader(w http.ResponseWriter, c map[string]string) {
+	for k, v := range c {
+		w.Header().Set(k, v)
+	}
+}
+
+// GetContentChecksum returns the checksum sent with the request,
+// either as header or announced to follow as trailer. Returns nil
+// if no checksum was sent.
+func GetContentChecksum(r *http.Request) (*Checksum, error) {
+	if trailer := r.Header.Get(xhttp.AmzTrailer); trailer != "" {
+		var t ChecksumType
+		for _, key := range strings.Split(trailer, ",") {
+			key = strings.ToLower(strings.TrimSpace(key))
+			if !strings.HasPrefix(key, "x-amz-checksum-") {
+				continue
+			}
+			if t != ChecksumNone {
+				return nil, ErrInvalidChecksum
+			}
+			t = NewChecksumType(strings.TrimPrefix(key, "x-amz-checksum-"))
+			if !t.IsSet() {
+				return nil, ErrInvalidChecksum
+			}
+		}
+		if t != ChecksumNone {
+			return &Checksum{Type: t | ChecksumTrailing}, nil
+		}
+	}
+
+	var res *Checksum
+	for _, t := range []ChecksumType{ChecksumCRC32, ChecksumCRC32C, ChecksumSHA1, ChecksumSHA256} {
+		value := r.Header.Get(t.Key())
+		if value == "" {
+			continue
+		}
+		if res != nil {
+			// Only one checksum may be sent.
+			return nil, ErrInvalidChecksum
+		}
+		res = NewChecksumString(t.String(), value)
+		if res == nil || res.Type.Is(ChecksumMultipart) {
+			return nil, ErrInvalidChecksum
+		}
+	}
+	if res == nil {
+		return nil, nil
+	}
+	if alg := r.Header.Get(xhttp.AmzSDKChecksumAlgo); alg != "" && NewChecksumType(alg) != res.Type {
+		return nil, ErrInvalidChecksum
+	}
+	return res, nil
+}
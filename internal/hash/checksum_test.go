@@ -0,0 +1,215 @@
+// Copyright (c) 2015-2021 MinIO, Inc.
+//
+// This file is part of MinIO Object Storage stack
+//
+// This program is free software: you can redistribute it and/or modify
+// it under the terms of the GNU Affero General Public License as published by
+// the Free Software Foundation, either version 3 of the License, or
+// (at your option) any later version.
+//
+// This program is distributed in the hope that it will be useful
+// but WITHOUT ANY WARRANTY; without even the implied warranty of
+// MERCHANTABILITY or FITNESS FOR A PARTICULAR PURPOSE.  See the
+// GNU Affero General Public License for more details.
+//
+// You should have received a copy of the GNU Affero General Public License
+// along with this program.  If not, see <http://www.gnu.org/licenses/>.
+
+package hash
+
+import (
+	"bytes"
+	"errors"
+	"io"
+	"io/ioutil"
+	"net/http"
+	"testing"
+
+	xhttp "github.com/minio/minio/internal/http"
+)
+
+func TestChecksumFromData(t *testing.T) {
+	testCases := []struct {
+		t        ChecksumType
+		expected string
+	}{
+		{ChecksumCRC32, "7YLNEQ=="},
+		{ChecksumCRC32C, "ksgKMQ=="},
+		{ChecksumSHA1, "gf6L/odXbD7LIkJvjleEc4KRes8="},
+		{ChecksumSHA256, "iNQmb9TmM40TuEX88olXnSCciXgjuSF9o+Fhk28DFYk="},
+	}
+	for i, tc := range testCases {
+		cs := NewChecksumFromData(tc.t, []byte("abcd"))
+		if cs.Encoded != tc.expected {
+			t.Errorf("Test %d: expected %s, got %s", i+1, tc.expected, cs.Encoded)
+		}
+		if err := cs.Matches([]byte("abcd")); err != nil {
+			t.Errorf("Test %d: unexpected error %v", i+1, err)
+		}
+		if err := cs.Matches([]byte("abce")); err == nil {
+			t.Errorf("Test %d: expected a mismatch", i+1)
+		}
+		if parsed := ParseChecksum(cs.String()); !parsed.Equal(cs) {
+			t.Errorf("Test %d: unable to parse %s", i+1, cs.String())
+		}
+	}
+}
+
+func TestNewChecksumString(t *testing.T) {
+	testCases := []struct {
+		alg, value string
+		valid      bool
+		multipart  bool
+	}{
+		{alg: "CRC32", value: "7YLNEQ==", valid: true},
+		{alg: "crc32c", value: "ksgKMQ==", valid: true},
+		{alg: "CRC32", value: "m6zs+w==-2", valid: true, multipart: true},
+		{alg: "CRC32", value: "m6zs+w==-0"},
+		{alg: "CRC32", value: "gf6L/odXbD7LIkJvjleEc4KRes8="},
+		{alg: "SHA1", value: "not base64"},
+		{alg: "MD5", value: "7YLNEQ=="},
+	}
+	for i, tc := range testCases {
+		cs := NewChecksumString(tc.alg, tc.value)
+		if cs.Valid() != tc.valid {
+			t.Fatalf("Test %d: expected valid %v, got %v", i+1, tc.valid, cs.Valid())
+		}
+		if tc.valid && cs.Type.Is(ChecksumMultipart) != tc.multipart {
+			t.Fatalf("Test %d: expected multipart %v", i+1, tc.multipart)
+		}
+	}
+}
+
+func TestCompositeChecksum(t *testing.T) {
+	parts := []*Checksum{
+		NewChecksumFromData(ChecksumCRC32, []byte("ab")),
+		NewChecksumFromData(ChecksumCRC32, []byte("cd")),
+	}
+	cs := NewCompositeChecksum(ChecksumCRC32, parts)
+	if cs.Encoded != "m6zs+w==-2" {
+		t.Fatalf("expected m6zs+w==-2, got %s", cs.Encoded)
+	}
+	if !cs.Type.Is(ChecksumMultipart) {
+		t.Fatal("expected a multipart checksum")
+	}
+	if parsed := ParseChecksum(cs.String()); !parsed.Equal(cs) {
+		t.Fatalf("unable to parse %s", cs.String())
+	}
+
+	parts[1] = NewChecksumFromData(ChecksumSHA1, []byte("cd"))
+	if NewCompositeChecksum(ChecksumCRC32, parts) != nil {
+		t.Fatal("expected mixed checksum types to be rejected")
+	}
+}
+
+func TestGetContentChecksum(t *testing.T) {
+	testCases := []struct {
+		headers  map[string]string
+		expected ChecksumType
+		err      bool
+	}{
+		{headers: map[string]string{}, expected: ChecksumNone},
+		{headers: map[string]string{xhttp.AmzChecksumCRC32: "7YLNEQ=="}, expected: ChecksumCRC32},
+		{headers: map[string]string{xhttp.AmzChecksumSHA256: "iNQmb9TmM40TuEX88olXnSCciXgjuSF9o+Fhk28DFYk=", xhttp.AmzSDKChecksumAlgo: "SHA256"}, expected: ChecksumSHA256},
+		{headers: map[string]string{xhttp.AmzTrailer: "x-amz-checksum-crc32c"}, expected: ChecksumCRC32C | ChecksumTrailing},
+		{headers: map[string]string{xhttp.AmzChecksumCRC32: "7YLNEQ==", xhttp.AmzChecksumCRC32C: "ksgKMQ=="}, err: true},
+		{headers: map[string]string{xhttp.AmzChecksumCRC32: "7YLNEQ==", xhttp.AmzSDKChecksumAlgo: "SHA1"}, err: true},
+		{headers: map[string]string{xhttp.AmzChecksumCRC32: "ksgKMQ==-2"}, err: true},
+		{headers: map[string]string{xhttp.AmzTrailer: "x-amz-checksum-md5"}, err: true},
+	}
+	for i, tc := range testCases {
+		req, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range tc.headers {
+			req.Header.Set(k, v)
+		}
+		cs, err := GetContentChecksum(req)
+		if tc.err {
+			if err == nil {
+				t.Errorf("Test %d: expected an error", i+1)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		got := ChecksumNone
+		if cs != nil {
+			got = cs.Type
+		}
+		if got != tc.expected {
+			t.Errorf("Test %d: expected type %v, got %v", i+1, tc.expected, got)
+		}
+	}
+}
+
+func TestHashReaderChecksum(t *testing.T) {
+	newRequest := func(headers map[string]string) *http.Request {
+		req, err := http.NewRequest(http.MethodPut, "http://localhost/bucket/object", nil)
+		if err != nil {
+			t.Fatal(err)
+		}
+		for k, v := range headers {
+			req.Header.Set(k, v)
+		}
+		return req
+	}
+
+	testCases := []struct {
+		req         *http.Request
+		trailer     http.Header
+		ignoreValue bool
+		expected    string
+		err         error
+	}{
+		// Test 1: valid checksum.
+		{req: newRequest(map[string]string{xhttp.AmzChecksumCRC32: "7YLNEQ=="}), expected: "7YLNEQ=="},
+		// Test 2: checksum mismatch.
+		{req: newRequest(map[string]string{xhttp.AmzChecksumCRC32C: "7YLNEQ=="}), err: ChecksumMismatch{Want: "7YLNEQ==", Got: "ksgKMQ=="}},
+		// Test 3: computed checksum.
+		{req: newRequest(map[string]string{xhttp.AmzChecksumSHA1: "AAAAAAAAAAAAAAAAAAAAAAAAAAA="}), ignoreValue: true, expected: "gf6L/odXbD7LIkJvjleEc4KRes8="},
+		// Test 4: valid trailing checksum.
+		{
+			req:      newRequest(map[string]string{xhttp.AmzTrailer: xhttp.AmzChecksumCRC32}),
+			trailer:  http.Header{http.CanonicalHeaderKey(xhttp.AmzChecksumCRC32): []string{"7YLNEQ=="}},
+			expected: "7YLNEQ==",
+		},
+		// Test 5: trailing checksum mismatch.
+		{
+			req:     newRequest(map[string]string{xhttp.AmzTrailer: xhttp.AmzChecksumCRC32}),
+			trailer: http.Header{http.CanonicalHeaderKey(xhttp.AmzChecksumCRC32): []string{"ksgKMQ=="}},
+			err:     ChecksumMismatch{Want: "ksgKMQ==", Got: "7YLNEQ=="},
+		},
+		// Test 6: missing trailing checksum.
+		{req: newRequest(map[string]string{xhttp.AmzTrailer: xhttp.AmzChecksumCRC32}), err: ErrInvalidChecksum},
+	}
+
+	for i, tc := range testCases {
+		r, err := NewReader(bytes.NewReader([]byte("abcd")), 4, "", "", 4)
+		if err != nil {
+			t.Fatal(err)
+		}
+		if err = r.AddChecksum(tc.req, tc.ignoreValue); err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		// Trailers are received after the content.
+		for k, v := range tc.trailer {
+			tc.req.Trailer[k] = v
+		}
+		_, err = io.Copy(ioutil.Discard, r)
+		if tc.err != nil {
+			if !errors.Is(err, tc.err) {
+				t.Errorf("Test %d: expected error %v, got %v", i+1, tc.err, err)
+			}
+			continue
+		}
+		if err != nil {
+			t.Fatalf("Test %d: unexpected error %v", i+1, err)
+		}
+		if r.Checksum().Encoded != tc.expected {
+			t.Errorf("Test %d: expected checksum %s, got %s", i+1, tc.expected, r.Checksum().Encoded)
+		}
+	}
+}
@@ -24,6 +24,7 @@ import (
 	"errors"
 	"hash"
 	"io"
+	"net/http"
 
 	"github.com/minio/minio/internal/etag"
 )
@@ -45,6 +46,12 @@ type Reader struct {
 	checksum      etag.ETag
 	contentSHA256 []byte
 
+	// Content checksum
+	contentHash   *Checksum
+	contentHasher hash.Hash
+	trailer       http.Header
+	ignoreValue   bool
+
 	sha256 hash.Hash
 }
 
@@ -141,7 +148,16 @@ func (r *Reader) Read(p []byte) (int, error) {
 		r.sha256.Write(p[:n])
 	}
 
+	if r.contentHasher != nil {
+		r.contentHasher.Write(p[:n])
+	}
+
 	if err == io.EOF { // Verify content SHA256, if set.
+		if r.contentHasher != nil {
+			if cerr := r.verifyChecksum(); cerr != nil {
+				return n, cerr
+			}
+		}
 		if r.sha256 != nil {
 			if sum := r.sha256.Sum(nil); !bytes.Equal(r.contentSHA256, sum) {
 				return n, SHA256Mismatch{
@@ -162,6 +178,78 @@ func (r *Reader) Read(p []byte) (int, error) {
 	return n, err
 }
 
+// AddChecksum adds the checksum sent with the request, if any, to be
+// verified while reading. If the checksum is sent as trailer its
+// value is read from the request trailers once all content is read.
+//
+// If ignoreValue is true the checksum is computed but not verified.
+func (r *Reader) AddChecksum(req *http.Request, ignoreValue bool) error {
+	cs, err := GetContentChecksum(req)
+	if err != nil {
+		return err
+	}
+	if cs == nil {
+		return nil
+	}
+	if cs.Type.Trailing() {
+		if req.Trailer == nil {
+			req.Trailer = make(http.Header)
+		}
+		r.trailer = req.Trailer
+	}
+	return r.AddNonTrailingChecksum(cs, ignoreValue)
+}
+
+// AddNonTrailingChecksum adds the checksum to be verified while
+// reading. If ignoreValue is true the checksum of type cs.Type is
+// computed and stored in cs, but not verified.
+func (r *Reader) AddNonTrailingChecksum(cs *Checksum, ignoreValue bool) error {
+	if cs == nil {
+		return nil
+	}
+	if r.bytesRead > 0 {
+		return errors.New("hash: already read from hash reader")
+	}
+	r.contentHasher = cs.Type.Hasher()
+	if r.contentHasher == nil {
+		return ErrInvalidChecksum
+	}
+	r.contentHash = cs
+	r.ignoreValue = ignoreValue
+	return nil
+}
+
+// verifyChecksum verifies the content checksum once all
+// content has been read.
+func (r *Reader) verifyChecksum() error {
+	cs := r.contentHash
+	sum := r.contentHasher.Sum(nil)
+	encoded := base64.StdEncoding.EncodeToString(sum)
+	if r.ignoreValue {
+		cs.Encoded, cs.Raw = encoded, sum
+		return nil
+	}
+	if cs.Type.Trailing() {
+		want := NewChecksumString(cs.Type.String(), r.trailer.Get(cs.Type.Key()))
+		if want == nil || want.Type.Is(ChecksumMultipart) {
+			return ErrInvalidChecksum
+		}
+		cs.Type &^= ChecksumTrailing
+		cs.Encoded, cs.Raw = want.Encoded, want.Raw
+	}
+	if !bytes.Equal(cs.Raw, sum) {
+		return ChecksumMismatch{Want: cs.Encoded, Got: encoded}
+	}
+	return nil
+}
+
+// Checksum returns the content checksum, nil if none has been added.
+// Checksums which are computed or sent as trailer only have a value
+// once all content has been read.
+func (r *Reader) Checksum() *Checksum {
+	return r.contentHash
+}
+
 // Size returns the absolute number of bytes the Reader
 // will return during reading. It returns -1 for unlimited
 // data.
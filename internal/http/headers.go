@@ -42,6 +42,20 @@ const (
 	Range              = "Range"
 )
 
+// Standard CORS HTTP header constants
+const (
+	Origin                        = "Origin"
+	Vary                          = "Vary"
+	AccessControlAllowOrigin      = "Access-Control-Allow-Origin"
+	AccessControlAllowMethods     = "Access-Control-Allow-Methods"
+	AccessControlAllowHeaders     = "Access-Control-Allow-Headers"
+	AccessControlAllowCredentials = "Access-Control-Allow-Credentials"
+	AccessControlExposeHeaders    = "Access-Control-Expose-Headers"
+	AccessControlMaxAge           = "Access-Control-Max-Age"
+	AccessControlRequestMethod    = "Access-Control-Request-Method"
+	AccessControlRequestHeaders   = "Access-Control-Request-Headers"
+)
+
 // Non standard S3 HTTP response constants
 const (
 	XCache       = "X-Cache"
@@ -94,6 +108,29 @@ const (
 	// Multipart parts count
 	AmzMpPartsCount = "x-amz-mp-parts-count"
 
+	// S3 additional checksums
+	AmzChecksumAlgo    = "x-amz-checksum-algorithm"
+	AmzChecksumCRC32   = "x-amz-checksum-crc32"
+	AmzChecksumCRC32C  = "x-amz-checksum-crc32c"
+	AmzChecksumSHA1    = "x-amz-checksum-sha1"
+	AmzChecksumSHA256  = "x-amz-checksum-sha256"
+	AmzChecksumMode    = "x-amz-checksum-mode"
+	AmzSDKChecksumAlgo = "x-amz-sdk-checksum-algorithm"
+	AmzTrailer         = "x-amz-trailer"
+
+	// Object attributes
+	AmzObjectAttributes = "x-amz-object-attributes"
+	AmzMaxParts         = "x-amz-max-parts"
+	AmzPartNumberMarker = "x-amz-part-number-marker"
+
+	// Object lambda, sent by transformation endpoints
+	AmzRequestRoute    = "x-amz-request-route"
+	AmzRequestToken    = "x-amz-request-token"
+	AmzFwdStatus       = "x-amz-fwd-status"
+	AmzFwdErrorCode    = "x-amz-fwd-error-code"
+	AmzFwdErrorMessage = "x-amz-fwd-error-message"
+	AmzFwdHeaderPrefix = "x-amz-fwd-header-"
+
 	// Object date/time of expiration
 	AmzExpiration = "x-amz-expiration"
 
@@ -150,6 +187,12 @@ const (
 	// Header indicates if the etag should be preserved by client
 	MinIOSourceETag = "x-minio-source-etag"
 
+	// Header requests the body to be appended to an object at the given offset
+	MinIOAppendOffset = "x-minio-append-offset"
+
+	// Header requests an object or a prefix to be renamed to the request path
+	MinIORenameSource = "x-minio-rename-source"
+
 	// Writes expected write quorum
 	MinIOWriteQuorum = "x-minio-write-quorum"
 
@@ -172,6 +215,8 @@ const (
 	MinIOSourceReplicationRequest = "X-Minio-Source-Replication-Request"
 	// Header indicates replication reset status.
 	MinIOReplicationResetStatus = "X-Minio-Replication-Reset-Status"
+	// Header indicates the deployment ID of the site a replicated object version was first written to.
+	MinIOSourceOrigin = "X-Minio-Source-Origin"
 
 	// predicted date/time of transition
 	MinIOTransition = "X-Minio-Transition"
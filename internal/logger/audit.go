@@ -24,6 +24,7 @@ import (
 	"io"
 	"net/http"
 	"strconv"
+	"sync/atomic"
 	"time"
 
 	"github.com/minio/minio/internal/logger/message/audit"
@@ -153,10 +154,43 @@ func GetAuditEntry(ctx context.Context) *audit.Entry {
 	return nil
 }
 
+// BucketLogTarget receives the audit entries of the requests
+// against buckets with server access logging enabled.
+type BucketLogTarget interface {
+	Enabled(bucket string) bool
+	Send(entry audit.Entry)
+}
+
+var bucketLogTarget atomic.Value
+
+// SetBucketLogTarget sets the server access logging target.
+func SetBucketLogTarget(t BucketLogTarget) {
+	bucketLogTarget.Store(t)
+}
+
+// getBucketLogTarget returns the server access logging target if
+// logging is enabled for the bucket of this request.
+func getBucketLogTarget(ctx context.Context) BucketLogTarget {
+	t, ok := bucketLogTarget.Load().(BucketLogTarget)
+	if !ok {
+		return nil
+	}
+	reqInfo := GetReqInfo(ctx)
+	if reqInfo == nil || reqInfo.BucketName == "" || !t.Enabled(reqInfo.BucketName) {
+		return nil
+	}
+	return t
+}
+
 // AuditLog - logs audit logs to all audit targets.
 func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqClaims map[string]interface{}, filterKeys ...string) {
+	var bucketTarget BucketLogTarget
+	if w != nil && r != nil {
+		bucketTarget = getBucketLogTarget(ctx)
+	}
+
 	// Fast exit if there is not audit target configured
-	if len(AuditTargets) == 0 {
+	if len(AuditTargets) == 0 && bucketTarget == nil {
 		return
 	}
 
@@ -198,6 +232,7 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 		entry.API.Status = http.StatusText(statusCode)
 		entry.API.StatusCode = statusCode
 		entry.API.TimeToResponse = strconv.FormatInt(timeToResponse.Nanoseconds(), 10) + "ns"
+		entry.AccessKey = reqInfo.AccessKey
 		entry.Tags = reqInfo.GetTagsMap()
 		// ttfb will be recorded only for GET requests, Ignore such cases where ttfb will be empty.
 		if timeToFirstByte != 0 {
@@ -214,4 +249,8 @@ func AuditLog(ctx context.Context, w http.ResponseWriter, r *http.Request, reqCl
 	for _, t := range AuditTargets {
 		_ = t.Send(entry, string(All))
 	}
+
+	if bucketTarget != nil {
+		bucketTarget.Send(entry)
+	}
 }
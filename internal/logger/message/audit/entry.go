@@ -47,6 +47,10 @@ type Entry struct {
 	RemoteHost string                 `json:"remotehost,omitempty"`
 	RequestID  string                 `json:"requestID,omitempty"`
 	UserAgent  string                 `json:"userAgent,omitempty"`
+	ReqMethod  string                 `json:"requestMethod,omitempty"`
+	ReqPath    string                 `json:"requestPath,omitempty"`
+	ReqHost    string                 `json:"requestHost,omitempty"`
+	AccessKey  string                 `json:"accessKey,omitempty"`
 	ReqClaims  map[string]interface{} `json:"requestClaims,omitempty"`
 	ReqQuery   map[string]string      `json:"requestQuery,omitempty"`
 	ReqHeader  map[string]string      `json:"requestHeader,omitempty"`
@@ -70,6 +74,9 @@ func ToEntry(w http.ResponseWriter, r *http.Request, reqClaims map[string]interf
 
 	entry.RemoteHost = handlers.GetSourceIP(r)
 	entry.UserAgent = r.UserAgent()
+	entry.ReqMethod = r.Method
+	entry.ReqPath = r.URL.EscapedPath()
+	entry.ReqHost = r.Host
 	entry.ReqClaims = reqClaims
 
 	q := r.URL.Query()